@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"kaspi-api-wrapper/internal/config"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/onec"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// newOneCExporter builds the 1C exporter with the counterparty mapping from ONEC_MAPPING_FILE,
+// documents are dated in the report time zone
+func newOneCExporter(log *slog.Logger, cfg *config.Config, storage onec.Storage) (*onec.Exporter, error) {
+	location, err := time.LoadLocation(cfg.Report.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping onec.Mapping
+	if cfg.OneC.MappingFile != "" {
+		mapping, err = onec.LoadMapping(cfg.OneC.MappingFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return onec.New(log, storage, onec.Config{Location: location, Mapping: mapping}), nil
+}
+
+// runOneCExport implements the export-1c subcommand, it writes the exchange file of a period
+// to a file or to stdout without starting the servers
+func runOneCExport(args []string) error {
+	flags := flag.NewFlagSet("export-1c", flag.ContinueOnError)
+	from := flags.String("from", "", "first day of the period as YYYY-MM-DD")
+	to := flags.String("to", "", "last day of the period as YYYY-MM-DD, defaults to -from")
+	tradePointID := flags.Int64("trade-point", 0, "export a single trade point")
+	format := flags.String("format", domain.OneCFormatCommerceML, "commerceml or statement")
+	out := flags.String("out", "", "output file, the file name of the export is used when set to a directory, stdout when empty")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.MustLoad()
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	store, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Stop()
+
+	exporter, err := newOneCExporter(log, cfg, store)
+	if err != nil {
+		return err
+	}
+
+	file, err := exporter.ExportOneC(context.Background(), domain.OneCExportRequest{
+		DateFrom:     *from,
+		DateTo:       *to,
+		TradePointID: *tradePointID,
+		Format:       *format,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(file.Data)
+		return err
+	}
+
+	path := *out
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, file.FileName)
+	}
+
+	if err = os.WriteFile(path, file.Data, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "written %s\n", path)
+	return nil
+}
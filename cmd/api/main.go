@@ -5,14 +5,27 @@ import (
 	"fmt"
 	"kaspi-api-wrapper/internal/app"
 	"kaspi-api-wrapper/internal/config"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers"
+	"kaspi-api-wrapper/internal/idempotency"
+	"kaspi-api-wrapper/internal/poller"
+	"kaspi-api-wrapper/internal/qrimage"
+	"kaspi-api-wrapper/internal/reconciliation"
+	"kaspi-api-wrapper/internal/refundsession"
+	"kaspi-api-wrapper/internal/remotepayment"
+	"kaspi-api-wrapper/internal/report"
 	"kaspi-api-wrapper/internal/service"
-	"kaspi-api-wrapper/internal/storage/postgres"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/tradepoint"
+	"kaspi-api-wrapper/internal/webhook"
 	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogpretty"
 	"log/slog"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 )
 
 const (
@@ -26,6 +39,30 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export-1c" {
+		if err := runOneCExport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "rotate-device-token-key" {
+		if err := runRotateDeviceTokenKey(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg := config.MustLoad()
 
 	log := setupLogger(cfg.Env)
@@ -37,18 +74,33 @@ func main() {
 
 	log.Debug("debug enabled")
 
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
-		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode,
-	)
-
-	log.Info("connecting to database", "host", cfg.Database.Host, "dbname", cfg.Database.Name)
-	storage, err := postgres.New(dsn)
+	log.Info("opening storage", "backend", cfg.Storage.Backend)
+	store, err := newStorage(cfg)
 	if err != nil {
 		panic(err)
 	}
-	defer storage.Stop()
+	defer store.Stop()
+
+	// replicas starting together apply the migrations once, the others wait for the lock
+	if err = migrateSchema(ctx, log, cfg, store); err != nil {
+		panic(err)
+	}
+
+	// the memory backend keeps no tokens at rest, so there is nothing to encrypt
+	if encrypter, ok := store.(storage.TokenEncrypter); ok {
+		if cfg.DeviceToken.Key == "" && cfg.DeviceToken.KeyFile == "" {
+			log.Warn("DEVICE_TOKEN_KEY is not set, device tokens are stored in plaintext")
+		} else {
+			// tokens stored before the key was configured are encrypted on the first start with it
+			encrypted, err := encrypter.EncryptDeviceTokens(ctx)
+			if err != nil {
+				panic(err)
+			}
+			if encrypted > 0 {
+				log.Info("encrypted stored device tokens", "count", encrypted)
+			}
+		}
+	}
 
 	tlsConfig := &service.TLSConfig{
 		Password:      cfg.KaspiAPI.KeyPass,
@@ -67,10 +119,163 @@ func main() {
 
 		tlsConfig,
 
-		storage,
+		store,
 	)
 
-	application := app.New(log, cfg.HTTPPort, cfg.KaspiAPI.Scheme, cfg.GRPCPort, kaspiService)
+	kaspiService.SetRetryPolicy(service.RetryPolicy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.BaseDelay,
+		MaxDelay:    cfg.Retry.MaxDelay,
+	})
+
+	kaspiService.SetDeviceTokenTTL(cfg.DeviceToken.CacheTTL)
+
+	if cfg.Breaker.Enabled {
+		kaspiService.EnableCircuitBreaker(service.BreakerConfig{
+			FailureThreshold: cfg.Breaker.FailureThreshold,
+			OpenTimeout:      cfg.Breaker.OpenTimeout,
+			HalfOpenMaxCalls: cfg.Breaker.HalfOpenMaxCalls,
+		})
+	}
+
+	var paymentPoller *poller.Poller
+	var paymentWatcher handlers.PaymentWatcher
+	if cfg.Poller.Enabled {
+		paymentPoller = poller.New(log, kaspiService, kaspiService, store, cfg.Poller.MaxConcurrent, domain.PollingOptions{
+			Interval:            cfg.Poller.DefaultInterval,
+			ScanTimeout:         cfg.Poller.DefaultScanTimeout,
+			ConfirmationTimeout: cfg.Poller.DefaultConfirmationTimeout,
+		})
+		kaspiService.SetPaymentTracker(paymentPoller)
+		paymentWatcher = paymentPoller
+	}
+
+	var webhookDispatcher *webhook.Dispatcher
+	var webhookProvider handlers.WebhookProvider
+	if len(cfg.Webhook.URLs) > 0 {
+		// receivers verify the signature, an empty secret would sign every event with a known key
+		if cfg.Webhook.Secret == "" {
+			panic("WEBHOOK_SECRET must be set when WEBHOOK_URLS is")
+		}
+
+		webhookDispatcher = webhook.New(log, store, webhook.Config{
+			URLs:         cfg.Webhook.URLs,
+			Secret:       cfg.Webhook.Secret,
+			MaxAttempts:  cfg.Webhook.MaxAttempts,
+			BaseDelay:    cfg.Webhook.BaseDelay,
+			MaxDelay:     cfg.Webhook.MaxDelay,
+			PollInterval: cfg.Webhook.PollInterval,
+			Timeout:      cfg.Webhook.Timeout,
+		})
+		webhookDispatcher.Start()
+
+		kaspiService.SetEventPublisher(webhookDispatcher)
+		webhookProvider = webhookDispatcher
+	}
+
+	// payments left unfinished by a restart are resumed once status changes can be published
+	if paymentPoller != nil {
+		if err = paymentPoller.Start(ctx); err != nil {
+			panic(err)
+		}
+	}
+
+	var idempotencyGuard handlers.IdempotencyGuard
+	if cfg.Idempotency.Enabled {
+		idempotencyGuard = idempotency.New(log, store, idempotency.Config{
+			TTL:         cfg.Idempotency.TTL,
+			LockTimeout: cfg.Idempotency.LockTimeout,
+			WaitTimeout: cfg.Idempotency.WaitTimeout,
+		})
+	}
+
+	qrRenderer, err := qrimage.New(log, store, qrimage.Config{
+		DefaultSize:            cfg.QRImage.DefaultSize,
+		MaxSize:                cfg.QRImage.MaxSize,
+		DefaultMargin:          cfg.QRImage.DefaultMargin,
+		DefaultErrorCorrection: cfg.QRImage.DefaultErrorCorrection,
+		LogoFile:               cfg.QRImage.LogoFile,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// refund sessions drive the standard scheme refund flow, the enhanced scheme serves the same
+	// routes so that they are rejected like the refund QR instead of looking disabled
+	var refundSessions *refundsession.Manager
+	var refundSessionProvider handlers.RefundSessionProvider
+	if cfg.RefundSession.Enabled && cfg.KaspiAPI.Scheme != "basic" {
+		refundSessions = refundsession.New(log, kaspiService, store, refundsession.Config{
+			DefaultPollingInterval: cfg.RefundSession.DefaultPollingInterval,
+			DefaultScanTimeout:     cfg.RefundSession.DefaultScanTimeout,
+			SelectionTimeout:       cfg.RefundSession.SelectionTimeout,
+		})
+		if err = refundSessions.Start(ctx); err != nil {
+			panic(err)
+		}
+		refundSessionProvider = refundSessions
+	}
+
+	// remote payments that were not paid in time are canceled in Kaspi
+	var remotePaymentSweeper *remotepayment.Sweeper
+	if cfg.RemotePayment.CancelEnabled && cfg.KaspiAPI.Scheme == "enhanced" {
+		kaspiService.SetRemotePaymentTimeout(cfg.RemotePayment.CancelTimeout)
+
+		remotePaymentSweeper = remotepayment.New(log, store, kaspiService, remotepayment.Config{
+			Timeout:       cfg.RemotePayment.CancelTimeout,
+			SweepInterval: cfg.RemotePayment.SweepInterval,
+		})
+		remotePaymentSweeper.Start()
+	}
+
+	// trade points are served from the database and refreshed from Kaspi in the background
+	kaspiService.SetTradePointStaleAfter(cfg.TradePoint.StaleAfter)
+
+	var tradePointSyncer *tradepoint.Syncer
+	if cfg.TradePoint.SyncEnabled {
+		tradePointSyncer = tradepoint.New(log, kaspiService, kaspiService, tradepoint.Config{
+			Enhanced: cfg.KaspiAPI.Scheme == "enhanced",
+			Interval: cfg.TradePoint.SyncInterval,
+		})
+		tradePointSyncer.Start()
+	}
+
+	// local payments and refunds are compared with Kaspi every night
+	var reconciler *reconciliation.Reconciler
+	var reconciliationProvider handlers.ReconciliationProvider
+	if cfg.Reconciliation.Enabled {
+		location, err := time.LoadLocation(cfg.Reconciliation.Timezone)
+		if err != nil {
+			panic(err)
+		}
+
+		reconciler, err = reconciliation.New(log, kaspiService, store, reconciliation.Config{
+			Scheme:   cfg.KaspiAPI.Scheme,
+			RunAt:    cfg.Reconciliation.RunAt,
+			Location: location,
+		})
+		if err != nil {
+			panic(err)
+		}
+		if err = reconciler.Start(ctx); err != nil {
+			panic(err)
+		}
+		reconciliationProvider = reconciler
+	}
+
+	// settlement reports split payments and refunds into days of the report time zone
+	reportLocation, err := time.LoadLocation(cfg.Report.Timezone)
+	if err != nil {
+		panic(err)
+	}
+	reportGenerator := report.New(log, store, report.Config{Location: reportLocation})
+
+	oneCExporter, err := newOneCExporter(log, cfg, store)
+	if err != nil {
+		panic(err)
+	}
+
+	application := app.New(log, cfg.HTTPPort, cfg.KaspiAPI.Scheme, cfg.GRPCPort, kaspiService, webhookProvider, paymentWatcher, qrRenderer, idempotencyGuard, refundSessionProvider, reconciliationProvider, reportGenerator, oneCExporter)
 
 	go func() {
 		defer wg.Done()
@@ -102,11 +307,43 @@ func main() {
 	application.HTTPSrv.Stop(ctx)
 	application.GRPCSrv.Stop()
 
+	if paymentPoller != nil {
+		paymentPoller.Stop()
+	}
+
+	if refundSessions != nil {
+		refundSessions.Stop()
+	}
+
+	if remotePaymentSweeper != nil {
+		remotePaymentSweeper.Stop()
+	}
+
+	if tradePointSyncer != nil {
+		tradePointSyncer.Stop()
+	}
+
+	if reconciler != nil {
+		reconciler.Stop()
+	}
+
+	if webhookDispatcher != nil {
+		webhookDispatcher.Stop()
+	}
+
 	wg.Wait()
 
 	log.Info("application stopped")
 }
 
+func databaseDSN(cfg *config.Config) string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
+		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode,
+	)
+}
+
 func setupLogger(env string) *slog.Logger {
 	var log *slog.Logger
 
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"kaspi-api-wrapper/internal/config"
+	"kaspi-api-wrapper/internal/storage"
+	"log/slog"
+)
+
+// migrateSchema applies the pending migrations when DB_MIGRATE_ON_START is set and otherwise only
+// checks the schema. It fails when the schema is newer than this binary, the queries of an older
+// binary may not match it
+func migrateSchema(ctx context.Context, log *slog.Logger, cfg *config.Config, store storage.Storage) error {
+	migratable, ok := store.(storage.Migratable)
+	if !ok {
+		return nil
+	}
+
+	m, err := migratable.Migrator()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Database.MigrateOnStart {
+		pending, err := m.Check(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			log.Warn("database schema is behind, run the migrate subcommand or set DB_MIGRATE_ON_START", "pending", pending)
+		}
+		return nil
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	if applied > 0 {
+		log.Info("applied database migrations", "count", applied, "version", m.Latest())
+	}
+
+	return nil
+}
+
+// runMigrate implements the migrate subcommand, it applies the pending migrations, or reverts the
+// newest ones with -down, and exits
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	down := flags.Int("down", 0, "revert this many migrations instead of applying the pending ones")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.MustLoad()
+
+	store, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Stop()
+
+	migratable, ok := store.(storage.Migratable)
+	if !ok {
+		return fmt.Errorf("the %s backend has no schema to migrate", cfg.Storage.Backend)
+	}
+
+	m, err := migratable.Migrator()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if *down > 0 {
+		reverted, err := m.Down(ctx, *down)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %d migrations\n", reverted)
+	} else {
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migrations\n", applied)
+	}
+
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version %d\n", version)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"kaspi-api-wrapper/internal/config"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/tokencrypt"
+	"os"
+)
+
+// newTokenCipher builds the cipher of device tokens from DEVICE_TOKEN_KEY or DEVICE_TOKEN_KEY_FILE,
+// it returns nil when no key is configured and tokens are stored in plaintext
+func newTokenCipher(cfg *config.Config) (*tokencrypt.Cipher, error) {
+	key, err := tokencrypt.LoadKey(cfg.DeviceToken.Key, cfg.DeviceToken.KeyFile)
+	if err != nil || key == nil {
+		return nil, err
+	}
+
+	var previous [][]byte
+	for _, value := range cfg.DeviceToken.PreviousKeys {
+		if value == "" {
+			continue
+		}
+
+		previousKey, err := tokencrypt.ParseKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("DEVICE_TOKEN_PREVIOUS_KEYS: %w", err)
+		}
+		previous = append(previous, previousKey)
+	}
+
+	return tokencrypt.New(key, previous...)
+}
+
+// runRotateDeviceTokenKey implements the rotate-device-token-key subcommand, it re-encrypts stored device
+// tokens with the current key. The lookup index changes with the key, so the servers must be stopped
+// while it runs
+func runRotateDeviceTokenKey(args []string) error {
+	flags := flag.NewFlagSet("rotate-device-token-key", flag.ContinueOnError)
+	decrypt := flags.Bool("decrypt", false, "store the tokens in plaintext again, before the encryption migration is reverted")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.MustLoad()
+
+	store, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Stop()
+
+	encrypter, ok := store.(storage.TokenEncrypter)
+	if !ok {
+		return fmt.Errorf("the %s backend keeps no device tokens to rotate", cfg.Storage.Backend)
+	}
+
+	var rewritten int
+	if *decrypt {
+		rewritten, err = encrypter.DecryptDeviceTokens(context.Background())
+	} else {
+		rewritten, err = encrypter.RotateDeviceTokens(context.Background())
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "rewritten %d device tokens\n", rewritten)
+	return nil
+}
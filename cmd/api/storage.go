@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"kaspi-api-wrapper/internal/config"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/storage/memory"
+	"kaspi-api-wrapper/internal/storage/postgres"
+	"kaspi-api-wrapper/internal/storage/sqlite"
+)
+
+// newStorage opens the backend selected with STORAGE_BACKEND. Backends that persist device tokens
+// get the cipher of DEVICE_TOKEN_KEY, so the returned storage reads and writes them encrypted
+func newStorage(cfg *config.Config) (storage.Storage, error) {
+	var store storage.Storage
+	var err error
+
+	switch cfg.Storage.Backend {
+	case storage.BackendPostgres:
+		store, err = postgres.New(databaseDSN(cfg))
+	case storage.BackendSQLite:
+		store, err = sqlite.New(cfg.Storage.SQLitePath)
+	case storage.BackendMemory:
+		store = memory.New()
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q, expected postgres, sqlite or memory", cfg.Storage.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := newTokenCipher(cfg)
+	if err != nil {
+		store.Stop()
+		return nil, err
+	}
+
+	if encrypter, ok := store.(storage.TokenEncrypter); ok && tokens != nil {
+		encrypter.SetTokenCipher(tokens)
+	}
+
+	return store, nil
+}
@@ -3,6 +3,7 @@ package app
 import (
 	grpcapp "kaspi-api-wrapper/internal/app/grpc"
 	"kaspi-api-wrapper/internal/app/http"
+	"kaspi-api-wrapper/internal/handlers"
 	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
 	"kaspi-api-wrapper/internal/handlers/http"
 	"kaspi-api-wrapper/internal/service"
@@ -16,9 +17,9 @@ type App struct {
 	grpcHandlers *grpchandler.Handlers
 }
 
-func New(log *slog.Logger, httpPort int, scheme string, grpcPort int, kaspiService *service.KaspiService) *App {
-	httpHandlers := http.NewHandlers(log, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService)
-	grpcHandlers := grpchandler.NewHandlers(log, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService)
+func New(log *slog.Logger, httpPort int, scheme string, grpcPort int, kaspiService *service.KaspiService, webhookProvider handlers.WebhookProvider, paymentWatcher handlers.PaymentWatcher, qrRenderer handlers.QRRenderer, idempotencyGuard handlers.IdempotencyGuard, refundSessionProvider handlers.RefundSessionProvider, reconciliationProvider handlers.ReconciliationProvider, reportProvider handlers.ReportProvider, oneCExporter handlers.OneCExporter) *App {
+	httpHandlers := http.NewHandlers(log, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, webhookProvider, paymentWatcher, qrRenderer, idempotencyGuard, refundSessionProvider, reconciliationProvider, reportProvider, oneCExporter, kaspiService)
+	grpcHandlers := grpchandler.NewHandlers(log, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, kaspiService, paymentWatcher, qrRenderer, idempotencyGuard, refundSessionProvider, reconciliationProvider, reportProvider, kaspiService)
 
 	httpApp := httpapp.New(log, httpPort, httpHandlers, scheme)
 	grpcApp := grpcapp.New(log, grpcPort, grpcHandlers, scheme)
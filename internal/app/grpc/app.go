@@ -6,9 +6,12 @@ import (
 	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
 	"kaspi-api-wrapper/internal/handlers/grpc/device"
 	grpcmiddleware "kaspi-api-wrapper/internal/handlers/grpc/middleware"
+	"kaspi-api-wrapper/internal/handlers/grpc/organization"
 	"kaspi-api-wrapper/internal/handlers/grpc/payment"
+	"kaspi-api-wrapper/internal/handlers/grpc/reconciliation"
 	"kaspi-api-wrapper/internal/handlers/grpc/refund"
 	"kaspi-api-wrapper/internal/handlers/grpc/refund_enhanced"
+	"kaspi-api-wrapper/internal/handlers/grpc/report"
 	"kaspi-api-wrapper/internal/handlers/grpc/utility"
 	"log/slog"
 	"net"
@@ -21,14 +24,26 @@ type App struct {
 }
 
 func New(log *slog.Logger, grpcPort int, handlers *grpchandler.Handlers, scheme string) *App {
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpcmiddleware.SchemeInterceptor(scheme),
+		grpcmiddleware.InitiatorInterceptor(),
+	}
+	if handlers.IdempotencyGuard != nil {
+		unaryInterceptors = append(unaryInterceptors, grpcmiddleware.IdempotencyInterceptor(log, handlers.IdempotencyGuard))
+	}
+
 	gRPCServer := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcmiddleware.SchemeInterceptor(scheme)))
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.StreamInterceptor(grpcmiddleware.SchemeStreamInterceptor(scheme)))
 
 	device.Register(gRPCServer, log, handlers.DeviceProvider, handlers.DeviceEnhancedProvider)
-	payment.Register(gRPCServer, log, handlers.PaymentProvider, handlers.PaymentEnhancedProvider)
-	refund.Register(gRPCServer, log, handlers.RefundProvider)
+	payment.Register(gRPCServer, log, handlers.PaymentProvider, handlers.PaymentEnhancedProvider, handlers.PaymentWatcher, handlers.QRRenderer)
+	refund.Register(gRPCServer, log, handlers.RefundProvider, handlers.RefundSessionProvider)
 	refund_enhanced.Register(gRPCServer, log, handlers.RefundEnhancedProvider)
 	utility.Register(gRPCServer, log, handlers.UtilityProvider)
+	reconciliation.Register(gRPCServer, log, handlers.ReconciliationProvider)
+	report.Register(gRPCServer, log, handlers.ReportProvider)
+	organization.Register(gRPCServer, log, handlers.OrganizationProvider)
 
 	return &App{
 		log:        log,
@@ -3,15 +3,30 @@ package config
 import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
+	"time"
 )
 
 type Config struct {
 	Env string `env:"ENV" env-default:"dev"`
 
-	HTTPPort int `env:"HTTP_PORT"`
-	GRPCPort int `env:"GRPC_PORT"`
-	KaspiAPI KaspiAPI
-	Database Database
+	HTTPPort       int `env:"HTTP_PORT"`
+	GRPCPort       int `env:"GRPC_PORT"`
+	KaspiAPI       KaspiAPI
+	Retry          Retry
+	Breaker        Breaker
+	Poller         Poller
+	Webhook        Webhook
+	Idempotency    Idempotency
+	DeviceToken    DeviceToken
+	QRImage        QRImage
+	RefundSession  RefundSession
+	RemotePayment  RemotePayment
+	TradePoint     TradePoint
+	Reconciliation Reconciliation
+	Report         Report
+	OneC           OneC
+	Storage        Storage
+	Database       Database
 }
 
 type KaspiAPI struct {
@@ -26,6 +41,106 @@ type KaspiAPI struct {
 	RootCAFile string `env:"KASPI_ROOT_CA_FILE" env-default:""`
 }
 
+type Retry struct {
+	MaxAttempts int           `env:"KASPI_RETRY_MAX_ATTEMPTS" env-default:"3"`
+	BaseDelay   time.Duration `env:"KASPI_RETRY_BASE_DELAY" env-default:"200ms"`
+	MaxDelay    time.Duration `env:"KASPI_RETRY_MAX_DELAY" env-default:"2s"`
+}
+
+type Breaker struct {
+	Enabled          bool          `env:"KASPI_BREAKER_ENABLED" env-default:"true"`
+	FailureThreshold int           `env:"KASPI_BREAKER_FAILURE_THRESHOLD" env-default:"5"`
+	OpenTimeout      time.Duration `env:"KASPI_BREAKER_OPEN_TIMEOUT" env-default:"30s"`
+	HalfOpenMaxCalls int           `env:"KASPI_BREAKER_HALF_OPEN_MAX_CALLS" env-default:"1"`
+}
+
+type Poller struct {
+	Enabled                    bool          `env:"POLLER_ENABLED" env-default:"true"`
+	MaxConcurrent              int           `env:"POLLER_MAX_CONCURRENT" env-default:"10"`
+	DefaultInterval            time.Duration `env:"POLLER_DEFAULT_INTERVAL" env-default:"5s"`
+	DefaultScanTimeout         time.Duration `env:"POLLER_DEFAULT_SCAN_TIMEOUT" env-default:"5m"`
+	DefaultConfirmationTimeout time.Duration `env:"POLLER_DEFAULT_CONFIRMATION_TIMEOUT" env-default:"2m"`
+}
+
+type Webhook struct {
+	URLs         []string      `env:"WEBHOOK_URLS" env-separator:","`
+	Secret       string        `env:"WEBHOOK_SECRET"`
+	MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	BaseDelay    time.Duration `env:"WEBHOOK_BASE_DELAY" env-default:"10s"`
+	MaxDelay     time.Duration `env:"WEBHOOK_MAX_DELAY" env-default:"1h"`
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"5s"`
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+}
+
+type Idempotency struct {
+	Enabled     bool          `env:"IDEMPOTENCY_ENABLED" env-default:"true"`
+	TTL         time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	LockTimeout time.Duration `env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"2m"`
+	WaitTimeout time.Duration `env:"IDEMPOTENCY_WAIT_TIMEOUT" env-default:"10s"`
+}
+
+// DeviceToken caches the tokens of devices that requests address by DeviceId, zero disables the cache.
+// Key or KeyFile holds the base64 encoded 32 byte master key that encrypts stored tokens, PreviousKeys
+// are only needed while tokens are rotated to a new key
+type DeviceToken struct {
+	CacheTTL     time.Duration `env:"DEVICE_TOKEN_CACHE_TTL" env-default:"5m"`
+	Key          string        `env:"DEVICE_TOKEN_KEY"`
+	KeyFile      string        `env:"DEVICE_TOKEN_KEY_FILE"`
+	PreviousKeys []string      `env:"DEVICE_TOKEN_PREVIOUS_KEYS" env-separator:","`
+}
+
+type QRImage struct {
+	DefaultSize            int    `env:"QR_IMAGE_DEFAULT_SIZE" env-default:"256"`
+	MaxSize                int    `env:"QR_IMAGE_MAX_SIZE" env-default:"2048"`
+	DefaultMargin          int    `env:"QR_IMAGE_DEFAULT_MARGIN" env-default:"4"`
+	DefaultErrorCorrection string `env:"QR_IMAGE_DEFAULT_ERROR_CORRECTION" env-default:"M"`
+	LogoFile               string `env:"QR_IMAGE_LOGO_FILE" env-default:""`
+}
+
+type RefundSession struct {
+	Enabled                bool          `env:"REFUND_SESSION_ENABLED" env-default:"true"`
+	DefaultPollingInterval time.Duration `env:"REFUND_SESSION_DEFAULT_POLLING_INTERVAL" env-default:"5s"`
+	DefaultScanTimeout     time.Duration `env:"REFUND_SESSION_DEFAULT_SCAN_TIMEOUT" env-default:"3m"`
+	SelectionTimeout       time.Duration `env:"REFUND_SESSION_SELECTION_TIMEOUT" env-default:"10m"`
+}
+
+type RemotePayment struct {
+	CancelEnabled bool          `env:"REMOTE_PAYMENT_CANCEL_ENABLED" env-default:"true"`
+	CancelTimeout time.Duration `env:"REMOTE_PAYMENT_CANCEL_TIMEOUT" env-default:"5m"`
+	SweepInterval time.Duration `env:"REMOTE_PAYMENT_SWEEP_INTERVAL" env-default:"30s"`
+}
+
+// TradePoint holds how often the trade point cache is synced with Kaspi and after how long without
+// a sync the cached trade points are reported as stale
+type TradePoint struct {
+	SyncEnabled  bool          `env:"TRADEPOINT_SYNC_ENABLED" env-default:"true"`
+	SyncInterval time.Duration `env:"TRADEPOINT_SYNC_INTERVAL" env-default:"1h"`
+	StaleAfter   time.Duration `env:"TRADEPOINT_STALE_AFTER" env-default:"2h"`
+}
+
+type Reconciliation struct {
+	Enabled  bool   `env:"RECONCILIATION_ENABLED" env-default:"true"`
+	RunAt    string `env:"RECONCILIATION_RUN_AT" env-default:"02:00"`
+	Timezone string `env:"RECONCILIATION_TIMEZONE" env-default:"Asia/Almaty"`
+}
+
+type Report struct {
+	Timezone string `env:"REPORT_TIMEZONE" env-default:"Asia/Almaty"`
+}
+
+// OneC holds the JSON file with the organization and the counterparty of every trade point,
+// dates of exported documents use the report time zone
+type OneC struct {
+	MappingFile string `env:"ONEC_MAPPING_FILE" env-default:""`
+}
+
+// Storage selects the backend: postgres, sqlite for a single instance with the database in SQLitePath,
+// or memory for tests and demos, which loses everything on restart
+type Storage struct {
+	Backend    string `env:"STORAGE_BACKEND" env-default:"postgres"`
+	SQLitePath string `env:"SQLITE_PATH" env-default:"kaspi.db"`
+}
+
 type Database struct {
 	Host     string `env:"DB_HOST" env-default:"localhost"`
 	Port     int    `env:"DB_PORT" env-default:"5432"`
@@ -33,6 +148,9 @@ type Database struct {
 	Password string `env:"DB_PASSWORD" env-default:"postgres"`
 	Name     string `env:"DB_NAME" env-default:"kaspi_pay"`
 	SSLMode  string `env:"DB_SSL_MODE" env-default:"disable"`
+
+	// MigrateOnStart applies the pending embedded migrations before the servers start
+	MigrateOnStart bool `env:"DB_MIGRATE_ON_START" env-default:"true"`
 }
 
 var (
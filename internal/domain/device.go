@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Schemes a device is registered in, the basic and standard schemes share the standard device API
+const (
+	DeviceSchemeStandard = "standard"
+	DeviceSchemeEnhanced = "enhanced"
+)
+
+// Device is a device registered through the wrapper. OrganizationBin is set for devices of the
+// enhanced scheme, DeletedAt once the device was deleted in Kaspi
+type Device struct {
+	DeviceID        string     `json:"DeviceId"`
+	DeviceToken     string     `json:"-"` // returned by the registration only
+	TradePointID    int64      `json:"TradePointId"`
+	Scheme          string     `json:"Scheme"`
+	OrganizationBin string     `json:"OrganizationBin,omitempty"`
+	Active          bool       `json:"Active"`
+	CreatedAt       time.Time  `json:"CreatedAt"`
+	DeletedAt       *time.Time `json:"DeletedAt,omitempty"`
+}
+
+// DeviceFilter narrows the device registry, zero values match every device.
+// Deleted devices are only listed with IncludeDeleted
+type DeviceFilter struct {
+	TradePointID    int64  `json:"TradePointId,omitempty"`
+	OrganizationBin string `json:"OrganizationBin,omitempty"`
+	IncludeDeleted  bool   `json:"IncludeDeleted,omitempty"`
+}
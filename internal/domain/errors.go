@@ -7,6 +7,28 @@ import (
 
 var (
 	ErrUnsupportedFeature = errors.New("please use enhanced methods")
+	ErrCircuitOpen        = errors.New("kaspi API circuit breaker is open")
+	ErrNotFound           = errors.New("not found")
+
+	ErrDeviceNotRegistered = fmt.Errorf("device is not registered: %w", ErrNotFound)
+	ErrDeviceInactive      = errors.New("device was deleted, register it again")
+
+	ErrOrganizationNotRegistered = errors.New("organization is not registered")
+	ErrOrganizationDisabled      = errors.New("organization is disabled")
+	ErrOrganizationExists        = errors.New("organization is already registered")
+
+	ErrTradePointNotFound = errors.New("trade point is not found in Kaspi")
+	ErrTradePointDisabled = errors.New("trade point is disabled")
+
+	ErrExternalIDInUse = errors.New("ExternalId is already used by a live payment with a different amount")
+
+	ErrRefundExceedsBalance = errors.New("refund amount exceeds the remaining refundable amount")
+	ErrRefundSessionState   = errors.New("refund session is not awaiting this step")
+
+	ErrReconciliationRunning = errors.New("reconciliation run is already in progress")
+
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
 )
 
 type KaspiError struct {
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Idempotency key statuses
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyRecord is a stored request fingerprint with the response returned for it
+type IdempotencyRecord struct {
+	Key            string
+	Fingerprint    string
+	Status         string
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -11,9 +11,33 @@ type BaseResponse struct {
 
 //////// 	Device domains		////////
 
+// TradePoint is a trade point of the partner. Kaspi returns the ID and the name, the rest is the
+// metadata kept by the wrapper in its trade point cache. Stale is set when the cache was not synced
+// with Kaspi for longer than the configured period
 type TradePoint struct {
-	TradePointID   int64  `json:"TradePointId"`
-	TradePointName string `json:"TradePointName"`
+	TradePointID    int64     `json:"TradePointId"`
+	TradePointName  string    `json:"TradePointName"`
+	OrganizationBin string    `json:"OrganizationBin,omitempty"`
+	Address         string    `json:"Address"`
+	Timezone        string    `json:"Timezone"`
+	Enabled         bool      `json:"Enabled"`
+	MinAmount       float64   `json:"MinAmount"`
+	MaxAmount       float64   `json:"MaxAmount"`
+	SyncedAt        time.Time `json:"SyncedAt"`
+	Stale           bool      `json:"Stale"`
+}
+
+// TradePointUpdateRequest replaces the metadata of a cached trade point, OrganizationBin selects the
+// trade points of an organization in the enhanced scheme. A missing Enabled keeps the current state,
+// zero amount limits mean no limit
+type TradePointUpdateRequest struct {
+	TradePointID    int64   `json:"TradePointId"`
+	OrganizationBin string  `json:"OrganizationBin,omitempty"`
+	Address         string  `json:"Address"`
+	Timezone        string  `json:"Timezone"`
+	Enabled         *bool   `json:"Enabled,omitempty"`
+	MinAmount       float64 `json:"MinAmount"`
+	MaxAmount       float64 `json:"MaxAmount"`
 }
 
 type DeviceRegisterRequest struct {
@@ -31,6 +55,7 @@ type DeviceRegisterResponse struct {
 
 type QRCreateRequest struct {
 	DeviceToken string  `json:"DeviceToken"`
+	DeviceID    string  `json:"DeviceId,omitempty"`
 	Amount      float64 `json:"Amount"`
 	ExternalID  string  `json:"ExternalId,omitempty"`
 }
@@ -51,6 +76,7 @@ type QRCreateResponse struct {
 
 type PaymentLinkCreateRequest struct {
 	DeviceToken string  `json:"DeviceToken"`
+	DeviceID    string  `json:"DeviceId,omitempty"`
 	Amount      float64 `json:"Amount"`
 	ExternalID  string  `json:"ExternalId,omitempty"`
 }
@@ -13,6 +13,7 @@ type EnhancedDeviceDeleteRequest struct {
 
 type EnhancedQRCreateRequest struct {
 	DeviceToken     string  `json:"DeviceToken"`
+	DeviceID        string  `json:"DeviceId,omitempty"`
 	Amount          float64 `json:"Amount"`
 	ExternalID      string  `json:"ExternalId,omitempty"`
 	OrganizationBin string  `json:"OrganizationBin"`
@@ -20,6 +21,7 @@ type EnhancedQRCreateRequest struct {
 
 type EnhancedPaymentLinkCreateRequest struct {
 	DeviceToken     string  `json:"DeviceToken"`
+	DeviceID        string  `json:"DeviceId,omitempty"`
 	Amount          float64 `json:"Amount"`
 	ExternalID      string  `json:"ExternalId,omitempty"`
 	OrganizationBin string  `json:"OrganizationBin"`
@@ -27,6 +29,7 @@ type EnhancedPaymentLinkCreateRequest struct {
 
 type EnhancedRefundRequest struct {
 	DeviceToken     string  `json:"DeviceToken"`
+	DeviceID        string  `json:"DeviceId,omitempty"`
 	QrPaymentID     int64   `json:"QrPaymentId"`
 	Amount          float64 `json:"Amount"`
 	OrganizationBin string  `json:"OrganizationBin"`
@@ -46,6 +49,7 @@ type RemotePaymentRequest struct {
 	Amount          float64 `json:"Amount"`
 	PhoneNumber     string  `json:"PhoneNumber"`
 	DeviceToken     int64   `json:"DeviceToken"`
+	DeviceID        string  `json:"DeviceId,omitempty"`
 	Comment         string  `json:"Comment,omitempty"`
 }
 
@@ -57,6 +61,7 @@ type RemotePaymentCancelRequest struct {
 	OrganizationBin string `json:"OrganizationBin"`
 	QrPaymentID     int64  `json:"QrPaymentId"`
 	DeviceToken     int64  `json:"DeviceToken"`
+	DeviceID        string `json:"DeviceId,omitempty"`
 }
 
 type RemotePaymentCancelResponse struct {
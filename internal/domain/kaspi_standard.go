@@ -6,6 +6,7 @@ import "time"
 
 type QRRefundCreateRequest struct {
 	DeviceToken string `json:"DeviceToken"`
+	DeviceID    string `json:"DeviceId,omitempty"`
 	ExternalID  string `json:"ExternalId,omitempty"`
 }
 
@@ -27,6 +28,7 @@ type RefundStatusResponse struct {
 
 type CustomerOperationsRequest struct {
 	DeviceToken string `json:"DeviceToken"`
+	DeviceID    string `json:"DeviceId,omitempty"`
 	QrReturnID  int64  `json:"QrReturnId"`
 	MaxResult   int64  `json:"MaxResult,omitempty"`
 }
@@ -51,6 +53,7 @@ type PaymentDetailsResponse struct {
 
 type RefundRequest struct {
 	DeviceToken string  `json:"DeviceToken"`
+	DeviceID    string  `json:"DeviceId,omitempty"`
 	QrPaymentID int64   `json:"QrPaymentId"`
 	QrReturnID  int64   `json:"QrReturnId"`
 	Amount      float64 `json:"Amount"`
@@ -60,4 +63,15 @@ type RefundResponse struct {
 	ReturnOperationID int64 `json:"ReturnOperationId"`
 }
 
+// RefundQR is a locally stored refund QR with its latest known status
+type RefundQR struct {
+	QrReturnID  int64     `json:"QrReturnId"`
+	DeviceToken string    `json:"DeviceToken"`
+	ExternalID  string    `json:"ExternalId,omitempty"`
+	ExpireDate  time.Time `json:"ExpireDate,omitempty"`
+	Status      string    `json:"Status"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
 //////// 	End of refund domains	(standard)	////////
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type TestScanRequest struct {
 	QrPaymentID string `json:"qrPaymentId"`
 }
@@ -15,3 +17,11 @@ type TestScanErrorRequest struct {
 type TestConfirmErrorRequest struct {
 	QrPaymentID string `json:"qrPaymentId"`
 }
+
+// CircuitBreakerState describes the breaker of a single Kaspi endpoint
+type CircuitBreakerState struct {
+	Endpoint string     `json:"endpoint"`
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// Formats of the 1C exchange files
+const (
+	OneCFormatCommerceML = "commerceml"
+	OneCFormatStatement  = "statement"
+)
+
+// OneCExportRequest selects the days exported to 1C, dates are YYYY-MM-DD and both are inclusive
+type OneCExportRequest struct {
+	DateFrom     string `json:"DateFrom"`
+	DateTo       string `json:"DateTo"`
+	TradePointID int64  `json:"TradePointId,omitempty"`
+	Format       string `json:"Format"`
+}
+
+// AccountingDocument is a processed payment or a succeeded refund exported to accounting.
+// Kind is SettlementSale or SettlementRefund, RefundID is set for refunds only
+type AccountingDocument struct {
+	Kind            string
+	RefundID        int64
+	QrPaymentID     int64
+	ExternalID      string
+	TransactionID   string
+	TradePointID    int64
+	OrganizationBin string
+	Amount          float64
+	CreatedAt       time.Time
+}
+
+// OneCCounterparty is a 1C counterparty the payments of a trade point are booked against
+type OneCCounterparty struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+	Bin  string `json:"Bin,omitempty"`
+}
+
+// OneCOrganization is the own organization and the bank account Kaspi settles to
+type OneCOrganization struct {
+	Name     string `json:"Name"`
+	Bin      string `json:"Bin"`
+	Account  string `json:"Account"`
+	BankName string `json:"BankName,omitempty"`
+	BankBic  string `json:"BankBic,omitempty"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Organization is an organization the wrapper operates in the enhanced scheme. Enhanced requests
+// with the BIN of an organization that is not registered, or is disabled, are rejected. Settings
+// keep operator defined values, e.g. the accounting code of the organization
+type Organization struct {
+	OrganizationBin string            `json:"OrganizationBin"`
+	Name            string            `json:"Name"`
+	Enabled         bool              `json:"Enabled"`
+	Settings        map[string]string `json:"Settings"`
+	CreatedAt       time.Time         `json:"CreatedAt"`
+	UpdatedAt       time.Time         `json:"UpdatedAt"`
+}
+
+// OrganizationRequest registers or updates an organization, a missing Enabled enables it
+type OrganizationRequest struct {
+	OrganizationBin string            `json:"OrganizationBin"`
+	Name            string            `json:"Name"`
+	Enabled         *bool             `json:"Enabled,omitempty"`
+	Settings        map[string]string `json:"Settings,omitempty"`
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Payment statuses reported by Kaspi (2.3.3), Expired and Canceled are set by the wrapper
+const (
+	PaymentStatusCreated   = "QrTokenCreated"
+	PaymentStatusWait      = "Wait"
+	PaymentStatusProcessed = "Processed"
+	PaymentStatusError     = "Error"
+	PaymentStatusExpired   = "Expired"
+	PaymentStatusCanceled  = "Canceled"
+)
+
+// RemotePaymentStatusCanceled is returned by Kaspi when a remote payment was canceled (4.6.3)
+const RemotePaymentStatusCanceled = "RemotePaymentCanceled"
+
+// Kinds of stored payments
+const (
+	PaymentKindQR     = "qr"
+	PaymentKindLink   = "link"
+	PaymentKindRemote = "remote"
+)
+
+// Payment is a locally stored payment with its latest known status
+type Payment struct {
+	QrPaymentID     int64     `json:"QrPaymentId"`
+	Kind            string    `json:"Kind"`
+	ExternalID      string    `json:"ExternalId,omitempty"`
+	DeviceToken     string    `json:"-"` // never returned to clients
+	TradePointID    int64     `json:"TradePointId,omitempty"`
+	OrganizationBin string    `json:"OrganizationBin,omitempty"`
+	Amount          float64   `json:"Amount"`
+	ExpireDate      time.Time `json:"ExpireDate,omitempty"`
+	PaymentMethods  []string  `json:"PaymentMethods,omitempty"`
+	Status          string    `json:"Status"`
+	TransactionID   string    `json:"TransactionId,omitempty"`
+	ProductType     string    `json:"ProductType,omitempty"`
+	LoanOfferName   string    `json:"LoanOfferName,omitempty"`
+	LoanTerm        int       `json:"LoanTerm,omitempty"`
+	QrToken         string    `json:"QrToken,omitempty"`
+	PaymentLink     string    `json:"PaymentLink,omitempty"`
+	PhoneNumber     string    `json:"PhoneNumber,omitempty"` // masked, remote payments only
+	Comment         string    `json:"Comment,omitempty"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"` // when the payment reached its status
+
+	// Behavior options in seconds as returned by Kaspi on creation
+	StatusPollingInterval      int `json:"-"`
+	ScanWaitTimeout            int `json:"-"`
+	PaymentConfirmationTimeout int `json:"-"`
+}
+
+// IsLive reports whether the payment can still be paid by the customer
+func (p Payment) IsLive(now time.Time) bool {
+	if p.Status != PaymentStatusCreated && p.Status != PaymentStatusWait {
+		return false
+	}
+
+	return p.ExpireDate.IsZero() || p.ExpireDate.After(now)
+}
+
+// PollingOptions rebuilds the options the payment was created with, zero for payments
+// Kaspi gave no behavior options for
+func (p Payment) PollingOptions() PollingOptions {
+	return PollingOptions{
+		Interval:            time.Duration(p.StatusPollingInterval) * time.Second,
+		ScanTimeout:         time.Duration(p.ScanWaitTimeout) * time.Second,
+		ConfirmationTimeout: time.Duration(p.PaymentConfirmationTimeout) * time.Second,
+	}
+}
+
+// QRCreateResponse rebuilds the Kaspi response the QR payment was created with
+func (p Payment) QRCreateResponse() *QRCreateResponse {
+	return &QRCreateResponse{
+		QrToken:        p.QrToken,
+		ExpireDate:     p.ExpireDate,
+		QrPaymentID:    p.QrPaymentID,
+		PaymentMethods: p.PaymentMethods,
+		QrPaymentBehaviorOptions: QRPaymentBehaviorOptions{
+			StatusPollingInterval:      p.StatusPollingInterval,
+			QrCodeScanWaitTimeout:      p.ScanWaitTimeout,
+			PaymentConfirmationTimeout: p.PaymentConfirmationTimeout,
+		},
+	}
+}
+
+// PaymentLinkCreateResponse rebuilds the Kaspi response the payment link was created with
+func (p Payment) PaymentLinkCreateResponse() *PaymentLinkCreateResponse {
+	return &PaymentLinkCreateResponse{
+		PaymentLink:    p.PaymentLink,
+		ExpireDate:     p.ExpireDate,
+		PaymentID:      p.QrPaymentID,
+		PaymentMethods: p.PaymentMethods,
+		PaymentBehaviorOptions: PaymentBehaviorOptions{
+			StatusPollingInterval:      p.StatusPollingInterval,
+			LinkActivationWaitTimeout:  p.ScanWaitTimeout,
+			PaymentConfirmationTimeout: p.PaymentConfirmationTimeout,
+		},
+	}
+}
+
+// IsPending reports whether the payment waits for the customer
+func (p Payment) IsPending() bool {
+	return p.Status == PaymentStatusCreated || p.Status == PaymentStatusWait
+}
+
+// MaskPhoneNumber keeps the country and operator code and the last two digits of a phone number
+func MaskPhoneNumber(phoneNumber string) string {
+	digits := []rune(strings.TrimSpace(phoneNumber))
+	if len(digits) <= 6 {
+		return strings.Repeat("*", len(digits))
+	}
+
+	return string(digits[:4]) + strings.Repeat("*", len(digits)-6) + string(digits[len(digits)-2:])
+}
+
+// IsTerminalPaymentStatus reports whether the payment status can no longer change
+func IsTerminalPaymentStatus(status string) bool {
+	switch status {
+	case PaymentStatusProcessed, PaymentStatusError, PaymentStatusExpired, PaymentStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// PollingOptions tells how long and how often a payment status is polled
+type PollingOptions struct {
+	Interval            time.Duration
+	ScanTimeout         time.Duration
+	ConfirmationTimeout time.Duration
+}
+
+// PollingOptions converts Kaspi behavior options given in seconds
+func (o QRPaymentBehaviorOptions) PollingOptions() PollingOptions {
+	return PollingOptions{
+		Interval:            time.Duration(o.StatusPollingInterval) * time.Second,
+		ScanTimeout:         time.Duration(o.QrCodeScanWaitTimeout) * time.Second,
+		ConfirmationTimeout: time.Duration(o.PaymentConfirmationTimeout) * time.Second,
+	}
+}
+
+// PollingOptions converts Kaspi behavior options given in seconds
+func (o PaymentBehaviorOptions) PollingOptions() PollingOptions {
+	return PollingOptions{
+		Interval:            time.Duration(o.StatusPollingInterval) * time.Second,
+		ScanTimeout:         time.Duration(o.LinkActivationWaitTimeout) * time.Second,
+		ConfirmationTimeout: time.Duration(o.PaymentConfirmationTimeout) * time.Second,
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Sort fields of the payment list, the QrPaymentId breaks ties so that pages are stable
+const (
+	PaymentSortCreatedAt = "CreatedAt"
+	PaymentSortAmount    = "Amount"
+)
+
+// Sort orders of the payment list
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// PaymentListRequest filters and pages stored payments, empty filters match every payment
+type PaymentListRequest struct {
+	TradePointID    int64     `json:"TradePointId,omitempty"`
+	DeviceID        string    `json:"DeviceId,omitempty"`
+	DeviceToken     string    `json:"-"` // resolved from the DeviceId, never taken from clients
+	OrganizationBin string    `json:"OrganizationBin,omitempty"`
+	Statuses        []string  `json:"Status,omitempty"`
+	MinAmount       *float64  `json:"MinAmount,omitempty"`
+	MaxAmount       *float64  `json:"MaxAmount,omitempty"`
+	From            time.Time `json:"From,omitempty"` // creation time, inclusive
+	To              time.Time `json:"To,omitempty"`   // creation time, exclusive
+	ExternalID      string    `json:"ExternalId,omitempty"`
+	Search          string    `json:"Search,omitempty"` // part of the ExternalId or the TransactionId
+	SortBy          string    `json:"SortBy,omitempty"`
+	SortOrder       string    `json:"SortOrder,omitempty"`
+	Limit           int       `json:"Limit,omitempty"`
+	Cursor          string    `json:"Cursor,omitempty"`
+}
+
+// PaymentCursor is the position after the last payment of a page
+type PaymentCursor struct {
+	SortBy      string    `json:"s"`
+	SortOrder   string    `json:"o"`
+	CreatedAt   time.Time `json:"c,omitempty"`
+	Amount      float64   `json:"a,omitempty"`
+	QrPaymentID int64     `json:"i"`
+}
+
+// PaymentFilter is a validated PaymentListRequest with its decoded cursor
+type PaymentFilter struct {
+	PaymentListRequest
+	After *PaymentCursor
+}
+
+// PaymentList is a page of stored payments, NextCursor is empty on the last page
+type PaymentList struct {
+	Items      []Payment `json:"Items"`
+	NextCursor string    `json:"NextCursor,omitempty"`
+}
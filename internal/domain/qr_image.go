@@ -0,0 +1,30 @@
+package domain
+
+// QR image formats
+const (
+	QRImageFormatPNG = "png"
+	QRImageFormatSVG = "svg"
+)
+
+// QR error correction levels, each restores about 7%, 15%, 25% and 30% of damaged modules
+const (
+	QRErrorCorrectionLow     = "L"
+	QRErrorCorrectionMedium  = "M"
+	QRErrorCorrectionQuarter = "Q"
+	QRErrorCorrectionHigh    = "H"
+)
+
+// QRImageOptions tells how a stored QR token is rendered, zero values mean defaults
+type QRImageOptions struct {
+	Format          string
+	Size            int  // image width and height in pixels
+	Margin          *int // quiet zone in modules
+	ErrorCorrection string
+	Logo            bool
+}
+
+// QRImage is a rendered QR token
+type QRImage struct {
+	ContentType string
+	Data        []byte
+}
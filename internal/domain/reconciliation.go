@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// Reconciliation run statuses
+const (
+	ReconciliationRunning   = "running"
+	ReconciliationCompleted = "completed"
+	ReconciliationFailed    = "failed"
+)
+
+// What started a reconciliation run
+const (
+	ReconciliationTriggerScheduled = "scheduled"
+	ReconciliationTriggerManual    = "manual"
+)
+
+// Kinds of discrepancies between local records and Kaspi
+const (
+	DiscrepancyStatusMismatch = "status_mismatch"
+	DiscrepancyAmountMismatch = "amount_mismatch"
+	DiscrepancyRefundMissing  = "refund_missing" // refunds recorded locally exceed what Kaspi refunded
+	DiscrepancyNotFound       = "not_found"      // the record is unknown to Kaspi
+	DiscrepancyCheckFailed    = "check_failed"   // Kaspi rejected the request, the record could not be checked
+)
+
+// Records compared by reconciliation
+const (
+	ReconciliationEntityPayment  = "payment"
+	ReconciliationEntityRefundQR = "refund_qr"
+	ReconciliationEntityRefunds  = "refunds"
+)
+
+// ReconciliationRequest selects the period of records to reconcile by their creation time,
+// the previous day is reconciled when both bounds are omitted
+type ReconciliationRequest struct {
+	From time.Time `json:"From"`
+	To   time.Time `json:"To"`
+}
+
+// ReconciliationRun is a single comparison of local records created in a period against Kaspi
+type ReconciliationRun struct {
+	ID              int64      `json:"Id"`
+	Trigger         string     `json:"Trigger"`
+	From            time.Time  `json:"From"`
+	To              time.Time  `json:"To"`
+	Status          string     `json:"Status"`
+	PaymentsChecked int        `json:"PaymentsChecked"`
+	RefundsChecked  int        `json:"RefundsChecked"`
+	Discrepancies   int        `json:"Discrepancies"`
+	Error           string     `json:"Error,omitempty"`
+	StartedAt       time.Time  `json:"StartedAt"`
+	FinishedAt      *time.Time `json:"FinishedAt,omitempty"`
+
+	// the instance executing the run, it renews HeartbeatAt until the run finishes
+	Owner       string    `json:"-"`
+	HeartbeatAt time.Time `json:"-"`
+}
+
+// ReconciliationDiscrepancy is a mismatch found by a reconciliation run
+type ReconciliationDiscrepancy struct {
+	ID          int64     `json:"Id"`
+	RunID       int64     `json:"RunId"`
+	Kind        string    `json:"Kind"`
+	Entity      string    `json:"Entity"`
+	QrPaymentID int64     `json:"QrPaymentId,omitempty"`
+	QrReturnID  int64     `json:"QrReturnId,omitempty"`
+	LocalValue  string    `json:"LocalValue,omitempty"`
+	RemoteValue string    `json:"RemoteValue,omitempty"`
+	Message     string    `json:"Message"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+}
+
+// ReconciliationReport is a run together with its discrepancies
+type ReconciliationReport struct {
+	ReconciliationRun
+	Items []ReconciliationDiscrepancy `json:"Items"`
+}
+
+// RefundTotal is the sum of succeeded refunds of a payment in the local ledger
+type RefundTotal struct {
+	QrPaymentID int64
+	DeviceToken string
+	Amount      float64
+}
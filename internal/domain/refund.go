@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Refund statuses in the local refund ledger
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund is a refund recorded in the local ledger, it is reserved as pending before Kaspi is called
+type Refund struct {
+	ID                int64     `json:"Id"`
+	QrPaymentID       int64     `json:"QrPaymentId"`
+	QrReturnID        int64     `json:"QrReturnId,omitempty"`
+	ReturnOperationID int64     `json:"ReturnOperationId,omitempty"`
+	Amount            float64   `json:"Amount"`
+	DeviceToken       string    `json:"DeviceToken"`
+	OrganizationBin   string    `json:"OrganizationBin,omitempty"`
+	Initiator         string    `json:"Initiator,omitempty"`
+	Status            string    `json:"Status"`
+	Error             string    `json:"Error,omitempty"`
+	CreatedAt         time.Time `json:"CreatedAt"`
+	UpdatedAt         time.Time `json:"UpdatedAt"`
+}
+
+// RefundBalance is what the ledger knows about a payment at the moment a refund is reserved
+type RefundBalance struct {
+	PaymentAmount float64 // zero when the payment was not created through the wrapper
+	Succeeded     float64
+	Pending       float64
+}
+
+// RefundBalanceError is returned when a refund would exceed the remaining refundable amount
+type RefundBalanceError struct {
+	Requested float64
+	Remaining float64
+}
+
+// Error implements error interface
+func (e *RefundBalanceError) Error() string {
+	return fmt.Sprintf("refund amount %.2f exceeds the remaining refundable amount %.2f", e.Requested, e.Remaining)
+}
+
+// Is makes errors.Is match ErrRefundExceedsBalance
+func (e *RefundBalanceError) Is(target error) bool {
+	return target == ErrRefundExceedsBalance
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// Refund session states, a session moves from awaiting_scan to awaiting_selection once the customer
+// scanned the refund QR and their operations are known, and then to refunding and completed
+const (
+	RefundSessionAwaitingScan      = "awaiting_scan"
+	RefundSessionAwaitingSelection = "awaiting_selection"
+	RefundSessionRefunding         = "refunding"
+	RefundSessionCompleted         = "completed"
+	RefundSessionFailed            = "failed"
+	RefundSessionExpired           = "expired"
+)
+
+type RefundSessionCreateRequest struct {
+	DeviceToken string `json:"DeviceToken"`
+	DeviceID    string `json:"DeviceId,omitempty"`
+	ExternalID  string `json:"ExternalId,omitempty"`
+	MaxResult   int64  `json:"MaxResult,omitempty"`
+}
+
+// RefundSessionRefundRequest selects the customer operation to refund and the amount
+type RefundSessionRefundRequest struct {
+	QrPaymentID int64   `json:"QrPaymentId"`
+	Amount      float64 `json:"Amount"`
+}
+
+// RefundSession is a persisted standard-scheme refund flow driven by the service
+type RefundSession struct {
+	QrReturnID              int64                   `json:"QrReturnId"`
+	DeviceToken             string                  `json:"-"` // kept for the later steps, never returned
+	ExternalID              string                  `json:"ExternalId,omitempty"`
+	MaxResult               int64                   `json:"-"`
+	QrToken                 string                  `json:"QrToken"`
+	ExpireDate              time.Time               `json:"ExpireDate"`
+	QrRefundBehaviorOptions QRRefundBehaviorOptions `json:"QrReturnBehaviorOptions"`
+
+	State       string `json:"State"`
+	KaspiStatus string `json:"Status,omitempty"`
+	// Deadline is when the current waiting state expires, it is nil in other states
+	Deadline   *time.Time          `json:"Deadline,omitempty"`
+	Operations []CustomerOperation `json:"Operations,omitempty"`
+
+	QrPaymentID           int64   `json:"QrPaymentId,omitempty"`
+	Amount                float64 `json:"Amount,omitempty"`
+	AvailableReturnAmount float64 `json:"AvailableReturnAmount,omitempty"`
+	ReturnOperationID     int64   `json:"ReturnOperationId,omitempty"`
+	Error                 string  `json:"Error,omitempty"`
+
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+// IsWaiting reports whether the session waits for the customer or the cashier
+func (s RefundSession) IsWaiting() bool {
+	return s.State == RefundSessionAwaitingScan || s.State == RefundSessionAwaitingSelection
+}
+
+// Operation returns the customer operation with the QrPaymentId
+func (s RefundSession) Operation(qrPaymentID int64) (CustomerOperation, bool) {
+	for _, op := range s.Operations {
+		if op.QrPaymentID == qrPaymentID {
+			return op, true
+		}
+	}
+
+	return CustomerOperation{}, false
+}
@@ -0,0 +1,103 @@
+package domain
+
+import "time"
+
+// Export formats of reports
+const (
+	ReportFormatJSON = "json"
+	ReportFormatCSV  = "csv"
+	ReportFormatXLSX = "xlsx"
+)
+
+// Kinds of settlement entries
+const (
+	SettlementSale   = "sale"
+	SettlementRefund = "refund"
+)
+
+// ProductTypeUnknown groups payments whose product type was never reported by Kaspi,
+// and in the payment methods breakdown those stored without their payment methods
+const ProductTypeUnknown = "Unknown"
+
+// SettlementReportRequest selects the days of a settlement report, dates are YYYY-MM-DD
+// in the report time zone and both are inclusive
+type SettlementReportRequest struct {
+	DateFrom        string `json:"DateFrom"`
+	DateTo          string `json:"DateTo"`
+	TradePointID    int64  `json:"TradePointId,omitempty"`
+	OrganizationBin string `json:"OrganizationBin,omitempty"`
+}
+
+// SettlementEntry is a processed payment or a succeeded refund counted in a settlement report
+type SettlementEntry struct {
+	Kind           string
+	QrPaymentID    int64
+	TradePointID   int64
+	DeviceID       string
+	ProductType    string
+	PaymentMethods []string
+	Amount         float64
+	CreatedAt      time.Time
+}
+
+// SettlementTotals sums sales and refunds, Net is GrossSales minus Refunds
+type SettlementTotals struct {
+	GrossSales   float64 `json:"GrossSales"`
+	Refunds      float64 `json:"Refunds"`
+	Net          float64 `json:"Net"`
+	SalesCount   int     `json:"SalesCount"`
+	RefundsCount int     `json:"RefundsCount"`
+}
+
+// SettlementBreakdown is the share of a product type, e.g. Gold, Red or Loan, in the totals
+type SettlementBreakdown struct {
+	ProductType string `json:"ProductType"`
+	SettlementTotals
+}
+
+// SettlementMethodsBreakdown is the share of payments that offered the same payment methods,
+// e.g. only Gold or Gold, Red and Loan, in the totals
+type SettlementMethodsBreakdown struct {
+	PaymentMethods []string `json:"PaymentMethods"`
+	SettlementTotals
+}
+
+// SettlementSummary holds the totals of a day, a trade point or a device with their breakdowns
+type SettlementSummary struct {
+	SettlementTotals
+	ByProductType    []SettlementBreakdown        `json:"ByProductType"`
+	ByPaymentMethods []SettlementMethodsBreakdown `json:"ByPaymentMethods"`
+}
+
+type SettlementDevice struct {
+	DeviceID string `json:"DeviceId"`
+	SettlementSummary
+}
+
+type SettlementTradePoint struct {
+	TradePointID int64 `json:"TradePointId"`
+	SettlementSummary
+	Devices []SettlementDevice `json:"Devices"`
+}
+
+type SettlementDay struct {
+	Date string `json:"Date"`
+	SettlementSummary
+	TradePoints []SettlementTradePoint `json:"TradePoints"`
+}
+
+// SettlementReport summarizes sales and refunds per day, trade point and device
+type SettlementReport struct {
+	DateFrom    string          `json:"DateFrom"`
+	DateTo      string          `json:"DateTo"`
+	Timezone    string          `json:"Timezone"`
+	GeneratedAt time.Time       `json:"GeneratedAt"`
+	Days        []SettlementDay `json:"Days"`
+}
+
+// ReportFile is an exported report ready for download
+type ReportFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
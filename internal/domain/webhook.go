@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types
+const (
+	EventPaymentStatusChanged       = "payment.status_changed"
+	EventRemotePaymentStatusChanged = "remote_payment.status_changed"
+	EventRefundStatusChanged        = "refund.status_changed"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+// WebhookEvent is a status change pushed to merchant-configured URLs
+type WebhookEvent struct {
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	SubjectID      int64           `json:"subjectId"`
+	PreviousStatus string          `json:"previousStatus,omitempty"`
+	Status         string          `json:"status"`
+	OccurredAt     time.Time       `json:"occurredAt"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+// PaymentEventData is the payment sent in webhook events, the device token
+// is left out so it never leaves the service
+type PaymentEventData struct {
+	QrPaymentID     int64     `json:"QrPaymentId"`
+	Kind            string    `json:"Kind"`
+	ExternalID      string    `json:"ExternalId,omitempty"`
+	TradePointID    int64     `json:"TradePointId,omitempty"`
+	OrganizationBin string    `json:"OrganizationBin,omitempty"`
+	Amount          float64   `json:"Amount"`
+	ExpireDate      time.Time `json:"ExpireDate,omitempty"`
+	PaymentMethods  []string  `json:"PaymentMethods,omitempty"`
+	Status          string    `json:"Status"`
+	TransactionID   string    `json:"TransactionId,omitempty"`
+	ProductType     string    `json:"ProductType,omitempty"`
+	LoanOfferName   string    `json:"LoanOfferName,omitempty"`
+	LoanTerm        int       `json:"LoanTerm,omitempty"`
+	PhoneNumber     string    `json:"PhoneNumber,omitempty"`
+	Comment         string    `json:"Comment,omitempty"`
+	CreatedAt       time.Time `json:"CreatedAt"`
+	UpdatedAt       time.Time `json:"UpdatedAt"`
+}
+
+// NewPaymentEventData copies the payment fields that are safe to publish
+func NewPaymentEventData(p Payment) PaymentEventData {
+	return PaymentEventData{
+		QrPaymentID:     p.QrPaymentID,
+		Kind:            p.Kind,
+		ExternalID:      p.ExternalID,
+		TradePointID:    p.TradePointID,
+		OrganizationBin: p.OrganizationBin,
+		Amount:          p.Amount,
+		ExpireDate:      p.ExpireDate,
+		PaymentMethods:  p.PaymentMethods,
+		Status:          p.Status,
+		TransactionID:   p.TransactionID,
+		ProductType:     p.ProductType,
+		LoanOfferName:   p.LoanOfferName,
+		LoanTerm:        p.LoanTerm,
+		PhoneNumber:     p.PhoneNumber,
+		Comment:         p.Comment,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// RefundEventData is the refund QR sent in webhook events without its device token
+type RefundEventData struct {
+	QrReturnID int64     `json:"QrReturnId"`
+	ExternalID string    `json:"ExternalId,omitempty"`
+	ExpireDate time.Time `json:"ExpireDate,omitempty"`
+	Status     string    `json:"Status"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+	UpdatedAt  time.Time `json:"UpdatedAt"`
+}
+
+// NewRefundEventData copies the refund QR fields that are safe to publish
+func NewRefundEventData(r RefundQR) RefundEventData {
+	return RefundEventData{
+		QrReturnID: r.QrReturnID,
+		ExternalID: r.ExternalID,
+		ExpireDate: r.ExpireDate,
+		Status:     r.Status,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+// WebhookDelivery is a single attempt queue entry of an event for one URL
+type WebhookDelivery struct {
+	ID       int64
+	URL      string
+	Attempts int
+	Event    WebhookEvent
+}
+
+// WebhookReplayFilter selects events that should be delivered again
+type WebhookReplayFilter struct {
+	EventID string    `json:"EventId,omitempty"`
+	Since   time.Time `json:"Since,omitempty"`
+	Until   time.Time `json:"Until,omitempty"`
+}
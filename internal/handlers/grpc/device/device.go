@@ -3,6 +3,7 @@ package device
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers"
 	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
@@ -45,18 +46,46 @@ func (s *serverAPI) GetTradePoints(ctx context.Context, req *devicev1.GetTradePo
 		return nil, grpchandler.HandleError(err, log)
 	}
 
-	resp := &devicev1.GetTradePointsResponse{
-		Tradepoints: make([]*devicev1.TradePoint, 0, len(tradePoints)),
+	return toTradePointsResponse(tradePoints), nil
+}
+
+// RefreshTradePoints implements kaspiv1.DeviceServiceServer
+func (s *serverAPI) RefreshTradePoints(ctx context.Context, req *devicev1.GetTradePointsRequest) (*devicev1.GetTradePointsResponse, error) {
+	log := s.log.With(
+		slog.String("method", "RefreshTradePoints"),
+	)
+
+	tradePoints, err := s.deviceProvider.RefreshTradePoints(ctx)
+	if err != nil {
+		log.Error("RefreshTradePoints failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
 	}
 
-	for _, tp := range tradePoints {
-		resp.Tradepoints = append(resp.Tradepoints, &devicev1.TradePoint{
-			TradepointId:   tp.TradePointID,
-			TradepointName: tp.TradePointName,
-		})
+	return toTradePointsResponse(tradePoints), nil
+}
+
+// UpdateTradePoint implements kaspiv1.DeviceServiceServer
+func (s *serverAPI) UpdateTradePoint(ctx context.Context, req *devicev1.UpdateTradePointRequest) (*devicev1.TradePoint, error) {
+	log := s.log.With(
+		slog.String("method", "UpdateTradePoint"),
+		slog.Int64("tradepointId", req.TradepointId),
+	)
+
+	tradePoint, err := s.deviceProvider.UpdateTradePoint(ctx, domain.TradePointUpdateRequest{
+		TradePointID:    req.TradepointId,
+		OrganizationBin: req.OrganizationBin,
+		Address:         req.Address,
+		Timezone:        req.Timezone,
+		Enabled:         req.Enabled,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+	})
+	if err != nil {
+		log.Error("failed to update trade point", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
 	}
 
-	return resp, nil
+	return toTradePoint(*tradePoint), nil
 }
 
 // RegisterDevice implements kaspiv1.DeviceServiceServer
@@ -98,3 +127,95 @@ func (s *serverAPI) DeleteDevice(ctx context.Context, req *devicev1.DeleteDevice
 
 	return &devicev1.DeleteDeviceResponse{}, nil
 }
+
+// ListDevices implements kaspiv1.DeviceServiceServer
+func (s *serverAPI) ListDevices(ctx context.Context, req *devicev1.ListDevicesRequest) (*devicev1.ListDevicesResponse, error) {
+	log := s.log.With(
+		slog.String("method", "ListDevices"),
+		slog.Int64("tradepointId", req.TradepointId),
+		slog.String("organizationBin", req.OrganizationBin),
+	)
+
+	devices, err := s.deviceProvider.ListDevices(ctx, domain.DeviceFilter{
+		TradePointID:    req.TradepointId,
+		OrganizationBin: req.OrganizationBin,
+		IncludeDeleted:  req.IncludeDeleted,
+	})
+	if err != nil {
+		log.Error("failed to list devices", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	resp := &devicev1.ListDevicesResponse{
+		Devices: make([]*devicev1.Device, 0, len(devices)),
+	}
+	for _, device := range devices {
+		resp.Devices = append(resp.Devices, toDevice(device))
+	}
+
+	return resp, nil
+}
+
+// GetDevice implements kaspiv1.DeviceServiceServer
+func (s *serverAPI) GetDevice(ctx context.Context, req *devicev1.GetDeviceRequest) (*devicev1.Device, error) {
+	log := s.log.With(
+		slog.String("method", "GetDevice"),
+		slog.String("deviceId", req.DeviceId),
+	)
+
+	device, err := s.deviceProvider.GetDevice(ctx, req.DeviceId)
+	if err != nil {
+		log.Error("failed to get device", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	return toDevice(*device), nil
+}
+
+func toDevice(device domain.Device) *devicev1.Device {
+	resp := &devicev1.Device{
+		DeviceId:        device.DeviceID,
+		TradepointId:    device.TradePointID,
+		OrganizationBin: device.OrganizationBin,
+		Active:          device.Active,
+		CreatedAt:       timestamppb.New(device.CreatedAt),
+	}
+
+	if device.DeletedAt != nil {
+		resp.DeletedAt = timestamppb.New(*device.DeletedAt)
+	}
+
+	return resp
+}
+
+func toTradePointsResponse(tradePoints []domain.TradePoint) *devicev1.GetTradePointsResponse {
+	resp := &devicev1.GetTradePointsResponse{
+		Tradepoints: make([]*devicev1.TradePoint, 0, len(tradePoints)),
+	}
+
+	for _, tp := range tradePoints {
+		resp.Tradepoints = append(resp.Tradepoints, toTradePoint(tp))
+	}
+
+	return resp
+}
+
+func toTradePoint(tp domain.TradePoint) *devicev1.TradePoint {
+	tradePoint := &devicev1.TradePoint{
+		TradepointId:    tp.TradePointID,
+		TradepointName:  tp.TradePointName,
+		OrganizationBin: tp.OrganizationBin,
+		Address:         tp.Address,
+		Timezone:        tp.Timezone,
+		Enabled:         tp.Enabled,
+		MinAmount:       tp.MinAmount,
+		MaxAmount:       tp.MaxAmount,
+		Stale:           tp.Stale,
+	}
+
+	if !tp.SyncedAt.IsZero() {
+		tradePoint.SyncedAt = timestamppb.New(tp.SyncedAt)
+	}
+
+	return tradePoint
+}
@@ -15,18 +15,18 @@ func (s *serverAPI) GetTradePointsEnhanced(ctx context.Context, req *devicev1.Ge
 		return nil, grpchandler.HandleError(err, s.log)
 	}
 
-	resp := &devicev1.GetTradePointsResponse{
-		Tradepoints: make([]*devicev1.TradePoint, 0, len(tradePoints)),
-	}
+	return toTradePointsResponse(tradePoints), nil
+}
 
-	for _, tp := range tradePoints {
-		resp.Tradepoints = append(resp.Tradepoints, &devicev1.TradePoint{
-			TradepointId:   tp.TradePointID,
-			TradepointName: tp.TradePointName,
-		})
+// RefreshTradePointsEnhanced implements kaspiv1.DeviceServiceServer
+func (s *serverAPI) RefreshTradePointsEnhanced(ctx context.Context, req *devicev1.GetTradePointsEnhancedRequest) (*devicev1.GetTradePointsResponse, error) {
+	tradePoints, err := s.deviceEnhancedProvider.RefreshTradePointsEnhanced(ctx, req.OrganizationBin)
+	if err != nil {
+		s.log.Error("RefreshTradePointsEnhanced failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
 	}
 
-	return resp, nil
+	return toTradePointsResponse(tradePoints), nil
 }
 
 // RegisterDeviceEnhanced implements kaspiv1.DeviceServiceServer
@@ -10,15 +10,20 @@ import (
 )
 
 type MockDeviceEnhancedProvider struct {
-	GetTradePointsEnhancedFunc func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
-	RegisterDeviceEnhancedFunc func(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
-	DeleteDeviceEnhancedFunc   func(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error
+	GetTradePointsEnhancedFunc     func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	RefreshTradePointsEnhancedFunc func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	RegisterDeviceEnhancedFunc     func(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
+	DeleteDeviceEnhancedFunc       func(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error
 }
 
 func (m *MockDeviceEnhancedProvider) GetTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
 	return m.GetTradePointsEnhancedFunc(ctx, organizationBin)
 }
 
+func (m *MockDeviceEnhancedProvider) RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	return m.RefreshTradePointsEnhancedFunc(ctx, organizationBin)
+}
+
 func (m *MockDeviceEnhancedProvider) RegisterDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error) {
 	return m.RegisterDeviceEnhancedFunc(ctx, req)
 }
@@ -1,15 +1,19 @@
 package device_test
 
 import (
+	"bytes"
 	"context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers/grpc/device"
+	"kaspi-api-wrapper/internal/storage"
 	devicev1 "kaspi-api-wrapper/pkg/protos/gen/go/device"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 )
 
 func setupTestLogger() *slog.Logger {
@@ -19,15 +23,27 @@ func setupTestLogger() *slog.Logger {
 }
 
 type MockDeviceProvider struct {
-	GetTradePointsFunc func(ctx context.Context) ([]domain.TradePoint, error)
-	RegisterDeviceFunc func(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
-	DeleteDeviceFunc   func(ctx context.Context, deviceToken string) error
+	GetTradePointsFunc     func(ctx context.Context) ([]domain.TradePoint, error)
+	RefreshTradePointsFunc func(ctx context.Context) ([]domain.TradePoint, error)
+	UpdateTradePointFunc   func(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error)
+	RegisterDeviceFunc     func(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
+	DeleteDeviceFunc       func(ctx context.Context, deviceToken string) error
+	ListDevicesFunc        func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	GetDeviceFunc          func(ctx context.Context, deviceID string) (*domain.Device, error)
 }
 
 func (m *MockDeviceProvider) GetTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
 	return m.GetTradePointsFunc(ctx)
 }
 
+func (m *MockDeviceProvider) RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
+	return m.RefreshTradePointsFunc(ctx)
+}
+
+func (m *MockDeviceProvider) UpdateTradePoint(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+	return m.UpdateTradePointFunc(ctx, req)
+}
+
 func (m *MockDeviceProvider) RegisterDevice(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error) {
 	return m.RegisterDeviceFunc(ctx, req)
 }
@@ -36,6 +52,14 @@ func (m *MockDeviceProvider) DeleteDevice(ctx context.Context, deviceToken strin
 	return m.DeleteDeviceFunc(ctx, deviceToken)
 }
 
+func (m *MockDeviceProvider) ListDevices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	return m.ListDevicesFunc(ctx, filter)
+}
+
+func (m *MockDeviceProvider) GetDevice(ctx context.Context, deviceID string) (*domain.Device, error) {
+	return m.GetDeviceFunc(ctx, deviceID)
+}
+
 func createTestServer(deviceProvider *MockDeviceProvider, deviceEnhancedProvider *MockDeviceEnhancedProvider) *deviceServer {
 	log := setupTestLogger()
 	srv := &deviceServer{
@@ -257,3 +281,102 @@ func TestDeleteDevice(t *testing.T) {
 		}
 	})
 }
+
+func TestListDevices(t *testing.T) {
+	deletedAt := time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	mockProvider := &MockDeviceProvider{
+		ListDevicesFunc: func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+			if filter.TradePointID != 7 || !filter.IncludeDeleted {
+				t.Errorf("Unexpected filter: %+v", filter)
+			}
+			return []domain.Device{
+				{DeviceID: "ACTIVE", DeviceToken: "secret-token", TradePointID: 7, Active: true},
+				{DeviceID: "DELETED", TradePointID: 7, DeletedAt: &deletedAt},
+			}, nil
+		},
+	}
+
+	srv := createTestServer(mockProvider, nil)
+
+	resp, err := srv.server.ListDevices(context.Background(), &devicev1.ListDevicesRequest{
+		TradepointId:   7,
+		IncludeDeleted: true,
+	})
+	if err != nil {
+		t.Fatalf("ListDevices returned error: %v", err)
+	}
+
+	if len(resp.Devices) != 2 {
+		t.Fatalf("Expected 2 devices, got %d", len(resp.Devices))
+	}
+
+	if resp.Devices[0].DeletedAt != nil {
+		t.Errorf("Expected active device without deletion time")
+	}
+
+	if !resp.Devices[1].DeletedAt.AsTime().Equal(deletedAt) {
+		t.Errorf("Expected deletion time %s, got %s", deletedAt, resp.Devices[1].DeletedAt.AsTime())
+	}
+
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	if bytes.Contains(data, []byte("secret-token")) {
+		t.Errorf("Device token must not be returned")
+	}
+}
+
+func TestGetDevice(t *testing.T) {
+	mockProvider := &MockDeviceProvider{
+		GetDeviceFunc: func(ctx context.Context, deviceID string) (*domain.Device, error) {
+			return nil, storage.ErrDeviceNotFound
+		},
+	}
+
+	srv := createTestServer(mockProvider, nil)
+
+	_, err := srv.server.GetDevice(context.Background(), &devicev1.GetDeviceRequest{DeviceId: "UNKNOWN"})
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected status code %s, got %s", codes.NotFound, status.Code(err))
+	}
+}
+
+func TestUpdateTradePoint(t *testing.T) {
+	syncedAt := time.Now().Add(-3 * time.Hour)
+
+	mockProvider := &MockDeviceProvider{
+		UpdateTradePointFunc: func(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+			if req.TradePointID != 1 || req.Enabled == nil || *req.Enabled || req.MaxAmount != 50000 {
+				t.Errorf("Unexpected request: %+v", req)
+			}
+			return &domain.TradePoint{
+				TradePointID:   req.TradePointID,
+				TradePointName: "Store 1",
+				Enabled:        *req.Enabled,
+				MaxAmount:      req.MaxAmount,
+				SyncedAt:       syncedAt,
+				Stale:          true,
+			}, nil
+		},
+	}
+
+	srv := createTestServer(mockProvider, nil)
+
+	enabled := false
+	resp, err := srv.server.UpdateTradePoint(context.Background(), &devicev1.UpdateTradePointRequest{
+		TradepointId: 1,
+		Enabled:      &enabled,
+		MaxAmount:    50000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.TradepointName != "Store 1" || resp.Enabled || resp.MaxAmount != 50000 || !resp.Stale ||
+		!resp.SyncedAt.AsTime().Equal(syncedAt.UTC()) {
+		t.Errorf("Unexpected trade point: %+v", resp)
+	}
+}
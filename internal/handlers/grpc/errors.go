@@ -16,6 +16,72 @@ func HandleError(err error, log *slog.Logger) error {
 		return status.Error(codes.PermissionDenied, err.Error())
 	}
 
+	if errors.Is(err, domain.ErrCircuitOpen) {
+		log.Warn("kaspi API circuit breaker is open", "error", err.Error())
+		return status.Error(codes.Unavailable, "Kaspi Pay service is temporarily unavailable")
+	}
+
+	if errors.Is(err, domain.ErrDeviceNotRegistered) {
+		log.Warn("device is not registered", "error", err.Error())
+		return status.Error(codes.NotFound, "Device is not registered")
+	}
+
+	if errors.Is(err, domain.ErrDeviceInactive) {
+		log.Warn("device is deleted", "error", err.Error())
+		return status.Error(codes.FailedPrecondition, "Device was deleted, register it again")
+	}
+
+	if errors.Is(err, domain.ErrOrganizationNotRegistered) {
+		log.Warn("organization is not registered", "error", err.Error())
+		return status.Error(codes.PermissionDenied, "Organization is not registered")
+	}
+
+	if errors.Is(err, domain.ErrOrganizationDisabled) {
+		log.Warn("organization is disabled", "error", err.Error())
+		return status.Error(codes.PermissionDenied, "Organization is disabled")
+	}
+
+	if errors.Is(err, domain.ErrTradePointNotFound) {
+		log.Warn("trade point is not found", "error", err.Error())
+		return status.Error(codes.NotFound, "Trade point not found")
+	}
+
+	if errors.Is(err, domain.ErrTradePointDisabled) {
+		log.Warn("trade point is disabled", "error", err.Error())
+		return status.Error(codes.FailedPrecondition, "Trade point is disabled")
+	}
+
+	if errors.Is(err, domain.ErrNotFound) {
+		log.Warn("resource not found", "error", err.Error())
+		return status.Error(codes.NotFound, "Resource not found")
+	}
+
+	if errors.Is(err, domain.ErrOrganizationExists) {
+		log.Warn("organization conflict", "error", err.Error())
+		return status.Error(codes.AlreadyExists, "Organization is already registered")
+	}
+
+	if errors.Is(err, domain.ErrExternalIDInUse) {
+		log.Warn("external ID conflict", "error", err.Error())
+		return status.Error(codes.AlreadyExists, "ExternalId is already used by a live payment with a different amount")
+	}
+
+	if errors.Is(err, domain.ErrRefundSessionState) {
+		log.Warn("refund session state conflict", "error", err.Error())
+		return status.Error(codes.FailedPrecondition, "Refund session is not awaiting this step")
+	}
+
+	if errors.Is(err, domain.ErrReconciliationRunning) {
+		log.Warn("reconciliation conflict", "error", err.Error())
+		return status.Error(codes.Aborted, "Reconciliation run is already in progress")
+	}
+
+	var balanceErr *domain.RefundBalanceError
+	if errors.As(err, &balanceErr) {
+		log.Warn("refund exceeds remaining balance", "error", err.Error())
+		return status.Errorf(codes.FailedPrecondition, "Refund amount exceeds the remaining refundable amount of %.2f", balanceErr.Remaining)
+	}
+
 	var valErr *validator.ValidationError
 	if errors.As(err, &valErr) {
 		log.Warn("validation error", "error", err.Error())
@@ -2,6 +2,7 @@ package grpchandler_test
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -39,6 +40,111 @@ func TestHandleError(t *testing.T) {
 		}
 	})
 
+	t.Run("handles circuit breaker open error", func(t *testing.T) {
+		err := fmt.Errorf("GET /partner/tradepoints: %w", domain.ErrCircuitOpen)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.Unavailable {
+			t.Errorf("Expected code Unavailable, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles not found error", func(t *testing.T) {
+		err := fmt.Errorf("refund %w", domain.ErrNotFound)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.NotFound {
+			t.Errorf("Expected code NotFound, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles unknown organization", func(t *testing.T) {
+		err := fmt.Errorf("service.kaspi.resolveOrganization: 180340021791: %w", domain.ErrOrganizationNotRegistered)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.PermissionDenied {
+			t.Errorf("Expected code PermissionDenied, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles disabled trade point", func(t *testing.T) {
+		err := fmt.Errorf("service.kaspi.RegisterDevice:2: %w", domain.ErrTradePointDisabled)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("Expected code FailedPrecondition, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles external ID conflict", func(t *testing.T) {
+		err := fmt.Errorf("service.kaspi.CreateQR: %w", domain.ErrExternalIDInUse)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.AlreadyExists {
+			t.Errorf("Expected code AlreadyExists, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles reconciliation in progress", func(t *testing.T) {
+		err := fmt.Errorf("reconciliation.StartReconciliation: %w", domain.ErrReconciliationRunning)
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.Aborted {
+			t.Errorf("Expected code Aborted, got %s", st.Code())
+		}
+	})
+
+	t.Run("handles refund exceeding balance", func(t *testing.T) {
+		err := fmt.Errorf("service.kaspi.RefundPayment: %w", &domain.RefundBalanceError{Requested: 100, Remaining: 30})
+
+		result := grpchandler.HandleError(err, log)
+
+		st, ok := status.FromError(result)
+		if !ok {
+			t.Fatal("Expected gRPC status error")
+		}
+
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("Expected code FailedPrecondition, got %s", st.Code())
+		}
+	})
+
 	t.Run("handles validation error", func(t *testing.T) {
 		err := &validator.ValidationError{
 			Field:   "deviceId",
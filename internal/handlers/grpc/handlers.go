@@ -16,6 +16,15 @@ type Handlers struct {
 	DeviceEnhancedProvider  handlers.DeviceEnhancedProvider
 	PaymentEnhancedProvider handlers.PaymentEnhancedProvider
 	RefundEnhancedProvider  handlers.RefundEnhancedProvider
+
+	PaymentWatcher handlers.PaymentWatcher
+	QRRenderer     handlers.QRRenderer
+
+	IdempotencyGuard       handlers.IdempotencyGuard
+	RefundSessionProvider  handlers.RefundSessionProvider
+	ReconciliationProvider handlers.ReconciliationProvider
+	ReportProvider         handlers.ReportProvider
+	OrganizationProvider   handlers.OrganizationProvider
 	//kaspiSvc *service.KaspiService
 }
 
@@ -30,6 +39,15 @@ func NewHandlers(
 	deviceEnhancedProvider handlers.DeviceEnhancedProvider,
 	paymentEnhancedProvider handlers.PaymentEnhancedProvider,
 	refundEnhancedProvider handlers.RefundEnhancedProvider,
+
+	paymentWatcher handlers.PaymentWatcher,
+	qrRenderer handlers.QRRenderer,
+
+	idempotencyGuard handlers.IdempotencyGuard,
+	refundSessionProvider handlers.RefundSessionProvider,
+	reconciliationProvider handlers.ReconciliationProvider,
+	reportProvider handlers.ReportProvider,
+	organizationProvider handlers.OrganizationProvider,
 ) *Handlers {
 	return &Handlers{
 		log:             log,
@@ -41,6 +59,15 @@ func NewHandlers(
 		DeviceEnhancedProvider:  deviceEnhancedProvider,
 		PaymentEnhancedProvider: paymentEnhancedProvider,
 		RefundEnhancedProvider:  refundEnhancedProvider,
+
+		PaymentWatcher: paymentWatcher,
+		QRRenderer:     qrRenderer,
+
+		IdempotencyGuard:       idempotencyGuard,
+		RefundSessionProvider:  refundSessionProvider,
+		ReconciliationProvider: reconciliationProvider,
+		ReportProvider:         reportProvider,
+		OrganizationProvider:   organizationProvider,
 		//kaspiSvc: kaspiSvc,
 	}
 }
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/idempotency"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	IdempotencyKeyMetadata     = "idempotency-key"
+	IdempotentReplayedMetadata = "idempotent-replayed"
+	maxIdempotencyKeyLength    = 255
+)
+
+type IdempotencyGuard interface {
+	Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (idempotency.Response, bool)) (idempotency.Response, bool, error)
+}
+
+// IdempotencyInterceptor executes unary calls carrying idempotency-key metadata only once,
+// duplicates get the stored response or status. Failures with an unknown outcome are stored as well,
+// only calls that certainly did not reach Kaspi are not stored so they can be retried.
+func IdempotencyInterceptor(log *slog.Logger, guard IdempotencyGuard) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(IdempotencyKeyMetadata)
+		if len(keys) == 0 || keys[0] == "" {
+			return handler(ctx, req)
+		}
+		key := keys[0]
+
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("%s must not exceed %d characters", IdempotencyKeyMetadata, maxIdempotencyKeyLength))
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		reqBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to encode request")
+		}
+
+		fingerprint := idempotency.Fingerprint([]byte(info.FullMethod), reqBytes)
+
+		var result interface{}
+		var resultErr error
+
+		stored, replayed, err := guard.Do(ctx, key, fingerprint, func(ctx context.Context) (idempotency.Response, bool) {
+			result, resultErr = handler(ctx, req)
+			return encodeResult(result, resultErr)
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrIdempotencyKeyReused):
+				return nil, status.Error(codes.FailedPrecondition, "Idempotency key was already used with a different request")
+			case errors.Is(err, domain.ErrIdempotencyInProgress):
+				return nil, status.Error(codes.Aborted, "Request with this idempotency key is still in progress")
+			default:
+				log.Error("idempotency check failed", "error", err.Error())
+				return nil, status.Error(codes.Internal, "Internal server error")
+			}
+		}
+
+		if !replayed {
+			return result, resultErr
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedMetadata, "true"))
+
+		return decodeResult(stored)
+	}
+}
+
+// encodeResult stores a response as Any and an error as its code and message
+func encodeResult(result interface{}, err error) (idempotency.Response, bool) {
+	if err != nil {
+		st := status.Convert(err)
+		return idempotency.Response{Status: int(st.Code()), Body: []byte(st.Message())}, !isNotExecuted(st.Code())
+	}
+
+	msg, ok := result.(proto.Message)
+	if !ok {
+		return idempotency.Response{}, false
+	}
+
+	packed, err := anypb.New(msg)
+	if err != nil {
+		return idempotency.Response{}, false
+	}
+
+	body, err := proto.Marshal(packed)
+	if err != nil {
+		return idempotency.Response{}, false
+	}
+
+	return idempotency.Response{Status: int(codes.OK), Body: body}, true
+}
+
+func decodeResult(stored idempotency.Response) (interface{}, error) {
+	if codes.Code(stored.Status) != codes.OK {
+		return nil, status.Error(codes.Code(stored.Status), string(stored.Body))
+	}
+
+	var packed anypb.Any
+	if err := proto.Unmarshal(stored.Body, &packed); err != nil {
+		return nil, status.Error(codes.Internal, "failed to decode stored response")
+	}
+
+	msg, err := packed.UnmarshalNew()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to decode stored response")
+	}
+
+	return msg, nil
+}
+
+// isNotExecuted reports whether a failed call certainly left nothing done and may be repeated,
+// Internal, Unknown or DeadlineExceeded may hide an operation Kaspi has executed
+func isNotExecuted(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
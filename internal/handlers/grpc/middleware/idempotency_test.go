@@ -0,0 +1,164 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers/grpc/middleware"
+	"kaspi-api-wrapper/internal/idempotency"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	refundv1 "kaspi-api-wrapper/pkg/protos/gen/go/refund"
+)
+
+// fakeGuard stores kept responses in memory
+type fakeGuard struct {
+	fingerprints map[string]string
+	responses    map[string]idempotency.Response
+}
+
+func newFakeGuard() *fakeGuard {
+	return &fakeGuard{
+		fingerprints: make(map[string]string),
+		responses:    make(map[string]idempotency.Response),
+	}
+}
+
+func (g *fakeGuard) Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (idempotency.Response, bool)) (idempotency.Response, bool, error) {
+	if fp, ok := g.fingerprints[key]; ok && fp != fingerprint {
+		return idempotency.Response{}, false, domain.ErrIdempotencyKeyReused
+	}
+
+	if resp, ok := g.responses[key]; ok {
+		return resp, true, nil
+	}
+
+	resp, keep := fn(ctx)
+	if keep {
+		g.fingerprints[key] = fingerprint
+		g.responses[key] = resp
+	}
+
+	return resp, false, nil
+}
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+	info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.RefundService/RefundPayment"}
+
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.IdempotencyKeyMetadata, key))
+	}
+
+	t.Run("replays stored response", func(t *testing.T) {
+		interceptor := middleware.IdempotencyInterceptor(log, newFakeGuard())
+
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return &refundv1.RefundPaymentResponse{ReturnOperationId: 7}, nil
+		}
+
+		req := &refundv1.RefundPaymentRequest{DeviceToken: "token", QrPaymentId: 15, Amount: 100}
+
+		if _, err := interceptor(withKey("key-1"), req, info, handler); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		resp, err := interceptor(withKey("key-1"), req, info, handler)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 handler call, got %d", calls)
+		}
+
+		if !proto.Equal(resp.(proto.Message), &refundv1.RefundPaymentResponse{ReturnOperationId: 7}) {
+			t.Errorf("Unexpected replayed response: %v", resp)
+		}
+	})
+
+	t.Run("replays stored status", func(t *testing.T) {
+		interceptor := middleware.IdempotencyInterceptor(log, newFakeGuard())
+
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return nil, status.Error(codes.InvalidArgument, "amount is invalid")
+		}
+
+		req := &refundv1.RefundPaymentRequest{DeviceToken: "token", QrPaymentId: 15}
+
+		_, _ = interceptor(withKey("key-2"), req, info, handler)
+		_, err := interceptor(withKey("key-2"), req, info, handler)
+
+		if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "amount is invalid" {
+			t.Errorf("Expected replayed InvalidArgument, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 handler call, got %d", calls)
+		}
+	})
+
+	t.Run("rejects key reuse with different request", func(t *testing.T) {
+		interceptor := middleware.IdempotencyInterceptor(log, newFakeGuard())
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &refundv1.RefundPaymentResponse{ReturnOperationId: 7}, nil
+		}
+
+		_, _ = interceptor(withKey("key-3"), &refundv1.RefundPaymentRequest{Amount: 100}, info, handler)
+		_, err := interceptor(withKey("key-3"), &refundv1.RefundPaymentRequest{Amount: 200}, info, handler)
+
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("Expected FailedPrecondition, got %v", err)
+		}
+	})
+
+	t.Run("stores failures with unknown outcome", func(t *testing.T) {
+		interceptor := middleware.IdempotencyInterceptor(log, newFakeGuard())
+
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return nil, status.Error(codes.Internal, "Internal server error")
+		}
+
+		req := &refundv1.RefundPaymentRequest{Amount: 100}
+		_, _ = interceptor(withKey("key-5"), req, info, handler)
+		_, err := interceptor(withKey("key-5"), req, info, handler)
+
+		if calls != 1 {
+			t.Errorf("Expected 1 handler call, got %d", calls)
+		}
+
+		if status.Code(err) != codes.Internal {
+			t.Errorf("Expected replayed Internal, got %v", err)
+		}
+	})
+
+	t.Run("does not store calls that did not reach Kaspi", func(t *testing.T) {
+		interceptor := middleware.IdempotencyInterceptor(log, newFakeGuard())
+
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return nil, status.Error(codes.Unavailable, "Kaspi Pay service is temporarily unavailable")
+		}
+
+		req := &refundv1.RefundPaymentRequest{Amount: 100}
+		_, _ = interceptor(withKey("key-4"), req, info, handler)
+		_, _ = interceptor(withKey("key-4"), req, info, handler)
+
+		if calls != 2 {
+			t.Errorf("Expected 2 handler calls, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/service"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	InitiatorMetadata  = "x-initiator"
+	maxInitiatorLength = 255
+)
+
+// InitiatorInterceptor passes the x-initiator metadata, e.g. the cashier or ERP user, to the service for the refund ledger
+func InitiatorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(InitiatorMetadata); len(values) > 0 {
+			initiator := strings.TrimSpace(values[0])
+			if len(initiator) > maxInitiatorLength {
+				initiator = initiator[:maxInitiatorLength]
+			}
+			if initiator != "" {
+				ctx = service.WithInitiator(ctx, initiator)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
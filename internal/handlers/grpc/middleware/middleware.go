@@ -13,17 +13,36 @@ import (
 
 var methodRequirements = map[string]string{
 	// Basic scheme methods (1)
-	"/kaspi.api.v1.DeviceService/GetTradePoints":      "basic",
-	"/kaspi.api.v1.DeviceService/RegisterDevice":      "basic",
-	"/kaspi.api.v1.DeviceService/DeleteDevice":        "basic",
-	"/kaspi.api.v1.PaymentService/CreateQR":           "basic",
-	"/kaspi.api.v1.PaymentService/CreatePaymentLink":  "basic",
-	"/kaspi.api.v1.PaymentService/GetPaymentStatus":   "basic",
-	"/kaspi.api.v1.UtilityService/HealthCheck":        "basic",
-	"/kaspi.api.v1.UtilityService/TestScanQR":         "basic",
-	"/kaspi.api.v1.UtilityService/TestConfirmPayment": "basic",
-	"/kaspi.api.v1.UtilityService/TestScanError":      "basic",
-	"/kaspi.api.v1.UtilityService/TestConfirmError":   "basic",
+	"/kaspi.api.v1.DeviceService/GetTradePoints":                  "basic",
+	"/kaspi.api.v1.DeviceService/RegisterDevice":                  "basic",
+	"/kaspi.api.v1.DeviceService/DeleteDevice":                    "basic",
+	"/kaspi.api.v1.DeviceService/ListDevices":                     "basic",
+	"/kaspi.api.v1.DeviceService/GetDevice":                       "basic",
+	"/kaspi.api.v1.DeviceService/RefreshTradePoints":              "basic",
+	"/kaspi.api.v1.DeviceService/UpdateTradePoint":                "basic",
+	"/kaspi.api.v1.PaymentService/CreateQR":                       "basic",
+	"/kaspi.api.v1.PaymentService/CreatePaymentLink":              "basic",
+	"/kaspi.api.v1.PaymentService/GetPaymentStatus":               "basic",
+	"/kaspi.api.v1.PaymentService/WatchPaymentStatus":             "basic",
+	"/kaspi.api.v1.PaymentService/GetPaymentsByExternalId":        "basic",
+	"/kaspi.api.v1.PaymentService/ListPayments":                   "basic",
+	"/kaspi.api.v1.PaymentService/RenderQR":                       "basic",
+	"/kaspi.api.v1.UtilityService/HealthCheck":                    "basic",
+	"/kaspi.api.v1.UtilityService/TestScanQR":                     "basic",
+	"/kaspi.api.v1.UtilityService/TestConfirmPayment":             "basic",
+	"/kaspi.api.v1.UtilityService/TestScanError":                  "basic",
+	"/kaspi.api.v1.UtilityService/TestConfirmError":               "basic",
+	"/kaspi.api.v1.ReconciliationService/StartReconciliation":     "basic",
+	"/kaspi.api.v1.ReconciliationService/ListReconciliationRuns":  "basic",
+	"/kaspi.api.v1.ReconciliationService/GetReconciliationRun":    "basic",
+	"/kaspi.api.v1.ReconciliationService/ExportReconciliationRun": "basic",
+	"/kaspi.api.v1.ReportService/GetSettlementReport":             "basic",
+	"/kaspi.api.v1.ReportService/ExportSettlementReport":          "basic",
+	"/kaspi.api.v1.OrganizationService/ListOrganizations":         "basic",
+	"/kaspi.api.v1.OrganizationService/GetOrganization":           "basic",
+	"/kaspi.api.v1.OrganizationService/CreateOrganization":        "basic",
+	"/kaspi.api.v1.OrganizationService/UpdateOrganization":        "basic",
+	"/kaspi.api.v1.OrganizationService/DeleteOrganization":        "basic",
 
 	// Standard scheme methods (2)
 	"/kaspi.api.v1.RefundService/CreateRefundQR":        "standard",
@@ -31,17 +50,22 @@ var methodRequirements = map[string]string{
 	"/kaspi.api.v1.RefundService/GetCustomerOperations": "standard",
 	"/kaspi.api.v1.RefundService/GetPaymentDetails":     "standard",
 	"/kaspi.api.v1.RefundService/RefundPayment":         "standard",
+	"/kaspi.api.v1.RefundService/CreateRefundSession":   "standard",
+	"/kaspi.api.v1.RefundService/GetRefundSession":      "standard",
+	"/kaspi.api.v1.RefundService/RefundSessionPayment":  "standard",
 
 	// Enhanced scheme methods (3)
-	"/kaspi.api.v1.DeviceService/GetTradePointsEnhanced":        "enhanced",
-	"/kaspi.api.v1.DeviceService/RegisterDeviceEnhanced":        "enhanced",
-	"/kaspi.api.v1.DeviceService/DeleteDeviceEnhanced":          "enhanced",
-	"/kaspi.api.v1.PaymentService/CreateQREnhanced":             "enhanced",
-	"/kaspi.api.v1.PaymentService/CreatePaymentLinkEnhanced":    "enhanced",
-	"/kaspi.api.v1.EnhancedRefundService/RefundPaymentEnhanced": "enhanced",
-	"/kaspi.api.v1.EnhancedRefundService/GetClientInfo":         "enhanced",
-	"/kaspi.api.v1.EnhancedRefundService/CreateRemotePayment":   "enhanced",
-	"/kaspi.api.v1.EnhancedRefundService/CancelRemotePayment":   "enhanced",
+	"/kaspi.api.v1.DeviceService/GetTradePointsEnhanced":            "enhanced",
+	"/kaspi.api.v1.DeviceService/RefreshTradePointsEnhanced":        "enhanced",
+	"/kaspi.api.v1.DeviceService/RegisterDeviceEnhanced":            "enhanced",
+	"/kaspi.api.v1.DeviceService/DeleteDeviceEnhanced":              "enhanced",
+	"/kaspi.api.v1.PaymentService/CreateQREnhanced":                 "enhanced",
+	"/kaspi.api.v1.PaymentService/CreatePaymentLinkEnhanced":        "enhanced",
+	"/kaspi.api.v1.EnhancedRefundService/RefundPaymentEnhanced":     "enhanced",
+	"/kaspi.api.v1.EnhancedRefundService/GetClientInfo":             "enhanced",
+	"/kaspi.api.v1.EnhancedRefundService/CreateRemotePayment":       "enhanced",
+	"/kaspi.api.v1.EnhancedRefundService/CancelRemotePayment":       "enhanced",
+	"/kaspi.api.v1.EnhancedRefundService/ListPendingRemotePayments": "enhanced",
 }
 
 // isMethodAllowed checks if the method is allowed in the current scheme
@@ -63,19 +87,39 @@ func isMethodAllowed(fullMethod string, currentScheme string) bool {
 	}
 }
 
+// checkScheme returns PermissionDenied if the method is not available in the current scheme
+func checkScheme(fullMethod string, currentScheme string) error {
+	if isMethodAllowed(fullMethod, currentScheme) {
+		return nil
+	}
+
+	methodName := strings.Split(fullMethod, "/")
+	shortName := methodName[len(methodName)-1]
+
+	message := fmt.Sprintf("Method %s requires %s scheme, but current scheme is %s",
+		shortName, methodRequirements[fullMethod], currentScheme)
+
+	return status.Error(codes.PermissionDenied, message)
+}
+
 // SchemeInterceptor creates a gRPC interceptor that restricts access based on scheme level
 func SchemeInterceptor(currentScheme string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if !isMethodAllowed(info.FullMethod, currentScheme) {
-			methodName := strings.Split(info.FullMethod, "/")
-			shortName := methodName[len(methodName)-1]
+		if err := checkScheme(info.FullMethod, currentScheme); err != nil {
+			return nil, err
+		}
 
-			message := fmt.Sprintf("Method %s requires %s scheme, but current scheme is %s",
-				shortName, methodRequirements[info.FullMethod], currentScheme)
+		return handler(ctx, req)
+	}
+}
 
-			return nil, status.Error(codes.PermissionDenied, message)
+// SchemeStreamInterceptor creates a gRPC stream interceptor that restricts access based on scheme level
+func SchemeStreamInterceptor(currentScheme string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkScheme(info.FullMethod, currentScheme); err != nil {
+			return err
 		}
 
-		return handler(ctx, req)
+		return handler(srv, ss)
 	}
 }
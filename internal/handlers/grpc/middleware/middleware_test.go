@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kaspi-api-wrapper/internal/handlers/grpc/middleware"
+)
+
+func TestSchemeInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	t.Run("allows basic method in basic scheme", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.PaymentService/CreateQR"}
+
+		resp, err := middleware.SchemeInterceptor("basic")(context.Background(), nil, info, handler)
+		if err != nil || resp != "ok" {
+			t.Errorf("Expected handler to be called, got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("rejects standard method in basic scheme", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.RefundService/CreateRefundQR"}
+
+		_, err := middleware.SchemeInterceptor("basic")(context.Background(), nil, info, handler)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Expected PermissionDenied, got %v", err)
+		}
+	})
+
+	t.Run("allows reconciliation in basic scheme", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.ReconciliationService/StartReconciliation"}
+
+		resp, err := middleware.SchemeInterceptor("basic")(context.Background(), nil, info, handler)
+		if err != nil || resp != "ok" {
+			t.Errorf("Expected handler to be called, got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("allows organization registry in basic scheme", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.OrganizationService/CreateOrganization"}
+
+		resp, err := middleware.SchemeInterceptor("basic")(context.Background(), nil, info, handler)
+		if err != nil || resp != "ok" {
+			t.Errorf("Expected handler to be called, got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("allows payment search in basic scheme", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/kaspi.api.v1.PaymentService/ListPayments"}
+
+		resp, err := middleware.SchemeInterceptor("basic")(context.Background(), nil, info, handler)
+		if err != nil || resp != "ok" {
+			t.Errorf("Expected handler to be called, got %v, %v", resp, err)
+		}
+	})
+}
+
+func TestSchemeStreamInterceptor(t *testing.T) {
+	called := false
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	t.Run("allows watch payment status in basic scheme", func(t *testing.T) {
+		called = false
+		info := &grpc.StreamServerInfo{FullMethod: "/kaspi.api.v1.PaymentService/WatchPaymentStatus", IsServerStream: true}
+
+		err := middleware.SchemeStreamInterceptor("basic")(nil, nil, info, handler)
+		if err != nil || !called {
+			t.Errorf("Expected handler to be called, got %v", err)
+		}
+	})
+
+	t.Run("rejects unknown stream method", func(t *testing.T) {
+		called = false
+		info := &grpc.StreamServerInfo{FullMethod: "/kaspi.api.v1.PaymentService/Unknown", IsServerStream: true}
+
+		err := middleware.SchemeStreamInterceptor("enhanced")(nil, nil, info, handler)
+		if status.Code(err) != codes.PermissionDenied || called {
+			t.Errorf("Expected PermissionDenied, got %v", err)
+		}
+	})
+}
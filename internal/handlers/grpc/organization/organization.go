@@ -0,0 +1,138 @@
+package organization
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers"
+	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
+	organizationv1 "kaspi-api-wrapper/pkg/protos/gen/go/organization"
+	"log/slog"
+)
+
+type serverAPI struct {
+	organizationv1.UnimplementedOrganizationServiceServer
+	log                  *slog.Logger
+	organizationProvider handlers.OrganizationProvider
+}
+
+func Register(gRPC *grpc.Server, log *slog.Logger, organizationProvider handlers.OrganizationProvider) {
+	organizationv1.RegisterOrganizationServiceServer(gRPC, &serverAPI{
+		log:                  log,
+		organizationProvider: organizationProvider,
+	})
+}
+
+func RegisterTest(log *slog.Logger, organizationProvider handlers.OrganizationProvider) organizationv1.OrganizationServiceServer {
+	return &serverAPI{
+		log:                  log,
+		organizationProvider: organizationProvider,
+	}
+}
+
+// ListOrganizations implements kaspiv1.OrganizationServiceServer
+func (s *serverAPI) ListOrganizations(ctx context.Context, req *organizationv1.ListOrganizationsRequest) (*organizationv1.ListOrganizationsResponse, error) {
+	log := s.log.With(
+		slog.String("method", "ListOrganizations"),
+	)
+
+	organizations, err := s.organizationProvider.ListOrganizations(ctx)
+	if err != nil {
+		log.Error("failed to list organizations", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	resp := &organizationv1.ListOrganizationsResponse{
+		Organizations: make([]*organizationv1.Organization, 0, len(organizations)),
+	}
+	for _, organization := range organizations {
+		resp.Organizations = append(resp.Organizations, toOrganization(organization))
+	}
+
+	return resp, nil
+}
+
+// GetOrganization implements kaspiv1.OrganizationServiceServer
+func (s *serverAPI) GetOrganization(ctx context.Context, req *organizationv1.GetOrganizationRequest) (*organizationv1.Organization, error) {
+	log := s.log.With(
+		slog.String("method", "GetOrganization"),
+		slog.String("organizationBin", req.OrganizationBin),
+	)
+
+	organization, err := s.organizationProvider.GetOrganization(ctx, req.OrganizationBin)
+	if err != nil {
+		log.Error("failed to get organization", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	return toOrganization(*organization), nil
+}
+
+// CreateOrganization implements kaspiv1.OrganizationServiceServer
+func (s *serverAPI) CreateOrganization(ctx context.Context, req *organizationv1.CreateOrganizationRequest) (*organizationv1.Organization, error) {
+	log := s.log.With(
+		slog.String("method", "CreateOrganization"),
+		slog.String("organizationBin", req.OrganizationBin),
+	)
+
+	organization, err := s.organizationProvider.CreateOrganization(ctx, domain.OrganizationRequest{
+		OrganizationBin: req.OrganizationBin,
+		Name:            req.Name,
+		Enabled:         req.Enabled,
+		Settings:        req.Settings,
+	})
+	if err != nil {
+		log.Error("failed to create organization", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	return toOrganization(*organization), nil
+}
+
+// UpdateOrganization implements kaspiv1.OrganizationServiceServer
+func (s *serverAPI) UpdateOrganization(ctx context.Context, req *organizationv1.UpdateOrganizationRequest) (*organizationv1.Organization, error) {
+	log := s.log.With(
+		slog.String("method", "UpdateOrganization"),
+		slog.String("organizationBin", req.OrganizationBin),
+	)
+
+	organization, err := s.organizationProvider.UpdateOrganization(ctx, domain.OrganizationRequest{
+		OrganizationBin: req.OrganizationBin,
+		Name:            req.Name,
+		Enabled:         req.Enabled,
+		Settings:        req.Settings,
+	})
+	if err != nil {
+		log.Error("failed to update organization", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	return toOrganization(*organization), nil
+}
+
+// DeleteOrganization implements kaspiv1.OrganizationServiceServer
+func (s *serverAPI) DeleteOrganization(ctx context.Context, req *organizationv1.DeleteOrganizationRequest) (*organizationv1.DeleteOrganizationResponse, error) {
+	log := s.log.With(
+		slog.String("method", "DeleteOrganization"),
+		slog.String("organizationBin", req.OrganizationBin),
+	)
+
+	if err := s.organizationProvider.DeleteOrganization(ctx, req.OrganizationBin); err != nil {
+		log.Error("failed to delete organization", "error", err.Error())
+		return nil, grpchandler.HandleError(err, log)
+	}
+
+	return &organizationv1.DeleteOrganizationResponse{}, nil
+}
+
+func toOrganization(organization domain.Organization) *organizationv1.Organization {
+	return &organizationv1.Organization{
+		OrganizationBin: organization.OrganizationBin,
+		Name:            organization.Name,
+		Enabled:         organization.Enabled,
+		Settings:        organization.Settings,
+		CreatedAt:       timestamppb.New(organization.CreatedAt),
+		UpdatedAt:       timestamppb.New(organization.UpdatedAt),
+	}
+}
@@ -0,0 +1,133 @@
+package organization_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers/grpc/organization"
+	"kaspi-api-wrapper/internal/storage"
+	organizationv1 "kaspi-api-wrapper/pkg/protos/gen/go/organization"
+)
+
+func setupTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+}
+
+type MockOrganizationProvider struct {
+	ListOrganizationsFunc  func(ctx context.Context) ([]domain.Organization, error)
+	GetOrganizationFunc    func(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	CreateOrganizationFunc func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	UpdateOrganizationFunc func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	DeleteOrganizationFunc func(ctx context.Context, organizationBin string) error
+}
+
+func (m *MockOrganizationProvider) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	return m.ListOrganizationsFunc(ctx)
+}
+
+func (m *MockOrganizationProvider) GetOrganization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	return m.GetOrganizationFunc(ctx, organizationBin)
+}
+
+func (m *MockOrganizationProvider) CreateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	return m.CreateOrganizationFunc(ctx, req)
+}
+
+func (m *MockOrganizationProvider) UpdateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	return m.UpdateOrganizationFunc(ctx, req)
+}
+
+func (m *MockOrganizationProvider) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	return m.DeleteOrganizationFunc(ctx, organizationBin)
+}
+
+func TestCreateOrganization(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("keeps missing enabled unset", func(t *testing.T) {
+		server := organization.RegisterTest(log, &MockOrganizationProvider{
+			CreateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+				if req.Enabled != nil || req.Settings["region"] != "Almaty" {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.Organization{OrganizationBin: req.OrganizationBin, Name: req.Name, Enabled: true, Settings: req.Settings}, nil
+			},
+		})
+
+		resp, err := server.CreateOrganization(context.Background(), &organizationv1.CreateOrganizationRequest{
+			OrganizationBin: "180340021791",
+			Name:            "Shop",
+			Settings:        map[string]string{"region": "Almaty"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.OrganizationBin != "180340021791" || !resp.Enabled || resp.Settings["region"] != "Almaty" {
+			t.Errorf("Unexpected organization: %+v", resp)
+		}
+	})
+
+	t.Run("maps registered BIN to AlreadyExists", func(t *testing.T) {
+		server := organization.RegisterTest(log, &MockOrganizationProvider{
+			CreateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+				return nil, fmt.Errorf("service: %w", domain.ErrOrganizationExists)
+			},
+		})
+
+		_, err := server.CreateOrganization(context.Background(), &organizationv1.CreateOrganizationRequest{OrganizationBin: "180340021791", Name: "Shop"})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("Expected code AlreadyExists, got %s", status.Code(err))
+		}
+	})
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	log := setupTestLogger()
+
+	server := organization.RegisterTest(log, &MockOrganizationProvider{
+		UpdateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+			if req.Enabled == nil || *req.Enabled {
+				t.Errorf("Expected enabled to be false, got %+v", req)
+			}
+			return &domain.Organization{OrganizationBin: req.OrganizationBin, Name: req.Name}, nil
+		},
+	})
+
+	enabled := false
+	resp, err := server.UpdateOrganization(context.Background(), &organizationv1.UpdateOrganizationRequest{
+		OrganizationBin: "180340021791",
+		Name:            "Shop",
+		Enabled:         &enabled,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Enabled {
+		t.Errorf("Expected disabled organization, got %+v", resp)
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	log := setupTestLogger()
+
+	server := organization.RegisterTest(log, &MockOrganizationProvider{
+		GetOrganizationFunc: func(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+			return nil, fmt.Errorf("service: %w", storage.ErrOrganizationNotFound)
+		},
+	})
+
+	_, err := server.GetOrganization(context.Background(), &organizationv1.GetOrganizationRequest{OrganizationBin: "180340021791"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected code NotFound, got %s", status.Code(err))
+	}
+}
@@ -3,6 +3,8 @@ package payment
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers"
@@ -16,21 +18,27 @@ type serverAPI struct {
 	log                     *slog.Logger
 	paymentProvider         handlers.PaymentProvider
 	paymentEnhancedProvider handlers.PaymentEnhancedProvider
+	paymentWatcher          handlers.PaymentWatcher
+	qrRenderer              handlers.QRRenderer
 }
 
-func Register(gRPC *grpc.Server, log *slog.Logger, paymentProvider handlers.PaymentProvider, paymentEnhancedProvider handlers.PaymentEnhancedProvider) {
+func Register(gRPC *grpc.Server, log *slog.Logger, paymentProvider handlers.PaymentProvider, paymentEnhancedProvider handlers.PaymentEnhancedProvider, paymentWatcher handlers.PaymentWatcher, qrRenderer handlers.QRRenderer) {
 	paymentv1.RegisterPaymentServiceServer(gRPC, &serverAPI{
 		log:                     log,
 		paymentProvider:         paymentProvider,
 		paymentEnhancedProvider: paymentEnhancedProvider,
+		paymentWatcher:          paymentWatcher,
+		qrRenderer:              qrRenderer,
 	})
 }
 
-func RegisterTest(log *slog.Logger, paymentProvider handlers.PaymentProvider, paymentEnhancedProvider handlers.PaymentEnhancedProvider) paymentv1.PaymentServiceServer {
+func RegisterTest(log *slog.Logger, paymentProvider handlers.PaymentProvider, paymentEnhancedProvider handlers.PaymentEnhancedProvider, paymentWatcher handlers.PaymentWatcher, qrRenderer handlers.QRRenderer) paymentv1.PaymentServiceServer {
 	return &serverAPI{
 		log:                     log,
 		paymentProvider:         paymentProvider,
 		paymentEnhancedProvider: paymentEnhancedProvider,
+		paymentWatcher:          paymentWatcher,
+		qrRenderer:              qrRenderer,
 	}
 }
 
@@ -38,6 +46,7 @@ func RegisterTest(log *slog.Logger, paymentProvider handlers.PaymentProvider, pa
 func (s *serverAPI) CreateQR(ctx context.Context, req *paymentv1.CreateQRRequest) (*paymentv1.CreateQRResponse, error) {
 	domainReq := domain.QRCreateRequest{
 		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
 		Amount:      req.Amount,
 		ExternalID:  req.ExternalId,
 	}
@@ -67,6 +76,7 @@ func (s *serverAPI) CreateQR(ctx context.Context, req *paymentv1.CreateQRRequest
 func (s *serverAPI) CreatePaymentLink(ctx context.Context, req *paymentv1.CreatePaymentLinkRequest) (*paymentv1.CreatePaymentLinkResponse, error) {
 	domainReq := domain.PaymentLinkCreateRequest{
 		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
 		Amount:      req.Amount,
 		ExternalID:  req.ExternalId,
 	}
@@ -116,3 +126,186 @@ func (s *serverAPI) GetPaymentStatus(ctx context.Context, req *paymentv1.GetPaym
 
 	return resp, nil
 }
+
+// WatchPaymentStatus implements kaspiv1.PaymentServiceServer
+func (s *serverAPI) WatchPaymentStatus(req *paymentv1.WatchPaymentStatusRequest, stream grpc.ServerStreamingServer[paymentv1.PaymentStatusUpdate]) error {
+	if s.paymentWatcher == nil {
+		return status.Error(codes.Unavailable, "Payment status watching is disabled")
+	}
+
+	ctx := stream.Context()
+
+	current, err := s.paymentProvider.GetPaymentStatus(ctx, req.QrPaymentId)
+	if err != nil {
+		s.log.Error("WatchPaymentStatus failed", "error", err.Error())
+		return grpchandler.HandleError(err, s.log)
+	}
+
+	if err = stream.Send(toPaymentStatusUpdate(req.QrPaymentId, *current)); err != nil {
+		return err
+	}
+
+	if domain.IsTerminalPaymentStatus(current.Status) {
+		return nil
+	}
+
+	updates, cancel := s.paymentWatcher.Subscribe(req.QrPaymentId)
+	defer cancel()
+
+	lastStatus := current.Status
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if update.Status == lastStatus {
+				continue
+			}
+			lastStatus = update.Status
+
+			if err = stream.Send(toPaymentStatusUpdate(req.QrPaymentId, update)); err != nil {
+				return err
+			}
+
+			if domain.IsTerminalPaymentStatus(update.Status) {
+				return nil
+			}
+		}
+	}
+}
+
+// GetPaymentsByExternalId implements kaspiv1.PaymentServiceServer
+func (s *serverAPI) GetPaymentsByExternalId(ctx context.Context, req *paymentv1.GetPaymentsByExternalIdRequest) (*paymentv1.GetPaymentsByExternalIdResponse, error) {
+	payments, err := s.paymentProvider.GetPaymentsByExternalID(ctx, req.ExternalId)
+	if err != nil {
+		s.log.Error("GetPaymentsByExternalId failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &paymentv1.GetPaymentsByExternalIdResponse{
+		Payments: make([]*paymentv1.StoredPayment, 0, len(payments)),
+	}
+
+	for _, payment := range payments {
+		resp.Payments = append(resp.Payments, toStoredPayment(payment))
+	}
+
+	return resp, nil
+}
+
+// ListPayments implements kaspiv1.PaymentServiceServer
+func (s *serverAPI) ListPayments(ctx context.Context, req *paymentv1.ListPaymentsRequest) (*paymentv1.ListPaymentsResponse, error) {
+	domainReq := domain.PaymentListRequest{
+		TradePointID:    req.TradePointId,
+		DeviceID:        req.DeviceId,
+		OrganizationBin: req.OrganizationBin,
+		Statuses:        req.Status,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+		ExternalID:      req.ExternalId,
+		Search:          req.Search,
+		SortBy:          req.SortBy,
+		SortOrder:       req.SortOrder,
+		Limit:           int(req.Limit),
+		Cursor:          req.Cursor,
+	}
+	if req.From != nil {
+		domainReq.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		domainReq.To = req.To.AsTime()
+	}
+
+	list, err := s.paymentProvider.ListPayments(ctx, domainReq)
+	if err != nil {
+		s.log.Error("ListPayments failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &paymentv1.ListPaymentsResponse{
+		Payments:   make([]*paymentv1.StoredPayment, 0, len(list.Items)),
+		NextCursor: list.NextCursor,
+	}
+
+	for _, payment := range list.Items {
+		resp.Payments = append(resp.Payments, toStoredPayment(payment))
+	}
+
+	return resp, nil
+}
+
+// RenderQR implements kaspiv1.PaymentServiceServer
+func (s *serverAPI) RenderQR(ctx context.Context, req *paymentv1.RenderQRRequest) (*paymentv1.RenderQRResponse, error) {
+	if s.qrRenderer == nil {
+		return nil, status.Error(codes.Unavailable, "QR rendering is not configured")
+	}
+
+	opts := domain.QRImageOptions{
+		Format:          req.Format,
+		Size:            int(req.Size),
+		ErrorCorrection: req.ErrorCorrection,
+		Logo:            req.Logo,
+	}
+
+	if req.Margin != nil {
+		margin := int(req.GetMargin())
+		opts.Margin = &margin
+	}
+
+	img, err := s.qrRenderer.RenderQR(ctx, req.QrPaymentId, opts)
+	if err != nil {
+		s.log.Error("RenderQR failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return &paymentv1.RenderQRResponse{
+		Image:       img.Data,
+		ContentType: img.ContentType,
+	}, nil
+}
+
+func toStoredPayment(payment domain.Payment) *paymentv1.StoredPayment {
+	stored := &paymentv1.StoredPayment{
+		QrPaymentId:     payment.QrPaymentID,
+		Kind:            payment.Kind,
+		ExternalId:      payment.ExternalID,
+		TradePointId:    payment.TradePointID,
+		OrganizationBin: payment.OrganizationBin,
+		Amount:          payment.Amount,
+		PaymentMethods:  payment.PaymentMethods,
+		Status:          payment.Status,
+		TransactionId:   payment.TransactionID,
+		ProductType:     payment.ProductType,
+		LoanOfferName:   payment.LoanOfferName,
+		LoanTerm:        int64(payment.LoanTerm),
+		QrToken:         payment.QrToken,
+		PaymentLink:     payment.PaymentLink,
+		PhoneNumber:     payment.PhoneNumber,
+		Comment:         payment.Comment,
+		CreatedAt:       timestamppb.New(payment.CreatedAt),
+		UpdatedAt:       timestamppb.New(payment.UpdatedAt),
+	}
+
+	if !payment.ExpireDate.IsZero() {
+		stored.ExpireDate = timestamppb.New(payment.ExpireDate)
+	}
+
+	return stored
+}
+
+func toPaymentStatusUpdate(qrPaymentID int64, result domain.PaymentStatusResponse) *paymentv1.PaymentStatusUpdate {
+	return &paymentv1.PaymentStatusUpdate{
+		QrPaymentId:   qrPaymentID,
+		Status:        result.Status,
+		TransactionId: result.TransactionID,
+		LoanOfferName: result.LoanOfferName,
+		LoanTerm:      int64(result.LoanTerm),
+		IsOffer:       result.IsOffer,
+		ProductType:   result.ProductType,
+		UpdatedAt:     timestamppb.Now(),
+	}
+}
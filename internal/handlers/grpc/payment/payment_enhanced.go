@@ -12,6 +12,7 @@ import (
 func (s *serverAPI) CreateQREnhanced(ctx context.Context, req *paymentv1.CreateQREnhancedRequest) (*paymentv1.CreateQRResponse, error) {
 	domainReq := domain.EnhancedQRCreateRequest{
 		DeviceToken:     req.DeviceToken,
+		DeviceID:        req.DeviceId,
 		Amount:          req.Amount,
 		ExternalID:      req.ExternalId,
 		OrganizationBin: req.OrganizationBin,
@@ -43,6 +44,7 @@ func (s *serverAPI) CreateQREnhanced(ctx context.Context, req *paymentv1.CreateQ
 func (s *serverAPI) CreatePaymentLinkEnhanced(ctx context.Context, req *paymentv1.CreatePaymentLinkEnhancedRequest) (*paymentv1.CreatePaymentLinkResponse, error) {
 	domainReq := domain.EnhancedPaymentLinkCreateRequest{
 		DeviceToken:     req.DeviceToken,
+		DeviceID:        req.DeviceId,
 		Amount:          req.Amount,
 		ExternalID:      req.ExternalId,
 		OrganizationBin: req.OrganizationBin,
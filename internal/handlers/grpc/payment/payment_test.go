@@ -2,13 +2,16 @@ package payment_test
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers/grpc/payment"
 	paymentv1 "kaspi-api-wrapper/pkg/protos/gen/go/payment"
@@ -24,6 +27,9 @@ type MockPaymentProvider struct {
 	CreateQRFunc          func(ctx context.Context, req domain.QRCreateRequest) (*domain.QRCreateResponse, error)
 	CreatePaymentLinkFunc func(ctx context.Context, req domain.PaymentLinkCreateRequest) (*domain.PaymentLinkCreateResponse, error)
 	GetPaymentStatusFunc  func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+
+	GetPaymentsByExternalIDFunc func(ctx context.Context, externalID string) ([]domain.Payment, error)
+	ListPaymentsFunc            func(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error)
 }
 
 func (m *MockPaymentProvider) CreateQR(ctx context.Context, req domain.QRCreateRequest) (*domain.QRCreateResponse, error) {
@@ -38,10 +44,18 @@ func (m *MockPaymentProvider) GetPaymentStatus(ctx context.Context, qrPaymentID
 	return m.GetPaymentStatusFunc(ctx, qrPaymentID)
 }
 
+func (m *MockPaymentProvider) GetPaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	return m.GetPaymentsByExternalIDFunc(ctx, externalID)
+}
+
+func (m *MockPaymentProvider) ListPayments(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error) {
+	return m.ListPaymentsFunc(ctx, req)
+}
+
 func createTestServer(paymentProvider *MockPaymentProvider, paymentEnhancedProvider *MockPaymentEnhancedProvider) *paymentServer {
 	log := setupTestLogger()
 	srv := &paymentServer{
-		server: payment.RegisterTest(log, paymentProvider, paymentEnhancedProvider),
+		server: payment.RegisterTest(log, paymentProvider, paymentEnhancedProvider, nil, nil),
 	}
 	return srv
 }
@@ -292,3 +306,293 @@ func TestGetPaymentStatus(t *testing.T) {
 		}
 	})
 }
+
+type MockPaymentWatcher struct {
+	updates []domain.PaymentStatusResponse
+}
+
+func (m *MockPaymentWatcher) Subscribe(qrPaymentID int64) (<-chan domain.PaymentStatusResponse, func()) {
+	ch := make(chan domain.PaymentStatusResponse, len(m.updates))
+	for _, update := range m.updates {
+		ch <- update
+	}
+	close(ch)
+
+	return ch, func() {}
+}
+
+// mockStatusStream collects messages sent by WatchPaymentStatus
+type mockStatusStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*paymentv1.PaymentStatusUpdate
+}
+
+func (m *mockStatusStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStatusStream) Send(update *paymentv1.PaymentStatusUpdate) error {
+	m.sent = append(m.sent, update)
+	return nil
+}
+
+func TestWatchPaymentStatus(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("streams transitions until terminal status", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusCreated}, nil
+			},
+		}
+		watcher := &MockPaymentWatcher{updates: []domain.PaymentStatusResponse{
+			{Status: domain.PaymentStatusCreated},
+			{Status: domain.PaymentStatusWait},
+			{Status: domain.PaymentStatusProcessed, TransactionID: "35134863"},
+			{Status: domain.PaymentStatusExpired},
+		}}
+
+		server := payment.RegisterTest(log, mockProvider, nil, watcher, nil)
+		stream := &mockStatusStream{ctx: context.Background()}
+
+		err := server.WatchPaymentStatus(&paymentv1.WatchPaymentStatusRequest{QrPaymentId: 15}, stream)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var statuses []string
+		for _, update := range stream.sent {
+			statuses = append(statuses, update.Status)
+		}
+
+		expected := []string{domain.PaymentStatusCreated, domain.PaymentStatusWait, domain.PaymentStatusProcessed}
+		if len(statuses) != len(expected) {
+			t.Fatalf("Expected statuses %v, got %v", expected, statuses)
+		}
+		for i := range expected {
+			if statuses[i] != expected[i] {
+				t.Errorf("Expected statuses %v, got %v", expected, statuses)
+				break
+			}
+		}
+
+		if stream.sent[2].TransactionId != "35134863" || stream.sent[2].QrPaymentId != 15 {
+			t.Errorf("Unexpected final update: %+v", stream.sent[2])
+		}
+	})
+
+	t.Run("closes immediately for terminal payment", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusError}, nil
+			},
+		}
+
+		server := payment.RegisterTest(log, mockProvider, nil, &MockPaymentWatcher{}, nil)
+		stream := &mockStatusStream{ctx: context.Background()}
+
+		err := server.WatchPaymentStatus(&paymentv1.WatchPaymentStatusRequest{QrPaymentId: 15}, stream)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(stream.sent) != 1 {
+			t.Errorf("Expected 1 update, got %d", len(stream.sent))
+		}
+	})
+
+	t.Run("returns error for unknown payment", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return nil, &domain.KaspiError{StatusCode: -1601, Message: "Purchase not found"}
+			},
+		}
+
+		server := payment.RegisterTest(log, mockProvider, nil, &MockPaymentWatcher{}, nil)
+		stream := &mockStatusStream{ctx: context.Background()}
+
+		err := server.WatchPaymentStatus(&paymentv1.WatchPaymentStatusRequest{QrPaymentId: 15}, stream)
+
+		st, ok := status.FromError(err)
+		if !ok || st.Code() != codes.NotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+
+	t.Run("returns unavailable without watcher", func(t *testing.T) {
+		server := payment.RegisterTest(log, &MockPaymentProvider{}, nil, nil, nil)
+		stream := &mockStatusStream{ctx: context.Background()}
+
+		err := server.WatchPaymentStatus(&paymentv1.WatchPaymentStatusRequest{QrPaymentId: 15}, stream)
+
+		st, ok := status.FromError(err)
+		if !ok || st.Code() != codes.Unavailable {
+			t.Errorf("Expected Unavailable, got %v", err)
+		}
+	})
+}
+
+func TestGetPaymentsByExternalId(t *testing.T) {
+	t.Run("successfully gets payments", func(t *testing.T) {
+		expireDate := time.Now().Add(5 * time.Minute)
+
+		mockProvider := &MockPaymentProvider{
+			GetPaymentsByExternalIDFunc: func(ctx context.Context, externalID string) ([]domain.Payment, error) {
+				return []domain.Payment{
+					{
+						QrPaymentID:    15,
+						Kind:           domain.PaymentKindQR,
+						ExternalID:     externalID,
+						DeviceToken:    "test-token",
+						Amount:         200.00,
+						ExpireDate:     expireDate,
+						PaymentMethods: []string{"Gold"},
+						Status:         domain.PaymentStatusCreated,
+						QrToken:        "51236903777280167836178166503744993984459",
+					},
+				}, nil
+			},
+		}
+
+		srv := createTestServer(mockProvider, nil)
+
+		resp, err := srv.server.GetPaymentsByExternalId(context.Background(), &paymentv1.GetPaymentsByExternalIdRequest{
+			ExternalId: "ORDER-15",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(resp.Payments) != 1 {
+			t.Fatalf("Expected 1 payment, got %d", len(resp.Payments))
+		}
+
+		got := resp.Payments[0]
+		if got.QrPaymentId != 15 || got.ExternalId != "ORDER-15" || got.QrToken == "" {
+			t.Errorf("Unexpected payment: %v", got)
+		}
+
+		if !got.ExpireDate.AsTime().Equal(expireDate) {
+			t.Errorf("Expected expire date %v, got %v", expireDate, got.ExpireDate.AsTime())
+		}
+	})
+
+	t.Run("returns NotFound for unknown external ID", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentsByExternalIDFunc: func(ctx context.Context, externalID string) ([]domain.Payment, error) {
+				return nil, fmt.Errorf("payment %w", domain.ErrNotFound)
+			},
+		}
+
+		srv := createTestServer(mockProvider, nil)
+
+		_, err := srv.server.GetPaymentsByExternalId(context.Background(), &paymentv1.GetPaymentsByExternalIdRequest{
+			ExternalId: "unknown",
+		})
+
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+}
+
+type MockQRRenderer struct {
+	RenderQRFunc func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error)
+}
+
+func (m *MockQRRenderer) RenderQR(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+	return m.RenderQRFunc(ctx, qrPaymentID, opts)
+}
+
+func TestRenderQR(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("successfully renders QR", func(t *testing.T) {
+		renderer := &MockQRRenderer{
+			RenderQRFunc: func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+				if opts.Format != "png" || opts.Size != 256 || opts.Margin == nil || *opts.Margin != 0 {
+					t.Errorf("Unexpected options: %+v", opts)
+				}
+
+				return &domain.QRImage{ContentType: "image/png", Data: []byte{0x89, 0x50}}, nil
+			},
+		}
+
+		server := payment.RegisterTest(log, &MockPaymentProvider{}, nil, nil, renderer)
+
+		margin := int32(0)
+		resp, err := server.RenderQR(context.Background(), &paymentv1.RenderQRRequest{
+			QrPaymentId: 15,
+			Format:      "png",
+			Size:        256,
+			Margin:      &margin,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.ContentType != "image/png" || len(resp.Image) != 2 {
+			t.Errorf("Unexpected response: %v", resp)
+		}
+	})
+
+	t.Run("returns NotFound for payment without token", func(t *testing.T) {
+		renderer := &MockQRRenderer{
+			RenderQRFunc: func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+				return nil, fmt.Errorf("QR token %w", domain.ErrNotFound)
+			},
+		}
+
+		server := payment.RegisterTest(log, &MockPaymentProvider{}, nil, nil, renderer)
+
+		_, err := server.RenderQR(context.Background(), &paymentv1.RenderQRRequest{QrPaymentId: 15})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+}
+
+func TestListPayments(t *testing.T) {
+	t.Run("maps filters and the page", func(t *testing.T) {
+		from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		minAmount := 100.0
+
+		mockProvider := &MockPaymentProvider{
+			ListPaymentsFunc: func(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error) {
+				if req.DeviceID != "DEV-1" || req.DeviceToken != "" || len(req.Statuses) != 1 || req.Search != "ORD" {
+					t.Errorf("Unexpected filters: %+v", req)
+				}
+				if req.MinAmount == nil || *req.MinAmount != minAmount || req.MaxAmount != nil {
+					t.Errorf("Unexpected amount range: %v - %v", req.MinAmount, req.MaxAmount)
+				}
+				if !req.From.Equal(from) || !req.To.IsZero() || req.Limit != 10 {
+					t.Errorf("Unexpected period or limit: %+v", req)
+				}
+
+				return &domain.PaymentList{
+					Items:      []domain.Payment{{QrPaymentID: 15, Status: domain.PaymentStatusWait}},
+					NextCursor: "next",
+				}, nil
+			},
+		}
+
+		srv := createTestServer(mockProvider, nil)
+
+		resp, err := srv.server.ListPayments(context.Background(), &paymentv1.ListPaymentsRequest{
+			DeviceId:  "DEV-1",
+			Status:    []string{domain.PaymentStatusWait},
+			MinAmount: &minAmount,
+			From:      timestamppb.New(from),
+			Search:    "ORD",
+			Limit:     10,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(resp.Payments) != 1 || resp.Payments[0].QrPaymentId != 15 || resp.NextCursor != "next" {
+			t.Errorf("Unexpected response: %v", resp)
+		}
+	})
+}
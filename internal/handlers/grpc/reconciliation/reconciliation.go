@@ -0,0 +1,158 @@
+package reconciliation
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers"
+	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
+	reconciliationv1 "kaspi-api-wrapper/pkg/protos/gen/go/reconciliation"
+	"log/slog"
+)
+
+const defaultRunsLimit = 20
+
+type serverAPI struct {
+	reconciliationv1.UnimplementedReconciliationServiceServer
+	log                    *slog.Logger
+	reconciliationProvider handlers.ReconciliationProvider
+}
+
+func Register(gRPC *grpc.Server, log *slog.Logger, reconciliationProvider handlers.ReconciliationProvider) {
+	reconciliationv1.RegisterReconciliationServiceServer(gRPC, &serverAPI{
+		log:                    log,
+		reconciliationProvider: reconciliationProvider,
+	})
+}
+
+func RegisterTest(log *slog.Logger, reconciliationProvider handlers.ReconciliationProvider) reconciliationv1.ReconciliationServiceServer {
+	return &serverAPI{
+		log:                    log,
+		reconciliationProvider: reconciliationProvider,
+	}
+}
+
+// StartReconciliation implements kaspiv1.ReconciliationServiceServer
+func (s *serverAPI) StartReconciliation(ctx context.Context, req *reconciliationv1.StartReconciliationRequest) (*reconciliationv1.ReconciliationRun, error) {
+	if s.reconciliationProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reconciliation is not enabled")
+	}
+
+	var domainReq domain.ReconciliationRequest
+	if req.From != nil {
+		domainReq.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		domainReq.To = req.To.AsTime()
+	}
+
+	run, err := s.reconciliationProvider.StartReconciliation(ctx, domainReq)
+	if err != nil {
+		s.log.Error("StartReconciliation failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return toReconciliationRun(*run), nil
+}
+
+// ListReconciliationRuns implements kaspiv1.ReconciliationServiceServer
+func (s *serverAPI) ListReconciliationRuns(ctx context.Context, req *reconciliationv1.ListReconciliationRunsRequest) (*reconciliationv1.ListReconciliationRunsResponse, error) {
+	if s.reconciliationProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reconciliation is not enabled")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultRunsLimit
+	}
+
+	runs, err := s.reconciliationProvider.ListReconciliationRuns(ctx, limit)
+	if err != nil {
+		s.log.Error("ListReconciliationRuns failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &reconciliationv1.ListReconciliationRunsResponse{
+		Runs: make([]*reconciliationv1.ReconciliationRun, 0, len(runs)),
+	}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, toReconciliationRun(run))
+	}
+
+	return resp, nil
+}
+
+// GetReconciliationRun implements kaspiv1.ReconciliationServiceServer
+func (s *serverAPI) GetReconciliationRun(ctx context.Context, req *reconciliationv1.GetReconciliationRunRequest) (*reconciliationv1.GetReconciliationRunResponse, error) {
+	if s.reconciliationProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reconciliation is not enabled")
+	}
+
+	report, err := s.reconciliationProvider.GetReconciliationRun(ctx, req.RunId)
+	if err != nil {
+		s.log.Error("GetReconciliationRun failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &reconciliationv1.GetReconciliationRunResponse{
+		Run:   toReconciliationRun(report.ReconciliationRun),
+		Items: make([]*reconciliationv1.ReconciliationDiscrepancy, 0, len(report.Items)),
+	}
+	for _, d := range report.Items {
+		resp.Items = append(resp.Items, &reconciliationv1.ReconciliationDiscrepancy{
+			Id:          d.ID,
+			RunId:       d.RunID,
+			Kind:        d.Kind,
+			Entity:      d.Entity,
+			QrPaymentId: d.QrPaymentID,
+			QrReturnId:  d.QrReturnID,
+			LocalValue:  d.LocalValue,
+			RemoteValue: d.RemoteValue,
+			Message:     d.Message,
+			CreatedAt:   timestamppb.New(d.CreatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// ExportReconciliationRun implements kaspiv1.ReconciliationServiceServer
+func (s *serverAPI) ExportReconciliationRun(ctx context.Context, req *reconciliationv1.ExportReconciliationRunRequest) (*reconciliationv1.ExportReconciliationRunResponse, error) {
+	if s.reconciliationProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reconciliation is not enabled")
+	}
+
+	data, err := s.reconciliationProvider.ExportReconciliationRun(ctx, req.RunId)
+	if err != nil {
+		s.log.Error("ExportReconciliationRun failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return &reconciliationv1.ExportReconciliationRunResponse{
+		Csv: data,
+	}, nil
+}
+
+func toReconciliationRun(run domain.ReconciliationRun) *reconciliationv1.ReconciliationRun {
+	resp := &reconciliationv1.ReconciliationRun{
+		Id:              run.ID,
+		Trigger:         run.Trigger,
+		From:            timestamppb.New(run.From),
+		To:              timestamppb.New(run.To),
+		Status:          run.Status,
+		PaymentsChecked: int32(run.PaymentsChecked),
+		RefundsChecked:  int32(run.RefundsChecked),
+		Discrepancies:   int32(run.Discrepancies),
+		Error:           run.Error,
+		StartedAt:       timestamppb.New(run.StartedAt),
+	}
+
+	if run.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+
+	return resp
+}
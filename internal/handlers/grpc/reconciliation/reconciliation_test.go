@@ -0,0 +1,144 @@
+package reconciliation_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers/grpc/reconciliation"
+	reconciliationv1 "kaspi-api-wrapper/pkg/protos/gen/go/reconciliation"
+)
+
+func setupTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+}
+
+type MockReconciliationProvider struct {
+	StartReconciliationFunc     func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error)
+	ListReconciliationRunsFunc  func(ctx context.Context, limit int) ([]domain.ReconciliationRun, error)
+	GetReconciliationRunFunc    func(ctx context.Context, runID int64) (*domain.ReconciliationReport, error)
+	ExportReconciliationRunFunc func(ctx context.Context, runID int64) ([]byte, error)
+}
+
+func (m *MockReconciliationProvider) StartReconciliation(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+	return m.StartReconciliationFunc(ctx, req)
+}
+
+func (m *MockReconciliationProvider) ListReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	return m.ListReconciliationRunsFunc(ctx, limit)
+}
+
+func (m *MockReconciliationProvider) GetReconciliationRun(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+	return m.GetReconciliationRunFunc(ctx, runID)
+}
+
+func (m *MockReconciliationProvider) ExportReconciliationRun(ctx context.Context, runID int64) ([]byte, error) {
+	return m.ExportReconciliationRunFunc(ctx, runID)
+}
+
+func TestStartReconciliation(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("starts run for the requested period", func(t *testing.T) {
+		from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		provider := &MockReconciliationProvider{
+			StartReconciliationFunc: func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+				if !req.From.Equal(from) || !req.To.Equal(from.AddDate(0, 0, 1)) {
+					t.Errorf("Unexpected period %s - %s", req.From, req.To)
+				}
+				return &domain.ReconciliationRun{ID: 3, Status: domain.ReconciliationRunning, From: req.From, To: req.To}, nil
+			},
+		}
+
+		server := reconciliation.RegisterTest(log, provider)
+
+		resp, err := server.StartReconciliation(context.Background(), &reconciliationv1.StartReconciliationRequest{
+			From: timestamppb.New(from),
+			To:   timestamppb.New(from.AddDate(0, 0, 1)),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if resp.Id != 3 || resp.Status != domain.ReconciliationRunning || resp.FinishedAt != nil {
+			t.Errorf("Unexpected run: %v", resp)
+		}
+	})
+
+	t.Run("maps run in progress to Aborted", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			StartReconciliationFunc: func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+				return nil, fmt.Errorf("reconciliation.StartReconciliation: %w", domain.ErrReconciliationRunning)
+			},
+		}
+
+		server := reconciliation.RegisterTest(log, provider)
+
+		_, err := server.StartReconciliation(context.Background(), &reconciliationv1.StartReconciliationRequest{})
+		if status.Code(err) != codes.Aborted {
+			t.Errorf("Expected code Aborted, got %s", status.Code(err))
+		}
+	})
+
+	t.Run("reconciliation disabled", func(t *testing.T) {
+		server := reconciliation.RegisterTest(log, nil)
+
+		_, err := server.StartReconciliation(context.Background(), &reconciliationv1.StartReconciliationRequest{})
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("Expected code Unavailable, got %s", status.Code(err))
+		}
+	})
+}
+
+func TestGetReconciliationRun(t *testing.T) {
+	log := setupTestLogger()
+
+	finishedAt := time.Now()
+	provider := &MockReconciliationProvider{
+		GetReconciliationRunFunc: func(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+			return &domain.ReconciliationReport{
+				ReconciliationRun: domain.ReconciliationRun{ID: runID, Status: domain.ReconciliationCompleted, FinishedAt: &finishedAt},
+				Items: []domain.ReconciliationDiscrepancy{
+					{RunID: runID, Kind: domain.DiscrepancyAmountMismatch, QrPaymentID: 15, LocalValue: "100.00", RemoteValue: "90.00"},
+				},
+			}, nil
+		},
+		ListReconciliationRunsFunc: func(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+			if limit != 20 {
+				t.Errorf("Expected default limit 20, got %d", limit)
+			}
+			return []domain.ReconciliationRun{{ID: 5}}, nil
+		},
+	}
+
+	server := reconciliation.RegisterTest(log, provider)
+
+	resp, err := server.GetReconciliationRun(context.Background(), &reconciliationv1.GetReconciliationRunRequest{RunId: 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.Run.Id != 5 || resp.Run.FinishedAt == nil {
+		t.Errorf("Unexpected run: %v", resp.Run)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].QrPaymentId != 15 || resp.Items[0].RemoteValue != "90.00" {
+		t.Errorf("Unexpected items: %v", resp.Items)
+	}
+
+	list, err := server.ListReconciliationRuns(context.Background(), &reconciliationv1.ListReconciliationRunsRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list.Runs) != 1 {
+		t.Errorf("Expected 1 run, got %d", len(list.Runs))
+	}
+}
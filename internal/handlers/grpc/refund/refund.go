@@ -13,21 +13,24 @@ import (
 
 type serverAPI struct {
 	refundv1.UnimplementedRefundServiceServer
-	log            *slog.Logger
-	refundProvider handlers.RefundProvider
+	log                   *slog.Logger
+	refundProvider        handlers.RefundProvider
+	refundSessionProvider handlers.RefundSessionProvider
 }
 
-func Register(gRPC *grpc.Server, log *slog.Logger, refundProvider handlers.RefundProvider) {
+func Register(gRPC *grpc.Server, log *slog.Logger, refundProvider handlers.RefundProvider, refundSessionProvider handlers.RefundSessionProvider) {
 	refundv1.RegisterRefundServiceServer(gRPC, &serverAPI{
-		log:            log,
-		refundProvider: refundProvider,
+		log:                   log,
+		refundProvider:        refundProvider,
+		refundSessionProvider: refundSessionProvider,
 	})
 }
 
-func RegisterTest(log *slog.Logger, refundProvider handlers.RefundProvider) refundv1.RefundServiceServer {
+func RegisterTest(log *slog.Logger, refundProvider handlers.RefundProvider, refundSessionProvider handlers.RefundSessionProvider) refundv1.RefundServiceServer {
 	return &serverAPI{
-		log:            log,
-		refundProvider: refundProvider,
+		log:                   log,
+		refundProvider:        refundProvider,
+		refundSessionProvider: refundSessionProvider,
 	}
 }
 
@@ -35,6 +38,7 @@ func RegisterTest(log *slog.Logger, refundProvider handlers.RefundProvider) refu
 func (s *serverAPI) CreateRefundQR(ctx context.Context, req *refundv1.CreateRefundQRRequest) (*refundv1.CreateRefundQRResponse, error) {
 	domainReq := domain.QRRefundCreateRequest{
 		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
 		ExternalID:  req.ExternalId,
 	}
 
@@ -76,6 +80,7 @@ func (s *serverAPI) GetRefundStatus(ctx context.Context, req *refundv1.GetRefund
 func (s *serverAPI) GetCustomerOperations(ctx context.Context, req *refundv1.GetCustomerOperationsRequest) (*refundv1.GetCustomerOperationsResponse, error) {
 	domainReq := domain.CustomerOperationsRequest{
 		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
 		QrReturnID:  req.QrReturnId,
 		MaxResult:   req.MaxResult,
 	}
@@ -104,7 +109,13 @@ func (s *serverAPI) GetCustomerOperations(ctx context.Context, req *refundv1.Get
 
 // GetPaymentDetails implements kaspiv1.RefundServiceServer
 func (s *serverAPI) GetPaymentDetails(ctx context.Context, req *refundv1.GetPaymentDetailsRequest) (*refundv1.GetPaymentDetailsResponse, error) {
-	details, err := s.refundProvider.GetPaymentDetails(ctx, req.QrPaymentId, req.DeviceToken)
+	deviceToken, err := s.refundProvider.ResolveDeviceToken(ctx, req.DeviceToken, req.DeviceId, req.OrganizationBin)
+	if err != nil {
+		s.log.Error("GetPaymentDetails failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	details, err := s.refundProvider.GetPaymentDetails(ctx, req.QrPaymentId, deviceToken)
 	if err != nil {
 		s.log.Error("GetPaymentDetails failed", "error", err.Error())
 		return nil, grpchandler.HandleError(err, s.log)
@@ -124,6 +135,7 @@ func (s *serverAPI) GetPaymentDetails(ctx context.Context, req *refundv1.GetPaym
 func (s *serverAPI) RefundPayment(ctx context.Context, req *refundv1.RefundPaymentRequest) (*refundv1.RefundPaymentResponse, error) {
 	domainReq := domain.RefundRequest{
 		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
 		QrPaymentID: req.QrPaymentId,
 		QrReturnID:  req.QrReturnId,
 		Amount:      req.Amount,
@@ -0,0 +1,102 @@
+package refund
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kaspi-api-wrapper/internal/domain"
+	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
+	refundv1 "kaspi-api-wrapper/pkg/protos/gen/go/refund"
+)
+
+// CreateRefundSession implements kaspiv1.RefundServiceServer
+func (s *serverAPI) CreateRefundSession(ctx context.Context, req *refundv1.CreateRefundSessionRequest) (*refundv1.RefundSession, error) {
+	if s.refundSessionProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Refund sessions are not enabled")
+	}
+
+	session, err := s.refundSessionProvider.CreateRefundSession(ctx, domain.RefundSessionCreateRequest{
+		DeviceToken: req.DeviceToken,
+		DeviceID:    req.DeviceId,
+		ExternalID:  req.ExternalId,
+		MaxResult:   req.MaxResult,
+	})
+	if err != nil {
+		s.log.Error("CreateRefundSession failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return toProtoRefundSession(session), nil
+}
+
+// GetRefundSession implements kaspiv1.RefundServiceServer
+func (s *serverAPI) GetRefundSession(ctx context.Context, req *refundv1.GetRefundSessionRequest) (*refundv1.RefundSession, error) {
+	if s.refundSessionProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Refund sessions are not enabled")
+	}
+
+	session, err := s.refundSessionProvider.GetRefundSession(ctx, req.QrReturnId)
+	if err != nil {
+		s.log.Error("GetRefundSession failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return toProtoRefundSession(session), nil
+}
+
+// RefundSessionPayment implements kaspiv1.RefundServiceServer
+func (s *serverAPI) RefundSessionPayment(ctx context.Context, req *refundv1.RefundSessionPaymentRequest) (*refundv1.RefundSession, error) {
+	if s.refundSessionProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Refund sessions are not enabled")
+	}
+
+	session, err := s.refundSessionProvider.RefundSessionPayment(ctx, req.QrReturnId, domain.RefundSessionRefundRequest{
+		QrPaymentID: req.QrPaymentId,
+		Amount:      req.Amount,
+	})
+	if err != nil {
+		s.log.Error("RefundSessionPayment failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return toProtoRefundSession(session), nil
+}
+
+func toProtoRefundSession(session *domain.RefundSession) *refundv1.RefundSession {
+	operations := make([]*refundv1.CustomerOperation, 0, len(session.Operations))
+	for _, op := range session.Operations {
+		operations = append(operations, &refundv1.CustomerOperation{
+			QrPaymentId:     op.QrPaymentID,
+			TransactionDate: timestamppb.New(op.TransactionDate),
+			Amount:          op.Amount,
+		})
+	}
+
+	resp := &refundv1.RefundSession{
+		QrReturnId: session.QrReturnID,
+		ExternalId: session.ExternalID,
+		QrToken:    session.QrToken,
+		ExpireDate: timestamppb.New(session.ExpireDate),
+		QrRefundBehaviorOptions: &refundv1.QRRefundBehaviorOptions{
+			QrCodeScanEventPollingInterval: int32(session.QrRefundBehaviorOptions.QrCodeScanEventPollingInterval),
+			QrCodeScanWaitTimeout:          int32(session.QrRefundBehaviorOptions.QrCodeScanWaitTimeout),
+		},
+		State:                 session.State,
+		Status:                session.KaspiStatus,
+		Operations:            operations,
+		QrPaymentId:           session.QrPaymentID,
+		Amount:                session.Amount,
+		AvailableReturnAmount: session.AvailableReturnAmount,
+		ReturnOperationId:     session.ReturnOperationID,
+		Error:                 session.Error,
+		CreatedAt:             timestamppb.New(session.CreatedAt),
+		UpdatedAt:             timestamppb.New(session.UpdatedAt),
+	}
+
+	if session.Deadline != nil {
+		resp.Deadline = timestamppb.New(*session.Deadline)
+	}
+
+	return resp
+}
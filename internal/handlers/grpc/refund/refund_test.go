@@ -26,6 +26,7 @@ type MockRefundProvider struct {
 	GetCustomerOperationsFunc func(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error)
 	GetPaymentDetailsFunc     func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
 	RefundPaymentFunc         func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error)
+	ResolveDeviceTokenFunc    func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
 }
 
 func (m *MockRefundProvider) CreateRefundQR(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error) {
@@ -48,10 +49,17 @@ func (m *MockRefundProvider) RefundPayment(ctx context.Context, req domain.Refun
 	return m.RefundPaymentFunc(ctx, req)
 }
 
+func (m *MockRefundProvider) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	if m.ResolveDeviceTokenFunc != nil {
+		return m.ResolveDeviceTokenFunc(ctx, deviceToken, deviceID, organizationBin)
+	}
+	return deviceToken, nil
+}
+
 func createTestServer(refundProvider *MockRefundProvider) *refundServer {
 	log := setupTestLogger()
 	srv := &refundServer{
-		server: refund.RegisterTest(log, refundProvider),
+		server: refund.RegisterTest(log, refundProvider, nil),
 	}
 	return srv
 }
@@ -282,6 +290,48 @@ func TestGetPaymentDetails(t *testing.T) {
 			t.Errorf("Expected available return amount 100.00, got %f", resp.AvailableReturnAmount)
 		}
 	})
+
+	t.Run("resolves the token of the device", func(t *testing.T) {
+		mockProvider := &MockRefundProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				if deviceID != "POS-1" || organizationBin != "180340021791" {
+					t.Errorf("Unexpected device %q of %q", deviceID, organizationBin)
+				}
+				return "test-token", nil
+			},
+			GetPaymentDetailsFunc: func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+				if deviceToken != "test-token" {
+					t.Errorf("Expected device token test-token, got %s", deviceToken)
+				}
+				return &domain.PaymentDetailsResponse{QrPaymentID: qrPaymentID}, nil
+			},
+		}
+
+		srv := createTestServer(mockProvider)
+		_, err := srv.server.GetPaymentDetails(context.Background(), &refundv1.GetPaymentDetailsRequest{
+			QrPaymentId:     123,
+			DeviceId:        "POS-1",
+			OrganizationBin: "180340021791",
+		})
+		if err != nil {
+			t.Fatalf("GetPaymentDetails returned error: %v", err)
+		}
+	})
+
+	t.Run("returns not found for unknown device", func(t *testing.T) {
+		mockProvider := &MockRefundProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				return "", domain.ErrDeviceNotRegistered
+			},
+		}
+
+		srv := createTestServer(mockProvider)
+		_, err := srv.server.GetPaymentDetails(context.Background(), &refundv1.GetPaymentDetailsRequest{QrPaymentId: 123, DeviceId: "UNKNOWN"})
+
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected status code %s, got %s", codes.NotFound, status.Code(err))
+		}
+	})
 }
 
 func TestRefundPayment(t *testing.T) {
@@ -3,6 +3,9 @@ package refund_enhanced
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers"
 	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
@@ -35,6 +38,7 @@ func RegisterTest(log *slog.Logger, refundEnhancedProvider handlers.RefundEnhanc
 func (s *serverAPI) RefundPaymentEnhanced(ctx context.Context, req *refundenhancedv1.RefundPaymentEnhancedRequest) (*refundenhancedv1.RefundPaymentEnhancedResponse, error) {
 	domainReq := domain.EnhancedRefundRequest{
 		DeviceToken:     req.DeviceToken,
+		DeviceID:        req.DeviceId,
 		QrPaymentID:     req.QrPaymentId,
 		Amount:          req.Amount,
 		OrganizationBin: req.OrganizationBin,
@@ -55,7 +59,19 @@ func (s *serverAPI) RefundPaymentEnhanced(ctx context.Context, req *refundenhanc
 
 // GetClientInfo implements kaspiv1.EnhancedRefundServiceServer
 func (s *serverAPI) GetClientInfo(ctx context.Context, req *refundenhancedv1.GetClientInfoRequest) (*refundenhancedv1.GetClientInfoResponse, error) {
-	info, err := s.refundEnhancedProvider.GetClientInfo(ctx, req.PhoneNumber, req.DeviceToken)
+	deviceToken := req.DeviceToken
+	if deviceToken == 0 && req.DeviceId != "" {
+		token, err := s.refundEnhancedProvider.ResolveDeviceToken(ctx, "", req.DeviceId, req.OrganizationBin)
+		if err != nil {
+			s.log.Error("GetClientInfo failed", "error", err.Error())
+			return nil, grpchandler.HandleError(err, s.log)
+		}
+		if deviceToken, err = strconv.ParseInt(token, 10, 64); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "device token of the device is not numeric")
+		}
+	}
+
+	info, err := s.refundEnhancedProvider.GetClientInfo(ctx, req.PhoneNumber, deviceToken)
 	if err != nil {
 		s.log.Error("GetClientInfo failed", "error", err.Error())
 		return nil, grpchandler.HandleError(err, s.log)
@@ -70,9 +86,12 @@ func (s *serverAPI) GetClientInfo(ctx context.Context, req *refundenhancedv1.Get
 
 // CreateRemotePayment implements kaspiv1.EnhancedRefundServiceServer
 func (s *serverAPI) CreateRemotePayment(ctx context.Context, req *refundenhancedv1.CreateRemotePaymentRequest) (*refundenhancedv1.CreateRemotePaymentResponse, error) {
-	deviceToken, err := strconv.ParseInt(req.DeviceToken, 10, 64)
-	if err != nil {
-		return nil, grpchandler.HandleError(err, s.log)
+	var deviceToken int64
+	if req.DeviceToken != "" {
+		var err error
+		if deviceToken, err = strconv.ParseInt(req.DeviceToken, 10, 64); err != nil {
+			return nil, grpchandler.HandleError(err, s.log)
+		}
 	}
 
 	domainReq := domain.RemotePaymentRequest{
@@ -80,6 +99,7 @@ func (s *serverAPI) CreateRemotePayment(ctx context.Context, req *refundenhanced
 		Amount:          req.Amount,
 		PhoneNumber:     req.PhoneNumber,
 		DeviceToken:     deviceToken,
+		DeviceID:        req.DeviceId,
 		Comment:         req.Comment,
 	}
 
@@ -102,6 +122,7 @@ func (s *serverAPI) CancelRemotePayment(ctx context.Context, req *refundenhanced
 		OrganizationBin: req.OrganizationBin,
 		QrPaymentID:     req.QrPaymentId,
 		DeviceToken:     req.DeviceToken,
+		DeviceID:        req.DeviceId,
 	}
 
 	result, err := s.refundEnhancedProvider.CancelRemotePayment(ctx, domainReq)
@@ -116,3 +137,31 @@ func (s *serverAPI) CancelRemotePayment(ctx context.Context, req *refundenhanced
 
 	return resp, nil
 }
+
+// ListPendingRemotePayments implements kaspiv1.EnhancedRefundServiceServer
+func (s *serverAPI) ListPendingRemotePayments(ctx context.Context, req *refundenhancedv1.ListPendingRemotePaymentsRequest) (*refundenhancedv1.ListPendingRemotePaymentsResponse, error) {
+	payments, err := s.refundEnhancedProvider.GetPendingRemotePayments(ctx, req.OrganizationBin)
+	if err != nil {
+		s.log.Error("ListPendingRemotePayments failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &refundenhancedv1.ListPendingRemotePaymentsResponse{
+		Payments: make([]*refundenhancedv1.RemotePayment, 0, len(payments)),
+	}
+
+	for _, payment := range payments {
+		resp.Payments = append(resp.Payments, &refundenhancedv1.RemotePayment{
+			QrPaymentId:     payment.QrPaymentID,
+			OrganizationBin: payment.OrganizationBin,
+			Amount:          payment.Amount,
+			PhoneNumber:     payment.PhoneNumber,
+			Comment:         payment.Comment,
+			Status:          payment.Status,
+			CreatedAt:       timestamppb.New(payment.CreatedAt),
+			UpdatedAt:       timestamppb.New(payment.UpdatedAt),
+		})
+	}
+
+	return resp, nil
+}
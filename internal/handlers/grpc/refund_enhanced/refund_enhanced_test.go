@@ -24,6 +24,8 @@ type MockRefundEnhancedProvider struct {
 	GetClientInfoFunc         func(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error)
 	CreateRemotePaymentFunc   func(ctx context.Context, req domain.RemotePaymentRequest) (*domain.RemotePaymentResponse, error)
 	CancelRemotePaymentFunc   func(ctx context.Context, req domain.RemotePaymentCancelRequest) (*domain.RemotePaymentCancelResponse, error)
+	GetPendingRemoteFunc      func(ctx context.Context, organizationBin string) ([]domain.Payment, error)
+	ResolveDeviceTokenFunc    func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
 }
 
 func (m *MockRefundEnhancedProvider) RefundPaymentEnhanced(ctx context.Context, req domain.EnhancedRefundRequest) (*domain.RefundResponse, error) {
@@ -42,6 +44,17 @@ func (m *MockRefundEnhancedProvider) CancelRemotePayment(ctx context.Context, re
 	return m.CancelRemotePaymentFunc(ctx, req)
 }
 
+func (m *MockRefundEnhancedProvider) GetPendingRemotePayments(ctx context.Context, organizationBin string) ([]domain.Payment, error) {
+	return m.GetPendingRemoteFunc(ctx, organizationBin)
+}
+
+func (m *MockRefundEnhancedProvider) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	if m.ResolveDeviceTokenFunc != nil {
+		return m.ResolveDeviceTokenFunc(ctx, deviceToken, deviceID, organizationBin)
+	}
+	return deviceToken, nil
+}
+
 func createTestServer(refundEnhancedProvider *MockRefundEnhancedProvider) *refundEnhancedServer {
 	log := setupTestLogger()
 	srv := &refundEnhancedServer{
@@ -173,6 +186,52 @@ func TestGetClientInfo(t *testing.T) {
 		}
 	})
 
+	t.Run("resolves the token of the device", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				if deviceID != "POS-1" || organizationBin != "180340021791" {
+					t.Errorf("Unexpected device %q of %q", deviceID, organizationBin)
+				}
+				return "2", nil
+			},
+			GetClientInfoFunc: func(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error) {
+				if deviceToken != 2 {
+					t.Errorf("Expected device token 2, got %d", deviceToken)
+				}
+				return &domain.ClientInfoResponse{ClientName: "John Doe"}, nil
+			},
+		}
+
+		srv := createTestServer(mockProvider)
+		_, err := srv.server.GetClientInfo(context.Background(), &refundenhancedv1.GetClientInfoRequest{
+			PhoneNumber:     "87071234567",
+			DeviceId:        "POS-1",
+			OrganizationBin: "180340021791",
+		})
+		if err != nil {
+			t.Fatalf("GetClientInfo returned error: %v", err)
+		}
+	})
+
+	t.Run("rejects device with a non-numeric token", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				return "2be4cc91-5895-48f8-8bc2-86c7bd419b3b", nil
+			},
+		}
+
+		srv := createTestServer(mockProvider)
+		_, err := srv.server.GetClientInfo(context.Background(), &refundenhancedv1.GetClientInfoRequest{
+			PhoneNumber:     "87071234567",
+			DeviceId:        "POS-1",
+			OrganizationBin: "180340021791",
+		})
+
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected status code %s, got %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+
 	t.Run("handles invalid phone number error", func(t *testing.T) {
 		mockProvider := &MockRefundEnhancedProvider{
 			GetClientInfoFunc: func(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error) {
@@ -247,6 +306,29 @@ func TestCreateRemotePayment(t *testing.T) {
 		}
 	})
 
+	t.Run("passes device ID without token", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			CreateRemotePaymentFunc: func(ctx context.Context, req domain.RemotePaymentRequest) (*domain.RemotePaymentResponse, error) {
+				if req.DeviceToken != 0 || req.DeviceID != "POS-1" {
+					t.Errorf("Unexpected device in request: %+v", req)
+				}
+				return &domain.RemotePaymentResponse{QrPaymentID: 15}, nil
+			},
+		}
+
+		srv := createTestServer(mockProvider)
+		req := &refundenhancedv1.CreateRemotePaymentRequest{
+			OrganizationBin: "180340021791",
+			Amount:          100.00,
+			PhoneNumber:     "87071234567",
+			DeviceId:        "POS-1",
+		}
+
+		if _, err := srv.server.CreateRemotePayment(context.Background(), req); err != nil {
+			t.Fatalf("CreateRemotePayment returned error: %v", err)
+		}
+	})
+
 	t.Run("handles invalid device token format", func(t *testing.T) {
 		mockProvider := &MockRefundEnhancedProvider{}
 
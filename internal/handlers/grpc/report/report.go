@@ -0,0 +1,160 @@
+package report
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers"
+	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
+	reportv1 "kaspi-api-wrapper/pkg/protos/gen/go/report"
+	"log/slog"
+	"strings"
+)
+
+type serverAPI struct {
+	reportv1.UnimplementedReportServiceServer
+	log            *slog.Logger
+	reportProvider handlers.ReportProvider
+}
+
+func Register(gRPC *grpc.Server, log *slog.Logger, reportProvider handlers.ReportProvider) {
+	reportv1.RegisterReportServiceServer(gRPC, &serverAPI{
+		log:            log,
+		reportProvider: reportProvider,
+	})
+}
+
+func RegisterTest(log *slog.Logger, reportProvider handlers.ReportProvider) reportv1.ReportServiceServer {
+	return &serverAPI{
+		log:            log,
+		reportProvider: reportProvider,
+	}
+}
+
+// GetSettlementReport implements kaspiv1.ReportServiceServer
+func (s *serverAPI) GetSettlementReport(ctx context.Context, req *reportv1.SettlementReportRequest) (*reportv1.SettlementReport, error) {
+	if s.reportProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reports are not enabled")
+	}
+
+	report, err := s.reportProvider.SettlementReport(ctx, toSettlementReportRequest(req))
+	if err != nil {
+		s.log.Error("GetSettlementReport failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	resp := &reportv1.SettlementReport{
+		DateFrom:    report.DateFrom,
+		DateTo:      report.DateTo,
+		Timezone:    report.Timezone,
+		GeneratedAt: timestamppb.New(report.GeneratedAt),
+		Days:        make([]*reportv1.SettlementDay, 0, len(report.Days)),
+	}
+
+	for _, day := range report.Days {
+		respDay := &reportv1.SettlementDay{
+			Date:             day.Date,
+			Totals:           toSettlementTotals(day.SettlementTotals),
+			ByProductType:    toSettlementBreakdown(day.ByProductType),
+			TradePoints:      make([]*reportv1.SettlementTradePoint, 0, len(day.TradePoints)),
+			ByPaymentMethods: toSettlementMethodsBreakdown(day.ByPaymentMethods),
+		}
+
+		for _, tradePoint := range day.TradePoints {
+			respTradePoint := &reportv1.SettlementTradePoint{
+				TradePointId:     tradePoint.TradePointID,
+				Totals:           toSettlementTotals(tradePoint.SettlementTotals),
+				ByProductType:    toSettlementBreakdown(tradePoint.ByProductType),
+				Devices:          make([]*reportv1.SettlementDevice, 0, len(tradePoint.Devices)),
+				ByPaymentMethods: toSettlementMethodsBreakdown(tradePoint.ByPaymentMethods),
+			}
+
+			for _, device := range tradePoint.Devices {
+				respTradePoint.Devices = append(respTradePoint.Devices, &reportv1.SettlementDevice{
+					DeviceId:         device.DeviceID,
+					Totals:           toSettlementTotals(device.SettlementTotals),
+					ByProductType:    toSettlementBreakdown(device.ByProductType),
+					ByPaymentMethods: toSettlementMethodsBreakdown(device.ByPaymentMethods),
+				})
+			}
+
+			respDay.TradePoints = append(respDay.TradePoints, respTradePoint)
+		}
+
+		resp.Days = append(resp.Days, respDay)
+	}
+
+	return resp, nil
+}
+
+// ExportSettlementReport implements kaspiv1.ReportServiceServer
+func (s *serverAPI) ExportSettlementReport(ctx context.Context, req *reportv1.ExportSettlementReportRequest) (*reportv1.ExportSettlementReportResponse, error) {
+	if s.reportProvider == nil {
+		return nil, status.Error(codes.Unavailable, "Reports are not enabled")
+	}
+
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = domain.ReportFormatJSON
+	}
+
+	file, err := s.reportProvider.ExportSettlementReport(ctx, toSettlementReportRequest(req.Report), format)
+	if err != nil {
+		s.log.Error("ExportSettlementReport failed", "error", err.Error())
+		return nil, grpchandler.HandleError(err, s.log)
+	}
+
+	return &reportv1.ExportSettlementReportResponse{
+		Data:        file.Data,
+		ContentType: file.ContentType,
+		FileName:    file.FileName,
+	}, nil
+}
+
+func toSettlementReportRequest(req *reportv1.SettlementReportRequest) domain.SettlementReportRequest {
+	if req == nil {
+		return domain.SettlementReportRequest{}
+	}
+
+	return domain.SettlementReportRequest{
+		DateFrom:        req.DateFrom,
+		DateTo:          req.DateTo,
+		TradePointID:    req.TradePointId,
+		OrganizationBin: req.OrganizationBin,
+	}
+}
+
+func toSettlementTotals(totals domain.SettlementTotals) *reportv1.SettlementTotals {
+	return &reportv1.SettlementTotals{
+		GrossSales:   totals.GrossSales,
+		Refunds:      totals.Refunds,
+		Net:          totals.Net,
+		SalesCount:   int32(totals.SalesCount),
+		RefundsCount: int32(totals.RefundsCount),
+	}
+}
+
+func toSettlementBreakdown(breakdown []domain.SettlementBreakdown) []*reportv1.SettlementBreakdown {
+	resp := make([]*reportv1.SettlementBreakdown, 0, len(breakdown))
+	for _, b := range breakdown {
+		resp = append(resp, &reportv1.SettlementBreakdown{
+			ProductType: b.ProductType,
+			Totals:      toSettlementTotals(b.SettlementTotals),
+		})
+	}
+	return resp
+}
+
+func toSettlementMethodsBreakdown(breakdown []domain.SettlementMethodsBreakdown) []*reportv1.SettlementMethodsBreakdown {
+	resp := make([]*reportv1.SettlementMethodsBreakdown, 0, len(breakdown))
+	for _, b := range breakdown {
+		resp = append(resp, &reportv1.SettlementMethodsBreakdown{
+			PaymentMethods: b.PaymentMethods,
+			Totals:         toSettlementTotals(b.SettlementTotals),
+		})
+	}
+	return resp
+}
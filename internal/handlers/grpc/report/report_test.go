@@ -0,0 +1,173 @@
+package report_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers/grpc/report"
+	"kaspi-api-wrapper/internal/validator"
+	reportv1 "kaspi-api-wrapper/pkg/protos/gen/go/report"
+)
+
+func setupTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+}
+
+type MockReportProvider struct {
+	SettlementReportFunc       func(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error)
+	ExportSettlementReportFunc func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error)
+}
+
+func (m *MockReportProvider) SettlementReport(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+	return m.SettlementReportFunc(ctx, req)
+}
+
+func (m *MockReportProvider) ExportSettlementReport(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+	return m.ExportSettlementReportFunc(ctx, req, format)
+}
+
+func TestGetSettlementReport(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("maps report hierarchy", func(t *testing.T) {
+		provider := &MockReportProvider{
+			SettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+				if req.DateFrom != "2026-05-01" || req.TradePointID != 7 {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+
+				totals := domain.SettlementTotals{GrossSales: 300, Refunds: 100, Net: 200, SalesCount: 2, RefundsCount: 1}
+				summary := domain.SettlementSummary{
+					SettlementTotals: totals,
+					ByProductType:    []domain.SettlementBreakdown{{ProductType: "Gold", SettlementTotals: totals}},
+					ByPaymentMethods: []domain.SettlementMethodsBreakdown{{PaymentMethods: []string{"Gold", "Red"}, SettlementTotals: totals}},
+				}
+
+				return &domain.SettlementReport{
+					DateFrom: req.DateFrom,
+					DateTo:   req.DateFrom,
+					Timezone: "Asia/Almaty",
+					Days: []domain.SettlementDay{{
+						Date:              "2026-05-01",
+						SettlementSummary: summary,
+						TradePoints: []domain.SettlementTradePoint{{
+							TradePointID:      7,
+							SettlementSummary: summary,
+							Devices:           []domain.SettlementDevice{{DeviceID: "A", SettlementSummary: summary}},
+						}},
+					}},
+				}, nil
+			},
+		}
+
+		server := report.RegisterTest(log, provider)
+		resp, err := server.GetSettlementReport(context.Background(), &reportv1.SettlementReportRequest{
+			DateFrom:     "2026-05-01",
+			TradePointId: 7,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if resp.Timezone != "Asia/Almaty" || len(resp.Days) != 1 || resp.Days[0].Totals.Net != 200 {
+			t.Fatalf("Unexpected report: %+v", resp)
+		}
+
+		tradePoints := resp.Days[0].TradePoints
+		if len(tradePoints) != 1 || len(tradePoints[0].Devices) != 1 || tradePoints[0].Devices[0].DeviceId != "A" {
+			t.Errorf("Unexpected trade points: %+v", tradePoints)
+		}
+
+		if breakdown := tradePoints[0].Devices[0].ByProductType; len(breakdown) != 1 || breakdown[0].ProductType != "Gold" {
+			t.Errorf("Unexpected breakdown: %+v", breakdown)
+		}
+
+		if breakdown := resp.Days[0].ByPaymentMethods; len(breakdown) != 1 || len(breakdown[0].PaymentMethods) != 2 || breakdown[0].Totals.Net != 200 {
+			t.Errorf("Unexpected payment methods breakdown: %+v", breakdown)
+		}
+	})
+
+	t.Run("returns invalid argument for invalid dates", func(t *testing.T) {
+		provider := &MockReportProvider{
+			SettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+				return nil, fmt.Errorf("report.SettlementReport: %w", &validator.ValidationError{
+					Field:   "dateFrom",
+					Message: "start date is required as YYYY-MM-DD",
+					Err:     validator.ErrInvalidValue,
+				})
+			},
+		}
+
+		server := report.RegisterTest(log, provider)
+		_, err := server.GetSettlementReport(context.Background(), &reportv1.SettlementReportRequest{})
+
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected code InvalidArgument, got %s", status.Code(err))
+		}
+	})
+
+	t.Run("returns unavailable when reports are disabled", func(t *testing.T) {
+		server := report.RegisterTest(log, nil)
+		_, err := server.GetSettlementReport(context.Background(), &reportv1.SettlementReportRequest{})
+
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("Expected code Unavailable, got %s", status.Code(err))
+		}
+	})
+}
+
+func TestExportSettlementReport(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("returns file", func(t *testing.T) {
+		provider := &MockReportProvider{
+			ExportSettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+				if format != domain.ReportFormatXLSX || req.DateFrom != "2026-05-01" {
+					t.Errorf("Unexpected request %+v in format %s", req, format)
+				}
+				return &domain.ReportFile{
+					FileName:    "settlement-2026-05-01_2026-05-01.xlsx",
+					ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+					Data:        []byte("PK"),
+				}, nil
+			},
+		}
+
+		server := report.RegisterTest(log, provider)
+		resp, err := server.ExportSettlementReport(context.Background(), &reportv1.ExportSettlementReportRequest{
+			Report: &reportv1.SettlementReportRequest{DateFrom: "2026-05-01"},
+			Format: "XLSX",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if resp.FileName != "settlement-2026-05-01_2026-05-01.xlsx" || string(resp.Data) != "PK" {
+			t.Errorf("Unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("defaults to JSON", func(t *testing.T) {
+		provider := &MockReportProvider{
+			ExportSettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+				if format != domain.ReportFormatJSON {
+					t.Errorf("Expected format json, got %s", format)
+				}
+				return &domain.ReportFile{FileName: "settlement.json", ContentType: "application/json", Data: []byte("{}")}, nil
+			},
+		}
+
+		server := report.RegisterTest(log, provider)
+		if _, err := server.ExportSettlementReport(context.Background(), &reportv1.ExportSettlementReportRequest{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
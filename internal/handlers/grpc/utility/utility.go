@@ -3,6 +3,7 @@ package utility
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/handlers"
 	grpchandler "kaspi-api-wrapper/internal/handlers/grpc"
@@ -38,9 +39,28 @@ func (s *serverAPI) HealthCheck(ctx context.Context, req *utilityv1.HealthCheckR
 		return nil, grpchandler.HandleError(err, s.log)
 	}
 
-	return &utilityv1.HealthCheckResponse{
-		Status: "OK",
-	}, nil
+	breakers := s.utilityProvider.CircuitBreakerStates()
+
+	resp := &utilityv1.HealthCheckResponse{
+		Status:          "OK",
+		CircuitBreakers: make([]*utilityv1.CircuitBreakerState, 0, len(breakers)),
+	}
+
+	for _, b := range breakers {
+		state := &utilityv1.CircuitBreakerState{
+			Endpoint: b.Endpoint,
+			State:    b.State,
+			Failures: int64(b.Failures),
+		}
+
+		if b.OpenedAt != nil {
+			state.OpenedAt = timestamppb.New(*b.OpenedAt)
+		}
+
+		resp.CircuitBreakers = append(resp.CircuitBreakers, state)
+	}
+
+	return resp, nil
 }
 
 // TestScanQR implements kaspiv1.UtilityServiceServer
@@ -27,12 +27,17 @@ type MockUtilityProvider struct {
 	TestConfirmPaymentFunc func(ctx context.Context, req domain.TestConfirmRequest) error
 	TestScanErrorFunc      func(ctx context.Context, req domain.TestScanErrorRequest) error
 	TestConfirmErrorFunc   func(ctx context.Context, req domain.TestConfirmErrorRequest) error
+	BreakerStates          []domain.CircuitBreakerState
 }
 
 func (m *MockUtilityProvider) HealthCheck(ctx context.Context) error {
 	return m.HealthCheckFunc(ctx)
 }
 
+func (m *MockUtilityProvider) CircuitBreakerStates() []domain.CircuitBreakerState {
+	return m.BreakerStates
+}
+
 func (m *MockUtilityProvider) TestScanQR(ctx context.Context, req domain.TestScanRequest) error {
 	return m.TestScanQRFunc(ctx, req)
 }
@@ -59,7 +64,7 @@ func TestHealthCheckKaspi(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/test/health", nil)
 		if err != nil {
@@ -106,7 +111,7 @@ func TestHealthCheckKaspi(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/test/health", nil)
 		if err != nil {
@@ -151,7 +156,7 @@ func TestTestScanQR(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"qrPaymentId": "123456"}`
 		req, err := http.NewRequest("POST", "/test/payment/scan", strings.NewReader(reqBody))
@@ -205,7 +210,7 @@ func TestTestScanQR(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"qrPaymentId": ""}`
 		req, err := http.NewRequest("POST", "/test/payment/scan", strings.NewReader(reqBody))
@@ -252,7 +257,7 @@ func TestTestConfirmPayment(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"qrPaymentId": "123456"}`
 		req, err := http.NewRequest("POST", "/test/payment/confirm", strings.NewReader(reqBody))
@@ -304,7 +309,7 @@ func TestTestScanError(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"qrPaymentId": "123456"}`
 		req, err := http.NewRequest("POST", "/test/payment/scanerror", strings.NewReader(reqBody))
@@ -356,7 +361,7 @@ func TestTestConfirmError(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"qrPaymentId": "123456"}`
 		req, err := http.NewRequest("POST", "/test/payment/confirmerror", strings.NewReader(reqBody))
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"github.com/go-chi/chi/v5"
 	"kaspi-api-wrapper/internal/domain"
 	"net/http"
+	"strconv"
 )
 
 // GetTradePoints handles retrieving trade points (2.2.2)
@@ -20,6 +22,52 @@ func (h *Handlers) GetTradePoints(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RefreshTradePoints handles an on-demand sync of the trade point cache with Kaspi
+func (h *Handlers) RefreshTradePoints(w http.ResponseWriter, r *http.Request) {
+	tradePoints, err := h.deviceProvider.RefreshTradePoints(r.Context())
+	if err != nil {
+		h.log.Error("failed to refresh trade points", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    tradePoints,
+	})
+}
+
+// UpdateTradePoint handles an update of the metadata of a cached trade point
+func (h *Handlers) UpdateTradePoint(w http.ResponseWriter, r *http.Request) {
+	var req domain.TradePointUpdateRequest
+	if !DecodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	tradePointID, err := strconv.ParseInt(chi.URLParam(r, "tradePointId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid trade point ID format")
+		return
+	}
+	if req.TradePointID != 0 && req.TradePointID != tradePointID {
+		BadRequestError(w, "TradePointId does not match the URL")
+		return
+	}
+	req.TradePointID = tradePointID
+
+	tradePoint, err := h.deviceProvider.UpdateTradePoint(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to update trade point", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    tradePoint,
+	})
+}
+
 // RegisterDevice handles device registration (2.2.3)
 func (h *Handlers) RegisterDevice(w http.ResponseWriter, r *http.Request) {
 	var req domain.DeviceRegisterRequest
@@ -62,3 +110,54 @@ func (h *Handlers) DeleteDevice(w http.ResponseWriter, r *http.Request) {
 		Data:    map[string]string{"message": "Device deleted successfully"},
 	})
 }
+
+// ListDevices handles listing devices registered through the wrapper
+func (h *Handlers) ListDevices(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.DeviceFilter{
+		OrganizationBin: query.Get("OrganizationBin"),
+	}
+
+	var err error
+
+	if value := query.Get("TradePointId"); value != "" {
+		if filter.TradePointID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			BadRequestError(w, "Invalid trade point ID format")
+			return
+		}
+	}
+
+	if value := query.Get("IncludeDeleted"); value != "" {
+		if filter.IncludeDeleted, err = strconv.ParseBool(value); err != nil {
+			BadRequestError(w, "Invalid IncludeDeleted format")
+			return
+		}
+	}
+
+	devices, err := h.deviceProvider.ListDevices(r.Context(), filter)
+	if err != nil {
+		h.log.Error("failed to list devices", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    devices,
+	})
+}
+
+// GetDevice handles retrieval of a registered device by its ID
+func (h *Handlers) GetDevice(w http.ResponseWriter, r *http.Request) {
+	device, err := h.deviceProvider.GetDevice(r.Context(), chi.URLParam(r, "deviceId"))
+	if err != nil {
+		h.log.Error("failed to get device", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    device,
+	})
+}
@@ -23,6 +23,23 @@ func (h *Handlers) GetTradePointsEnhanced(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// RefreshTradePointsEnhanced handles an on-demand sync of the cached trade points of an organization
+func (h *Handlers) RefreshTradePointsEnhanced(w http.ResponseWriter, r *http.Request) {
+	organizationBin := chi.URLParam(r, "organizationBin")
+
+	tradePoints, err := h.deviceEnhancedProvider.RefreshTradePointsEnhanced(r.Context(), organizationBin)
+	if err != nil {
+		h.log.Error("failed to refresh trade points (enhanced)", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    tradePoints,
+	})
+}
+
 // RegisterDeviceEnhanced processes a request to register a device in the enhanced scheme (4.2.3)
 func (h *Handlers) RegisterDeviceEnhanced(w http.ResponseWriter, r *http.Request) {
 	var req domain.EnhancedDeviceRegisterRequest
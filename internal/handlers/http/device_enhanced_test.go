@@ -15,15 +15,20 @@ import (
 )
 
 type MockDeviceEnhancedProvider struct {
-	GetTradePointsEnhancedFunc func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
-	RegisterDeviceEnhancedFunc func(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
-	DeleteDeviceEnhancedFunc   func(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error
+	GetTradePointsEnhancedFunc     func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	RefreshTradePointsEnhancedFunc func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	RegisterDeviceEnhancedFunc     func(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
+	DeleteDeviceEnhancedFunc       func(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error
 }
 
 func (m *MockDeviceEnhancedProvider) GetTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
 	return m.GetTradePointsEnhancedFunc(ctx, organizationBin)
 }
 
+func (m *MockDeviceEnhancedProvider) RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	return m.RefreshTradePointsEnhancedFunc(ctx, organizationBin)
+}
+
 func (m *MockDeviceEnhancedProvider) RegisterDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error) {
 	return m.RegisterDeviceEnhancedFunc(ctx, req)
 }
@@ -49,7 +54,7 @@ func TestGetTradePointsEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Get("/tradepoints/enhanced/{organizationBin}", h.GetTradePointsEnhanced)
@@ -122,7 +127,7 @@ func TestRegisterDeviceEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Post("/device/register/enhanced", h.RegisterDeviceEnhanced)
@@ -186,7 +191,7 @@ func TestRegisterDeviceEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Post("/device/register/enhanced", h.RegisterDeviceEnhanced)
@@ -244,7 +249,7 @@ func TestDeleteDeviceEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Post("/device/delete/enhanced", h.DeleteDeviceEnhanced)
@@ -292,7 +297,7 @@ func TestDeleteDeviceEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Post("/device/delete/enhanced", h.DeleteDeviceEnhanced)
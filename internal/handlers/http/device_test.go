@@ -3,24 +3,41 @@ package http_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"kaspi-api-wrapper/internal/domain"
 	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"kaspi-api-wrapper/internal/storage"
 	"kaspi-api-wrapper/internal/validator"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type MockDeviceProvider struct {
-	GetTradePointsFunc func(ctx context.Context) ([]domain.TradePoint, error)
-	RegisterDeviceFunc func(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
-	DeleteDeviceFunc   func(ctx context.Context, deviceToken string) error
+	GetTradePointsFunc     func(ctx context.Context) ([]domain.TradePoint, error)
+	RefreshTradePointsFunc func(ctx context.Context) ([]domain.TradePoint, error)
+	UpdateTradePointFunc   func(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error)
+	RegisterDeviceFunc     func(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
+	DeleteDeviceFunc       func(ctx context.Context, deviceToken string) error
+	ListDevicesFunc        func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	GetDeviceFunc          func(ctx context.Context, deviceID string) (*domain.Device, error)
 }
 
 func (m *MockDeviceProvider) GetTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
 	return m.GetTradePointsFunc(ctx)
 }
 
+func (m *MockDeviceProvider) RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
+	return m.RefreshTradePointsFunc(ctx)
+}
+
+func (m *MockDeviceProvider) UpdateTradePoint(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+	return m.UpdateTradePointFunc(ctx, req)
+}
+
 func (m *MockDeviceProvider) RegisterDevice(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error) {
 	if m.RegisterDeviceFunc != nil {
 		return m.RegisterDeviceFunc(ctx, req)
@@ -64,6 +81,14 @@ func (m *MockDeviceProvider) DeleteDevice(ctx context.Context, deviceToken strin
 	return nil
 }
 
+func (m *MockDeviceProvider) ListDevices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	return m.ListDevicesFunc(ctx, filter)
+}
+
+func (m *MockDeviceProvider) GetDevice(ctx context.Context, deviceID string) (*domain.Device, error) {
+	return m.GetDeviceFunc(ctx, deviceID)
+}
+
 func TestGetTradePoints(t *testing.T) {
 	log := setupTestLogger()
 
@@ -77,7 +102,7 @@ func TestGetTradePoints(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := createRequest(http.MethodGet, "/handlers/tradepoints", nil)
 		if err != nil {
@@ -129,7 +154,7 @@ func TestGetTradePoints(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := createRequest(http.MethodGet, "/handlers/tradepoints", nil)
 		if err != nil {
@@ -173,7 +198,7 @@ func TestRegisterDevice(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		registerReq := domain.DeviceRegisterRequest{
 			DeviceID:     "TEST-DEVICE",
@@ -222,7 +247,7 @@ func TestRegisterDevice(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockDeviceProvider{}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		registerReq := domain.DeviceRegisterRequest{
 			DeviceID: "TEST-DEVICE",
@@ -268,7 +293,7 @@ func TestDeleteDevice(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		deleteReq := struct {
 			DeviceToken string `json:"deviceToken"`
@@ -303,7 +328,7 @@ func TestDeleteDevice(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockDeviceProvider{}
 
-		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		deleteReq := struct {
 			DeviceToken string `json:"deviceToken"`
@@ -340,3 +365,186 @@ func TestDeleteDevice(t *testing.T) {
 		}
 	})
 }
+
+func TestListDevices(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("passes filters", func(t *testing.T) {
+		mockProvider := &MockDeviceProvider{
+			ListDevicesFunc: func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+				if filter.TradePointID != 7 || filter.OrganizationBin != "180340021791" || !filter.IncludeDeleted {
+					t.Errorf("Unexpected filter: %+v", filter)
+				}
+				return []domain.Device{{DeviceID: "TEST-DEVICE", TradePointID: 7, Active: true}}, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/devices?TradePointId=7&OrganizationBin=180340021791&IncludeDeleted=true", nil)
+		recorder := httptest.NewRecorder()
+
+		h.ListDevices(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data []domain.Device `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if len(resp.Data) != 1 || resp.Data[0].DeviceID != "TEST-DEVICE" {
+			t.Errorf("Unexpected devices: %+v", resp.Data)
+		}
+	})
+
+	t.Run("rejects invalid trade point", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, &MockDeviceProvider{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/devices?TradePointId=abc", nil)
+		recorder := httptest.NewRecorder()
+
+		h.ListDevices(recorder, req)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
+
+func TestGetDevice(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockDeviceProvider, url string) *httptest.ResponseRecorder {
+		h := httphandler.NewHandlers(log, provider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Get("/devices/{deviceId}", h.GetDevice)
+
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+
+		return recorder
+	}
+
+	t.Run("returns device", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{
+			GetDeviceFunc: func(ctx context.Context, deviceID string) (*domain.Device, error) {
+				if deviceID != "TEST-DEVICE" {
+					t.Errorf("Expected device TEST-DEVICE, got %s", deviceID)
+				}
+				return &domain.Device{DeviceID: deviceID, DeviceToken: "test-token", Active: true}, nil
+			},
+		}, "/devices/TEST-DEVICE")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.Device `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if resp.Data.DeviceID != "TEST-DEVICE" || !resp.Data.Active {
+			t.Errorf("Unexpected device: %+v", resp.Data)
+		}
+
+		if strings.Contains(recorder.Body.String(), "test-token") {
+			t.Errorf("Device token must not be returned: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{
+			GetDeviceFunc: func(ctx context.Context, deviceID string) (*domain.Device, error) {
+				return nil, fmt.Errorf("service.kaspi.GetDevice: %w", storage.ErrDeviceNotFound)
+			},
+		}, "/devices/UNKNOWN")
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
+
+func TestTradePointCache(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockDeviceProvider, method, url, body string) *httptest.ResponseRecorder {
+		h := httphandler.NewHandlers(log, provider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Post("/tradepoints/refresh", h.RefreshTradePoints)
+		r.Put("/tradepoints/{tradePointId}", h.UpdateTradePoint)
+
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(method, url, strings.NewReader(body)))
+
+		return recorder
+	}
+
+	t.Run("refreshes trade points", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{
+			RefreshTradePointsFunc: func(ctx context.Context) ([]domain.TradePoint, error) {
+				return []domain.TradePoint{{TradePointID: 1, TradePointName: "Store 1", Enabled: true}}, nil
+			},
+		}, http.MethodPost, "/tradepoints/refresh", "")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data []domain.TradePoint `json:"data"`
+		}
+		if err := parseResponse(recorder, &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if len(resp.Data) != 1 || resp.Data[0].TradePointName != "Store 1" {
+			t.Errorf("Unexpected trade points: %+v", resp.Data)
+		}
+	})
+
+	t.Run("updates trade point of the URL", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{
+			UpdateTradePointFunc: func(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+				if req.TradePointID != 2 || req.Timezone != "Asia/Almaty" || req.Enabled == nil || *req.Enabled {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.TradePoint{TradePointID: req.TradePointID, Timezone: req.Timezone}, nil
+			},
+		}, http.MethodPut, "/tradepoints/2", `{"Timezone": "Asia/Almaty", "Enabled": false}`)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
+	t.Run("rejects trade point ID that does not match the URL", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{}, http.MethodPut, "/tradepoints/2", `{"TradePointId": 3}`)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		recorder := serve(&MockDeviceProvider{
+			UpdateTradePointFunc: func(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+				return nil, fmt.Errorf("service: %w", storage.ErrTradePointNotFound)
+			},
+		}, http.MethodPut, "/tradepoints/2", `{}`)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"kaspi-api-wrapper/internal/domain"
 	"kaspi-api-wrapper/internal/validator"
 	"log/slog"
@@ -16,6 +17,85 @@ func HandleError(w http.ResponseWriter, err error, log *slog.Logger) {
 		return
 	}
 
+	if errors.Is(err, domain.ErrCircuitOpen) {
+		log.Warn("kaspi API circuit breaker is open", "error", err.Error())
+		ServiceUnavailableError(w, "Kaspi Pay service is temporarily unavailable")
+		return
+	}
+
+	if errors.Is(err, domain.ErrDeviceNotRegistered) {
+		log.Warn("device is not registered", "error", err.Error())
+		NotFoundError(w, "Device is not registered")
+		return
+	}
+
+	if errors.Is(err, domain.ErrDeviceInactive) {
+		log.Warn("device is deleted", "error", err.Error())
+		BadRequestError(w, "Device was deleted, register it again")
+		return
+	}
+
+	if errors.Is(err, domain.ErrOrganizationNotRegistered) {
+		log.Warn("organization is not registered", "error", err.Error())
+		ForbiddenError(w, "Organization is not registered")
+		return
+	}
+
+	if errors.Is(err, domain.ErrOrganizationDisabled) {
+		log.Warn("organization is disabled", "error", err.Error())
+		ForbiddenError(w, "Organization is disabled")
+		return
+	}
+
+	if errors.Is(err, domain.ErrTradePointNotFound) {
+		log.Warn("trade point is not found", "error", err.Error())
+		NotFoundError(w, "Trade point not found")
+		return
+	}
+
+	if errors.Is(err, domain.ErrTradePointDisabled) {
+		log.Warn("trade point is disabled", "error", err.Error())
+		BadRequestError(w, "Trade point is disabled")
+		return
+	}
+
+	if errors.Is(err, domain.ErrNotFound) {
+		log.Warn("resource not found", "error", err.Error())
+		NotFoundError(w, "Resource not found")
+		return
+	}
+
+	if errors.Is(err, domain.ErrOrganizationExists) {
+		log.Warn("organization conflict", "error", err.Error())
+		ConflictError(w, "Organization is already registered")
+		return
+	}
+
+	if errors.Is(err, domain.ErrExternalIDInUse) {
+		log.Warn("external ID conflict", "error", err.Error())
+		ConflictError(w, "ExternalId is already used by a live payment with a different amount")
+		return
+	}
+
+	if errors.Is(err, domain.ErrRefundSessionState) {
+		log.Warn("refund session state conflict", "error", err.Error())
+		ConflictError(w, "Refund session is not awaiting this step")
+		return
+	}
+
+	if errors.Is(err, domain.ErrReconciliationRunning) {
+		log.Warn("reconciliation conflict", "error", err.Error())
+		ConflictError(w, "Reconciliation run is already in progress")
+		return
+	}
+
+	var balanceErr *domain.RefundBalanceError
+	if errors.As(err, &balanceErr) {
+		log.Warn("refund exceeds remaining balance", "error", err.Error())
+		BadRequestError(w, fmt.Sprintf("Refund amount exceeds the remaining refundable amount of %.2f", balanceErr.Remaining))
+		return
+	}
+
 	var valErr *validator.ValidationError
 	if errors.As(err, &valErr) {
 		log.Warn("validation error", "error", err.Error())
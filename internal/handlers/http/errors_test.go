@@ -2,6 +2,7 @@ package http_test
 
 import (
 	"errors"
+	"fmt"
 	"kaspi-api-wrapper/internal/domain"
 	httphandler "kaspi-api-wrapper/internal/handlers/http"
 	"net/http"
@@ -55,6 +56,72 @@ func TestHandleKaspiError(t *testing.T) {
 			expectedStatus: http.StatusServiceUnavailable,
 			expectedMsg:    "Kaspi Pay service is temporarily unavailable",
 		},
+		{
+			name:           "Circuit breaker open",
+			err:            fmt.Errorf("GET /partner/tradepoints: %w", domain.ErrCircuitOpen),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedMsg:    "Kaspi Pay service is temporarily unavailable",
+		},
+		{
+			name:           "Resource not found",
+			err:            fmt.Errorf("webhook.ReplayWebhooks: webhook event %w", domain.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Resource not found",
+		},
+		{
+			name:           "Device not registered",
+			err:            fmt.Errorf("service.kaspi.ResolveDeviceToken: POS-1: %w", domain.ErrDeviceNotRegistered),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Device is not registered",
+		},
+		{
+			name:           "Device deleted",
+			err:            fmt.Errorf("service.kaspi.ResolveDeviceToken: POS-1: %w", domain.ErrDeviceInactive),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Device was deleted, register it again",
+		},
+		{
+			name:           "Organization not registered",
+			err:            fmt.Errorf("service.kaspi.resolveOrganization: 180340021791: %w", domain.ErrOrganizationNotRegistered),
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Organization is not registered",
+		},
+		{
+			name:           "Organization disabled",
+			err:            fmt.Errorf("service.kaspi.resolveOrganization: 180340021791: %w", domain.ErrOrganizationDisabled),
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "Organization is disabled",
+		},
+		{
+			name:           "Trade point not found",
+			err:            fmt.Errorf("service.kaspi.RegisterDevice:2: %w", domain.ErrTradePointNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "Trade point not found",
+		},
+		{
+			name:           "Trade point disabled",
+			err:            fmt.Errorf("service.kaspi.RegisterDevice:2: %w", domain.ErrTradePointDisabled),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Trade point is disabled",
+		},
+		{
+			name:           "ExternalId in use",
+			err:            fmt.Errorf("service.kaspi.CreateQR: %w", domain.ErrExternalIDInUse),
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "ExternalId is already used by a live payment with a different amount",
+		},
+		{
+			name:           "Reconciliation in progress",
+			err:            fmt.Errorf("reconciliation.StartReconciliation: %w", domain.ErrReconciliationRunning),
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "Reconciliation run is already in progress",
+		},
+		{
+			name:           "Refund exceeds balance",
+			err:            fmt.Errorf("service.kaspi.RefundPayment: %w", &domain.RefundBalanceError{Requested: 100, Remaining: 30}),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "Refund amount exceeds the remaining refundable amount of 30.00",
+		},
 		{
 			name:           "Unknown error",
 			err:            &domain.KaspiError{StatusCode: -12345, Message: "Unknown error"},
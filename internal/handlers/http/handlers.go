@@ -26,6 +26,17 @@ type Handlers struct {
 	deviceEnhancedProvider  handlers.DeviceEnhancedProvider
 	paymentEnhancedProvider handlers.PaymentEnhancedProvider
 	refundEnhancedProvider  handlers.RefundEnhancedProvider
+
+	webhookProvider handlers.WebhookProvider
+	paymentWatcher  handlers.PaymentWatcher
+	qrRenderer      handlers.QRRenderer
+
+	idempotencyGuard       handlers.IdempotencyGuard
+	refundSessionProvider  handlers.RefundSessionProvider
+	reconciliationProvider handlers.ReconciliationProvider
+	reportProvider         handlers.ReportProvider
+	oneCExporter           handlers.OneCExporter
+	organizationProvider   handlers.OrganizationProvider
 	//kaspiSvc *service.KaspiService
 }
 
@@ -40,6 +51,17 @@ func NewHandlers(
 	deviceEnhancedProvider handlers.DeviceEnhancedProvider,
 	paymentEnhancedProvider handlers.PaymentEnhancedProvider,
 	refundEnhancedProvider handlers.RefundEnhancedProvider,
+
+	webhookProvider handlers.WebhookProvider,
+	paymentWatcher handlers.PaymentWatcher,
+	qrRenderer handlers.QRRenderer,
+
+	idempotencyGuard handlers.IdempotencyGuard,
+	refundSessionProvider handlers.RefundSessionProvider,
+	reconciliationProvider handlers.ReconciliationProvider,
+	reportProvider handlers.ReportProvider,
+	oneCExporter handlers.OneCExporter,
+	organizationProvider handlers.OrganizationProvider,
 ) *Handlers {
 	return &Handlers{
 		log:             log,
@@ -51,6 +73,17 @@ func NewHandlers(
 		deviceEnhancedProvider:  deviceEnhancedProvider,
 		paymentEnhancedProvider: paymentEnhancedProvider,
 		refundEnhancedProvider:  refundEnhancedProvider,
+
+		webhookProvider: webhookProvider,
+		paymentWatcher:  paymentWatcher,
+		qrRenderer:      qrRenderer,
+
+		idempotencyGuard:       idempotencyGuard,
+		refundSessionProvider:  refundSessionProvider,
+		reconciliationProvider: reconciliationProvider,
+		reportProvider:         reportProvider,
+		oneCExporter:           oneCExporter,
+		organizationProvider:   organizationProvider,
 		//kaspiSvc: kaspiSvc,
 	}
 }
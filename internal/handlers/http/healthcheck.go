@@ -11,6 +11,9 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, Response{
 		Success: true,
-		Data:    map[string]string{"status": "ok"},
+		Data: map[string]any{
+			"status":          "ok",
+			"circuitBreakers": h.utilityProvider.CircuitBreakerStates(),
+		},
 	})
 }
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/idempotency"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+type IdempotencyGuard interface {
+	Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (idempotency.Response, bool)) (idempotency.Response, bool, error)
+}
+
+// Idempotency executes POST requests carrying an Idempotency-Key header only once,
+// duplicates get the stored response. Server errors are stored as well because Kaspi may have
+// executed the operation, only 503 is not stored as it is returned when Kaspi was not reached
+// or reported itself unavailable, so the request can be retried.
+func Idempotency(log *slog.Logger, guard IdempotencyGuard) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				respondError(w, http.StatusBadRequest, fmt.Sprintf("%s must not exceed %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Request body exceeds size limit")
+				return
+			}
+
+			fingerprint := idempotency.Fingerprint([]byte(r.Method), []byte(r.URL.Path), []byte(r.URL.RawQuery), body)
+
+			resp, replayed, err := guard.Do(r.Context(), key, fingerprint, func(ctx context.Context) (idempotency.Response, bool) {
+				rec := &responseRecorder{header: w.Header(), status: http.StatusOK}
+
+				req := r.WithContext(ctx)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+
+				next.ServeHTTP(rec, req)
+
+				return idempotency.Response{Status: rec.status, Body: rec.body.Bytes()}, rec.status != http.StatusServiceUnavailable
+			})
+			if err != nil {
+				switch {
+				case errors.Is(err, domain.ErrIdempotencyKeyReused):
+					respondError(w, http.StatusUnprocessableEntity, "Idempotency key was already used with a different request")
+				case errors.Is(err, domain.ErrIdempotencyInProgress):
+					respondError(w, http.StatusConflict, "Request with this idempotency key is still in progress")
+				default:
+					log.Error("idempotency check failed", "error", err.Error())
+					respondError(w, http.StatusInternalServerError, "Internal server error")
+				}
+				return
+			}
+
+			if replayed {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+			}
+
+			w.WriteHeader(resp.Status)
+			w.Write(resp.Body)
+		})
+	}
+}
+
+// responseRecorder buffers the response so that it can be stored before it is sent
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.status = status
+	rec.wroteHeader = true
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(b)
+}
+
+// respondError sends an error response in the API format
+func respondError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	response := fmt.Sprintf(`{"success":false,"error":%q}`, message)
+	w.Write([]byte(response))
+}
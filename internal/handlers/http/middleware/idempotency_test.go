@@ -0,0 +1,144 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/handlers/http/middleware"
+	"kaspi-api-wrapper/internal/idempotency"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeGuard stores kept responses in memory
+type fakeGuard struct {
+	fingerprints map[string]string
+	responses    map[string]idempotency.Response
+}
+
+func newFakeGuard() *fakeGuard {
+	return &fakeGuard{
+		fingerprints: make(map[string]string),
+		responses:    make(map[string]idempotency.Response),
+	}
+}
+
+func (g *fakeGuard) Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (idempotency.Response, bool)) (idempotency.Response, bool, error) {
+	if fp, ok := g.fingerprints[key]; ok && fp != fingerprint {
+		return idempotency.Response{}, false, domain.ErrIdempotencyKeyReused
+	}
+
+	if resp, ok := g.responses[key]; ok {
+		return resp, true, nil
+	}
+
+	resp, keep := fn(ctx)
+	if keep {
+		g.fingerprints[key] = fingerprint
+		g.responses[key] = resp
+	}
+
+	return resp, false, nil
+}
+
+func TestIdempotency(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+
+	newHandler := func(calls *int, status int) http.Handler {
+		return middleware.Idempotency(log, newFakeGuard())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls++
+			body, _ := io.ReadAll(r.Body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"success":true,"data":` + string(body) + `}`))
+		}))
+	}
+
+	post := func(h http.Handler, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/payment/return", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("replays stored response for duplicate", func(t *testing.T) {
+		calls := 0
+		h := newHandler(&calls, http.StatusOK)
+
+		first := post(h, "key-1", `{"Amount":100}`)
+		second := post(h, "key-1", `{"Amount":100}`)
+
+		if calls != 1 {
+			t.Errorf("Expected 1 handler call, got %d", calls)
+		}
+
+		if second.Code != http.StatusOK || second.Body.String() != first.Body.String() {
+			t.Errorf("Expected replayed response %q, got %d %q", first.Body.String(), second.Code, second.Body.String())
+		}
+
+		if second.Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+			t.Error("Expected replayed header")
+		}
+	})
+
+	t.Run("returns 422 for key reuse with different body", func(t *testing.T) {
+		calls := 0
+		h := newHandler(&calls, http.StatusOK)
+
+		post(h, "key-2", `{"Amount":100}`)
+		second := post(h, "key-2", `{"Amount":200}`)
+
+		if second.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected status code %d, got %d", http.StatusUnprocessableEntity, second.Code)
+		}
+	})
+
+	t.Run("stores server errors with unknown outcome", func(t *testing.T) {
+		calls := 0
+		h := newHandler(&calls, http.StatusInternalServerError)
+
+		post(h, "key-3", `{"Amount":100}`)
+		second := post(h, "key-3", `{"Amount":100}`)
+
+		if calls != 1 {
+			t.Errorf("Expected 1 handler call, got %d", calls)
+		}
+
+		if second.Code != http.StatusInternalServerError || second.Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+			t.Errorf("Expected replayed 500, got %d", second.Code)
+		}
+	})
+
+	t.Run("does not store service unavailable", func(t *testing.T) {
+		calls := 0
+		h := newHandler(&calls, http.StatusServiceUnavailable)
+
+		post(h, "key-4", `{"Amount":100}`)
+		post(h, "key-4", `{"Amount":100}`)
+
+		if calls != 2 {
+			t.Errorf("Expected 2 handler calls, got %d", calls)
+		}
+	})
+
+	t.Run("passes through requests without key", func(t *testing.T) {
+		calls := 0
+		h := newHandler(&calls, http.StatusOK)
+
+		post(h, "", `{"Amount":100}`)
+		post(h, "", `{"Amount":100}`)
+
+		if calls != 2 {
+			t.Errorf("Expected 2 handler calls, got %d", calls)
+		}
+	})
+}
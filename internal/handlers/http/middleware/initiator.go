@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"kaspi-api-wrapper/internal/service"
+	"net/http"
+	"strings"
+)
+
+const (
+	InitiatorHeader    = "X-Initiator"
+	maxInitiatorLength = 255
+)
+
+// Initiator passes the X-Initiator header, e.g. the cashier or ERP user, to the service for the refund ledger
+func Initiator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		initiator := strings.TrimSpace(r.Header.Get(InitiatorHeader))
+		if initiator != "" {
+			if len(initiator) > maxInitiatorLength {
+				initiator = initiator[:maxInitiatorLength]
+			}
+			r = r.WithContext(service.WithInitiator(r.Context(), initiator))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ExportOneC handles downloading payments and refunds of a period as a 1C exchange file
+func (h *Handlers) ExportOneC(w http.ResponseWriter, r *http.Request) {
+	if h.oneCExporter == nil {
+		ServiceUnavailableError(w, "1C export is not enabled")
+		return
+	}
+
+	query := r.URL.Query()
+	req := domain.OneCExportRequest{
+		DateFrom: query.Get("DateFrom"),
+		DateTo:   query.Get("DateTo"),
+		Format:   strings.ToLower(query.Get("Format")),
+	}
+
+	if value := query.Get("TradePointId"); value != "" {
+		var err error
+		if req.TradePointID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			BadRequestError(w, "Invalid trade point ID format")
+			return
+		}
+	}
+
+	file, err := h.oneCExporter.ExportOneC(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to export to 1C", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Data)
+}
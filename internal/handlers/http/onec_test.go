@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockOneCExporter struct {
+	ExportOneCFunc func(ctx context.Context, req domain.OneCExportRequest) (*domain.ReportFile, error)
+}
+
+func (m *MockOneCExporter) ExportOneC(ctx context.Context, req domain.OneCExportRequest) (*domain.ReportFile, error) {
+	return m.ExportOneCFunc(ctx, req)
+}
+
+func TestExportOneC(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(exporter *MockOneCExporter, url string) *httptest.ResponseRecorder {
+		var h *httphandler.Handlers
+		if exporter != nil {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, exporter, nil)
+		} else {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+
+		h.ExportOneC(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("downloads exchange file", func(t *testing.T) {
+		exporter := &MockOneCExporter{
+			ExportOneCFunc: func(ctx context.Context, req domain.OneCExportRequest) (*domain.ReportFile, error) {
+				if req.DateFrom != "2026-05-01" || req.DateTo != "2026-05-07" || req.TradePointID != 3 || req.Format != domain.OneCFormatStatement {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.ReportFile{
+					FileName:    "kl_to_1c-2026-05-01_2026-05-07.txt",
+					ContentType: "text/plain; charset=windows-1251",
+					Data:        []byte("1CClientBankExchange\r\n"),
+				}, nil
+			},
+		}
+
+		recorder := serve(exporter, "/exports/1c?DateFrom=2026-05-01&DateTo=2026-05-07&TradePointId=3&Format=Statement")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		if got := recorder.Header().Get("Content-Disposition"); got != `attachment; filename="kl_to_1c-2026-05-01_2026-05-07.txt"` {
+			t.Errorf("Unexpected Content-Disposition %s", got)
+		}
+
+		if recorder.Body.String() != "1CClientBankExchange\r\n" {
+			t.Errorf("Unexpected body %q", recorder.Body.String())
+		}
+	})
+
+	t.Run("rejects invalid trade point", func(t *testing.T) {
+		recorder := serve(&MockOneCExporter{}, "/exports/1c?DateFrom=2026-05-01&TradePointId=x")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns unavailable without exporter", func(t *testing.T) {
+		recorder := serve(nil, "/exports/1c?DateFrom=2026-05-01")
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+)
+
+// ListOrganizations handles listing of the registered organizations
+func (h *Handlers) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.organizationProvider.ListOrganizations(r.Context())
+	if err != nil {
+		h.log.Error("failed to list organizations", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    organizations,
+	})
+}
+
+// GetOrganization handles retrieval of a registered organization by its BIN
+func (h *Handlers) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	organization, err := h.organizationProvider.GetOrganization(r.Context(), chi.URLParam(r, "organizationBin"))
+	if err != nil {
+		h.log.Error("failed to get organization", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    organization,
+	})
+}
+
+// CreateOrganization handles registration of an organization
+func (h *Handlers) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req domain.OrganizationRequest
+	if !DecodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	organization, err := h.organizationProvider.CreateOrganization(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to create organization", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    organization,
+	})
+}
+
+// UpdateOrganization handles changes of the name, state and settings of an organization
+func (h *Handlers) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req domain.OrganizationRequest
+	if !DecodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	organizationBin := chi.URLParam(r, "organizationBin")
+	if req.OrganizationBin != "" && req.OrganizationBin != organizationBin {
+		BadRequestError(w, "OrganizationBin does not match the URL")
+		return
+	}
+	req.OrganizationBin = organizationBin
+
+	organization, err := h.organizationProvider.UpdateOrganization(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to update organization", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    organization,
+	})
+}
+
+// DeleteOrganization handles removal of a registered organization
+func (h *Handlers) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	err := h.organizationProvider.DeleteOrganization(r.Context(), chi.URLParam(r, "organizationBin"))
+	if err != nil {
+		h.log.Error("failed to delete organization", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    map[string]string{"message": "Organization deleted successfully"},
+	})
+}
@@ -0,0 +1,135 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"kaspi-api-wrapper/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MockOrganizationProvider struct {
+	ListOrganizationsFunc  func(ctx context.Context) ([]domain.Organization, error)
+	GetOrganizationFunc    func(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	CreateOrganizationFunc func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	UpdateOrganizationFunc func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	DeleteOrganizationFunc func(ctx context.Context, organizationBin string) error
+}
+
+func (m *MockOrganizationProvider) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	return m.ListOrganizationsFunc(ctx)
+}
+
+func (m *MockOrganizationProvider) GetOrganization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	return m.GetOrganizationFunc(ctx, organizationBin)
+}
+
+func (m *MockOrganizationProvider) CreateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	return m.CreateOrganizationFunc(ctx, req)
+}
+
+func (m *MockOrganizationProvider) UpdateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	return m.UpdateOrganizationFunc(ctx, req)
+}
+
+func (m *MockOrganizationProvider) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	return m.DeleteOrganizationFunc(ctx, organizationBin)
+}
+
+func TestOrganizations(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockOrganizationProvider, method, url, body string) *httptest.ResponseRecorder {
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, provider)
+
+		r := chi.NewRouter()
+		r.Get("/organizations/{organizationBin}", h.GetOrganization)
+		r.Post("/organizations", h.CreateOrganization)
+		r.Put("/organizations/{organizationBin}", h.UpdateOrganization)
+
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(method, url, strings.NewReader(body)))
+
+		return recorder
+	}
+
+	t.Run("creates organization", func(t *testing.T) {
+		recorder := serve(&MockOrganizationProvider{
+			CreateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+				if req.OrganizationBin != "180340021791" || req.Name != "Shop" || req.Settings["region"] != "Almaty" {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.Organization{OrganizationBin: req.OrganizationBin, Name: req.Name, Enabled: true}, nil
+			},
+		}, http.MethodPost, "/organizations", `{"OrganizationBin": "180340021791", "Name": "Shop", "Settings": {"region": "Almaty"}}`)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.Organization `json:"data"`
+		}
+		if err := parseResponse(recorder, &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if resp.Data.OrganizationBin != "180340021791" || !resp.Data.Enabled {
+			t.Errorf("Unexpected organization: %+v", resp.Data)
+		}
+	})
+
+	t.Run("rejects registered BIN", func(t *testing.T) {
+		recorder := serve(&MockOrganizationProvider{
+			CreateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+				return nil, fmt.Errorf("service: %w", domain.ErrOrganizationExists)
+			},
+		}, http.MethodPost, "/organizations", `{"OrganizationBin": "180340021791", "Name": "Shop"}`)
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("Expected status code %d, got %d", http.StatusConflict, recorder.Code)
+		}
+	})
+
+	t.Run("updates organization of the URL", func(t *testing.T) {
+		recorder := serve(&MockOrganizationProvider{
+			UpdateOrganizationFunc: func(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+				if req.OrganizationBin != "180340021791" || req.Enabled == nil || *req.Enabled {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.Organization{OrganizationBin: req.OrganizationBin, Name: req.Name}, nil
+			},
+		}, http.MethodPut, "/organizations/180340021791", `{"Name": "Shop", "Enabled": false}`)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
+	t.Run("rejects BIN that does not match the URL", func(t *testing.T) {
+		recorder := serve(&MockOrganizationProvider{}, http.MethodPut, "/organizations/180340021791",
+			`{"OrganizationBin": "123456789012", "Name": "Shop"}`)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		recorder := serve(&MockOrganizationProvider{
+			GetOrganizationFunc: func(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+				return nil, fmt.Errorf("service: %w", storage.ErrOrganizationNotFound)
+			},
+		}, http.MethodGet, "/organizations/180340021791", "")
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
@@ -4,7 +4,10 @@ import (
 	"github.com/go-chi/chi/v5"
 	"kaspi-api-wrapper/internal/domain"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // CreateQR handles QR code creation for payment (2.3.1)
@@ -64,3 +67,102 @@ func (h *Handlers) GetPaymentStatus(w http.ResponseWriter, r *http.Request) {
 		Data:    status,
 	})
 }
+
+// GetPaymentsByExternalID handles lookup of stored payments by the ExternalId they were created with
+func (h *Handlers) GetPaymentsByExternalID(w http.ResponseWriter, r *http.Request) {
+	// chi matches the escaped path, so ExternalIds with reserved characters come in encoded
+	externalID, err := url.PathUnescape(chi.URLParam(r, "externalId"))
+	if err != nil {
+		BadRequestError(w, "Invalid external ID")
+		return
+	}
+
+	payments, err := h.paymentProvider.GetPaymentsByExternalID(r.Context(), externalID)
+	if err != nil {
+		h.log.Error("failed to get payments by external ID", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    payments,
+	})
+}
+
+// ListPayments handles searching stored payments with filters and cursor pagination
+func (h *Handlers) ListPayments(w http.ResponseWriter, r *http.Request) {
+	req, msg := parsePaymentListQuery(r.URL.Query())
+	if msg != "" {
+		BadRequestError(w, msg)
+		return
+	}
+
+	list, err := h.paymentProvider.ListPayments(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to list payments", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    list,
+	})
+}
+
+// parsePaymentListQuery reads the list filters, Status may be repeated or comma separated.
+// A non-empty message describes the first malformed parameter
+func parsePaymentListQuery(query url.Values) (domain.PaymentListRequest, string) {
+	req := domain.PaymentListRequest{
+		DeviceID:        query.Get("DeviceId"),
+		OrganizationBin: query.Get("OrganizationBin"),
+		ExternalID:      query.Get("ExternalId"),
+		Search:          query.Get("Search"),
+		SortBy:          query.Get("SortBy"),
+		SortOrder:       strings.ToLower(query.Get("SortOrder")),
+		Cursor:          query.Get("Cursor"),
+	}
+
+	for _, value := range query["Status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				req.Statuses = append(req.Statuses, status)
+			}
+		}
+	}
+
+	var err error
+
+	if value := query.Get("TradePointId"); value != "" {
+		if req.TradePointID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return req, "Invalid trade point ID format"
+		}
+	}
+
+	if value := query.Get("Limit"); value != "" {
+		if req.Limit, err = strconv.Atoi(value); err != nil {
+			return req, "Invalid limit format"
+		}
+	}
+
+	for name, target := range map[string]**float64{"MinAmount": &req.MinAmount, "MaxAmount": &req.MaxAmount} {
+		if value := query.Get(name); value != "" {
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return req, "Invalid " + name + " format"
+			}
+			*target = &amount
+		}
+	}
+
+	for name, target := range map[string]*time.Time{"From": &req.From, "To": &req.To} {
+		if value := query.Get(name); value != "" {
+			if *target, err = time.Parse(time.RFC3339, value); err != nil {
+				return req, "Invalid " + name + " format, expected RFC 3339"
+			}
+		}
+	}
+
+	return req, ""
+}
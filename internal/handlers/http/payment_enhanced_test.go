@@ -133,7 +133,7 @@ func TestCreateQREnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -188,7 +188,7 @@ func TestCreateQREnhancedHandler(t *testing.T) {
 	t.Run("rejects missing OrganizationBin", func(t *testing.T) {
 		mockProvider := &MockPaymentEnhancedProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -258,7 +258,7 @@ func TestCreatePaymentLinkEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// sseKeepAliveInterval is how often a comment is sent to keep idle connections open
+const sseKeepAliveInterval = 15 * time.Second
+
+// PaymentStatusEvents streams payment status changes as Server-Sent Events.
+// The event ID is the payment status, so a client reconnecting with Last-Event-ID
+// only receives the current status if it differs from the one it has already seen.
+func (h *Handlers) PaymentStatusEvents(w http.ResponseWriter, r *http.Request) {
+	if h.paymentWatcher == nil {
+		ServiceUnavailableError(w, "Payment status watching is disabled")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		InternalServerError(w, "Streaming is not supported")
+		return
+	}
+
+	qrPaymentID, err := strconv.ParseInt(chi.URLParam(r, "qrPaymentId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid payment ID format")
+		return
+	}
+
+	current, err := h.paymentProvider.GetPaymentStatus(r.Context(), qrPaymentID)
+	if err != nil {
+		h.log.Error("failed to get payment status", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	lastStatus := r.Header.Get("Last-Event-ID")
+
+	if current.Status != lastStatus {
+		if err = writeStatusEvent(w, *current); err != nil {
+			return
+		}
+		lastStatus = current.Status
+	}
+	flusher.Flush()
+
+	if domain.IsTerminalPaymentStatus(current.Status) {
+		return
+	}
+
+	updates, cancel := h.paymentWatcher.Subscribe(qrPaymentID)
+	defer cancel()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			if update.Status == lastStatus {
+				continue
+			}
+			lastStatus = update.Status
+
+			if err = writeStatusEvent(w, update); err != nil {
+				return
+			}
+			flusher.Flush()
+
+			if domain.IsTerminalPaymentStatus(update.Status) {
+				return
+			}
+		}
+	}
+}
+
+// writeStatusEvent writes a single "status" event
+func writeStatusEvent(w http.ResponseWriter, status domain.PaymentStatusResponse) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: status\ndata: %s\n\n", status.Status, data)
+	return err
+}
@@ -0,0 +1,146 @@
+package http_test
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type MockPaymentWatcher struct {
+	updates []domain.PaymentStatusResponse
+}
+
+func (m *MockPaymentWatcher) Subscribe(qrPaymentID int64) (<-chan domain.PaymentStatusResponse, func()) {
+	ch := make(chan domain.PaymentStatusResponse, len(m.updates))
+	for _, update := range m.updates {
+		ch <- update
+	}
+	close(ch)
+
+	return ch, func() {}
+}
+
+func statusProvider(status string) *MockPaymentProvider {
+	return &MockPaymentProvider{
+		GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+			if qrPaymentID != 15 {
+				return nil, &domain.KaspiError{StatusCode: -1601, Message: "Purchase not found"}
+			}
+			return &domain.PaymentStatusResponse{Status: status}, nil
+		},
+	}
+}
+
+func servePaymentStatusEvents(h *httphandler.Handlers, path, lastEventID string) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	r.Get("/payment/status/{qrPaymentId}/events", h.PaymentStatusEvents)
+
+	req := httptest.NewRequest("GET", path, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func TestPaymentStatusEventsHandler(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("streams status changes until terminal status", func(t *testing.T) {
+		watcher := &MockPaymentWatcher{updates: []domain.PaymentStatusResponse{
+			{Status: domain.PaymentStatusWait},
+			{Status: domain.PaymentStatusProcessed},
+			{Status: domain.PaymentStatusExpired},
+		}}
+
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusCreated), nil, nil, nil, nil, nil, nil, watcher, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/15/events", "")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		if ct := recorder.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("Expected Content-Type text/event-stream, got %s", ct)
+		}
+
+		body := recorder.Body.String()
+		for _, id := range []string{"id: QrTokenCreated\n", "id: Wait\n", "id: Processed\n"} {
+			if !strings.Contains(body, id) {
+				t.Errorf("Expected body to contain %q, got %s", id, body)
+			}
+		}
+
+		if strings.Contains(body, "Expired") {
+			t.Errorf("Expected stream to close after terminal status, got %s", body)
+		}
+	})
+
+	t.Run("skips already seen status on resumption", func(t *testing.T) {
+		watcher := &MockPaymentWatcher{updates: []domain.PaymentStatusResponse{
+			{Status: domain.PaymentStatusProcessed},
+		}}
+
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusWait), nil, nil, nil, nil, nil, nil, watcher, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/15/events", domain.PaymentStatusWait)
+
+		body := recorder.Body.String()
+		if strings.Contains(body, "id: Wait\n") {
+			t.Errorf("Expected Wait not to be resent, got %s", body)
+		}
+
+		if !strings.Contains(body, "id: Processed\n") {
+			t.Errorf("Expected Processed event, got %s", body)
+		}
+	})
+
+	t.Run("closes immediately for terminal payment", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusError), nil, nil, nil, nil, nil, nil, &MockPaymentWatcher{}, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/15/events", "")
+
+		if strings.Count(recorder.Body.String(), "event: status") != 1 {
+			t.Errorf("Expected a single event, got %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("returns not found for unknown payment", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusWait), nil, nil, nil, nil, nil, nil, &MockPaymentWatcher{}, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/16/events", "")
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+
+	t.Run("returns bad request for invalid payment ID", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusWait), nil, nil, nil, nil, nil, nil, &MockPaymentWatcher{}, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/abc/events", "")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns service unavailable without watcher", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, statusProvider(domain.PaymentStatusWait), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		recorder := servePaymentStatusEvents(h, "/payment/status/15/events", "")
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"kaspi-api-wrapper/internal/domain"
 	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"kaspi-api-wrapper/internal/storage"
 	"kaspi-api-wrapper/internal/validator"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -18,6 +20,9 @@ type MockPaymentProvider struct {
 	CreateQRFunc          func(ctx context.Context, req domain.QRCreateRequest) (*domain.QRCreateResponse, error)
 	CreatePaymentLinkFunc func(ctx context.Context, req domain.PaymentLinkCreateRequest) (*domain.PaymentLinkCreateResponse, error)
 	GetPaymentStatusFunc  func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+
+	GetPaymentsByExternalIDFunc func(ctx context.Context, externalID string) ([]domain.Payment, error)
+	ListPaymentsFunc            func(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error)
 }
 
 func (m *MockPaymentProvider) CreateQR(ctx context.Context, req domain.QRCreateRequest) (*domain.QRCreateResponse, error) {
@@ -86,6 +91,14 @@ func (m *MockPaymentProvider) GetPaymentStatus(ctx context.Context, qrPaymentID
 	return m.GetPaymentStatusFunc(ctx, qrPaymentID)
 }
 
+func (m *MockPaymentProvider) GetPaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	return m.GetPaymentsByExternalIDFunc(ctx, externalID)
+}
+
+func (m *MockPaymentProvider) ListPayments(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error) {
+	return m.ListPaymentsFunc(ctx, req)
+}
+
 func TestCreateQRHandler(t *testing.T) {
 	log := setupTestLogger()
 
@@ -116,7 +129,7 @@ func TestCreateQRHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		createReq := domain.QRCreateRequest{
 			DeviceToken: "test-token",
@@ -170,7 +183,7 @@ func TestCreateQRHandler(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockPaymentProvider{}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		createReq := domain.QRCreateRequest{
 			DeviceToken: "test-token",
@@ -238,7 +251,7 @@ func TestCreatePaymentLinkFunc(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		createReq := domain.PaymentLinkCreateRequest{
 			DeviceToken: "test-token",
@@ -304,7 +317,7 @@ func TestCreatePaymentLinkFunc(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockPaymentProvider{}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		createReq := domain.PaymentLinkCreateRequest{
 			DeviceToken: "",
@@ -348,7 +361,7 @@ func TestCreatePaymentLinkFunc(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		createReq := domain.PaymentLinkCreateRequest{
 			DeviceToken: "invalid-token",
@@ -412,7 +425,7 @@ func TestGetPaymentStatus(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Get("/payment/status/{qrPaymentId}", h.GetPaymentStatus)
@@ -460,3 +473,168 @@ func TestGetPaymentStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestGetPaymentsByExternalID(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("successfully gets payments", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentsByExternalIDFunc: func(ctx context.Context, externalID string) ([]domain.Payment, error) {
+				if externalID != "ORDER/15" {
+					return nil, errors.New("invalid external ID")
+				}
+
+				return []domain.Payment{
+					{QrPaymentID: 16, Kind: domain.PaymentKindQR, ExternalID: externalID, DeviceToken: "secret-token", Amount: 200.00, Status: "Wait"},
+					{QrPaymentID: 15, Kind: domain.PaymentKindQR, ExternalID: externalID, Amount: 200.00, Status: "Expired"},
+				}, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Get("/payments/by-external-id/{externalId}", h.GetPaymentsByExternalID)
+
+		req, err := http.NewRequest("GET", "/payments/by-external-id/ORDER%2F15", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Success bool             `json:"success"`
+			Data    []domain.Payment `json:"data"`
+		}
+		if err = json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if !resp.Success {
+			t.Errorf("Expected success to be true, got false")
+		}
+
+		if len(resp.Data) != 2 || resp.Data[0].QrPaymentID != 16 {
+			t.Errorf("Unexpected payments: %+v", resp.Data)
+		}
+
+		if strings.Contains(recorder.Body.String(), "secret-token") {
+			t.Errorf("Expected response without the device token, got %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("returns 404 for unknown external ID", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			GetPaymentsByExternalIDFunc: func(ctx context.Context, externalID string) ([]domain.Payment, error) {
+				return nil, storage.ErrPaymentNotFound
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Get("/payments/by-external-id/{externalId}", h.GetPaymentsByExternalID)
+
+		req, err := http.NewRequest("GET", "/payments/by-external-id/unknown", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
+
+func TestListPayments(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("passes filters from the query", func(t *testing.T) {
+		mockProvider := &MockPaymentProvider{
+			ListPaymentsFunc: func(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error) {
+				if req.TradePointID != 7 || req.OrganizationBin != "180340021791" || req.Search != "ORD" {
+					t.Errorf("Unexpected filters: %+v", req)
+				}
+				if req.DeviceID != "DEV-1" || req.DeviceToken != "" {
+					t.Errorf("Expected the DeviceId filter only, got %q and token %q", req.DeviceID, req.DeviceToken)
+				}
+				if len(req.Statuses) != 2 || req.Statuses[0] != "Wait" || req.Statuses[1] != "Processed" {
+					t.Errorf("Unexpected statuses: %v", req.Statuses)
+				}
+				if req.MinAmount == nil || *req.MinAmount != 100 || req.MaxAmount != nil {
+					t.Errorf("Unexpected amount range: %v - %v", req.MinAmount, req.MaxAmount)
+				}
+				if req.From.IsZero() || !req.To.IsZero() {
+					t.Errorf("Unexpected period: %s - %s", req.From, req.To)
+				}
+				if req.SortBy != "Amount" || req.SortOrder != "asc" || req.Limit != 50 || req.Cursor != "abc" {
+					t.Errorf("Unexpected paging: %+v", req)
+				}
+
+				return &domain.PaymentList{
+					Items:      []domain.Payment{{QrPaymentID: 15, Amount: 200.00, Status: "Wait"}},
+					NextCursor: "next",
+				}, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		query := "TradePointId=7&DeviceId=DEV-1&DeviceToken=secret&OrganizationBin=180340021791&Status=Wait,Processed&MinAmount=100" +
+			"&From=2026-05-01T00:00:00%2B05:00&Search=ORD&SortBy=Amount&SortOrder=ASC&Limit=50&Cursor=abc"
+		req, err := http.NewRequest("GET", "/payments?"+query, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		h.ListPayments(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Success bool               `json:"success"`
+			Data    domain.PaymentList `json:"data"`
+		}
+		if err = json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if len(resp.Data.Items) != 1 || resp.Data.NextCursor != "next" {
+			t.Errorf("Unexpected page: %+v", resp.Data)
+		}
+	})
+
+	t.Run("rejects malformed parameters", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, &MockPaymentProvider{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		for _, query := range []string{"TradePointId=abc", "MaxAmount=ten", "To=yesterday", "Limit=all"} {
+			req, err := http.NewRequest("GET", "/payments?"+query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			h.ListPayments(recorder, req)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("Expected status code %d for %s, got %d", http.StatusBadRequest, query, recorder.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+)
+
+// RenderQR handles rendering of a stored QR token as a PNG or SVG image
+func (h *Handlers) RenderQR(w http.ResponseWriter, r *http.Request) {
+	if h.qrRenderer == nil {
+		ServiceUnavailableError(w, "QR rendering is not configured")
+		return
+	}
+
+	qrPaymentID, err := strconv.ParseInt(chi.URLParam(r, "qrPaymentId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid payment ID format")
+		return
+	}
+
+	query := r.URL.Query()
+
+	opts := domain.QRImageOptions{
+		Format:          query.Get("format"),
+		ErrorCorrection: query.Get("errorCorrection"),
+	}
+
+	if size := query.Get("size"); size != "" {
+		opts.Size, err = strconv.Atoi(size)
+		if err != nil {
+			BadRequestError(w, "Invalid size format")
+			return
+		}
+	}
+
+	if margin := query.Get("margin"); margin != "" {
+		value, err := strconv.Atoi(margin)
+		if err != nil {
+			BadRequestError(w, "Invalid margin format")
+			return
+		}
+		opts.Margin = &value
+	}
+
+	if logo := query.Get("logo"); logo != "" {
+		opts.Logo, err = strconv.ParseBool(logo)
+		if err != nil {
+			BadRequestError(w, "Invalid logo format")
+			return
+		}
+	}
+
+	img, err := h.qrRenderer.RenderQR(r.Context(), qrPaymentID, opts)
+	if err != nil {
+		h.log.Error("failed to render QR", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(img.Data)))
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(img.Data)
+}
@@ -0,0 +1,109 @@
+package http_test
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"kaspi-api-wrapper/internal/validator"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockQRRenderer struct {
+	RenderQRFunc func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error)
+}
+
+func (m *MockQRRenderer) RenderQR(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+	return m.RenderQRFunc(ctx, qrPaymentID, opts)
+}
+
+func TestRenderQR(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(renderer *MockQRRenderer, url string) *httptest.ResponseRecorder {
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, renderer, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Get("/qr/{qrPaymentId}/image", h.RenderQR)
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("successfully renders QR", func(t *testing.T) {
+		renderer := &MockQRRenderer{
+			RenderQRFunc: func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+				if qrPaymentID != 15 {
+					t.Errorf("Expected qrPaymentID 15, got %d", qrPaymentID)
+				}
+
+				if opts.Format != "svg" || opts.Size != 300 || opts.Margin == nil || *opts.Margin != 2 ||
+					opts.ErrorCorrection != "H" || !opts.Logo {
+					t.Errorf("Unexpected options: %+v", opts)
+				}
+
+				return &domain.QRImage{ContentType: "image/svg+xml", Data: []byte("<svg/>")}, nil
+			},
+		}
+
+		recorder := serve(renderer, "/qr/15/image?format=svg&size=300&margin=2&errorCorrection=H&logo=true")
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		if recorder.Header().Get("Content-Type") != "image/svg+xml" {
+			t.Errorf("Expected content type image/svg+xml, got %s", recorder.Header().Get("Content-Type"))
+		}
+
+		if recorder.Body.String() != "<svg/>" {
+			t.Errorf("Unexpected body: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("leaves omitted options to defaults", func(t *testing.T) {
+		renderer := &MockQRRenderer{
+			RenderQRFunc: func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+				if opts.Format != "" || opts.Size != 0 || opts.Margin != nil || opts.Logo {
+					t.Errorf("Expected empty options, got %+v", opts)
+				}
+
+				return &domain.QRImage{ContentType: "image/png", Data: []byte{0x89}}, nil
+			},
+		}
+
+		recorder := serve(renderer, "/qr/15/image")
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
+	t.Run("rejects invalid size", func(t *testing.T) {
+		recorder := serve(&MockQRRenderer{}, "/qr/15/image?size=big")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns validation errors", func(t *testing.T) {
+		renderer := &MockQRRenderer{
+			RenderQRFunc: func(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+				return nil, &validator.ValidationError{Field: "format", Message: "format must be png or svg", Err: validator.ErrInvalidValue}
+			},
+		}
+
+		recorder := serve(renderer, "/qr/15/image?format=gif")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
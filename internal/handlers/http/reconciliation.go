@@ -0,0 +1,121 @@
+package http
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+)
+
+const defaultReconciliationRunsLimit = 20
+
+// StartReconciliation handles starting a reconciliation run, the previous day is
+// reconciled when the body is empty
+func (h *Handlers) StartReconciliation(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliationProvider == nil {
+		ServiceUnavailableError(w, "Reconciliation is not enabled")
+		return
+	}
+
+	var req domain.ReconciliationRequest
+	if r.ContentLength != 0 {
+		if !DecodeJSONRequest(w, r, &req) {
+			return
+		}
+	}
+
+	run, err := h.reconciliationProvider.StartReconciliation(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to start reconciliation", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, Response{
+		Success: true,
+		Data:    run,
+	})
+}
+
+// ListReconciliationRuns handles listing the latest reconciliation runs
+func (h *Handlers) ListReconciliationRuns(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliationProvider == nil {
+		ServiceUnavailableError(w, "Reconciliation is not enabled")
+		return
+	}
+
+	limit := defaultReconciliationRunsLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			BadRequestError(w, "Invalid limit format")
+			return
+		}
+	}
+
+	runs, err := h.reconciliationProvider.ListReconciliationRuns(r.Context(), limit)
+	if err != nil {
+		h.log.Error("failed to list reconciliation runs", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    runs,
+	})
+}
+
+// GetReconciliationRun handles retrieval of a reconciliation run with its discrepancies
+func (h *Handlers) GetReconciliationRun(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliationProvider == nil {
+		ServiceUnavailableError(w, "Reconciliation is not enabled")
+		return
+	}
+
+	runID, err := strconv.ParseInt(chi.URLParam(r, "runId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid run ID format")
+		return
+	}
+
+	report, err := h.reconciliationProvider.GetReconciliationRun(r.Context(), runID)
+	if err != nil {
+		h.log.Error("failed to get reconciliation run", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// ExportReconciliationRun handles downloading the discrepancies of a run as CSV
+func (h *Handlers) ExportReconciliationRun(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliationProvider == nil {
+		ServiceUnavailableError(w, "Reconciliation is not enabled")
+		return
+	}
+
+	runID, err := strconv.ParseInt(chi.URLParam(r, "runId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid run ID format")
+		return
+	}
+
+	data, err := h.reconciliationProvider.ExportReconciliationRun(r.Context(), runID)
+	if err != nil {
+		h.log.Error("failed to export reconciliation run", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"reconciliation-%d.csv\"", runID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,245 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockReconciliationProvider struct {
+	StartReconciliationFunc     func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error)
+	ListReconciliationRunsFunc  func(ctx context.Context, limit int) ([]domain.ReconciliationRun, error)
+	GetReconciliationRunFunc    func(ctx context.Context, runID int64) (*domain.ReconciliationReport, error)
+	ExportReconciliationRunFunc func(ctx context.Context, runID int64) ([]byte, error)
+}
+
+func (m *MockReconciliationProvider) StartReconciliation(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+	return m.StartReconciliationFunc(ctx, req)
+}
+
+func (m *MockReconciliationProvider) ListReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	return m.ListReconciliationRunsFunc(ctx, limit)
+}
+
+func (m *MockReconciliationProvider) GetReconciliationRun(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+	return m.GetReconciliationRunFunc(ctx, runID)
+}
+
+func (m *MockReconciliationProvider) ExportReconciliationRun(ctx context.Context, runID int64) ([]byte, error) {
+	return m.ExportReconciliationRunFunc(ctx, runID)
+}
+
+func TestReconciliationHandlers(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockReconciliationProvider, method, url, body string) *httptest.ResponseRecorder {
+		var h *httphandler.Handlers
+		if provider != nil {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, provider, nil, nil, nil)
+		} else {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		}
+
+		r := chi.NewRouter()
+		r.Post("/reconciliation/runs", h.StartReconciliation)
+		r.Get("/reconciliation/runs", h.ListReconciliationRuns)
+		r.Get("/reconciliation/runs/{runId}", h.GetReconciliationRun)
+		r.Get("/reconciliation/runs/{runId}/csv", h.ExportReconciliationRun)
+
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("starts run for the requested period", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			StartReconciliationFunc: func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+				if req.From.Day() != 1 || req.To.Day() != 2 {
+					t.Errorf("Unexpected period %s - %s", req.From, req.To)
+				}
+				return &domain.ReconciliationRun{ID: 3, Status: domain.ReconciliationRunning, From: req.From, To: req.To}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/reconciliation/runs",
+			`{"From": "2026-05-01T00:00:00+05:00", "To": "2026-05-02T00:00:00+05:00"}`)
+
+		if recorder.Code != http.StatusAccepted {
+			t.Fatalf("Expected status code %d, got %d", http.StatusAccepted, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.ReconciliationRun `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if resp.Data.ID != 3 || resp.Data.Status != domain.ReconciliationRunning {
+			t.Errorf("Unexpected run: %+v", resp.Data)
+		}
+	})
+
+	t.Run("starts run without a body", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			StartReconciliationFunc: func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+				if !req.From.IsZero() || !req.To.IsZero() {
+					t.Errorf("Expected empty period, got %s - %s", req.From, req.To)
+				}
+				return &domain.ReconciliationRun{ID: 1}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/reconciliation/runs", "")
+
+		if recorder.Code != http.StatusAccepted {
+			t.Errorf("Expected status code %d, got %d", http.StatusAccepted, recorder.Code)
+		}
+	})
+
+	t.Run("rejects run while another is in progress", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			StartReconciliationFunc: func(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+				return nil, fmt.Errorf("reconciliation.StartReconciliation: %w", domain.ErrReconciliationRunning)
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/reconciliation/runs", "")
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("Expected status code %d, got %d", http.StatusConflict, recorder.Code)
+		}
+	})
+
+	t.Run("lists runs with the default limit", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			ListReconciliationRunsFunc: func(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+				if limit != 20 {
+					t.Errorf("Expected limit 20, got %d", limit)
+				}
+				return []domain.ReconciliationRun{{ID: 2}, {ID: 1}}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodGet, "/reconciliation/runs", "")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data []domain.ReconciliationRun `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if len(resp.Data) != 2 {
+			t.Errorf("Expected 2 runs, got %d", len(resp.Data))
+		}
+	})
+
+	t.Run("rejects invalid limit", func(t *testing.T) {
+		recorder := serve(&MockReconciliationProvider{}, http.MethodGet, "/reconciliation/runs?limit=abc", "")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns run with discrepancies", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			GetReconciliationRunFunc: func(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+				return &domain.ReconciliationReport{
+					ReconciliationRun: domain.ReconciliationRun{ID: runID, Status: domain.ReconciliationCompleted, Discrepancies: 1},
+					Items: []domain.ReconciliationDiscrepancy{
+						{RunID: runID, Kind: domain.DiscrepancyStatusMismatch, QrPaymentID: 15, CreatedAt: time.Now()},
+					},
+				}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodGet, "/reconciliation/runs/5", "")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.ReconciliationReport `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if resp.Data.ID != 5 || len(resp.Data.Items) != 1 || resp.Data.Items[0].QrPaymentID != 15 {
+			t.Errorf("Unexpected report: %+v", resp.Data)
+		}
+	})
+
+	t.Run("returns not found for unknown run", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			GetReconciliationRunFunc: func(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+				return nil, fmt.Errorf("reconciliation run %w", domain.ErrNotFound)
+			},
+		}
+
+		recorder := serve(provider, http.MethodGet, "/reconciliation/runs/99", "")
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+
+	t.Run("exports run as CSV", func(t *testing.T) {
+		provider := &MockReconciliationProvider{
+			ExportReconciliationRunFunc: func(ctx context.Context, runID int64) ([]byte, error) {
+				return []byte("RunId,Kind\n5,status_mismatch\n"), nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodGet, "/reconciliation/runs/5/csv", "")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Errorf("Expected CSV content type, got %s", ct)
+		}
+		if cd := recorder.Header().Get("Content-Disposition"); !strings.Contains(cd, "reconciliation-5.csv") {
+			t.Errorf("Unexpected Content-Disposition %s", cd)
+		}
+		if !strings.Contains(recorder.Body.String(), "status_mismatch") {
+			t.Errorf("Unexpected body %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("rejects invalid run ID", func(t *testing.T) {
+		recorder := serve(&MockReconciliationProvider{}, http.MethodGet, "/reconciliation/runs/abc", "")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("reconciliation disabled", func(t *testing.T) {
+		recorder := serve(nil, http.MethodGet, "/reconciliation/runs", "")
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"github.com/go-chi/chi/v5"
 	"kaspi-api-wrapper/internal/domain"
 	"net/http"
 	"strconv"
@@ -26,10 +27,18 @@ func (h *Handlers) RefundPaymentEnhanced(w http.ResponseWriter, r *http.Request)
 	})
 }
 
-// GetClientInfo handles getting client information by phone number
+// GetClientInfo handles getting client information by phone number, the device is addressed by
+// its deviceId and organizationBin or by its token
 func (h *Handlers) GetClientInfo(w http.ResponseWriter, r *http.Request) {
-	phoneNumber := r.URL.Query().Get("phoneNumber")
-	deviceToken := r.URL.Query().Get("deviceToken")
+	query := r.URL.Query()
+	phoneNumber := query.Get("phoneNumber")
+
+	deviceToken, err := h.refundEnhancedProvider.ResolveDeviceToken(r.Context(), query.Get("deviceToken"), query.Get("deviceId"), query.Get("organizationBin"))
+	if err != nil {
+		h.log.Error("failed to resolve device", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
 
 	deviceTokenInt64, err := strconv.ParseInt(deviceToken, 10, 64)
 	if err != nil {
@@ -88,3 +97,20 @@ func (h *Handlers) CancelRemotePayment(w http.ResponseWriter, r *http.Request) {
 		Data:    resp,
 	})
 }
+
+// GetPendingRemotePayments handles a request to list remote payments of an organization still waiting for the customer
+func (h *Handlers) GetPendingRemotePayments(w http.ResponseWriter, r *http.Request) {
+	organizationBin := chi.URLParam(r, "organizationBin")
+
+	payments, err := h.refundEnhancedProvider.GetPendingRemotePayments(r.Context(), organizationBin)
+	if err != nil {
+		h.log.Error("failed to get pending remote payments", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    payments,
+	})
+}
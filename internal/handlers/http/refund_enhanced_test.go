@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/go-chi/chi/v5"
 	"kaspi-api-wrapper/internal/domain"
 	httphandler "kaspi-api-wrapper/internal/handlers/http"
 	"kaspi-api-wrapper/internal/validator"
@@ -18,6 +19,8 @@ type MockRefundEnhancedProvider struct {
 	GetClientInfoFunc         func(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error)
 	CreateRemotePaymentFunc   func(ctx context.Context, req domain.RemotePaymentRequest) (*domain.RemotePaymentResponse, error)
 	CancelRemotePaymentFunc   func(ctx context.Context, req domain.RemotePaymentCancelRequest) (*domain.RemotePaymentCancelResponse, error)
+	GetPendingRemoteFunc      func(ctx context.Context, organizationBin string) ([]domain.Payment, error)
+	ResolveDeviceTokenFunc    func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
 }
 
 func (m *MockRefundEnhancedProvider) RefundPaymentEnhanced(ctx context.Context, req domain.EnhancedRefundRequest) (*domain.RefundResponse, error) {
@@ -142,6 +145,17 @@ func (m *MockRefundEnhancedProvider) CancelRemotePayment(ctx context.Context, re
 	}, nil
 }
 
+func (m *MockRefundEnhancedProvider) GetPendingRemotePayments(ctx context.Context, organizationBin string) ([]domain.Payment, error) {
+	return m.GetPendingRemoteFunc(ctx, organizationBin)
+}
+
+func (m *MockRefundEnhancedProvider) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	if m.ResolveDeviceTokenFunc != nil {
+		return m.ResolveDeviceTokenFunc(ctx, deviceToken, deviceID, organizationBin)
+	}
+	return deviceToken, nil
+}
+
 func TestRefundPaymentEnhancedHandler(t *testing.T) {
 	log := setupTestLogger()
 
@@ -170,7 +184,7 @@ func TestRefundPaymentEnhancedHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -221,7 +235,7 @@ func TestRefundPaymentEnhancedHandler(t *testing.T) {
 	t.Run("rejects missing OrganizationBin", func(t *testing.T) {
 		mockProvider := &MockRefundEnhancedProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -279,7 +293,7 @@ func TestGetClientInfoHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/api/remote/client-info?phoneNumber=87071234567&deviceToken=2", nil)
 		if err != nil {
@@ -320,10 +334,42 @@ func TestGetClientInfoHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("resolves the token of the device", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				if deviceToken != "" || deviceID != "POS-1" || organizationBin != "180340021791" {
+					t.Errorf("Unexpected device %q %q %q", deviceToken, deviceID, organizationBin)
+				}
+				return "2", nil
+			},
+			GetClientInfoFunc: func(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error) {
+				if deviceToken != 2 {
+					t.Errorf("Expected device token 2, got %d", deviceToken)
+				}
+				return &domain.ClientInfoResponse{ClientName: "John Doe"}, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := http.NewRequest("GET", "/api/remote/client-info?phoneNumber=87071234567&deviceId=POS-1&organizationBin=180340021791", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		h.GetClientInfo(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
 	t.Run("rejects missing parameters", func(t *testing.T) {
 		mockProvider := &MockRefundEnhancedProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/api/remote/client-info?phoneNumber=87071234567", nil)
 		if err != nil {
@@ -368,7 +414,7 @@ func TestCreateRemotePaymentHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"OrganizationBin": "180340021791",
@@ -420,7 +466,7 @@ func TestCreateRemotePaymentHandler(t *testing.T) {
 	t.Run("rejects missing PhoneNumber", func(t *testing.T) {
 		mockProvider := &MockRefundEnhancedProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"OrganizationBin": "180340021791",
@@ -482,7 +528,7 @@ func TestCancelRemotePaymentHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"OrganizationBin": "180340021791",
@@ -539,7 +585,7 @@ func TestCancelRemotePaymentHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider)
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"OrganizationBin": "180340021791",
@@ -576,3 +622,77 @@ func TestCancelRemotePaymentHandler(t *testing.T) {
 		}
 	})
 }
+
+func TestGetPendingRemotePaymentsHandler(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(mockProvider *MockRefundEnhancedProvider, url string) *httptest.ResponseRecorder {
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		r := chi.NewRouter()
+		r.Get("/remote/pending/{organizationBin}", h.GetPendingRemotePayments)
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("successfully lists pending remote payments", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			GetPendingRemoteFunc: func(ctx context.Context, organizationBin string) ([]domain.Payment, error) {
+				if organizationBin != "180340021791" {
+					return nil, fmt.Errorf("invalid organization BIN")
+				}
+
+				return []domain.Payment{{
+					QrPaymentID: 15,
+					Kind:        domain.PaymentKindRemote,
+					Amount:      100,
+					PhoneNumber: "8707*****67",
+					Comment:     "Test payment",
+					Status:      domain.PaymentStatusWait,
+				}}, nil
+			},
+		}
+
+		recorder := serve(mockProvider, "/remote/pending/180340021791")
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Success bool             `json:"success"`
+			Data    []domain.Payment `json:"data"`
+		}
+		err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+		if err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if !resp.Success || len(resp.Data) != 1 || resp.Data[0].PhoneNumber != "8707*****67" {
+			t.Errorf("Unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("returns validation errors", func(t *testing.T) {
+		mockProvider := &MockRefundEnhancedProvider{
+			GetPendingRemoteFunc: func(ctx context.Context, organizationBin string) ([]domain.Payment, error) {
+				return nil, &validator.ValidationError{
+					Field:   "OrganizationBin",
+					Message: "OrganizationBin is required",
+					Err:     validator.ErrRequiredField,
+				}
+			},
+		}
+
+		recorder := serve(mockProvider, "/remote/pending/%20")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
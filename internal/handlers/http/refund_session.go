@@ -0,0 +1,90 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+)
+
+// CreateRefundSession handles starting a refund session
+func (h *Handlers) CreateRefundSession(w http.ResponseWriter, r *http.Request) {
+	if h.refundSessionProvider == nil {
+		ServiceUnavailableError(w, "Refund sessions are not enabled")
+		return
+	}
+
+	var req domain.RefundSessionCreateRequest
+	if !DecodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	session, err := h.refundSessionProvider.CreateRefundSession(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to create refund session", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    session,
+	})
+}
+
+// GetRefundSession handles refund session retrieval
+func (h *Handlers) GetRefundSession(w http.ResponseWriter, r *http.Request) {
+	if h.refundSessionProvider == nil {
+		ServiceUnavailableError(w, "Refund sessions are not enabled")
+		return
+	}
+
+	qrReturnID, err := strconv.ParseInt(chi.URLParam(r, "qrReturnId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid refund ID format")
+		return
+	}
+
+	session, err := h.refundSessionProvider.GetRefundSession(r.Context(), qrReturnID)
+	if err != nil {
+		h.log.Error("failed to get refund session", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    session,
+	})
+}
+
+// RefundSessionPayment handles refunding the operation chosen in a refund session
+func (h *Handlers) RefundSessionPayment(w http.ResponseWriter, r *http.Request) {
+	if h.refundSessionProvider == nil {
+		ServiceUnavailableError(w, "Refund sessions are not enabled")
+		return
+	}
+
+	qrReturnID, err := strconv.ParseInt(chi.URLParam(r, "qrReturnId"), 10, 64)
+	if err != nil {
+		BadRequestError(w, "Invalid refund ID format")
+		return
+	}
+
+	var req domain.RefundSessionRefundRequest
+	if !DecodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	session, err := h.refundSessionProvider.RefundSessionPayment(r.Context(), qrReturnID, req)
+	if err != nil {
+		h.log.Error("failed to refund session payment", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    session,
+	})
+}
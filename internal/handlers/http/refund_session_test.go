@@ -0,0 +1,134 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type MockRefundSessionProvider struct {
+	CreateRefundSessionFunc  func(ctx context.Context, req domain.RefundSessionCreateRequest) (*domain.RefundSession, error)
+	GetRefundSessionFunc     func(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error)
+	RefundSessionPaymentFunc func(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error)
+}
+
+func (m *MockRefundSessionProvider) CreateRefundSession(ctx context.Context, req domain.RefundSessionCreateRequest) (*domain.RefundSession, error) {
+	return m.CreateRefundSessionFunc(ctx, req)
+}
+
+func (m *MockRefundSessionProvider) GetRefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error) {
+	return m.GetRefundSessionFunc(ctx, qrReturnID)
+}
+
+func (m *MockRefundSessionProvider) RefundSessionPayment(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error) {
+	return m.RefundSessionPaymentFunc(ctx, qrReturnID, req)
+}
+
+func TestRefundSessionHandlers(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockRefundSessionProvider, method, url, body string) *httptest.ResponseRecorder {
+		var h *httphandler.Handlers
+		if provider != nil {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, provider, nil, nil, nil, nil)
+		} else {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		}
+
+		r := chi.NewRouter()
+		r.Post("/return/sessions", h.CreateRefundSession)
+		r.Get("/return/sessions/{qrReturnId}", h.GetRefundSession)
+		r.Post("/return/sessions/{qrReturnId}/refund", h.RefundSessionPayment)
+
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("creates session", func(t *testing.T) {
+		provider := &MockRefundSessionProvider{
+			CreateRefundSessionFunc: func(ctx context.Context, req domain.RefundSessionCreateRequest) (*domain.RefundSession, error) {
+				return &domain.RefundSession{QrReturnID: 15, DeviceToken: req.DeviceToken, State: domain.RefundSessionAwaitingScan}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/return/sessions", `{"DeviceToken": "test-token"}`)
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.RefundSession `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if resp.Data.QrReturnID != 15 || resp.Data.State != domain.RefundSessionAwaitingScan {
+			t.Errorf("Unexpected session: %+v", resp.Data)
+		}
+
+		if strings.Contains(recorder.Body.String(), "test-token") {
+			t.Errorf("Device token must not be returned: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("refunds chosen operation", func(t *testing.T) {
+		provider := &MockRefundSessionProvider{
+			RefundSessionPaymentFunc: func(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error) {
+				if qrReturnID != 15 || req.QrPaymentID != 42 || req.Amount != 100 {
+					t.Errorf("Unexpected request %d %+v", qrReturnID, req)
+				}
+				return &domain.RefundSession{QrReturnID: 15, State: domain.RefundSessionCompleted, ReturnOperationID: 7}, nil
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/return/sessions/15/refund", `{"QrPaymentId": 42, "Amount": 100}`)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
+	t.Run("returns conflict when session is not awaiting selection", func(t *testing.T) {
+		provider := &MockRefundSessionProvider{
+			RefundSessionPaymentFunc: func(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error) {
+				return nil, fmt.Errorf("refundsession.RefundSessionPayment: %w", domain.ErrRefundSessionState)
+			},
+		}
+
+		recorder := serve(provider, http.MethodPost, "/return/sessions/15/refund", `{"QrPaymentId": 42, "Amount": 100}`)
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("Expected status code %d, got %d", http.StatusConflict, recorder.Code)
+		}
+	})
+
+	t.Run("returns bad request for invalid ID", func(t *testing.T) {
+		recorder := serve(&MockRefundSessionProvider{}, http.MethodGet, "/return/sessions/abc", "")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns service unavailable when sessions are disabled", func(t *testing.T) {
+		recorder := serve(nil, http.MethodGet, "/return/sessions/15", "")
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -64,17 +64,23 @@ func (h *Handlers) GetCustomerOperations(w http.ResponseWriter, r *http.Request)
 	})
 }
 
-// GetPaymentDetails handles getting payment details
+// GetPaymentDetails handles getting payment details, the device is addressed by its DeviceId or token
 func (h *Handlers) GetPaymentDetails(w http.ResponseWriter, r *http.Request) {
-	qrPaymentIDStr := r.URL.Query().Get("QrPaymentId")
-	deviceToken := r.URL.Query().Get("DeviceToken")
+	query := r.URL.Query()
 
-	qrPaymentID, err := strconv.ParseInt(qrPaymentIDStr, 10, 64)
+	qrPaymentID, err := strconv.ParseInt(query.Get("QrPaymentId"), 10, 64)
 	if err != nil {
 		BadRequestError(w, "Invalid payment ID format")
 		return
 	}
 
+	deviceToken, err := h.refundProvider.ResolveDeviceToken(r.Context(), query.Get("DeviceToken"), query.Get("DeviceId"), query.Get("OrganizationBin"))
+	if err != nil {
+		h.log.Error("failed to resolve device", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
 	details, err := h.refundProvider.GetPaymentDetails(r.Context(), qrPaymentID, deviceToken)
 	if err != nil {
 		h.log.Error("failed to get payment details", "error", err.Error())
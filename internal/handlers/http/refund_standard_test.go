@@ -21,6 +21,7 @@ type MockRefundProvider struct {
 	GetCustomerOperationsFunc func(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error)
 	GetPaymentDetailsFunc     func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
 	RefundPaymentFunc         func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error)
+	ResolveDeviceTokenFunc    func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
 }
 
 func (m *MockRefundProvider) CreateRefundQR(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error) {
@@ -127,6 +128,13 @@ func (m *MockRefundProvider) RefundPayment(ctx context.Context, req domain.Refun
 	}, nil
 }
 
+func (m *MockRefundProvider) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	if m.ResolveDeviceTokenFunc != nil {
+		return m.ResolveDeviceTokenFunc(ctx, deviceToken, deviceID, organizationBin)
+	}
+	return deviceToken, nil
+}
+
 func TestCreateRefundQRHandler(t *testing.T) {
 	log := setupTestLogger()
 
@@ -151,7 +159,7 @@ func TestCreateRefundQRHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"DeviceToken": "test-token", "ExternalId": "15"}`
 		req, err := http.NewRequest("POST", "/api/return/create", strings.NewReader(reqBody))
@@ -201,7 +209,7 @@ func TestCreateRefundQRHandler(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockRefundProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"ExternalId": "15"}`
 		req, err := http.NewRequest("POST", "/api/return/create", strings.NewReader(reqBody))
@@ -251,7 +259,7 @@ func TestGetRefundStatusHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		r := chi.NewRouter()
 		r.Get("/return/status/{qrReturnId}", h.GetRefundStatus)
@@ -325,7 +333,7 @@ func TestGetCustomerOperationsHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{"DeviceToken": "test-token", "QrReturnId": 15, "MaxResult": 10}`
 		req, err := http.NewRequest("POST", "/api/return/operations", strings.NewReader(reqBody))
@@ -400,7 +408,7 @@ func TestGetPaymentDetailsHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/api/payment/details?QrPaymentId=123&DeviceToken=test-token", nil)
 		if err != nil {
@@ -449,10 +457,65 @@ func TestGetPaymentDetailsHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("resolves the token of the device", func(t *testing.T) {
+		mockProvider := &MockRefundProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				if deviceToken != "" || deviceID != "POS-1" || organizationBin != "180340021791" {
+					t.Errorf("Unexpected device %q %q %q", deviceToken, deviceID, organizationBin)
+				}
+				return "test-token", nil
+			},
+			GetPaymentDetailsFunc: func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+				if deviceToken != "test-token" {
+					t.Errorf("Expected device token test-token, got %s", deviceToken)
+				}
+				return &domain.PaymentDetailsResponse{QrPaymentID: qrPaymentID}, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := http.NewRequest("GET", "/api/payment/details?QrPaymentId=123&DeviceId=POS-1&OrganizationBin=180340021791", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		h.GetPaymentDetails(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+	})
+
+	t.Run("returns not found for unknown device", func(t *testing.T) {
+		mockProvider := &MockRefundProvider{
+			ResolveDeviceTokenFunc: func(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+				return "", fmt.Errorf("service.kaspi.ResolveDeviceToken: %s: %w", deviceID, domain.ErrDeviceNotRegistered)
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := http.NewRequest("GET", "/api/payment/details?QrPaymentId=123&DeviceId=UNKNOWN", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		h.GetPaymentDetails(recorder, req)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+
 	t.Run("rejects missing parameters", func(t *testing.T) {
 		mockProvider := &MockRefundProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		req, err := http.NewRequest("GET", "/api/payment/details?QrPaymentId=123", nil)
 		if err != nil {
@@ -497,7 +560,7 @@ func TestRefundPaymentHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -548,7 +611,7 @@ func TestRefundPaymentHandler(t *testing.T) {
 	t.Run("rejects invalid request", func(t *testing.T) {
 		mockProvider := &MockRefundProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"QrPaymentId": 123,
@@ -588,7 +651,7 @@ func TestRefundPaymentHandler(t *testing.T) {
 	t.Run("rejects invalid amount", func(t *testing.T) {
 		mockProvider := &MockRefundProvider{}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
@@ -633,7 +696,7 @@ func TestRefundPaymentHandler(t *testing.T) {
 			},
 		}
 
-		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil)
+		h := httphandler.NewHandlers(log, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		reqBody := `{
 			"DeviceToken": "test-token",
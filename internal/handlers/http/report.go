@@ -0,0 +1,61 @@
+package http
+
+import (
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetSettlementReport handles the daily settlement report, JSON is returned in the standard
+// response while CSV and XLSX are sent as a file download
+func (h *Handlers) GetSettlementReport(w http.ResponseWriter, r *http.Request) {
+	if h.reportProvider == nil {
+		ServiceUnavailableError(w, "Reports are not enabled")
+		return
+	}
+
+	query := r.URL.Query()
+	req := domain.SettlementReportRequest{
+		DateFrom:        query.Get("DateFrom"),
+		DateTo:          query.Get("DateTo"),
+		OrganizationBin: query.Get("OrganizationBin"),
+	}
+
+	if value := query.Get("TradePointId"); value != "" {
+		var err error
+		if req.TradePointID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			BadRequestError(w, "Invalid trade point ID format")
+			return
+		}
+	}
+
+	format := strings.ToLower(query.Get("Format"))
+	if format == "" || format == domain.ReportFormatJSON {
+		report, err := h.reportProvider.SettlementReport(r.Context(), req)
+		if err != nil {
+			h.log.Error("failed to build settlement report", "error", err.Error())
+			HandleError(w, err, h.log)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, Response{
+			Success: true,
+			Data:    report,
+		})
+		return
+	}
+
+	file, err := h.reportProvider.ExportSettlementReport(r.Context(), req, format)
+	if err != nil {
+		h.log.Error("failed to export settlement report", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.FileName))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Data)
+}
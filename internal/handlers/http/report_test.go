@@ -0,0 +1,150 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"kaspi-api-wrapper/internal/validator"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockReportProvider struct {
+	SettlementReportFunc       func(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error)
+	ExportSettlementReportFunc func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error)
+}
+
+func (m *MockReportProvider) SettlementReport(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+	return m.SettlementReportFunc(ctx, req)
+}
+
+func (m *MockReportProvider) ExportSettlementReport(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+	return m.ExportSettlementReportFunc(ctx, req, format)
+}
+
+func TestGetSettlementReport(t *testing.T) {
+	log := setupTestLogger()
+
+	serve := func(provider *MockReportProvider, url string) *httptest.ResponseRecorder {
+		var h *httphandler.Handlers
+		if provider != nil {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, provider, nil, nil)
+		} else {
+			h = httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+
+		h.GetSettlementReport(recorder, req)
+
+		return recorder
+	}
+
+	t.Run("returns JSON report by default", func(t *testing.T) {
+		provider := &MockReportProvider{
+			SettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+				if req.DateFrom != "2026-05-01" || req.DateTo != "2026-05-02" || req.TradePointID != 7 || req.OrganizationBin != "123456789012" {
+					t.Errorf("Unexpected request: %+v", req)
+				}
+				return &domain.SettlementReport{
+					DateFrom: req.DateFrom,
+					DateTo:   req.DateTo,
+					Days: []domain.SettlementDay{{
+						Date: "2026-05-01",
+						SettlementSummary: domain.SettlementSummary{
+							SettlementTotals: domain.SettlementTotals{GrossSales: 300, Refunds: 100, Net: 200, SalesCount: 2, RefundsCount: 1},
+						},
+					}},
+				}, nil
+			},
+		}
+
+		recorder := serve(provider, "/reports/settlement?DateFrom=2026-05-01&DateTo=2026-05-02&TradePointId=7&OrganizationBin=123456789012")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Data domain.SettlementReport `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if len(resp.Data.Days) != 1 || resp.Data.Days[0].Net != 200 {
+			t.Errorf("Unexpected report: %+v", resp.Data)
+		}
+	})
+
+	t.Run("downloads CSV file", func(t *testing.T) {
+		provider := &MockReportProvider{
+			ExportSettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+				if format != domain.ReportFormatCSV {
+					t.Errorf("Expected format csv, got %s", format)
+				}
+				return &domain.ReportFile{
+					FileName:    "settlement-2026-05-01_2026-05-01.csv",
+					ContentType: "text/csv",
+					Data:        []byte("Date,Level\n"),
+				}, nil
+			},
+		}
+
+		recorder := serve(provider, "/reports/settlement?DateFrom=2026-05-01&Format=CSV")
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		if got := recorder.Header().Get("Content-Type"); got != "text/csv" {
+			t.Errorf("Expected Content-Type text/csv, got %s", got)
+		}
+
+		if got := recorder.Header().Get("Content-Disposition"); got != `attachment; filename="settlement-2026-05-01_2026-05-01.csv"` {
+			t.Errorf("Unexpected Content-Disposition %s", got)
+		}
+
+		if recorder.Body.String() != "Date,Level\n" {
+			t.Errorf("Unexpected body %q", recorder.Body.String())
+		}
+	})
+
+	t.Run("rejects invalid trade point", func(t *testing.T) {
+		recorder := serve(&MockReportProvider{}, "/reports/settlement?DateFrom=2026-05-01&TradePointId=abc")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns validation error", func(t *testing.T) {
+		provider := &MockReportProvider{
+			ExportSettlementReportFunc: func(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+				return nil, fmt.Errorf("report.ExportSettlementReport: %w", &validator.ValidationError{
+					Field:   "format",
+					Message: "format must be json, csv or xlsx",
+					Err:     validator.ErrInvalidValue,
+				})
+			},
+		}
+
+		recorder := serve(provider, "/reports/settlement?DateFrom=2026-05-01&Format=pdf")
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("returns unavailable when reports are disabled", func(t *testing.T) {
+		recorder := serve(nil, "/reports/settlement?DateFrom=2026-05-01")
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -32,24 +32,59 @@ func (r *Router) Setup() *chi.Mux {
 	router.Get("/health", r.handlers.HealthCheck)
 
 	router.Route("/api", func(apiRouter chi.Router) {
+		// X-Initiator header for the refund ledger
+		apiRouter.Use(middleware2.Initiator)
+
+		if r.handlers.idempotencyGuard != nil {
+			// Idempotency-Key header on POST routes
+			apiRouter.Use(middleware2.Idempotency(r.log, r.handlers.idempotencyGuard))
+		}
+
 		// 2.2.2 - Get trade points
 		apiRouter.Get("/tradepoints", r.handlers.GetTradePoints)
 
+		// Trade point cache: on-demand sync with Kaspi and the metadata kept by the wrapper
+		apiRouter.Post("/tradepoints/refresh", r.handlers.RefreshTradePoints)
+		apiRouter.Put("/tradepoints/{tradePointId}", r.handlers.UpdateTradePoint)
+
 		// 2.2.3 - Register device
 		apiRouter.Post("/device/register", r.handlers.RegisterDevice)
 
 		// 2.2.4 - Delete device
 		apiRouter.Post("/device/delete", r.handlers.DeleteDevice)
 
+		// Devices registered through the wrapper, filtered by trade point or organization BIN
+		apiRouter.Get("/devices", r.handlers.ListDevices)
+		apiRouter.Get("/devices/{deviceId}", r.handlers.GetDevice)
+
+		// Organizations operated in the enhanced scheme, enhanced requests for other BINs are rejected
+		apiRouter.Get("/organizations", r.handlers.ListOrganizations)
+		apiRouter.Post("/organizations", r.handlers.CreateOrganization)
+		apiRouter.Get("/organizations/{organizationBin}", r.handlers.GetOrganization)
+		apiRouter.Put("/organizations/{organizationBin}", r.handlers.UpdateOrganization)
+		apiRouter.Delete("/organizations/{organizationBin}", r.handlers.DeleteOrganization)
+
 		// 2.3.1 - Create QR code
 		apiRouter.Post("/qr/create", r.handlers.CreateQR)
 
+		// QR token of a created payment as a PNG or SVG image
+		apiRouter.Get("/qr/{qrPaymentId}/image", r.handlers.RenderQR)
+
 		// 2.3.2 - Create payment link
 		apiRouter.Post("/qr/create-link", r.handlers.CreatePaymentLink)
 
 		// 2.3.3 - Get payment status
 		apiRouter.Get("/payment/status/{qrPaymentId}", r.handlers.GetPaymentStatus)
 
+		// Live payment status changes (Server-Sent Events)
+		apiRouter.Get("/payment/status/{qrPaymentId}/events", r.handlers.PaymentStatusEvents)
+
+		// Stored payments filtered by trade point, device, BIN, status, amount, period or ExternalId
+		apiRouter.Get("/payments", r.handlers.ListPayments)
+
+		// Stored payments created with the ExternalId, e.g. an ERP order number
+		apiRouter.Get("/payments/by-external-id/{externalId}", r.handlers.GetPaymentsByExternalID)
+
 		// Standard scheme endpoints (available in standard and enhanced schemes)
 		standardScheme := middleware2.SchemeMiddleware(r.scheme, "standard")
 
@@ -68,10 +103,16 @@ func (r *Router) Setup() *chi.Mux {
 		// 3.4.5 - Refund payment
 		apiRouter.With(standardScheme).Post("/payment/return", r.handlers.RefundPayment)
 
+		// Refund sessions driving 3.4.1 - 3.4.5 in one flow
+		apiRouter.With(standardScheme).Post("/return/sessions", r.handlers.CreateRefundSession)
+		apiRouter.With(standardScheme).Get("/return/sessions/{qrReturnId}", r.handlers.GetRefundSession)
+		apiRouter.With(standardScheme).Post("/return/sessions/{qrReturnId}/refund", r.handlers.RefundSessionPayment)
+
 		enhancedScheme := middleware2.SchemeMiddleware(r.scheme, "enhanced")
 
 		// 4.2.2 - Get trade points (enhanced)
 		apiRouter.With(enhancedScheme).Get("/tradepoints/enhanced/{organizationBin}", r.handlers.GetTradePointsEnhanced)
+		apiRouter.With(enhancedScheme).Post("/tradepoints/enhanced/{organizationBin}/refresh", r.handlers.RefreshTradePointsEnhanced)
 
 		// 4.2.3 - Register device (enhanced)
 		apiRouter.With(enhancedScheme).Post("/device/register/enhanced", r.handlers.RegisterDeviceEnhanced)
@@ -97,6 +138,24 @@ func (r *Router) Setup() *chi.Mux {
 		// 4.6.3 - Cancel remote payment
 		apiRouter.With(enhancedScheme).Post("/remote/cancel", r.handlers.CancelRemotePayment)
 
+		// Remote payments of an organization still waiting for the customer
+		apiRouter.With(enhancedScheme).Get("/remote/pending/{organizationBin}", r.handlers.GetPendingRemotePayments)
+
+		// Redeliver webhook events, e.g. after a receiver outage
+		apiRouter.Post("/webhooks/replay", r.handlers.ReplayWebhooks)
+
+		// Reconciliation of local payments and refunds against Kaspi
+		apiRouter.Post("/reconciliation/runs", r.handlers.StartReconciliation)
+		apiRouter.Get("/reconciliation/runs", r.handlers.ListReconciliationRuns)
+		apiRouter.Get("/reconciliation/runs/{runId}", r.handlers.GetReconciliationRun)
+		apiRouter.Get("/reconciliation/runs/{runId}/csv", r.handlers.ExportReconciliationRun)
+
+		// Daily settlement reports built from local payments and refunds
+		apiRouter.Get("/reports/settlement", r.handlers.GetSettlementReport)
+
+		// Exchange files for 1C accounting
+		apiRouter.Get("/exports/1c", r.handlers.ExportOneC)
+
 		router.Route("/test", func(apiRouter chi.Router) {
 			// 5.1 - Healthcheck
 			apiRouter.Get("/health", r.handlers.HealthCheckKaspi)
@@ -16,8 +16,9 @@ func (h *Handlers) HealthCheckKaspi(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, Response{
 		Success: true,
-		Data: map[string]string{
-			"status": "ok",
+		Data: map[string]any{
+			"status":          "ok",
+			"circuitBreakers": h.utilityProvider.CircuitBreakerStates(),
 		},
 	})
 }
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+)
+
+// ReplayWebhooks handles requeueing of webhook deliveries
+func (h *Handlers) ReplayWebhooks(w http.ResponseWriter, r *http.Request) {
+	if h.webhookProvider == nil {
+		ServiceUnavailableError(w, "Webhooks are not configured")
+		return
+	}
+
+	var filter domain.WebhookReplayFilter
+	if !DecodeJSONRequest(w, r, &filter) {
+		return
+	}
+
+	replayed, err := h.webhookProvider.ReplayWebhooks(r.Context(), filter)
+	if err != nil {
+		h.log.Error("failed to replay webhooks", "error", err.Error())
+		HandleError(w, err, h.log)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, Response{
+		Success: true,
+		Data:    map[string]int64{"replayed": replayed},
+	})
+}
@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	httphandler "kaspi-api-wrapper/internal/handlers/http"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MockWebhookProvider struct {
+	ReplayWebhooksFunc func(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error)
+}
+
+func (m *MockWebhookProvider) ReplayWebhooks(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	return m.ReplayWebhooksFunc(ctx, filter)
+}
+
+func TestReplayWebhooksHandler(t *testing.T) {
+	log := setupTestLogger()
+
+	t.Run("successfully replays event", func(t *testing.T) {
+		mockProvider := &MockWebhookProvider{
+			ReplayWebhooksFunc: func(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+				if filter.EventID != "event-1" {
+					return 0, fmt.Errorf("unexpected event ID %s", filter.EventID)
+				}
+				return 2, nil
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := createRequest("POST", "/webhooks/replay", domain.WebhookReplayFilter{EventID: "event-1"})
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+		h.ReplayWebhooks(recorder, req)
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+		}
+
+		var resp struct {
+			Success bool             `json:"success"`
+			Data    map[string]int64 `json:"data"`
+		}
+		err = json.Unmarshal(recorder.Body.Bytes(), &resp)
+		if err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if !resp.Success || resp.Data["replayed"] != 2 {
+			t.Errorf("Expected 2 replayed deliveries, got %+v", resp)
+		}
+	})
+
+	t.Run("returns not found for unknown event", func(t *testing.T) {
+		mockProvider := &MockWebhookProvider{
+			ReplayWebhooksFunc: func(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+				return 0, fmt.Errorf("webhook event %w", domain.ErrNotFound)
+			},
+		}
+
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, mockProvider, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := createRequest("POST", "/webhooks/replay", domain.WebhookReplayFilter{EventID: "missing"})
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+		h.ReplayWebhooks(recorder, req)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected status code %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+
+	t.Run("returns service unavailable when webhooks are disabled", func(t *testing.T) {
+		h := httphandler.NewHandlers(log, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		req, err := createRequest("POST", "/webhooks/replay", domain.WebhookReplayFilter{EventID: "event-1"})
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		recorder := httptest.NewRecorder()
+		h.ReplayWebhooks(recorder, req)
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
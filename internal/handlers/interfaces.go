@@ -3,24 +3,51 @@ package handlers
 import (
 	"context"
 	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/idempotency"
 )
 
 type DeviceProvider interface {
 	GetTradePoints(ctx context.Context) ([]domain.TradePoint, error)
+	RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error)
+	UpdateTradePoint(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error)
 	RegisterDevice(ctx context.Context, req domain.DeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
 	DeleteDevice(ctx context.Context, deviceToken string) error
+	ListDevices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	GetDevice(ctx context.Context, deviceID string) (*domain.Device, error)
 }
 
 type DeviceEnhancedProvider interface {
 	GetTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
 	RegisterDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error)
 	DeleteDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error
 }
 
+// OrganizationProvider manages the organizations registered for the enhanced scheme
+type OrganizationProvider interface {
+	ListOrganizations(ctx context.Context) ([]domain.Organization, error)
+	GetOrganization(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	CreateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	UpdateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationBin string) error
+}
+
 type PaymentProvider interface {
 	CreateQR(ctx context.Context, req domain.QRCreateRequest) (*domain.QRCreateResponse, error)
 	CreatePaymentLink(ctx context.Context, req domain.PaymentLinkCreateRequest) (*domain.PaymentLinkCreateResponse, error)
 	GetPaymentStatus(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+	GetPaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error)
+	ListPayments(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error)
+}
+
+// PaymentWatcher streams status changes of a payment until it reaches a terminal status
+type PaymentWatcher interface {
+	Subscribe(qrPaymentID int64) (<-chan domain.PaymentStatusResponse, func())
+}
+
+// QRRenderer renders stored QR tokens as images
+type QRRenderer interface {
+	RenderQR(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error)
 }
 
 type PaymentEnhancedProvider interface {
@@ -34,6 +61,14 @@ type RefundProvider interface {
 	GetCustomerOperations(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error)
 	GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
 	RefundPayment(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error)
+	ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
+}
+
+// RefundSessionProvider drives the standard refund flow as a persisted session
+type RefundSessionProvider interface {
+	CreateRefundSession(ctx context.Context, req domain.RefundSessionCreateRequest) (*domain.RefundSession, error)
+	GetRefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error)
+	RefundSessionPayment(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error)
 }
 
 type RefundEnhancedProvider interface {
@@ -41,6 +76,27 @@ type RefundEnhancedProvider interface {
 	GetClientInfo(ctx context.Context, phoneNumber string, deviceToken int64) (*domain.ClientInfoResponse, error)
 	CreateRemotePayment(ctx context.Context, req domain.RemotePaymentRequest) (*domain.RemotePaymentResponse, error)
 	CancelRemotePayment(ctx context.Context, req domain.RemotePaymentCancelRequest) (*domain.RemotePaymentCancelResponse, error)
+	GetPendingRemotePayments(ctx context.Context, organizationBin string) ([]domain.Payment, error)
+	ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
+}
+
+// ReconciliationProvider compares local records with Kaspi and reports the discrepancies
+type ReconciliationProvider interface {
+	StartReconciliation(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error)
+	ListReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error)
+	GetReconciliationRun(ctx context.Context, runID int64) (*domain.ReconciliationReport, error)
+	ExportReconciliationRun(ctx context.Context, runID int64) ([]byte, error)
+}
+
+// ReportProvider builds settlement reports and exports them as files
+type ReportProvider interface {
+	SettlementReport(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error)
+	ExportSettlementReport(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error)
+}
+
+// OneCExporter writes payments and refunds as exchange files for 1C accounting
+type OneCExporter interface {
+	ExportOneC(ctx context.Context, req domain.OneCExportRequest) (*domain.ReportFile, error)
 }
 
 type UtilityProvider interface {
@@ -49,4 +105,14 @@ type UtilityProvider interface {
 	TestConfirmPayment(ctx context.Context, req domain.TestConfirmRequest) error
 	TestScanError(ctx context.Context, req domain.TestScanErrorRequest) error
 	TestConfirmError(ctx context.Context, req domain.TestConfirmErrorRequest) error
+	CircuitBreakerStates() []domain.CircuitBreakerState
+}
+
+type WebhookProvider interface {
+	ReplayWebhooks(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error)
+}
+
+// IdempotencyGuard executes requests with the same idempotency key only once
+type IdempotencyGuard interface {
+	Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (idempotency.Response, bool)) (idempotency.Response, bool, error)
 }
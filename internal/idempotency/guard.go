@@ -0,0 +1,224 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// completeAttempts is how many times a response is stored before the response is returned,
+// later attempts run in the background
+const completeAttempts = 3
+
+type Storage interface {
+	AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, expiredBefore, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error)
+	IdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, responseStatus int, responseBody []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+// Config describes how long keys are kept and how duplicates wait for the first attempt
+type Config struct {
+	TTL          time.Duration // how long a completed response is replayed
+	LockTimeout  time.Duration // after this an unfinished attempt is considered dead
+	WaitTimeout  time.Duration // how long an in-flight duplicate waits before it is rejected
+	PollInterval time.Duration // how often an in-flight duplicate checks the first attempt
+}
+
+// Response is the stored result of a request, Status and Body are protocol specific
+type Response struct {
+	Status int
+	Body   []byte
+}
+
+// Guard makes sure a request with the same idempotency key is executed only once
+type Guard struct {
+	log     *slog.Logger
+	storage Storage
+	cfg     Config
+
+	mu         sync.Mutex
+	completing map[string]string // fingerprints of keys whose response is still being stored
+}
+
+func New(log *slog.Logger, storage Storage, cfg Config) *Guard {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = 2 * time.Minute
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 100 * time.Millisecond
+	}
+
+	return &Guard{
+		log:        log,
+		storage:    storage,
+		cfg:        cfg,
+		completing: make(map[string]string),
+	}
+}
+
+// Fingerprint returns a digest identifying the request the key was used with
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Do executes fn once per key. Duplicates with the same fingerprint get the stored response
+// (replayed is true), duplicates with another fingerprint get domain.ErrIdempotencyKeyReused
+// and duplicates of an unfinished request wait for it or get domain.ErrIdempotencyInProgress.
+// If fn reports the response must not be kept, the key is released so the request can be retried.
+func (g *Guard) Do(ctx context.Context, key, fingerprint string, fn func(ctx context.Context) (resp Response, keep bool)) (Response, bool, error) {
+	const op = "idempotency.Do"
+
+	log := g.log.With(
+		slog.String("op", op),
+		slog.String("idempotencyKey", key),
+	)
+
+	var waitUntil time.Time
+
+	for {
+		now := time.Now()
+
+		// a response that is still being stored keeps its key locked even after the lock timeout
+		storedFingerprint, completing := g.completingFingerprint(key)
+		if !completing {
+			record, acquired, err := g.storage.AcquireIdempotencyKey(ctx, key, fingerprint, now.Add(-g.cfg.TTL), now.Add(-g.cfg.LockTimeout))
+			if err != nil {
+				if errors.Is(err, storage.ErrIdempotencyKeyNotFound) {
+					// released between the attempt to acquire and the lookup
+					continue
+				}
+				return Response{}, false, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if acquired {
+				return g.execute(ctx, log, key, fingerprint, fn)
+			}
+
+			if record.Status == domain.IdempotencyStatusCompleted && record.Fingerprint == fingerprint {
+				log.Debug("replaying stored response")
+				return Response{Status: record.ResponseStatus, Body: record.ResponseBody}, true, nil
+			}
+			storedFingerprint = record.Fingerprint
+		}
+
+		if storedFingerprint != fingerprint {
+			log.Warn("idempotency key reused with a different request")
+			return Response{}, false, domain.ErrIdempotencyKeyReused
+		}
+
+		if waitUntil.IsZero() {
+			waitUntil = now.Add(g.cfg.WaitTimeout)
+		}
+
+		if !now.Before(waitUntil) {
+			log.Warn("duplicate request rejected while the first attempt is in progress")
+			return Response{}, false, domain.ErrIdempotencyInProgress
+		}
+
+		select {
+		case <-ctx.Done():
+			return Response{}, false, ctx.Err()
+		case <-time.After(g.cfg.PollInterval):
+		}
+	}
+}
+
+func (g *Guard) execute(ctx context.Context, log *slog.Logger, key, fingerprint string, fn func(ctx context.Context) (Response, bool)) (Response, bool, error) {
+	// the outcome must be recorded even if the client has gone away
+	storeCtx := context.WithoutCancel(ctx)
+
+	var resp Response
+	keep := false
+
+	// release the key if the response is not kept or fn panics
+	defer func() {
+		if !keep {
+			if err := g.storage.ReleaseIdempotencyKey(storeCtx, key); err != nil {
+				log.Error("failed to release idempotency key", "error", err.Error())
+			}
+		}
+	}()
+
+	resp, keep = fn(ctx)
+	if !keep {
+		return resp, false, nil
+	}
+
+	g.complete(storeCtx, log, key, fingerprint, resp)
+
+	return resp, false, nil
+}
+
+// complete stores the response of the key. If it cannot be stored the key stays locked and storing
+// is retried in the background, duplicates are held back instead of calling Kaspi again
+func (g *Guard) complete(ctx context.Context, log *slog.Logger, key, fingerprint string, resp Response) {
+	delay := g.cfg.PollInterval
+	maxDelay := max(g.cfg.LockTimeout/4, delay)
+
+	store := func(attempt int) bool {
+		err := g.storage.CompleteIdempotencyKey(ctx, key, resp.Status, resp.Body)
+		if err != nil {
+			log.Error("failed to store idempotent response", "attempt", attempt, "error", err.Error())
+			return false
+		}
+		return true
+	}
+
+	for attempt := 1; attempt <= completeAttempts; attempt++ {
+		if store(attempt) {
+			return
+		}
+		if attempt < completeAttempts {
+			time.Sleep(delay)
+			delay = min(delay*2, maxDelay)
+		}
+	}
+
+	g.mu.Lock()
+	g.completing[key] = fingerprint
+	g.mu.Unlock()
+
+	go func() {
+		defer func() {
+			g.mu.Lock()
+			delete(g.completing, key)
+			g.mu.Unlock()
+		}()
+
+		for attempt := completeAttempts + 1; ; attempt++ {
+			time.Sleep(delay)
+			delay = min(delay*2, maxDelay)
+
+			if store(attempt) {
+				log.Info("stored idempotent response after retries", "attempts", attempt)
+				return
+			}
+		}
+	}()
+}
+
+// completingFingerprint returns the fingerprint of a key whose response is still being stored
+func (g *Guard) completingFingerprint(key string) (string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fingerprint, ok := g.completing[key]
+	return fingerprint, ok
+}
@@ -0,0 +1,256 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/idempotency"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MockStorage keeps idempotency records in memory
+type MockStorage struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+
+	// CompleteFailures is the number of completions that fail before one succeeds
+	CompleteFailures int
+}
+
+func (m *MockStorage) AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, expiredBefore, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.records == nil {
+		m.records = make(map[string]*domain.IdempotencyRecord)
+	}
+
+	record, ok := m.records[key]
+	if ok && record.CreatedAt.After(expiredBefore) &&
+		(record.Status != domain.IdempotencyStatusInProgress || record.UpdatedAt.After(staleBefore)) {
+		copied := *record
+		return &copied, false, nil
+	}
+
+	now := time.Now()
+	m.records[key] = &domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      domain.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	return m.records[key], true, nil
+}
+
+func (m *MockStorage) IdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[key]
+	if !ok {
+		return nil, storage.ErrIdempotencyKeyNotFound
+	}
+
+	copied := *record
+	return &copied, nil
+}
+
+func (m *MockStorage) CompleteIdempotencyKey(ctx context.Context, key string, responseStatus int, responseBody []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.CompleteFailures > 0 {
+		m.CompleteFailures--
+		return errors.New("db down")
+	}
+
+	record := m.records[key]
+	record.Status = domain.IdempotencyStatusCompleted
+	record.ResponseStatus = responseStatus
+	record.ResponseBody = responseBody
+
+	return nil
+}
+
+func (m *MockStorage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[key]; ok && record.Status == domain.IdempotencyStatusInProgress {
+		delete(m.records, key)
+	}
+
+	return nil
+}
+
+func newGuard() *idempotency.Guard {
+	return idempotency.New(slogdiscard.NewDiscardLogger(), &MockStorage{}, idempotency.Config{
+		WaitTimeout:  200 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	})
+}
+
+func TestGuard(t *testing.T) {
+	t.Run("replays stored response", func(t *testing.T) {
+		guard := newGuard()
+
+		var calls atomic.Int32
+		fn := func(ctx context.Context) (idempotency.Response, bool) {
+			calls.Add(1)
+			return idempotency.Response{Status: 200, Body: []byte(`{"success":true}`)}, true
+		}
+
+		_, replayed, err := guard.Do(context.Background(), "key-1", "fp", fn)
+		if err != nil || replayed {
+			t.Fatalf("Expected first call to execute, got replayed=%v err=%v", replayed, err)
+		}
+
+		resp, replayed, err := guard.Do(context.Background(), "key-1", "fp", fn)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !replayed || resp.Status != 200 || string(resp.Body) != `{"success":true}` {
+			t.Errorf("Expected replayed response, got %+v (replayed=%v)", resp, replayed)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 execution, got %d", calls.Load())
+		}
+	})
+
+	t.Run("rejects key reuse with different fingerprint", func(t *testing.T) {
+		guard := newGuard()
+
+		fn := func(ctx context.Context) (idempotency.Response, bool) {
+			return idempotency.Response{Status: 200}, true
+		}
+
+		if _, _, err := guard.Do(context.Background(), "key-2", "fp-1", fn); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, _, err := guard.Do(context.Background(), "key-2", "fp-2", fn)
+		if !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+			t.Errorf("Expected ErrIdempotencyKeyReused, got %v", err)
+		}
+	})
+
+	t.Run("releases key when response is not kept", func(t *testing.T) {
+		guard := newGuard()
+
+		var calls atomic.Int32
+		fn := func(ctx context.Context) (idempotency.Response, bool) {
+			calls.Add(1)
+			return idempotency.Response{Status: 500}, false
+		}
+
+		for i := 0; i < 2; i++ {
+			_, replayed, err := guard.Do(context.Background(), "key-3", "fp", fn)
+			if err != nil || replayed {
+				t.Fatalf("Expected call to execute, got replayed=%v err=%v", replayed, err)
+			}
+		}
+
+		if calls.Load() != 2 {
+			t.Errorf("Expected 2 executions, got %d", calls.Load())
+		}
+	})
+
+	t.Run("duplicate waits for in-flight request", func(t *testing.T) {
+		guard := newGuard()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		go func() {
+			_, _, _ = guard.Do(context.Background(), "key-4", "fp", func(ctx context.Context) (idempotency.Response, bool) {
+				close(started)
+				<-release
+				return idempotency.Response{Status: 200, Body: []byte("first")}, true
+			})
+		}()
+
+		<-started
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+		resp, replayed, err := guard.Do(context.Background(), "key-4", "fp", func(ctx context.Context) (idempotency.Response, bool) {
+			t.Error("Expected duplicate not to execute")
+			return idempotency.Response{}, true
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !replayed || string(resp.Body) != "first" {
+			t.Errorf("Expected response of the first request, got %+v", resp)
+		}
+	})
+
+	t.Run("rejects duplicate after wait timeout", func(t *testing.T) {
+		guard := newGuard()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		go func() {
+			_, _, _ = guard.Do(context.Background(), "key-5", "fp", func(ctx context.Context) (idempotency.Response, bool) {
+				close(started)
+				<-release
+				return idempotency.Response{Status: 200}, true
+			})
+		}()
+
+		<-started
+
+		_, _, err := guard.Do(context.Background(), "key-5", "fp", func(ctx context.Context) (idempotency.Response, bool) {
+			t.Error("Expected duplicate not to execute")
+			return idempotency.Response{}, true
+		})
+		if !errors.Is(err, domain.ErrIdempotencyInProgress) {
+			t.Errorf("Expected ErrIdempotencyInProgress, got %v", err)
+		}
+	})
+	t.Run("keeps key locked until the response is stored", func(t *testing.T) {
+		store := &MockStorage{CompleteFailures: 5}
+		guard := idempotency.New(slogdiscard.NewDiscardLogger(), store, idempotency.Config{
+			LockTimeout:  time.Millisecond,
+			WaitTimeout:  time.Second,
+			PollInterval: time.Millisecond,
+		})
+
+		var calls atomic.Int32
+		fn := func(ctx context.Context) (idempotency.Response, bool) {
+			calls.Add(1)
+			return idempotency.Response{Status: 200, Body: []byte("first")}, true
+		}
+
+		if _, _, err := guard.Do(context.Background(), "key-6", "fp", fn); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// the lock timeout has passed, but the duplicate waits for the response to be stored
+		time.Sleep(5 * time.Millisecond)
+
+		resp, replayed, err := guard.Do(context.Background(), "key-6", "fp", fn)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !replayed || string(resp.Body) != "first" {
+			t.Errorf("Expected response of the first request, got %+v (replayed=%v)", resp, replayed)
+		}
+
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 execution, got %d", calls.Load())
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrSchemaNewer = errors.New("database schema is newer than this binary supports")
+	ErrDirty       = errors.New("a migration failed and left the database schema dirty")
+)
+
+// the file names of golang-migrate, e.g. 000001_devices.up.sql
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change with the SQL that applies and reverts it
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Locker serializes the migrators of several instances on conn and returns the function releasing
+// the lock, it is nil for databases used by a single instance
+type Locker func(ctx context.Context, conn *sql.Conn) (unlock func() error, err error)
+
+// Migrator applies migrations and records the version in the schema_migrations table of golang-migrate,
+// so databases migrated with the migrate CLI are picked up where it stopped
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	lock       Locker
+}
+
+// New reads the migrations in the root of fsys
+func New(db *sql.DB, fsys fs.FS, lock Locker) (*Migrator, error) {
+	const op = "migrator.New"
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	m := &Migrator{db: db, lock: lock}
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%s: migration %d has no up file", op, migration.Version)
+		}
+		m.migrations = append(m.migrations, *migration)
+	}
+
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+
+	return m, nil
+}
+
+// Latest returns the version of the newest migration, zero when there are none
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the database schema, zero for an empty database, and whether
+// a failed migration left it dirty
+func (m *Migrator) Version(ctx context.Context) (uint64, bool, error) {
+	const op = "migrator.Version"
+
+	var version uint64
+	var dirty bool
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, dirty, err = m.version(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, dirty, nil
+}
+
+// Check returns the number of migrations the database is behind, it fails when the schema
+// is newer than the latest migration or dirty
+func (m *Migrator) Check(ctx context.Context) (int, error) {
+	const op = "migrator.Check"
+
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = m.usable(version, dirty); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(m.pending(version)), nil
+}
+
+// Up applies the pending migrations and returns how many were applied. Every migration runs
+// in its own transaction together with the version update
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	const op = "migrator.Up"
+
+	var applied int
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err = m.usable(version, dirty); err != nil {
+			return err
+		}
+
+		for _, migration := range m.pending(version) {
+			if err = m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return applied, nil
+}
+
+// Down reverts up to steps migrations, newest first, and returns how many were reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	const op = "migrator.Down"
+
+	var reverted int
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err = m.usable(version, dirty); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			var previous uint64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err = m.apply(ctx, conn, migration.Down, previous); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return reverted, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reverted, nil
+}
+
+func (m *Migrator) usable(version uint64, dirty bool) error {
+	if dirty {
+		return fmt.Errorf("%w at version %d, fix it and reset the dirty flag in schema_migrations", ErrDirty, version)
+	}
+
+	if version > m.Latest() {
+		return fmt.Errorf("%w: schema version %d, latest known migration %d", ErrSchemaNewer, version, m.Latest())
+	}
+
+	return nil
+}
+
+func (m *Migrator) pending(version uint64) []Migration {
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// locked runs fn on a single connection holding the lock of the database
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.lock != nil {
+		unlock, err := m.lock(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("lock: %w", err)
+		}
+		defer unlock()
+	}
+
+	// the table of golang-migrate, a database migrated with its CLI has it already
+	if _, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)
+	`); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// version reads the schema version, an empty schema table means version zero
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	if version < 0 {
+		// golang-migrate records a fully reverted schema as -1
+		return 0, dirty, nil
+	}
+
+	return uint64(version), dirty, nil
+}
+
+// apply runs the SQL of a migration and records the new version in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, statements string, version uint64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, statements); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+
+	if version > 0 {
+		// the version is a number, so it is inlined instead of using placeholders that differ between databases
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO schema_migrations (version, dirty) VALUES (%d, FALSE)`, version))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
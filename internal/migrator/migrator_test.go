@@ -0,0 +1,156 @@
+package migrator_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
+	"kaspi-api-wrapper/internal/migrator"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+var migrations = fstest.MapFS{
+	"000001_items.up.sql":   {Data: []byte(`CREATE TABLE items (id INTEGER PRIMARY KEY);`)},
+	"000001_items.down.sql": {Data: []byte(`DROP TABLE items;`)},
+	"000002_name.up.sql":    {Data: []byte(`ALTER TABLE items ADD COLUMN name TEXT; INSERT INTO items (id, name) VALUES (1, 'first');`)},
+	"000002_name.down.sql":  {Data: []byte(`ALTER TABLE items DROP COLUMN name;`)},
+	"README.md":             {Data: []byte(`not a migration`)},
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newMigrator(t *testing.T, db *sql.DB, fsys fstest.MapFS) *migrator.Migrator {
+	m, err := migrator.New(db, fsys, nil)
+	if err != nil {
+		t.Fatalf("migrator.New: %v", err)
+	}
+	return m
+}
+
+func TestUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	m := newMigrator(t, db, migrations)
+
+	if m.Latest() != 2 {
+		t.Fatalf("Latest = %d, want 2", m.Latest())
+	}
+
+	pending, err := m.Check(ctx)
+	if err != nil || pending != 2 {
+		t.Fatalf("Check = %d, %v, want 2 pending", pending, err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil || applied != 2 {
+		t.Fatalf("Up = %d, %v, want 2 applied", applied, err)
+	}
+
+	var name string
+	if err = db.QueryRow(`SELECT name FROM items WHERE id = 1`).Scan(&name); err != nil || name != "first" {
+		t.Fatalf("name = %q, %v", name, err)
+	}
+
+	// a second start has nothing to apply
+	if applied, err = m.Up(ctx); err != nil || applied != 0 {
+		t.Fatalf("second Up = %d, %v, want 0 applied", applied, err)
+	}
+
+	reverted, err := m.Down(ctx, 1)
+	if err != nil || reverted != 1 {
+		t.Fatalf("Down = %d, %v, want 1 reverted", reverted, err)
+	}
+
+	version, dirty, err := m.Version(ctx)
+	if err != nil || version != 1 || dirty {
+		t.Fatalf("Version = %d, %v, %v, want 1", version, dirty, err)
+	}
+
+	if reverted, err = m.Down(ctx, 5); err != nil || reverted != 1 {
+		t.Fatalf("Down = %d, %v, want 1 reverted", reverted, err)
+	}
+
+	if version, _, err = m.Version(ctx); err != nil || version != 0 {
+		t.Fatalf("Version = %d, %v, want 0", version, err)
+	}
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	broken := fstest.MapFS{
+		"000001_items.up.sql":  migrations["000001_items.up.sql"],
+		"000002_broken.up.sql": {Data: []byte(`CREATE TABLE other (id INTEGER); INSERT INTO missing VALUES (1);`)},
+	}
+	m := newMigrator(t, db, broken)
+
+	applied, err := m.Up(ctx)
+	if err == nil || applied != 1 {
+		t.Fatalf("Up = %d, %v, want 1 applied and an error", applied, err)
+	}
+
+	version, dirty, err := m.Version(ctx)
+	if err != nil || version != 1 || dirty {
+		t.Fatalf("Version = %d, %v, %v, want clean 1", version, dirty, err)
+	}
+
+	var count int
+	if err = db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE name = 'other'`).Scan(&count); err != nil || count != 0 {
+		t.Fatalf("table of the failed migration exists: %d, %v", count, err)
+	}
+}
+
+func TestSchemaNewerThanBinary(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	if _, err := newMigrator(t, db, migrations).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// an older binary knows only the first migration
+	older := newMigrator(t, db, fstest.MapFS{"000001_items.up.sql": migrations["000001_items.up.sql"]})
+
+	if _, err := older.Check(ctx); !errors.Is(err, migrator.ErrSchemaNewer) {
+		t.Fatalf("Check error = %v, want ErrSchemaNewer", err)
+	}
+	if _, err := older.Up(ctx); !errors.Is(err, migrator.ErrSchemaNewer) {
+		t.Fatalf("Up error = %v, want ErrSchemaNewer", err)
+	}
+}
+
+func TestDirtySchema(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	m := newMigrator(t, db, migrations)
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// golang-migrate leaves the flag when a migration fails halfway
+	if _, err := db.Exec(`UPDATE schema_migrations SET dirty = TRUE`); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	if _, err := m.Check(ctx); !errors.Is(err, migrator.ErrDirty) {
+		t.Fatalf("Check error = %v, want ErrDirty", err)
+	}
+}
+
+func TestNewRejectsMissingUpFile(t *testing.T) {
+	_, err := migrator.New(openDB(t), fstest.MapFS{"000001_items.down.sql": migrations["000001_items.down.sql"]}, nil)
+	if err == nil {
+		t.Fatal("New accepted a migration without up file")
+	}
+}
@@ -0,0 +1,115 @@
+package onec
+
+import (
+	"encoding/xml"
+	"kaspi-api-wrapper/internal/domain"
+	"strconv"
+	"time"
+)
+
+// commerceMLVersion is the CommerceML 2 schema version 1C exchange processors expect
+const commerceMLVersion = "2.10"
+
+// Business operations of CommerceML documents, the wrapper records incoming payments and their refunds
+const (
+	operationPayment = "Выплата безналичных денег"
+	operationRefund  = "Возврат безналичных денег"
+)
+
+type commerceMLInfo struct {
+	XMLName       xml.Name             `xml:"КоммерческаяИнформация"`
+	SchemaVersion string               `xml:"ВерсияСхемы,attr"`
+	GeneratedAt   string               `xml:"ДатаФормирования,attr"`
+	Documents     []commerceMLDocument `xml:"Документ"`
+}
+
+type commerceMLDocument struct {
+	ID             string                   `xml:"Ид"`
+	Number         string                   `xml:"Номер"`
+	Date           string                   `xml:"Дата"`
+	Operation      string                   `xml:"ХозОперация"`
+	Role           string                   `xml:"Роль"`
+	Currency       string                   `xml:"Валюта"`
+	Rate           string                   `xml:"Курс"`
+	Amount         string                   `xml:"Сумма"`
+	Counterparties []commerceMLCounterparty `xml:"Контрагенты>Контрагент"`
+	Time           string                   `xml:"Время"`
+	Comment        string                   `xml:"Комментарий"`
+	Requisites     []commerceMLRequisite    `xml:"ЗначенияРеквизитов>ЗначениеРеквизита"`
+}
+
+type commerceMLCounterparty struct {
+	ID   string `xml:"Ид"`
+	Name string `xml:"Наименование"`
+	Role string `xml:"Роль"`
+	Bin  string `xml:"ИНН,omitempty"`
+}
+
+type commerceMLRequisite struct {
+	Name  string `xml:"Наименование"`
+	Value string `xml:"Значение"`
+}
+
+// writeCommerceML lists every payment and refund as a CommerceML document of the seller,
+// the trade point counterparty is the buyer
+func writeCommerceML(documents []domain.AccountingDocument, mapping Mapping, now time.Time) ([]byte, error) {
+	info := commerceMLInfo{
+		SchemaVersion: commerceMLVersion,
+		GeneratedAt:   now.Format("2006-01-02T15:04:05"),
+		Documents:     make([]commerceMLDocument, 0, len(documents)),
+	}
+
+	for _, doc := range documents {
+		counterparty := mapping.Counterparty(doc.TradePointID)
+
+		operation := operationPayment
+		if doc.Kind == domain.SettlementRefund {
+			operation = operationRefund
+		}
+
+		requisites := []commerceMLRequisite{
+			{Name: "QrPaymentId", Value: strconv.FormatInt(doc.QrPaymentID, 10)},
+		}
+		if doc.ExternalID != "" {
+			requisites = append(requisites, commerceMLRequisite{Name: "ExternalId", Value: doc.ExternalID})
+		}
+		if doc.TransactionID != "" {
+			requisites = append(requisites, commerceMLRequisite{Name: "TransactionId", Value: doc.TransactionID})
+		}
+		if doc.TradePointID != 0 {
+			requisites = append(requisites, commerceMLRequisite{Name: "TradePointId", Value: strconv.FormatInt(doc.TradePointID, 10)})
+		}
+		if doc.Kind == domain.SettlementRefund {
+			payment := doc
+			payment.Kind = domain.SettlementSale
+			requisites = append(requisites, commerceMLRequisite{Name: "Основание", Value: documentID(payment)})
+		}
+
+		info.Documents = append(info.Documents, commerceMLDocument{
+			ID:        documentID(doc),
+			Number:    documentNumber(doc),
+			Date:      doc.CreatedAt.Format(time.DateOnly),
+			Operation: operation,
+			Role:      "Продавец",
+			Currency:  "KZT",
+			Rate:      "1",
+			Amount:    formatAmount(doc.Amount),
+			Counterparties: []commerceMLCounterparty{{
+				ID:   counterparty.ID,
+				Name: counterparty.Name,
+				Role: "Покупатель",
+				Bin:  counterparty.Bin,
+			}},
+			Time:       doc.CreatedAt.Format(time.TimeOnly),
+			Comment:    purpose(doc),
+			Requisites: requisites,
+		})
+	}
+
+	data, err := xml.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
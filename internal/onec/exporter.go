@@ -0,0 +1,152 @@
+package onec
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// defaultMaxDays keeps an exchange file within a quarter
+const defaultMaxDays = 92
+
+type Storage interface {
+	AccountingDocuments(ctx context.Context, from, to time.Time, tradePointID int64) ([]domain.AccountingDocument, error)
+}
+
+// Config holds the time zone of the exported dates, the longest allowed period and the counterparty mapping
+type Config struct {
+	Location *time.Location
+	MaxDays  int
+	Mapping  Mapping
+}
+
+// Exporter writes processed payments and succeeded refunds as 1C exchange files
+type Exporter struct {
+	log     *slog.Logger
+	storage Storage
+	cfg     Config
+}
+
+func New(log *slog.Logger, storage Storage, cfg Config) *Exporter {
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	if cfg.MaxDays <= 0 {
+		cfg.MaxDays = defaultMaxDays
+	}
+
+	return &Exporter{
+		log:     log,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// ExportOneC renders the documents of the period as CommerceML XML or as a 1CClientBankExchange statement
+func (e *Exporter) ExportOneC(ctx context.Context, req domain.OneCExportRequest) (*domain.ReportFile, error) {
+	const op = "onec.ExportOneC"
+
+	if req.Format == "" {
+		req.Format = domain.OneCFormatCommerceML
+	}
+
+	if err := validator.ValidateOneCExportFormat(req.Format); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	from, to, err := validator.ValidateDateRange(req.DateFrom, req.DateTo, e.cfg.Location, e.cfg.MaxDays)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	documents, err := e.storage.AccountingDocuments(ctx, from, to.AddDate(0, 0, 1), req.TradePointID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range documents {
+		documents[i].CreatedAt = documents[i].CreatedAt.In(e.cfg.Location)
+	}
+
+	period := from.Format(time.DateOnly) + "_" + to.Format(time.DateOnly)
+	now := time.Now().In(e.cfg.Location)
+
+	var file *domain.ReportFile
+	switch req.Format {
+	case domain.OneCFormatStatement:
+		var data []byte
+		data, err = writeStatement(documents, e.cfg.Mapping, from, to, now)
+		file = &domain.ReportFile{
+			FileName:    "kl_to_1c-" + period + ".txt",
+			ContentType: "text/plain; charset=windows-1251",
+			Data:        data,
+		}
+	default:
+		var data []byte
+		data, err = writeCommerceML(documents, e.cfg.Mapping, now)
+		file = &domain.ReportFile{
+			FileName:    "1c-" + period + ".xml",
+			ContentType: "application/xml",
+			Data:        data,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.log.Info("1C export generated",
+		slog.String("format", req.Format),
+		slog.String("period", period),
+		slog.Int("documents", len(documents)),
+	)
+
+	return file, nil
+}
+
+// documentID keys a document by the Kaspi transaction, a refund gets its ledger ID appended
+// since several refunds can belong to one payment
+func documentID(doc domain.AccountingDocument) string {
+	id := doc.TransactionID
+	if id == "" {
+		id = "QR" + strconv.FormatInt(doc.QrPaymentID, 10)
+	}
+
+	if doc.Kind == domain.SettlementRefund {
+		id += "-R" + strconv.FormatInt(doc.RefundID, 10)
+	}
+
+	return id
+}
+
+// documentNumber is the ExternalId of the payment in the merchant system, or the Kaspi payment ID
+func documentNumber(doc domain.AccountingDocument) string {
+	if doc.ExternalID != "" {
+		return doc.ExternalID
+	}
+	return strconv.FormatInt(doc.QrPaymentID, 10)
+}
+
+// purpose describes a document for people reading it in 1C
+func purpose(doc domain.AccountingDocument) string {
+	text := "Оплата Kaspi QR " + strconv.FormatInt(doc.QrPaymentID, 10)
+	if doc.Kind == domain.SettlementRefund {
+		text = "Возврат по Kaspi QR " + strconv.FormatInt(doc.QrPaymentID, 10)
+	}
+
+	if doc.TransactionID != "" {
+		text += ", транзакция " + doc.TransactionID
+	}
+	if doc.ExternalID != "" {
+		text += ", заказ " + doc.ExternalID
+	}
+
+	return text
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
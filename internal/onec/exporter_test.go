@@ -0,0 +1,218 @@
+package onec_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/onec"
+	"kaspi-api-wrapper/internal/validator"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+type MockStorage struct {
+	documents    []domain.AccountingDocument
+	from         time.Time
+	to           time.Time
+	tradePointID int64
+}
+
+func (m *MockStorage) AccountingDocuments(ctx context.Context, from, to time.Time, tradePointID int64) ([]domain.AccountingDocument, error) {
+	m.from, m.to, m.tradePointID = from, to, tradePointID
+	return m.documents, nil
+}
+
+func newExporter(t *testing.T) (*onec.Exporter, *MockStorage) {
+	location, err := time.LoadLocation("Asia/Almaty")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	storage := &MockStorage{documents: []domain.AccountingDocument{
+		{Kind: domain.SettlementSale, QrPaymentID: 15, ExternalID: "ORDER-1", TransactionID: "TX15", TradePointID: 1,
+			Amount: 1500, CreatedAt: time.Date(2026, 5, 1, 5, 0, 0, 0, time.UTC)},
+		{Kind: domain.SettlementSale, QrPaymentID: 16, TradePointID: 2,
+			Amount: 700.5, CreatedAt: time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)},
+		{Kind: domain.SettlementRefund, RefundID: 3, QrPaymentID: 15, ExternalID: "ORDER-1", TransactionID: "TX15", TradePointID: 1,
+			Amount: 500, CreatedAt: time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)},
+	}}
+
+	mapping := onec.Mapping{
+		Organization: domain.OneCOrganization{Name: "ТОО Әлем", Bin: "123456789012", Account: "KZ000000000000000001"},
+		Counterparties: map[string]domain.OneCCounterparty{
+			"1": {ID: "cp-1", Name: "Магазин на Абая", Bin: "210987654321"},
+		},
+	}
+
+	return onec.New(slogdiscard.NewDiscardLogger(), storage, onec.Config{Location: location, Mapping: mapping}), storage
+}
+
+func TestExportCommerceML(t *testing.T) {
+	exporter, storage := newExporter(t)
+
+	file, err := exporter.ExportOneC(context.Background(), domain.OneCExportRequest{
+		DateFrom:     "2026-05-01",
+		TradePointID: 1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if storage.tradePointID != 1 || storage.to.Sub(storage.from) != 24*time.Hour {
+		t.Errorf("Unexpected query %s - %s for trade point %d", storage.from, storage.to, storage.tradePointID)
+	}
+
+	if file.FileName != "1c-2026-05-01_2026-05-01.xml" || file.ContentType != "application/xml" {
+		t.Errorf("Unexpected file %s of type %s", file.FileName, file.ContentType)
+	}
+
+	var info struct {
+		Documents []struct {
+			ID           string `xml:"Ид"`
+			Number       string `xml:"Номер"`
+			Operation    string `xml:"ХозОперация"`
+			Amount       string `xml:"Сумма"`
+			Time         string `xml:"Время"`
+			Counterparty string `xml:"Контрагенты>Контрагент>Ид"`
+		} `xml:"Документ"`
+	}
+	if err = xml.Unmarshal(file.Data, &info); err != nil {
+		t.Fatalf("Failed to parse XML: %v", err)
+	}
+
+	if len(info.Documents) != 3 {
+		t.Fatalf("Expected 3 documents, got %d", len(info.Documents))
+	}
+
+	payment := info.Documents[0]
+	if payment.ID != "TX15" || payment.Number != "ORDER-1" || payment.Amount != "1500.00" ||
+		payment.Time != "10:00:00" || payment.Counterparty != "cp-1" {
+		t.Errorf("Unexpected payment document: %+v", payment)
+	}
+
+	unmapped := info.Documents[1]
+	if unmapped.ID != "QR16" || unmapped.Number != "16" || unmapped.Counterparty != "kaspi" {
+		t.Errorf("Unexpected document without identifiers: %+v", unmapped)
+	}
+
+	refund := info.Documents[2]
+	if refund.ID != "TX15-R3" || refund.Operation != "Возврат безналичных денег" {
+		t.Errorf("Unexpected refund document: %+v", refund)
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	exporter, _ := newExporter(t)
+
+	file, err := exporter.ExportOneC(context.Background(), domain.OneCExportRequest{
+		DateFrom: "2026-05-01",
+		DateTo:   "2026-05-02",
+		Format:   domain.OneCFormatStatement,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if file.FileName != "kl_to_1c-2026-05-01_2026-05-02.txt" {
+		t.Errorf("Unexpected file name %s", file.FileName)
+	}
+
+	data, err := charmap.Windows1251.NewDecoder().Bytes(file.Data)
+	if err != nil {
+		t.Fatalf("Failed to decode Windows-1251: %v", err)
+	}
+	text := string(data)
+
+	if !strings.HasPrefix(text, "1CClientBankExchange\r\n") || !strings.HasSuffix(text, "КонецФайла\r\n") {
+		t.Errorf("Unexpected file boundaries")
+	}
+
+	for _, line := range []string{
+		"ДатаНачала=01.05.2026",
+		"ДатаКонца=02.05.2026",
+		"ВсегоПоступило=2200.50",
+		"ВсегоСписано=500.00",
+		"Плательщик=Магазин на Абая",
+		// Ә is not part of Windows-1251
+		"Получатель=ТОО Алем",
+		"ИдентификаторДокумента=TX15-R3",
+		"НазначениеПлатежа=Возврат по Kaspi QR 15, транзакция TX15, заказ ORDER-1",
+	} {
+		if !strings.Contains(text, line+"\r\n") {
+			t.Errorf("Missing line %q", line)
+		}
+	}
+
+	if count := strings.Count(text, "СекцияДокумент="); count != 3 {
+		t.Errorf("Expected 3 documents, got %d", count)
+	}
+}
+
+func TestExportValidation(t *testing.T) {
+	exporter, _ := newExporter(t)
+
+	requests := []domain.OneCExportRequest{
+		{DateFrom: "2026-05-01", Format: "dbf"},
+		{DateFrom: "2026-05-02", DateTo: "2026-05-01"},
+		{},
+	}
+
+	for _, req := range requests {
+		_, err := exporter.ExportOneC(context.Background(), req)
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "mapping.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write mapping: %v", err)
+		}
+		return path
+	}
+
+	t.Run("loads counterparties", func(t *testing.T) {
+		mapping, err := onec.LoadMapping(write(t, `{
+			"Organization": {"Name": "ТОО Ромашка", "Bin": "123456789012", "Account": "KZ01"},
+			"Counterparties": {"7": {"Id": "cp-7", "Name": "Точка 7"}},
+			"DefaultCounterparty": {"Id": "cp-0", "Name": "Прочие"}
+		}`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := mapping.Counterparty(7); got.ID != "cp-7" {
+			t.Errorf("Expected cp-7, got %+v", got)
+		}
+		if got := mapping.Counterparty(8); got.ID != "cp-0" {
+			t.Errorf("Expected default counterparty, got %+v", got)
+		}
+	})
+
+	t.Run("rejects invalid trade point", func(t *testing.T) {
+		_, err := onec.LoadMapping(write(t, `{"Counterparties": {"main": {"Id": "cp", "Name": "Main"}}}`))
+		if err == nil {
+			t.Error("Expected error for non numeric trade point")
+		}
+	})
+
+	t.Run("rejects counterparty without id", func(t *testing.T) {
+		_, err := onec.LoadMapping(write(t, `{"Counterparties": {"1": {"Name": "Main"}}}`))
+		if err == nil {
+			t.Error("Expected error for counterparty without Id")
+		}
+	})
+}
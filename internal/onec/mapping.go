@@ -0,0 +1,59 @@
+package onec
+
+import (
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"os"
+	"strconv"
+)
+
+// defaultCounterparty books payments of unmapped trade points against Kaspi
+var defaultCounterparty = domain.OneCCounterparty{ID: "kaspi", Name: "Kaspi.kz"}
+
+// Mapping describes the own organization and the 1C counterparty of every trade point,
+// trade points are keyed by their ID as a string since JSON keys can not be numbers
+type Mapping struct {
+	Organization        domain.OneCOrganization            `json:"Organization"`
+	Counterparties      map[string]domain.OneCCounterparty `json:"Counterparties"`
+	DefaultCounterparty *domain.OneCCounterparty           `json:"DefaultCounterparty,omitempty"`
+}
+
+// LoadMapping reads a mapping from a JSON file
+func LoadMapping(path string) (Mapping, error) {
+	const op = "onec.LoadMapping"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Mapping{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var mapping Mapping
+	if err = json.Unmarshal(data, &mapping); err != nil {
+		return Mapping{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for key, counterparty := range mapping.Counterparties {
+		if _, err = strconv.ParseInt(key, 10, 64); err != nil {
+			return Mapping{}, fmt.Errorf("%s: trade point %q is not a number", op, key)
+		}
+		if counterparty.ID == "" || counterparty.Name == "" {
+			return Mapping{}, fmt.Errorf("%s: counterparty of trade point %s needs an Id and a Name", op, key)
+		}
+	}
+
+	return mapping, nil
+}
+
+// Counterparty returns the counterparty of a trade point, falling back to the default one
+func (m Mapping) Counterparty(tradePointID int64) domain.OneCCounterparty {
+	if counterparty, ok := m.Counterparties[strconv.FormatInt(tradePointID, 10)]; ok {
+		return counterparty
+	}
+
+	if m.DefaultCounterparty != nil {
+		return *m.DefaultCounterparty
+	}
+
+	return defaultCounterparty
+}
@@ -0,0 +1,111 @@
+package onec
+
+import (
+	"bytes"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"kaspi-api-wrapper/internal/domain"
+	"strings"
+	"time"
+)
+
+// statementDate is the date format of 1CClientBankExchange files
+const statementDate = "02.01.2006"
+
+// kazakhLetters maps the letters missing in Windows-1251 to their closest Russian ones
+var kazakhLetters = strings.NewReplacer(
+	"Ә", "А", "ә", "а", "Ғ", "Г", "ғ", "г", "Қ", "К", "қ", "к", "Ң", "Н", "ң", "н",
+	"Ө", "О", "ө", "о", "Ұ", "У", "ұ", "у", "Ү", "У", "ү", "у", "Һ", "Х", "һ", "х", "І", "И", "і", "и",
+)
+
+// statementWriter collects key=value lines of a 1CClientBankExchange file
+type statementWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *statementWriter) line(key string, value ...string) {
+	w.buf.WriteString(key)
+	if len(value) > 0 {
+		w.buf.WriteString("=")
+		w.buf.WriteString(strings.NewReplacer("\r", " ", "\n", " ").Replace(value[0]))
+	}
+	w.buf.WriteString("\r\n")
+}
+
+// writeStatement renders the documents as a bank statement of the organization account in the
+// 1CClientBankExchange 1.03 format, payments are incoming and refunds are outgoing transfers
+func writeStatement(documents []domain.AccountingDocument, mapping Mapping, from, to, now time.Time) ([]byte, error) {
+	org := mapping.Organization
+
+	var incoming, outgoing float64
+	for _, doc := range documents {
+		if doc.Kind == domain.SettlementRefund {
+			outgoing += doc.Amount
+		} else {
+			incoming += doc.Amount
+		}
+	}
+
+	w := &statementWriter{}
+	w.line("1CClientBankExchange")
+	w.line("ВерсияФормата", "1.03")
+	w.line("Кодировка", "Windows")
+	w.line("Отправитель", "Kaspi API Wrapper")
+	w.line("Получатель", "Бухгалтерский учет")
+	w.line("ДатаСоздания", now.Format(statementDate))
+	w.line("ВремяСоздания", now.Format(time.TimeOnly))
+	w.line("ДатаНачала", from.Format(statementDate))
+	w.line("ДатаКонца", to.Format(statementDate))
+	w.line("РасчСчет", org.Account)
+	w.line("Документ", "Платежное поручение")
+
+	w.line("СекцияРасчСчет")
+	w.line("ДатаНачала", from.Format(statementDate))
+	w.line("ДатаКонца", to.Format(statementDate))
+	w.line("РасчСчет", org.Account)
+	w.line("ВсегоПоступило", formatAmount(incoming))
+	w.line("ВсегоСписано", formatAmount(outgoing))
+	w.line("КонецРасчСчет")
+
+	for _, doc := range documents {
+		counterparty := mapping.Counterparty(doc.TradePointID)
+		date := doc.CreatedAt.Format(statementDate)
+
+		w.line("СекцияДокумент", "Платежное поручение")
+		w.line("Номер", documentNumber(doc))
+		w.line("Дата", date)
+		w.line("Сумма", formatAmount(doc.Amount))
+
+		if doc.Kind == domain.SettlementRefund {
+			w.line("ПлательщикСчет", org.Account)
+			w.line("Плательщик", org.Name)
+			w.line("ПлательщикИНН", org.Bin)
+			w.line("ПлательщикБанк1", org.BankName)
+			w.line("ПлательщикБИК", org.BankBic)
+			w.line("ПолучательСчет", "")
+			w.line("Получатель", counterparty.Name)
+			w.line("ПолучательИНН", counterparty.Bin)
+			w.line("ДатаСписано", date)
+		} else {
+			w.line("ПлательщикСчет", "")
+			w.line("Плательщик", counterparty.Name)
+			w.line("ПлательщикИНН", counterparty.Bin)
+			w.line("ПолучательСчет", org.Account)
+			w.line("Получатель", org.Name)
+			w.line("ПолучательИНН", org.Bin)
+			w.line("ПолучательБанк1", org.BankName)
+			w.line("ПолучательБИК", org.BankBic)
+			w.line("ДатаПоступило", date)
+		}
+
+		w.line("ВидОплаты", "01")
+		w.line("НазначениеПлатежа", purpose(doc))
+		w.line("ИдентификаторДокумента", documentID(doc))
+		w.line("КонецДокумента")
+	}
+
+	w.line("КонецФайла")
+
+	encoder := encoding.ReplaceUnsupported(charmap.Windows1251.NewEncoder())
+	return encoder.Bytes([]byte(kazakhLetters.Replace(w.buf.String())))
+}
@@ -0,0 +1,344 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type StatusProvider interface {
+	GetPaymentStatus(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+}
+
+type StatusUpdater interface {
+	ExpirePayment(ctx context.Context, qrPaymentID int64) error
+}
+
+// PaymentStorage lists the stored payments that were not finished before a restart
+type PaymentStorage interface {
+	PendingPayments(ctx context.Context) ([]domain.Payment, error)
+}
+
+// Poller tracks created payments in the background until they reach a terminal state
+type Poller struct {
+	log            *slog.Logger
+	statusProvider StatusProvider
+	statusUpdater  StatusUpdater
+	storage        PaymentStorage
+	sem            chan struct{}
+	defaults       domain.PollingOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	tracked map[int64]*tracking
+}
+
+// progress is how far the payment got when its polling started
+type progress struct {
+	started   time.Time
+	status    string
+	scannedAt time.Time
+}
+
+// tracking is the state of a single polled payment shared by all its subscribers
+type tracking struct {
+	last        *domain.PaymentStatusResponse
+	subscribers map[chan domain.PaymentStatusResponse]struct{}
+}
+
+// New creates a poller that makes at most maxConcurrent status requests at a time,
+// defaults fill in options Kaspi did not prescribe (e.g. for remote payments)
+func New(log *slog.Logger, statusProvider StatusProvider, statusUpdater StatusUpdater, storage PaymentStorage, maxConcurrent int, defaults domain.PollingOptions) *Poller {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Poller{
+		log:            log,
+		statusProvider: statusProvider,
+		statusUpdater:  statusUpdater,
+		storage:        storage,
+		sem:            make(chan struct{}, maxConcurrent),
+		defaults:       defaults,
+		ctx:            ctx,
+		cancel:         cancel,
+		tracked:        make(map[int64]*tracking),
+	}
+}
+
+// Start resumes tracking the stored payments that still wait for the customer, the scan timeout is counted
+// from the creation and the confirmation timeout from the scan, so that a restart does not extend them
+func (p *Poller) Start(ctx context.Context) error {
+	const op = "poller.Start"
+
+	payments, err := p.storage.PendingPayments(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, payment := range payments {
+		resumed := progress{started: payment.CreatedAt, status: payment.Status}
+		if payment.Status == domain.PaymentStatusWait {
+			resumed.scannedAt = payment.UpdatedAt
+		}
+		p.track(payment.QrPaymentID, payment.PollingOptions(), resumed)
+	}
+
+	p.log.Info("payment polling resumed", slog.String("op", op), slog.Int("count", len(payments)))
+
+	return nil
+}
+
+// Track starts polling the payment status using the intervals prescribed by Kaspi
+func (p *Poller) Track(qrPaymentID int64, opts domain.PollingOptions) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.track(qrPaymentID, opts, progress{started: time.Now(), status: domain.PaymentStatusCreated})
+}
+
+// Subscribe returns a channel that receives every status change of the payment.
+// The payment is tracked with default options if it is not polled yet, so all
+// subscribers share a single upstream poll. The channel is closed when the payment
+// reaches a terminal status, expires or the poller stops; call cancel to unsubscribe earlier.
+func (p *Poller) Subscribe(qrPaymentID int64) (<-chan domain.PaymentStatusResponse, func()) {
+	ch := make(chan domain.PaymentStatusResponse, 4)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := p.track(qrPaymentID, domain.PollingOptions{}, progress{started: time.Now(), status: domain.PaymentStatusCreated})
+	if t == nil {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if t.last != nil {
+		ch <- *t.last
+	}
+	t.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if _, ok := t.subscribers[ch]; ok {
+			delete(t.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, cancel
+}
+
+// track starts the polling goroutine unless the payment is already tracked, p.mu must be held
+func (p *Poller) track(qrPaymentID int64, opts domain.PollingOptions, from progress) *tracking {
+	const op = "poller.Track"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("qrPaymentID", qrPaymentID),
+	)
+
+	if opts.Interval <= 0 {
+		opts.Interval = p.defaults.Interval
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.ScanTimeout <= 0 {
+		opts.ScanTimeout = p.defaults.ScanTimeout
+	}
+	if opts.ConfirmationTimeout <= 0 {
+		opts.ConfirmationTimeout = p.defaults.ConfirmationTimeout
+	}
+
+	if p.ctx.Err() != nil {
+		log.Warn("poller is stopped, payment is not tracked")
+		return nil
+	}
+
+	if t, ok := p.tracked[qrPaymentID]; ok {
+		return t
+	}
+
+	t := &tracking{subscribers: make(map[chan domain.PaymentStatusResponse]struct{})}
+	if from.status != domain.PaymentStatusCreated {
+		// subscribers of a resumed payment get the status it had before the restart
+		t.last = &domain.PaymentStatusResponse{Status: from.status}
+	}
+	p.tracked[qrPaymentID] = t
+	p.wg.Add(1)
+
+	log.Debug("tracking payment",
+		"interval", opts.Interval,
+		"scanTimeout", opts.ScanTimeout,
+		"confirmationTimeout", opts.ConfirmationTimeout,
+	)
+
+	go func() {
+		defer p.wg.Done()
+		defer p.untrack(qrPaymentID, t)
+
+		p.poll(qrPaymentID, opts, from)
+	}()
+
+	return t
+}
+
+// untrack forgets the payment and closes all its subscriptions
+func (p *Poller) untrack(qrPaymentID int64, t *tracking) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tracked, qrPaymentID)
+
+	for ch := range t.subscribers {
+		delete(t.subscribers, ch)
+		close(ch)
+	}
+}
+
+// broadcast remembers the status and sends it to all subscribers of the payment.
+// A subscriber that is not keeping up loses its oldest pending status, never the latest one.
+func (p *Poller) broadcast(qrPaymentID int64, status domain.PaymentStatusResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t, ok := p.tracked[qrPaymentID]
+	if !ok {
+		return
+	}
+
+	t.last = &status
+
+	for ch := range t.subscribers {
+		select {
+		case ch <- status:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- status
+		}
+	}
+}
+
+// Tracked returns the number of payments currently being polled
+func (p *Poller) Tracked() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.tracked)
+}
+
+// Stop stops all polling goroutines and waits for them to exit
+func (p *Poller) Stop() {
+	const op = "poller.Stop"
+
+	p.log.With(slog.String("op", op)).Info("stopping payment poller", "tracked", p.Tracked())
+
+	// cancel under the lock so that Track never adds to the wait group after Wait started
+	p.mu.Lock()
+	p.cancel()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *Poller) poll(qrPaymentID int64, opts domain.PollingOptions, from progress) {
+	const op = "poller.poll"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("qrPaymentID", qrPaymentID),
+	)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	scannedAt := from.scannedAt
+	lastStatus := from.status
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			log.Debug("polling stopped on shutdown", "status", lastStatus)
+			return
+		case <-ticker.C:
+		}
+
+		status, err := p.fetchStatus(qrPaymentID)
+		if err != nil {
+			log.Warn("failed to poll payment status", "error", err.Error())
+		} else if status.Status != lastStatus {
+			log.Debug("payment status changed", "from", lastStatus, "to", status.Status)
+			lastStatus = status.Status
+			p.broadcast(qrPaymentID, *status)
+		}
+
+		if domain.IsTerminalPaymentStatus(lastStatus) {
+			log.Debug("payment reached terminal status", "status", lastStatus)
+			return
+		}
+
+		if lastStatus == domain.PaymentStatusWait && scannedAt.IsZero() {
+			scannedAt = time.Now()
+		}
+
+		if p.timedOut(from.started, scannedAt, opts) {
+			p.expire(log, qrPaymentID)
+			return
+		}
+	}
+}
+
+// fetchStatus requests the payment status while holding a concurrency slot
+func (p *Poller) fetchStatus(qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	}
+	defer func() { <-p.sem }()
+
+	ctx, cancel := context.WithTimeout(p.ctx, 30*time.Second)
+	defer cancel()
+
+	return p.statusProvider.GetPaymentStatus(ctx, qrPaymentID)
+}
+
+// timedOut applies the scan timeout until the QR is scanned and the confirmation timeout after that
+func (p *Poller) timedOut(started, scannedAt time.Time, opts domain.PollingOptions) bool {
+	if scannedAt.IsZero() {
+		return opts.ScanTimeout > 0 && time.Since(started) > opts.ScanTimeout
+	}
+
+	return opts.ConfirmationTimeout > 0 && time.Since(scannedAt) > opts.ConfirmationTimeout
+}
+
+func (p *Poller) expire(log *slog.Logger, qrPaymentID int64) {
+	log.Info("payment expired")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := p.statusUpdater.ExpirePayment(ctx, qrPaymentID)
+	if err != nil {
+		log.Error("failed to mark payment as expired", "error", err.Error())
+	}
+
+	p.broadcast(qrPaymentID, domain.PaymentStatusResponse{Status: domain.PaymentStatusExpired})
+}
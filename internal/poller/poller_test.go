@@ -0,0 +1,369 @@
+package poller_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/poller"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type MockStatusProvider struct {
+	GetPaymentStatusFunc func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+}
+
+func (m *MockStatusProvider) GetPaymentStatus(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+	return m.GetPaymentStatusFunc(ctx, qrPaymentID)
+}
+
+type MockStatusUpdater struct {
+	mu      sync.Mutex
+	updated map[int64]string
+}
+
+func (m *MockStatusUpdater) ExpirePayment(ctx context.Context, qrPaymentID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.updated == nil {
+		m.updated = make(map[int64]string)
+	}
+	m.updated[qrPaymentID] = domain.PaymentStatusExpired
+
+	return nil
+}
+
+func (m *MockStatusUpdater) status(qrPaymentID int64) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.updated[qrPaymentID]
+}
+
+type MockPaymentStorage struct {
+	PendingPaymentsFunc func(ctx context.Context) ([]domain.Payment, error)
+}
+
+func (m *MockPaymentStorage) PendingPayments(ctx context.Context) ([]domain.Payment, error) {
+	if m.PendingPaymentsFunc != nil {
+		return m.PendingPaymentsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met in time")
+}
+
+func TestPoller(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+
+	t.Run("stops at terminal status", func(t *testing.T) {
+		var calls atomic.Int32
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				if calls.Add(1) < 3 {
+					return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+				}
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed}, nil
+			},
+		}
+		updater := &MockStatusUpdater{}
+
+		p := poller.New(log, provider, updater, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+		defer p.Stop()
+
+		p.Track(15, domain.PollingOptions{
+			Interval:            time.Millisecond,
+			ScanTimeout:         time.Second,
+			ConfirmationTimeout: time.Second,
+		})
+
+		waitUntil(t, func() bool { return p.Tracked() == 0 })
+
+		if calls.Load() != 3 {
+			t.Errorf("Expected 3 status requests, got %d", calls.Load())
+		}
+
+		if status := updater.status(15); status != "" {
+			t.Errorf("Expected no expiry update, got %s", status)
+		}
+	})
+
+	t.Run("expires payment after scan timeout", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusCreated}, nil
+			},
+		}
+		updater := &MockStatusUpdater{}
+
+		p := poller.New(log, provider, updater, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+		defer p.Stop()
+
+		p.Track(16, domain.PollingOptions{
+			Interval:            time.Millisecond,
+			ScanTimeout:         20 * time.Millisecond,
+			ConfirmationTimeout: time.Second,
+		})
+
+		waitUntil(t, func() bool { return updater.status(16) == domain.PaymentStatusExpired })
+	})
+
+	t.Run("expires payment after confirmation timeout", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+			},
+		}
+		updater := &MockStatusUpdater{}
+
+		p := poller.New(log, provider, updater, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+		defer p.Stop()
+
+		p.Track(17, domain.PollingOptions{
+			Interval:            time.Millisecond,
+			ScanTimeout:         time.Hour,
+			ConfirmationTimeout: 20 * time.Millisecond,
+		})
+
+		waitUntil(t, func() bool { return updater.status(17) == domain.PaymentStatusExpired })
+	})
+
+	t.Run("limits concurrent status requests", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+
+				for {
+					current := maxInFlight.Load()
+					if n <= current || maxInFlight.CompareAndSwap(current, n) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed}, nil
+			},
+		}
+
+		p := poller.New(log, provider, &MockStatusUpdater{}, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+		defer p.Stop()
+
+		for id := int64(1); id <= 10; id++ {
+			p.Track(id, domain.PollingOptions{Interval: time.Millisecond})
+		}
+
+		waitUntil(t, func() bool { return p.Tracked() == 0 })
+
+		if maxInFlight.Load() > 2 {
+			t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+		}
+	})
+
+	t.Run("stop ends tracking", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+			},
+		}
+
+		p := poller.New(log, provider, &MockStatusUpdater{}, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+
+		p.Track(18, domain.PollingOptions{Interval: time.Millisecond})
+		p.Stop()
+
+		if p.Tracked() != 0 {
+			t.Errorf("Expected no tracked payments after stop, got %d", p.Tracked())
+		}
+
+		p.Track(19, domain.PollingOptions{Interval: time.Millisecond})
+		if p.Tracked() != 0 {
+			t.Errorf("Expected stopped poller to ignore new payments, got %d", p.Tracked())
+		}
+	})
+
+	t.Run("subscribers share one poll and receive transitions", func(t *testing.T) {
+		var calls atomic.Int32
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				switch calls.Add(1) {
+				case 1:
+					return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+				case 2:
+					return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+				default:
+					return &domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed, TransactionID: "35134863"}, nil
+				}
+			},
+		}
+
+		p := poller.New(log, provider, &MockStatusUpdater{}, &MockPaymentStorage{}, 2, domain.PollingOptions{Interval: 10 * time.Millisecond})
+		defer p.Stop()
+
+		first, cancelFirst := p.Subscribe(20)
+		defer cancelFirst()
+		second, cancelSecond := p.Subscribe(20)
+		defer cancelSecond()
+
+		if p.Tracked() != 1 {
+			t.Fatalf("Expected 1 tracked payment, got %d", p.Tracked())
+		}
+
+		for _, ch := range []<-chan domain.PaymentStatusResponse{first, second} {
+			var statuses []string
+			for status := range ch {
+				statuses = append(statuses, status.Status)
+			}
+
+			if len(statuses) != 2 || statuses[0] != domain.PaymentStatusWait || statuses[1] != domain.PaymentStatusProcessed {
+				t.Errorf("Expected [Wait Processed], got %v", statuses)
+			}
+		}
+
+		if calls.Load() != 3 {
+			t.Errorf("Expected 3 status requests, got %d", calls.Load())
+		}
+	})
+
+	t.Run("subscribers receive expiry", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusCreated}, nil
+			},
+		}
+
+		p := poller.New(log, provider, &MockStatusUpdater{}, &MockPaymentStorage{}, 2, domain.PollingOptions{
+			Interval:    time.Millisecond,
+			ScanTimeout: 20 * time.Millisecond,
+		})
+		defer p.Stop()
+
+		ch, cancel := p.Subscribe(21)
+		defer cancel()
+
+		var last string
+		for status := range ch {
+			last = status.Status
+		}
+
+		if last != domain.PaymentStatusExpired {
+			t.Errorf("Expected last status Expired, got %s", last)
+		}
+	})
+
+	t.Run("subscribe on stopped poller returns closed channel", func(t *testing.T) {
+		p := poller.New(log, &MockStatusProvider{}, &MockStatusUpdater{}, &MockPaymentStorage{}, 2, domain.PollingOptions{})
+		p.Stop()
+
+		ch, cancel := p.Subscribe(22)
+		defer cancel()
+
+		if _, ok := <-ch; ok {
+			t.Error("Expected closed channel")
+		}
+	})
+
+	t.Run("start resumes pending payments", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				if qrPaymentID == 23 {
+					return &domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed}, nil
+				}
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusCreated}, nil
+			},
+		}
+		updater := &MockStatusUpdater{}
+		storage := &MockPaymentStorage{
+			PendingPaymentsFunc: func(ctx context.Context) ([]domain.Payment, error) {
+				return []domain.Payment{
+					{QrPaymentID: 23, Status: domain.PaymentStatusWait, CreatedAt: time.Now()},
+					// created before the restart with its scan timeout already spent
+					{QrPaymentID: 24, Status: domain.PaymentStatusCreated, ScanWaitTimeout: 1, CreatedAt: time.Now().Add(-time.Minute)},
+				}, nil
+			},
+		}
+
+		p := poller.New(log, provider, updater, storage, 2, domain.PollingOptions{Interval: time.Millisecond})
+		defer p.Stop()
+
+		if err := p.Start(context.Background()); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+
+		waitUntil(t, func() bool { return p.Tracked() == 0 })
+
+		if status := updater.status(23); status != "" {
+			t.Errorf("Expected processed payment not to expire, got %s", status)
+		}
+		if status := updater.status(24); status != domain.PaymentStatusExpired {
+			t.Errorf("Expected stale payment to expire, got %s", status)
+		}
+	})
+
+	t.Run("start keeps the scan time of payments in wait", func(t *testing.T) {
+		provider := &MockStatusProvider{
+			GetPaymentStatusFunc: func(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+				return &domain.PaymentStatusResponse{Status: domain.PaymentStatusWait}, nil
+			},
+		}
+		updater := &MockStatusUpdater{}
+		storage := &MockPaymentStorage{
+			PendingPaymentsFunc: func(ctx context.Context) ([]domain.Payment, error) {
+				// scanned before the restart with its confirmation timeout already spent
+				return []domain.Payment{{
+					QrPaymentID:                25,
+					Status:                     domain.PaymentStatusWait,
+					ScanWaitTimeout:            3600,
+					PaymentConfirmationTimeout: 30,
+					CreatedAt:                  time.Now().Add(-time.Minute),
+					UpdatedAt:                  time.Now().Add(-time.Minute),
+				}}, nil
+			},
+		}
+
+		p := poller.New(log, provider, updater, storage, 2, domain.PollingOptions{Interval: time.Millisecond})
+		defer p.Stop()
+
+		if err := p.Start(context.Background()); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+
+		waitUntil(t, func() bool { return p.Tracked() == 0 })
+
+		if status := updater.status(25); status != domain.PaymentStatusExpired {
+			t.Errorf("Expected payment scanned before the restart to expire, got %s", status)
+		}
+	})
+
+	t.Run("start fails on storage error", func(t *testing.T) {
+		storage := &MockPaymentStorage{
+			PendingPaymentsFunc: func(ctx context.Context) ([]domain.Payment, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		p := poller.New(log, &MockStatusProvider{}, &MockStatusUpdater{}, storage, 2, domain.PollingOptions{})
+		defer p.Stop()
+
+		if err := p.Start(context.Background()); err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
@@ -0,0 +1,328 @@
+package qrimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/skip2/go-qrcode"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// logoRatio is the part of the QR code width covered by the logo, the logo area with its
+// padding stays well below the 25% of modules that level Q restores
+const logoRatio = 0.2
+
+// PaymentStorage gives access to stored payments and their tokens
+type PaymentStorage interface {
+	Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error)
+}
+
+// Config holds rendering defaults and limits
+type Config struct {
+	DefaultSize            int
+	MaxSize                int
+	DefaultMargin          int
+	DefaultErrorCorrection string
+	LogoFile               string
+}
+
+// Renderer renders stored QR tokens and payment links as PNG or SVG images
+type Renderer struct {
+	log     *slog.Logger
+	storage PaymentStorage
+	cfg     Config
+
+	logo    image.Image
+	logoPNG []byte
+}
+
+// New creates a renderer, the logo is loaded once and used for requests that ask for it
+func New(log *slog.Logger, storage PaymentStorage, cfg Config) (*Renderer, error) {
+	const op = "qrimage.New"
+
+	if cfg.DefaultSize <= 0 {
+		cfg.DefaultSize = 256
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 2048
+	}
+	if cfg.DefaultMargin < 0 {
+		cfg.DefaultMargin = 4
+	}
+	if cfg.DefaultErrorCorrection == "" {
+		cfg.DefaultErrorCorrection = domain.QRErrorCorrectionMedium
+	}
+
+	r := &Renderer{
+		log:     log,
+		storage: storage,
+		cfg:     cfg,
+	}
+
+	if cfg.LogoFile != "" {
+		logo, err := loadLogo(cfg.LogoFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var buf bytes.Buffer
+		if err = png.Encode(&buf, logo); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.logo = logo
+		r.logoPNG = buf.Bytes()
+	}
+
+	return r, nil
+}
+
+// RenderQR renders the QR token of a stored payment, payment links are encoded as their URL
+func (r *Renderer) RenderQR(ctx context.Context, qrPaymentID int64, opts domain.QRImageOptions) (*domain.QRImage, error) {
+	const op = "qrimage.RenderQR"
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("qrPaymentID", qrPaymentID),
+	)
+
+	if qrPaymentID <= 0 {
+		return nil, &validator.ValidationError{
+			Field:   "qrPaymentId",
+			Message: "Invalid payment ID format",
+			Err:     validator.ErrInvalidID,
+		}
+	}
+
+	opts = r.withDefaults(opts)
+	if err := validator.ValidateQRImageOptions(opts, r.cfg.MaxSize); err != nil {
+		log.Warn("invalid QR image options", "error", err.Error())
+		return nil, err
+	}
+
+	if opts.Logo && r.logo == nil {
+		return nil, &validator.ValidationError{
+			Field:   "logo",
+			Message: "logo is not configured",
+			Err:     validator.ErrInvalidValue,
+		}
+	}
+
+	payment, err := r.storage.Payment(ctx, qrPaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	content := payment.QrToken
+	if content == "" {
+		content = payment.PaymentLink
+	}
+	if content == "" {
+		return nil, fmt.Errorf("%s: QR token %w", op, domain.ErrNotFound)
+	}
+
+	code, err := qrcode.New(content, recoveryLevel(opts))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	code.DisableBorder = true
+
+	modules := code.Bitmap()
+
+	var img *domain.QRImage
+	switch opts.Format {
+	case domain.QRImageFormatSVG:
+		img = r.renderSVG(modules, opts)
+	default:
+		img, err = r.renderPNG(modules, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("QR image rendered", "format", opts.Format, "size", opts.Size)
+
+	return img, nil
+}
+
+func (r *Renderer) withDefaults(opts domain.QRImageOptions) domain.QRImageOptions {
+	opts.Format = strings.ToLower(opts.Format)
+	if opts.Format == "" {
+		opts.Format = domain.QRImageFormatPNG
+	}
+
+	if opts.Size == 0 {
+		opts.Size = r.cfg.DefaultSize
+	}
+
+	if opts.Margin == nil {
+		margin := r.cfg.DefaultMargin
+		opts.Margin = &margin
+	}
+
+	opts.ErrorCorrection = strings.ToUpper(opts.ErrorCorrection)
+	if opts.ErrorCorrection == "" {
+		opts.ErrorCorrection = r.cfg.DefaultErrorCorrection
+	}
+
+	return opts
+}
+
+// recoveryLevel maps the error correction level, a logo hides the center of the code
+// so at least level Q is used with it
+func recoveryLevel(opts domain.QRImageOptions) qrcode.RecoveryLevel {
+	switch {
+	case opts.ErrorCorrection == domain.QRErrorCorrectionHigh:
+		return qrcode.Highest
+	case opts.ErrorCorrection == domain.QRErrorCorrectionQuarter || opts.Logo:
+		return qrcode.High
+	case opts.ErrorCorrection == domain.QRErrorCorrectionLow:
+		return qrcode.Low
+	default:
+		return qrcode.Medium
+	}
+}
+
+// renderPNG scales modules by a whole number of pixels and centers the code in the image
+func (r *Renderer) renderPNG(modules [][]bool, opts domain.QRImageOptions) (*domain.QRImage, error) {
+	count := len(modules)
+	total := count + 2**opts.Margin
+
+	scale := opts.Size / total
+	if scale < 1 {
+		return nil, &validator.ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("size is too small for the QR code, at least %d pixels are required", total),
+			Err:     validator.ErrInvalidValue,
+		}
+	}
+
+	offset := (opts.Size - count*scale) / 2
+
+	img := image.NewRGBA(image.Rect(0, 0, opts.Size, opts.Size))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+
+			rect := image.Rect(offset+x*scale, offset+y*scale, offset+(x+1)*scale, offset+(y+1)*scale)
+			draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+		}
+	}
+
+	if opts.Logo {
+		logoSize := int(float64(count*scale) * logoRatio)
+		logoOffset := (opts.Size - logoSize) / 2
+
+		background := image.Rect(logoOffset-scale, logoOffset-scale, logoOffset+logoSize+scale, logoOffset+logoSize+scale)
+		draw.Draw(img, background, image.White, image.Point{}, draw.Src)
+
+		logo := scaleImage(r.logo, logoSize)
+		draw.Draw(img, image.Rect(logoOffset, logoOffset, logoOffset+logoSize, logoOffset+logoSize), logo, image.Point{}, draw.Over)
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return &domain.QRImage{
+		ContentType: "image/png",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// renderSVG draws the code in module units, runs of dark modules in a row are merged into one rectangle
+func (r *Renderer) renderSVG(modules [][]bool, opts domain.QRImageOptions) *domain.QRImage {
+	count := len(modules)
+	margin := *opts.Margin
+	total := count + 2*margin
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, total, total)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#ffffff"/>`, total, total)
+
+	sb.WriteString(`<path fill="#000000" d="`)
+	for y, row := range modules {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+
+			fmt.Fprintf(&sb, "M%d %dh%dv1h-%dz", margin+start, margin+y, x-start, x-start)
+		}
+	}
+	sb.WriteString(`"/>`)
+
+	if opts.Logo {
+		logoSize := float64(count) * logoRatio
+		logoOffset := (float64(total) - logoSize) / 2
+
+		fmt.Fprintf(&sb, `<rect x="%g" y="%g" width="%g" height="%g" fill="#ffffff"/>`,
+			logoOffset-1, logoOffset-1, logoSize+2, logoSize+2)
+		fmt.Fprintf(&sb, `<image x="%g" y="%g" width="%g" height="%g" href="data:image/png;base64,%s"/>`,
+			logoOffset, logoOffset, logoSize, logoSize, base64.StdEncoding.EncodeToString(r.logoPNG))
+	}
+
+	sb.WriteString(`</svg>`)
+
+	return &domain.QRImage{
+		ContentType: "image/svg+xml",
+		Data:        []byte(sb.String()),
+	}
+}
+
+func loadLogo(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	logo, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("decode logo %s: %w", path, err)
+	}
+
+	return logo, nil
+}
+
+// scaleImage resizes the image to a square using nearest neighbor sampling
+func scaleImage(src image.Image, size int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	if size <= 0 {
+		return dst
+	}
+
+	bounds := src.Bounds()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			sx := bounds.Min.X + x*bounds.Dx()/size
+			sy := bounds.Min.Y + y*bounds.Dy()/size
+			dst.Set(x, y, color.RGBAModel.Convert(src.At(sx, sy)))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,198 @@
+package qrimage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/qrimage"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type MockPaymentStorage struct {
+	payments map[int64]domain.Payment
+}
+
+func (m *MockPaymentStorage) Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+	payment, ok := m.payments[qrPaymentID]
+	if !ok {
+		return nil, storage.ErrPaymentNotFound
+	}
+	return &payment, nil
+}
+
+func newStorage() *MockPaymentStorage {
+	return &MockPaymentStorage{payments: map[int64]domain.Payment{
+		15: {QrPaymentID: 15, Kind: domain.PaymentKindQR, QrToken: "51236903777280167836178166503744993984459"},
+		16: {QrPaymentID: 16, Kind: domain.PaymentKindLink, PaymentLink: "https://pay.kaspi.kz/pay/123456789"},
+		17: {QrPaymentID: 17, Kind: domain.PaymentKindRemote},
+	}}
+}
+
+func newRenderer(t *testing.T, cfg qrimage.Config) *qrimage.Renderer {
+	t.Helper()
+
+	renderer, err := qrimage.New(slogdiscard.NewDiscardLogger(), newStorage(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create renderer: %v", err)
+	}
+
+	return renderer
+}
+
+func writeLogo(t *testing.T) string {
+	t.Helper()
+
+	logo := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			logo.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create logo: %v", err)
+	}
+	defer file.Close()
+
+	if err = png.Encode(file, logo); err != nil {
+		t.Fatalf("Failed to encode logo: %v", err)
+	}
+
+	return path
+}
+
+func TestRenderQR(t *testing.T) {
+	t.Run("renders PNG of the requested size", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{DefaultMargin: 4})
+
+		img, err := renderer.RenderQR(context.Background(), 15, domain.QRImageOptions{Size: 300})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if img.ContentType != "image/png" {
+			t.Errorf("Expected content type image/png, got %s", img.ContentType)
+		}
+
+		decoded, err := png.Decode(bytes.NewReader(img.Data))
+		if err != nil {
+			t.Fatalf("Failed to decode PNG: %v", err)
+		}
+
+		if decoded.Bounds().Dx() != 300 || decoded.Bounds().Dy() != 300 {
+			t.Errorf("Expected 300x300 image, got %v", decoded.Bounds())
+		}
+
+		// quiet zone stays white
+		if r, _, _, _ := decoded.At(0, 0).RGBA(); r != 0xffff {
+			t.Error("Expected white quiet zone")
+		}
+	})
+
+	t.Run("renders SVG", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{})
+
+		margin := 0
+		img, err := renderer.RenderQR(context.Background(), 15, domain.QRImageOptions{
+			Format:          "svg",
+			Size:            200,
+			Margin:          &margin,
+			ErrorCorrection: "h",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		svg := string(img.Data)
+		if img.ContentType != "image/svg+xml" || !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `width="200"`) {
+			t.Errorf("Unexpected SVG: %s", svg)
+		}
+
+		// without margin the top left finder pattern starts at the origin
+		if !strings.Contains(svg, `d="M0 0h7v1h-7z`) {
+			t.Errorf("Expected finder pattern at the origin, got %s", svg)
+		}
+	})
+
+	t.Run("renders payment link", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{})
+
+		if _, err := renderer.RenderQR(context.Background(), 16, domain.QRImageOptions{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("renders logo", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{LogoFile: writeLogo(t)})
+
+		img, err := renderer.RenderQR(context.Background(), 15, domain.QRImageOptions{Size: 400, Logo: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		decoded, err := png.Decode(bytes.NewReader(img.Data))
+		if err != nil {
+			t.Fatalf("Failed to decode PNG: %v", err)
+		}
+
+		r, g, _, _ := decoded.At(200, 200).RGBA()
+		if r != 0xffff || g != 0 {
+			t.Errorf("Expected logo in the center, got %v", decoded.At(200, 200))
+		}
+
+		svg, err := renderer.RenderQR(context.Background(), 15, domain.QRImageOptions{Format: "svg", Logo: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !strings.Contains(string(svg.Data), "data:image/png;base64,") {
+			t.Error("Expected embedded logo in SVG")
+		}
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{MaxSize: 1000})
+
+		margin := 20
+		testCases := []domain.QRImageOptions{
+			{Format: "gif"},
+			{Size: 1001},
+			{Size: 20},
+			{Margin: &margin},
+			{ErrorCorrection: "X"},
+			{Logo: true},
+		}
+
+		for _, opts := range testCases {
+			_, err := renderer.RenderQR(context.Background(), 15, opts)
+
+			var valErr *validator.ValidationError
+			if !errors.As(err, &valErr) {
+				t.Errorf("Expected validation error for %+v, got %v", opts, err)
+			}
+		}
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		renderer := newRenderer(t, qrimage.Config{})
+
+		for _, id := range []int64{1, 17} {
+			_, err := renderer.RenderQR(context.Background(), id, domain.QRImageOptions{})
+			if !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for %d, got %v", id, err)
+			}
+		}
+	})
+}
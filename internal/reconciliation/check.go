@@ -0,0 +1,258 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// check is the state of a single run
+type check struct {
+	*Reconciler
+	log *slog.Logger
+	run *domain.ReconciliationRun
+
+	// details are loaded once per payment, they are needed for both amount and refund checks
+	details map[int64]*domain.PaymentDetailsResponse
+}
+
+// all checks payments and, where the scheme allows it, refunds of the run period. Errors that make
+// every further request fail too, such as an open circuit or a stopped service, abort the run
+func (c *check) all(ctx context.Context) error {
+	payments, err := c.storage.PaymentsCreatedBetween(ctx, c.run.From, c.run.To)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if err = c.payment(ctx, payment); err != nil {
+			return err
+		}
+		c.run.PaymentsChecked++
+	}
+
+	if !c.hasPaymentDetails() {
+		return nil
+	}
+
+	// refund QRs only exist in the standard scheme
+	if c.cfg.Scheme == "standard" {
+		refundQRs, err := c.storage.RefundQRsCreatedBetween(ctx, c.run.From, c.run.To)
+		if err != nil {
+			return err
+		}
+
+		for _, refundQR := range refundQRs {
+			if err = c.refundQR(ctx, refundQR); err != nil {
+				return err
+			}
+			c.run.RefundsChecked++
+		}
+	}
+
+	totals, err := c.storage.RefundTotals(ctx, c.run.From, c.run.To)
+	if err != nil {
+		return err
+	}
+
+	for _, total := range totals {
+		if err = c.refundTotal(ctx, total); err != nil {
+			return err
+		}
+		c.run.RefundsChecked++
+	}
+
+	return nil
+}
+
+// payment compares the status and, for paid payments, the amount
+func (c *check) payment(ctx context.Context, payment domain.Payment) error {
+	status, err := c.provider.GetPaymentStatus(ctx, payment.QrPaymentID)
+	if err != nil {
+		return c.upstreamError(ctx, err, domain.ReconciliationDiscrepancy{
+			Entity:      domain.ReconciliationEntityPayment,
+			QrPaymentID: payment.QrPaymentID,
+			LocalValue:  payment.Status,
+		})
+	}
+
+	if statusDiffers(payment.Status, status.Status) {
+		c.discrepancy(ctx, domain.ReconciliationDiscrepancy{
+			Kind:        domain.DiscrepancyStatusMismatch,
+			Entity:      domain.ReconciliationEntityPayment,
+			QrPaymentID: payment.QrPaymentID,
+			LocalValue:  payment.Status,
+			RemoteValue: status.Status,
+			Message:     fmt.Sprintf("payment is %s locally but %s in Kaspi", payment.Status, status.Status),
+		})
+	}
+
+	if !c.hasPaymentDetails() || status.Status != domain.PaymentStatusProcessed {
+		return nil
+	}
+
+	details, err := c.paymentDetails(ctx, payment.QrPaymentID, payment.DeviceToken)
+	if err != nil {
+		return c.upstreamError(ctx, err, domain.ReconciliationDiscrepancy{
+			Entity:      domain.ReconciliationEntityPayment,
+			QrPaymentID: payment.QrPaymentID,
+		})
+	}
+
+	if !sameAmount(payment.Amount, details.TotalAmount) {
+		c.discrepancy(ctx, domain.ReconciliationDiscrepancy{
+			Kind:        domain.DiscrepancyAmountMismatch,
+			Entity:      domain.ReconciliationEntityPayment,
+			QrPaymentID: payment.QrPaymentID,
+			LocalValue:  formatAmount(payment.Amount),
+			RemoteValue: formatAmount(details.TotalAmount),
+			Message:     "payment amount differs from the amount paid in Kaspi",
+		})
+	}
+
+	return nil
+}
+
+// refundQR compares the status of a refund QR
+func (c *check) refundQR(ctx context.Context, refundQR domain.RefundQR) error {
+	status, err := c.provider.GetRefundStatus(ctx, refundQR.QrReturnID)
+	if err != nil {
+		return c.upstreamError(ctx, err, domain.ReconciliationDiscrepancy{
+			Entity:     domain.ReconciliationEntityRefundQR,
+			QrReturnID: refundQR.QrReturnID,
+			LocalValue: refundQR.Status,
+		})
+	}
+
+	if statusDiffers(refundQR.Status, status.Status) {
+		c.discrepancy(ctx, domain.ReconciliationDiscrepancy{
+			Kind:        domain.DiscrepancyStatusMismatch,
+			Entity:      domain.ReconciliationEntityRefundQR,
+			QrReturnID:  refundQR.QrReturnID,
+			LocalValue:  refundQR.Status,
+			RemoteValue: status.Status,
+			Message:     fmt.Sprintf("refund QR is %s locally but %s in Kaspi", refundQR.Status, status.Status),
+		})
+	}
+
+	return nil
+}
+
+// refundTotal compares the refunds recorded in the ledger with the amount Kaspi has refunded
+func (c *check) refundTotal(ctx context.Context, total domain.RefundTotal) error {
+	details, err := c.paymentDetails(ctx, total.QrPaymentID, total.DeviceToken)
+	if err != nil {
+		return c.upstreamError(ctx, err, domain.ReconciliationDiscrepancy{
+			Entity:      domain.ReconciliationEntityRefunds,
+			QrPaymentID: total.QrPaymentID,
+			LocalValue:  formatAmount(total.Amount),
+		})
+	}
+
+	refunded := details.TotalAmount - details.AvailableReturnAmount
+
+	// refunds made outside the wrapper, e.g. in the Kaspi Pay app, make Kaspi report more
+	if total.Amount > refunded && !sameAmount(total.Amount, refunded) {
+		c.discrepancy(ctx, domain.ReconciliationDiscrepancy{
+			Kind:        domain.DiscrepancyRefundMissing,
+			Entity:      domain.ReconciliationEntityRefunds,
+			QrPaymentID: total.QrPaymentID,
+			LocalValue:  formatAmount(total.Amount),
+			RemoteValue: formatAmount(refunded),
+			Message:     "refunds recorded locally exceed the amount refunded in Kaspi",
+		})
+	}
+
+	return nil
+}
+
+// hasPaymentDetails reports whether Kaspi returns payment details in the scheme,
+// they are available in the standard and enhanced schemes
+func (c *check) hasPaymentDetails() bool {
+	return c.cfg.Scheme == "standard" || c.cfg.Scheme == "enhanced"
+}
+
+func (c *check) paymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+	if details, ok := c.details[qrPaymentID]; ok {
+		return details, nil
+	}
+
+	details, err := c.provider.GetPaymentDetails(ctx, qrPaymentID, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c.details[qrPaymentID] = details
+
+	return details, nil
+}
+
+// upstreamError records a Kaspi rejection as a discrepancy of the record and returns
+// any other error, which aborts the run
+func (c *check) upstreamError(ctx context.Context, err error, discrepancy domain.ReconciliationDiscrepancy) error {
+	kaspiErr, ok := domain.IsKaspiError(err)
+	if !ok || errors.Is(err, domain.ErrCircuitOpen) || ctx.Err() != nil {
+		return err
+	}
+
+	switch kaspiErr.StatusCode {
+	case -1601, -99000001:
+		// Purchase not found
+		discrepancy.Kind = domain.DiscrepancyNotFound
+		discrepancy.Message = "record is not known to Kaspi"
+	case -999:
+		// Service temporarily unavailable
+		return err
+	default:
+		discrepancy.Kind = domain.DiscrepancyCheckFailed
+		discrepancy.Message = kaspiErr.Error()
+	}
+
+	c.discrepancy(ctx, discrepancy)
+
+	return nil
+}
+
+// discrepancy stores a mismatch of the run, a failure to store it is logged and the run goes on
+func (c *check) discrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) {
+	discrepancy.RunID = c.run.ID
+	discrepancy.CreatedAt = time.Now()
+
+	c.log.Warn("reconciliation discrepancy",
+		"kind", discrepancy.Kind,
+		"entity", discrepancy.Entity,
+		"qrPaymentID", discrepancy.QrPaymentID,
+		"qrReturnID", discrepancy.QrReturnID,
+		"local", discrepancy.LocalValue,
+		"remote", discrepancy.RemoteValue,
+	)
+
+	if err := c.storage.SaveReconciliationDiscrepancy(ctx, discrepancy); err != nil {
+		c.log.Error("failed to save reconciliation discrepancy", "error", err.Error())
+		return
+	}
+
+	c.run.Discrepancies++
+}
+
+// statusDiffers reports whether a local status contradicts the Kaspi one. Expired and Canceled
+// are set by the wrapper and only contradict a payment that Kaspi reports as paid
+func statusDiffers(local, remote string) bool {
+	if local == remote {
+		return false
+	}
+
+	switch local {
+	case domain.PaymentStatusExpired, domain.PaymentStatusCanceled:
+		return remote == domain.PaymentStatusProcessed
+	default:
+		return true
+	}
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
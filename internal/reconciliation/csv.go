@@ -0,0 +1,62 @@
+package reconciliation
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"RunId", "Kind", "Entity", "QrPaymentId", "QrReturnId", "LocalValue", "RemoteValue", "Message", "CreatedAt",
+}
+
+// ExportReconciliationRun returns the discrepancies of a run as CSV
+func (r *Reconciler) ExportReconciliationRun(ctx context.Context, runID int64) ([]byte, error) {
+	const op = "reconciliation.ExportReconciliationRun"
+
+	report, err := r.GetReconciliationRun(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err = w.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, d := range report.Items {
+		err = w.Write([]string{
+			strconv.FormatInt(d.RunID, 10),
+			d.Kind,
+			d.Entity,
+			formatID(d.QrPaymentID),
+			formatID(d.QrReturnID),
+			d.LocalValue,
+			d.RemoteValue,
+			d.Message,
+			d.CreatedAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	w.Flush()
+	if err = w.Error(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,419 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxPeriod limits manual runs so that a single run does not hammer Kaspi for hours
+const maxPeriod = 31 * 24 * time.Hour
+
+// runLease is how long a running run stays owned by its instance without a heartbeat, an instance
+// that starts fails the runs of other instances only when their lease expired
+const runLease = 5 * time.Minute
+
+// KaspiProvider re-queries Kaspi for the state of locally recorded operations
+type KaspiProvider interface {
+	GetPaymentStatus(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error)
+	GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
+	GetRefundStatus(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error)
+}
+
+type Storage interface {
+	PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error)
+	RefundQRsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.RefundQR, error)
+	RefundTotals(ctx context.Context, from, to time.Time) ([]domain.RefundTotal, error)
+
+	CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) (*domain.ReconciliationRun, error)
+	FinishReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error
+	RenewReconciliationRun(ctx context.Context, id int64, heartbeatAt time.Time) error
+	InterruptReconciliationRuns(ctx context.Context, owner string, staleBefore time.Time, reason string) (int64, error)
+	SaveReconciliationDiscrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) error
+	ReconciliationRun(ctx context.Context, id int64) (*domain.ReconciliationRun, error)
+	ReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error)
+	ReconciliationDiscrepancies(ctx context.Context, runID int64) ([]domain.ReconciliationDiscrepancy, error)
+}
+
+// Config holds the Kaspi scheme, which decides what can be checked, and the nightly schedule
+type Config struct {
+	Scheme   string
+	RunAt    string // time of day of the nightly run as HH:MM, empty disables the schedule
+	Location *time.Location
+	Instance string // owner of the runs started here, the host name by default
+}
+
+// Reconciler compares payments and refunds recorded locally with their state in Kaspi
+// and stores every mismatch as a discrepancy of a reconciliation run
+type Reconciler struct {
+	log      *slog.Logger
+	provider KaspiProvider
+	storage  Storage
+	cfg      Config
+	runAt    time.Duration // offset of the nightly run from midnight
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running bool
+}
+
+func New(log *slog.Logger, provider KaspiProvider, storage Storage, cfg Config) (*Reconciler, error) {
+	const op = "reconciliation.New"
+
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+
+	if cfg.Instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		cfg.Instance = hostname
+	}
+
+	var runAt time.Duration
+	if cfg.RunAt != "" {
+		t, err := time.Parse("15:04", cfg.RunAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid run time %q: %w", op, cfg.RunAt, err)
+		}
+		runAt = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Reconciler{
+		log:      log,
+		provider: provider,
+		storage:  storage,
+		cfg:      cfg,
+		runAt:    runAt,
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// Start fails runs interrupted by a previous shutdown of this instance or abandoned by another one,
+// and starts the nightly schedule
+func (r *Reconciler) Start(ctx context.Context) error {
+	const op = "reconciliation.Start"
+
+	log := r.log.With(slog.String("op", op))
+
+	interrupted, err := r.storage.InterruptReconciliationRuns(ctx, r.cfg.Instance, time.Now().Add(-runLease), "interrupted by shutdown")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if interrupted > 0 {
+		log.Warn("interrupted reconciliation runs marked as failed", "count", interrupted)
+	}
+
+	if r.cfg.RunAt == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		r.schedule()
+	}()
+
+	log.Info("nightly reconciliation scheduled", "runAt", r.cfg.RunAt, "location", r.cfg.Location.String())
+
+	return nil
+}
+
+// Stop stops the schedule and waits for the current run to finish
+func (r *Reconciler) Stop() {
+	r.log.Info("stopping reconciler", slog.String("op", "reconciliation.Stop"))
+
+	// cancel under the lock so that a run never adds to the wait group after Wait started
+	r.mu.Lock()
+	r.cancel()
+	r.mu.Unlock()
+
+	r.wg.Wait()
+}
+
+// StartReconciliation starts a run for the requested period in the background and returns it
+func (r *Reconciler) StartReconciliation(ctx context.Context, req domain.ReconciliationRequest) (*domain.ReconciliationRun, error) {
+	const op = "reconciliation.StartReconciliation"
+
+	if req.From.IsZero() && req.To.IsZero() {
+		req.From, req.To = r.previousDay(time.Now())
+	}
+
+	if err := validator.ValidateReconciliationRequest(req, maxPeriod); err != nil {
+		return nil, err
+	}
+
+	run, err := r.begin(ctx, req, domain.ReconciliationTriggerManual)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	go r.execute(*run)
+
+	return run, nil
+}
+
+// Reconcile runs a reconciliation for the period and waits for its result
+func (r *Reconciler) Reconcile(ctx context.Context, req domain.ReconciliationRequest, trigger string) (*domain.ReconciliationRun, error) {
+	const op = "reconciliation.Reconcile"
+
+	run, err := r.begin(ctx, req, trigger)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	finished := r.execute(*run)
+
+	return &finished, nil
+}
+
+// ListReconciliationRuns returns the latest runs, newest first
+func (r *Reconciler) ListReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	const op = "reconciliation.ListReconciliationRuns"
+
+	if limit <= 0 || limit > 100 {
+		return nil, &validator.ValidationError{
+			Field:   "limit",
+			Message: "limit must be between 1 and 100",
+			Err:     validator.ErrInvalidValue,
+		}
+	}
+
+	runs, err := r.storage.ReconciliationRuns(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if runs == nil {
+		runs = []domain.ReconciliationRun{}
+	}
+
+	return runs, nil
+}
+
+// GetReconciliationRun returns a run with its discrepancies
+func (r *Reconciler) GetReconciliationRun(ctx context.Context, runID int64) (*domain.ReconciliationReport, error) {
+	const op = "reconciliation.GetReconciliationRun"
+
+	run, err := r.storage.ReconciliationRun(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	discrepancies, err := r.storage.ReconciliationDiscrepancies(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if discrepancies == nil {
+		discrepancies = []domain.ReconciliationDiscrepancy{}
+	}
+
+	return &domain.ReconciliationReport{
+		ReconciliationRun: *run,
+		Items:             discrepancies,
+	}, nil
+}
+
+// begin claims the reconciler for a single run and records the run as running,
+// the run must be finished with execute
+func (r *Reconciler) begin(ctx context.Context, req domain.ReconciliationRequest, trigger string) (*domain.ReconciliationRun, error) {
+	r.mu.Lock()
+	if r.ctx.Err() != nil {
+		r.mu.Unlock()
+		return nil, errors.New("reconciler is stopped")
+	}
+	if r.running {
+		r.mu.Unlock()
+		return nil, domain.ErrReconciliationRunning
+	}
+	r.running = true
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	run, err := r.storage.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   trigger,
+		From:      req.From,
+		To:        req.To,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: time.Now(),
+		Owner:     r.cfg.Instance,
+	})
+	if err != nil {
+		r.release()
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (r *Reconciler) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.running = false
+	r.wg.Done()
+}
+
+// execute checks all records of the run period and stores the outcome of the run
+func (r *Reconciler) execute(run domain.ReconciliationRun) domain.ReconciliationRun {
+	const op = "reconciliation.execute"
+
+	defer r.release()
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("runID", run.ID),
+	)
+
+	log.Info("reconciliation started", "from", run.From, "to", run.To, "trigger", run.Trigger)
+
+	c := &check{
+		Reconciler: r,
+		log:        log,
+		run:        &run,
+		details:    make(map[int64]*domain.PaymentDetailsResponse),
+	}
+
+	stopHeartbeat := r.heartbeat(log, run.ID)
+	err := c.all(r.ctx)
+	stopHeartbeat()
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.ReconciliationCompleted
+	if err != nil {
+		run.Status = domain.ReconciliationFailed
+		run.Error = err.Error()
+		log.Error("reconciliation failed", "error", err.Error())
+	}
+
+	// the run is finished even when it was interrupted by shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err = r.storage.FinishReconciliationRun(ctx, run); err != nil {
+		log.Error("failed to store reconciliation result", "error", err.Error())
+	}
+
+	log.Info("reconciliation finished",
+		"status", run.Status,
+		"payments", run.PaymentsChecked,
+		"refunds", run.RefundsChecked,
+		"discrepancies", run.Discrepancies,
+	)
+
+	return run
+}
+
+// heartbeat renews the lease of the run until the returned function is called
+func (r *Reconciler) heartbeat(log *slog.Logger, runID int64) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(runLease / 5)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := r.storage.RenewReconciliationRun(ctx, runID, time.Now())
+			cancel()
+			if err != nil {
+				log.Warn("failed to renew reconciliation run lease", "error", err.Error())
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// schedule starts a run for the previous day every night at the configured time, every instance
+// sharing the database schedules it and the storage lets only the first one start the run
+func (r *Reconciler) schedule() {
+	const op = "reconciliation.schedule"
+
+	log := r.log.With(slog.String("op", op))
+
+	for {
+		next := r.nextRun(time.Now())
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		from, to := r.previousDay(next)
+
+		_, err := r.Reconcile(r.ctx, domain.ReconciliationRequest{From: from, To: to}, domain.ReconciliationTriggerScheduled)
+		if errors.Is(err, storage.ErrReconciliationRunExists) {
+			log.Info("nightly reconciliation already started by another instance", "from", from, "to", to)
+			continue
+		}
+		if err != nil {
+			log.Error("failed to start nightly reconciliation", "error", err.Error())
+		}
+	}
+}
+
+// nextRun returns the next time of the nightly run after now
+func (r *Reconciler) nextRun(now time.Time) time.Time {
+	now = now.In(r.cfg.Location)
+
+	next := startOfDay(now).Add(r.runAt)
+	if !next.After(now) {
+		next = startOfDay(now.AddDate(0, 0, 1)).Add(r.runAt)
+	}
+
+	return next
+}
+
+// previousDay returns the bounds of the calendar day before t in the configured location
+func (r *Reconciler) previousDay(t time.Time) (time.Time, time.Time) {
+	today := startOfDay(t.In(r.cfg.Location))
+
+	return today.AddDate(0, 0, -1), today
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// sameAmount compares amounts in tenge with tiyn precision
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
@@ -0,0 +1,473 @@
+package reconciliation_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/reconciliation"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type MockStorage struct {
+	mu sync.Mutex
+
+	payments  []domain.Payment
+	refundQRs []domain.RefundQR
+	totals    []domain.RefundTotal
+
+	runs          map[int64]domain.ReconciliationRun
+	discrepancies []domain.ReconciliationDiscrepancy
+
+	interruptedOwner  string
+	interruptedBefore time.Time
+}
+
+func newMockStorage() *MockStorage {
+	return &MockStorage{runs: make(map[int64]domain.ReconciliationRun)}
+}
+
+func (m *MockStorage) PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error) {
+	return m.payments, nil
+}
+
+func (m *MockStorage) RefundQRsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.RefundQR, error) {
+	return m.refundQRs, nil
+}
+
+func (m *MockStorage) RefundTotals(ctx context.Context, from, to time.Time) ([]domain.RefundTotal, error) {
+	return m.totals, nil
+}
+
+func (m *MockStorage) CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) (*domain.ReconciliationRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if run.Trigger == domain.ReconciliationTriggerScheduled {
+		for _, stored := range m.runs {
+			if stored.Trigger == run.Trigger && stored.From.Equal(run.From) && stored.To.Equal(run.To) {
+				return nil, storage.ErrReconciliationRunExists
+			}
+		}
+	}
+
+	run.ID = int64(len(m.runs) + 1)
+	m.runs[run.ID] = run
+
+	return &run, nil
+}
+
+func (m *MockStorage) FinishReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.runs[run.ID] = run
+
+	return nil
+}
+
+func (m *MockStorage) RenewReconciliationRun(ctx context.Context, id int64, heartbeatAt time.Time) error {
+	return nil
+}
+
+func (m *MockStorage) InterruptReconciliationRuns(ctx context.Context, owner string, staleBefore time.Time, reason string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.interruptedOwner, m.interruptedBefore = owner, staleBefore
+
+	return 0, nil
+}
+
+func (m *MockStorage) SaveReconciliationDiscrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	discrepancy.ID = int64(len(m.discrepancies) + 1)
+	m.discrepancies = append(m.discrepancies, discrepancy)
+
+	return nil
+}
+
+func (m *MockStorage) ReconciliationRun(ctx context.Context, id int64) (*domain.ReconciliationRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	run, ok := m.runs[id]
+	if !ok {
+		return nil, storage.ErrReconciliationRunNotFound
+	}
+
+	return &run, nil
+}
+
+func (m *MockStorage) ReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	return nil, nil
+}
+
+func (m *MockStorage) ReconciliationDiscrepancies(ctx context.Context, runID int64) ([]domain.ReconciliationDiscrepancy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []domain.ReconciliationDiscrepancy
+	for _, d := range m.discrepancies {
+		if d.RunID == runID {
+			result = append(result, d)
+		}
+	}
+
+	return result, nil
+}
+
+type MockProvider struct {
+	statuses       map[int64]string
+	details        map[int64]domain.PaymentDetailsResponse
+	refundStatuses map[int64]string
+	errs           map[int64]error
+
+	detailsCalls int
+	block        chan struct{}
+}
+
+func (m *MockProvider) GetPaymentStatus(ctx context.Context, qrPaymentID int64) (*domain.PaymentStatusResponse, error) {
+	if m.block != nil {
+		<-m.block
+	}
+	if err, ok := m.errs[qrPaymentID]; ok {
+		return nil, err
+	}
+	return &domain.PaymentStatusResponse{Status: m.statuses[qrPaymentID]}, nil
+}
+
+func (m *MockProvider) GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+	m.detailsCalls++
+	details := m.details[qrPaymentID]
+	return &details, nil
+}
+
+func (m *MockProvider) GetRefundStatus(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error) {
+	return &domain.RefundStatusResponse{Status: m.refundStatuses[qrReturnID]}, nil
+}
+
+func newReconciler(t *testing.T, provider *MockProvider, store *MockStorage, scheme string) *reconciliation.Reconciler {
+	t.Helper()
+
+	r, err := reconciliation.New(slogdiscard.NewDiscardLogger(), provider, store, reconciliation.Config{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("Failed to create reconciler: %v", err)
+	}
+	t.Cleanup(r.Stop)
+
+	return r
+}
+
+func period() domain.ReconciliationRequest {
+	to := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	return domain.ReconciliationRequest{From: to.AddDate(0, 0, -1), To: to}
+}
+
+func kinds(discrepancies []domain.ReconciliationDiscrepancy) map[int64]string {
+	result := make(map[int64]string)
+	for _, d := range discrepancies {
+		id := d.QrPaymentID
+		if id == 0 {
+			id = d.QrReturnID
+		}
+		result[id] = d.Kind
+	}
+	return result
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("flags status and amount mismatches of payments", func(t *testing.T) {
+		store := newMockStorage()
+		store.payments = []domain.Payment{
+			{QrPaymentID: 1, Status: domain.PaymentStatusWait, Amount: 100},
+			{QrPaymentID: 2, Status: domain.PaymentStatusProcessed, Amount: 200},
+			{QrPaymentID: 3, Status: domain.PaymentStatusProcessed, Amount: 300},
+			{QrPaymentID: 4, Status: domain.PaymentStatusExpired, Amount: 400},
+		}
+		provider := &MockProvider{
+			statuses: map[int64]string{
+				1: domain.PaymentStatusProcessed,
+				2: domain.PaymentStatusProcessed,
+				3: domain.PaymentStatusProcessed,
+				4: domain.PaymentStatusWait,
+			},
+			details: map[int64]domain.PaymentDetailsResponse{
+				1: {TotalAmount: 100},
+				2: {TotalAmount: 150},
+				3: {TotalAmount: 300.001},
+			},
+		}
+
+		r := newReconciler(t, provider, store, "standard")
+
+		run, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if run.Status != domain.ReconciliationCompleted {
+			t.Errorf("Expected completed run, got %s (%s)", run.Status, run.Error)
+		}
+		if run.PaymentsChecked != 4 || run.Discrepancies != 2 {
+			t.Errorf("Expected 4 payments and 2 discrepancies, got %d and %d", run.PaymentsChecked, run.Discrepancies)
+		}
+
+		found := kinds(store.discrepancies)
+		if found[1] != domain.DiscrepancyStatusMismatch {
+			t.Errorf("Expected status mismatch of payment 1, got %q", found[1])
+		}
+		if found[2] != domain.DiscrepancyAmountMismatch {
+			t.Errorf("Expected amount mismatch of payment 2, got %q", found[2])
+		}
+		if _, ok := found[4]; ok {
+			t.Error("Expected an expired payment that is still waiting in Kaspi to match")
+		}
+	})
+
+	t.Run("flags refunds missing in Kaspi", func(t *testing.T) {
+		store := newMockStorage()
+		store.refundQRs = []domain.RefundQR{{QrReturnID: 10, Status: domain.PaymentStatusWait}}
+		store.totals = []domain.RefundTotal{
+			{QrPaymentID: 1, Amount: 50},
+			{QrPaymentID: 2, Amount: 50},
+		}
+		provider := &MockProvider{
+			refundStatuses: map[int64]string{10: domain.PaymentStatusWait},
+			details: map[int64]domain.PaymentDetailsResponse{
+				1: {TotalAmount: 100, AvailableReturnAmount: 80},
+				2: {TotalAmount: 100, AvailableReturnAmount: 20},
+			},
+		}
+
+		r := newReconciler(t, provider, store, "standard")
+
+		run, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if run.RefundsChecked != 3 || run.Discrepancies != 1 {
+			t.Errorf("Expected 3 refunds and 1 discrepancy, got %d and %d", run.RefundsChecked, run.Discrepancies)
+		}
+
+		d := store.discrepancies[0]
+		if d.Kind != domain.DiscrepancyRefundMissing || d.QrPaymentID != 1 {
+			t.Errorf("Expected missing refund of payment 1, got %s of %d", d.Kind, d.QrPaymentID)
+		}
+		if d.LocalValue != "50.00" || d.RemoteValue != "20.00" {
+			t.Errorf("Unexpected amounts %s and %s", d.LocalValue, d.RemoteValue)
+		}
+	})
+
+	t.Run("checks amounts and refunds in the enhanced scheme", func(t *testing.T) {
+		store := newMockStorage()
+		store.payments = []domain.Payment{{QrPaymentID: 1, Status: domain.PaymentStatusProcessed, Amount: 100}}
+		store.refundQRs = []domain.RefundQR{{QrReturnID: 10, Status: domain.PaymentStatusWait}}
+		store.totals = []domain.RefundTotal{{QrPaymentID: 1, Amount: 50}}
+		provider := &MockProvider{
+			statuses: map[int64]string{1: domain.PaymentStatusProcessed},
+			details: map[int64]domain.PaymentDetailsResponse{
+				1: {TotalAmount: 90, AvailableReturnAmount: 90},
+			},
+		}
+
+		r := newReconciler(t, provider, store, "enhanced")
+
+		run, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if run.Status != domain.ReconciliationCompleted {
+			t.Fatalf("Expected completed run, got %s (%s)", run.Status, run.Error)
+		}
+		// refund QRs are not created in the enhanced scheme, only the ledger total is checked
+		if run.RefundsChecked != 1 || run.Discrepancies != 2 {
+			t.Errorf("Expected 1 refund and 2 discrepancies, got %d and %d", run.RefundsChecked, run.Discrepancies)
+		}
+
+		var amountMismatch, refundMissing bool
+		for _, d := range store.discrepancies {
+			amountMismatch = amountMismatch || d.Kind == domain.DiscrepancyAmountMismatch
+			refundMissing = refundMissing || d.Kind == domain.DiscrepancyRefundMissing
+		}
+		if !amountMismatch || !refundMissing {
+			t.Errorf("Expected amount mismatch and missing refund, got %v", store.discrepancies)
+		}
+	})
+
+	t.Run("records payments unknown to Kaspi and aborts when Kaspi is unavailable", func(t *testing.T) {
+		store := newMockStorage()
+		store.payments = []domain.Payment{
+			{QrPaymentID: 1, Status: domain.PaymentStatusWait},
+			{QrPaymentID: 2, Status: domain.PaymentStatusWait},
+			{QrPaymentID: 3, Status: domain.PaymentStatusWait},
+		}
+		provider := &MockProvider{
+			statuses: map[int64]string{3: domain.PaymentStatusWait},
+			errs: map[int64]error{
+				1: &domain.KaspiError{StatusCode: -1601, Message: "Purchase not found"},
+				2: &domain.KaspiError{StatusCode: -999, Message: "Service unavailable"},
+			},
+		}
+
+		r := newReconciler(t, provider, store, "basic")
+
+		run, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if run.Status != domain.ReconciliationFailed || run.Error == "" {
+			t.Errorf("Expected failed run, got %s", run.Status)
+		}
+		if run.PaymentsChecked != 1 {
+			t.Errorf("Expected 1 checked payment, got %d", run.PaymentsChecked)
+		}
+		if len(store.discrepancies) != 1 || store.discrepancies[0].Kind != domain.DiscrepancyNotFound {
+			t.Errorf("Expected a not found discrepancy, got %v", store.discrepancies)
+		}
+		if provider.detailsCalls != 0 {
+			t.Error("Expected no payment details outside the standard scheme")
+		}
+	})
+}
+
+func TestStartReconciliation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a second run while one is in progress", func(t *testing.T) {
+		store := newMockStorage()
+		store.payments = []domain.Payment{{QrPaymentID: 1, Status: domain.PaymentStatusWait}}
+		provider := &MockProvider{
+			statuses: map[int64]string{1: domain.PaymentStatusWait},
+			block:    make(chan struct{}),
+		}
+
+		r := newReconciler(t, provider, store, "basic")
+
+		run, err := r.StartReconciliation(ctx, domain.ReconciliationRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if run.Status != domain.ReconciliationRunning || run.Trigger != domain.ReconciliationTriggerManual {
+			t.Errorf("Expected running manual run, got %s %s", run.Status, run.Trigger)
+		}
+		if got := run.To.Sub(run.From); got != 24*time.Hour {
+			t.Errorf("Expected the previous day by default, got %s", got)
+		}
+
+		_, err = r.StartReconciliation(ctx, domain.ReconciliationRequest{})
+		if !errors.Is(err, domain.ErrReconciliationRunning) {
+			t.Errorf("Expected ErrReconciliationRunning, got %v", err)
+		}
+
+		close(provider.block)
+	})
+
+	t.Run("starts a scheduled run of a period once", func(t *testing.T) {
+		r := newReconciler(t, &MockProvider{}, newMockStorage(), "basic")
+
+		if _, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerScheduled); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		_, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerScheduled)
+		if !errors.Is(err, storage.ErrReconciliationRunExists) {
+			t.Errorf("Expected ErrReconciliationRunExists, got %v", err)
+		}
+
+		if _, err = r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual); err != nil {
+			t.Errorf("Expected a manual run after the skipped one, got %v", err)
+		}
+	})
+
+	t.Run("rejects too long periods", func(t *testing.T) {
+		r := newReconciler(t, &MockProvider{}, newMockStorage(), "basic")
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := r.StartReconciliation(ctx, domain.ReconciliationRequest{From: from, To: from.AddDate(0, 2, 0)})
+		if err == nil {
+			t.Error("Expected validation error")
+		}
+	})
+}
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("interrupts runs of this instance and runs with an expired lease", func(t *testing.T) {
+		store := newMockStorage()
+
+		r, err := reconciliation.New(slogdiscard.NewDiscardLogger(), &MockProvider{}, store, reconciliation.Config{
+			Scheme:   "basic",
+			Instance: "instance-1",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create reconciler: %v", err)
+		}
+		t.Cleanup(r.Stop)
+
+		if err = r.Start(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if store.interruptedOwner != "instance-1" {
+			t.Errorf("Expected runs of instance-1 to be interrupted, got %q", store.interruptedOwner)
+		}
+		if lease := time.Since(store.interruptedBefore); lease < 4*time.Minute || lease > 6*time.Minute {
+			t.Errorf("Expected runs older than the lease to be interrupted, got %s", lease)
+		}
+
+		run, err := r.Reconcile(ctx, period(), domain.ReconciliationTriggerManual)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stored, _ := store.ReconciliationRun(ctx, run.ID); stored.Owner != "instance-1" {
+			t.Errorf("Expected the run owned by instance-1, got %q", stored.Owner)
+		}
+	})
+}
+
+func TestExportReconciliationRun(t *testing.T) {
+	store := newMockStorage()
+	store.payments = []domain.Payment{{QrPaymentID: 7, Status: domain.PaymentStatusWait}}
+	provider := &MockProvider{statuses: map[int64]string{7: domain.PaymentStatusProcessed}}
+
+	r := newReconciler(t, provider, store, "basic")
+
+	run, err := r.Reconcile(context.Background(), period(), domain.ReconciliationTriggerManual)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := r.ExportReconciliationRun(context.Background(), run.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected header and 1 row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "RunId,Kind,Entity,QrPaymentId") {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "1,status_mismatch,payment,7,,Wait,Processed,") {
+		t.Errorf("Unexpected row %q", lines[1])
+	}
+
+	_, err = r.ExportReconciliationRun(context.Background(), 99)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected not found, got %v", err)
+	}
+}
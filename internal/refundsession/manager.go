@@ -0,0 +1,472 @@
+package refundsession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// RefundProvider runs the single steps of the standard refund flow against Kaspi
+type RefundProvider interface {
+	CreateRefundQR(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error)
+	GetRefundStatus(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error)
+	GetCustomerOperations(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error)
+	GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
+	RefundPayment(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error)
+	ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error)
+}
+
+type Storage interface {
+	SaveRefundSession(ctx context.Context, session domain.RefundSession) error
+	UpdateRefundSession(ctx context.Context, session domain.RefundSession, fromState string) error
+	RefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error)
+	WaitingRefundSessions(ctx context.Context) ([]domain.RefundSession, error)
+}
+
+// Config holds fallbacks for behavior options Kaspi did not return and the selection timeout
+type Config struct {
+	DefaultPollingInterval time.Duration
+	DefaultScanTimeout     time.Duration
+	SelectionTimeout       time.Duration // how long the cashier has to choose the operation after the scan
+}
+
+// Manager drives refund sessions: it polls the refund QR until the customer scans it,
+// loads the customer operations and refunds the operation chosen by the cashier
+type Manager struct {
+	log      *slog.Logger
+	provider RefundProvider
+	storage  Storage
+	cfg      Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	watched map[int64]struct{}
+}
+
+func New(log *slog.Logger, provider RefundProvider, storage Storage, cfg Config) *Manager {
+	if cfg.DefaultPollingInterval <= 0 {
+		cfg.DefaultPollingInterval = 5 * time.Second
+	}
+	if cfg.DefaultScanTimeout <= 0 {
+		cfg.DefaultScanTimeout = 3 * time.Minute
+	}
+	if cfg.SelectionTimeout <= 0 {
+		cfg.SelectionTimeout = 10 * time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Manager{
+		log:      log,
+		provider: provider,
+		storage:  storage,
+		cfg:      cfg,
+		ctx:      ctx,
+		cancel:   cancel,
+		watched:  make(map[int64]struct{}),
+	}
+}
+
+// Start resumes sessions that were waiting when the service stopped
+func (m *Manager) Start(ctx context.Context) error {
+	const op = "refundsession.Start"
+
+	sessions, err := m.storage.WaitingRefundSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, session := range sessions {
+		m.watch(session)
+	}
+
+	m.log.Info("refund sessions resumed", slog.String("op", op), slog.Int("count", len(sessions)))
+
+	return nil
+}
+
+// Stop stops watching sessions and waits for the watchers to exit, the sessions stay persisted
+func (m *Manager) Stop() {
+	m.log.Info("stopping refund session manager", slog.String("op", "refundsession.Stop"))
+
+	// cancel under the lock so that watch never adds to the wait group after Wait started
+	m.mu.Lock()
+	m.cancel()
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// CreateRefundSession creates a refund QR and starts waiting for the customer to scan it
+func (m *Manager) CreateRefundSession(ctx context.Context, req domain.RefundSessionCreateRequest) (*domain.RefundSession, error) {
+	const op = "refundsession.CreateRefundSession"
+
+	// the session keeps the token for the later steps, so a DeviceId is resolved once here
+	deviceToken, err := m.provider.ResolveDeviceToken(ctx, req.DeviceToken, req.DeviceID, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a token resolved from the DeviceId is not logged
+	device := slog.String("deviceToken", deviceToken)
+	if req.DeviceToken == "" && req.DeviceID != "" {
+		device = slog.String("deviceId", req.DeviceID)
+	}
+
+	log := m.log.With(
+		slog.String("op", op),
+		device,
+	)
+
+	qr, err := m.provider.CreateRefundQR(ctx, domain.QRRefundCreateRequest{
+		DeviceToken: deviceToken,
+		ExternalID:  req.ExternalID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	deadline := now.Add(m.scanTimeout(qr.QrRefundBehaviorOptions))
+
+	session := domain.RefundSession{
+		QrReturnID:              qr.QrReturnID,
+		DeviceToken:             deviceToken,
+		ExternalID:              req.ExternalID,
+		MaxResult:               req.MaxResult,
+		QrToken:                 qr.QrToken,
+		ExpireDate:              qr.ExpireDate,
+		QrRefundBehaviorOptions: qr.QrRefundBehaviorOptions,
+		State:                   domain.RefundSessionAwaitingScan,
+		KaspiStatus:             domain.PaymentStatusCreated,
+		Deadline:                &deadline,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+
+	if err = m.storage.SaveRefundSession(ctx, session); err != nil {
+		log.Error("failed to save refund session", "qrReturnID", qr.QrReturnID, "error", err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("refund session started", "qrReturnID", qr.QrReturnID, "deadline", deadline)
+
+	m.watch(session)
+
+	return &session, nil
+}
+
+// GetRefundSession returns the current state of a session
+func (m *Manager) GetRefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error) {
+	const op = "refundsession.GetRefundSession"
+
+	if qrReturnID <= 0 {
+		return nil, &validator.ValidationError{
+			Field:   "qrReturnId",
+			Message: "Invalid refund ID format",
+			Err:     validator.ErrInvalidID,
+		}
+	}
+
+	session, err := m.storage.RefundSession(ctx, qrReturnID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+// RefundSessionPayment refunds the customer operation chosen by the cashier. A refund that Kaspi
+// rejected, e.g. for an amount above AvailableReturnAmount, leaves the session awaiting another choice.
+func (m *Manager) RefundSessionPayment(ctx context.Context, qrReturnID int64, req domain.RefundSessionRefundRequest) (*domain.RefundSession, error) {
+	const op = "refundsession.RefundSessionPayment"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("qrReturnID", qrReturnID),
+		slog.Int64("qrPaymentID", req.QrPaymentID),
+		slog.Float64("amount", req.Amount),
+	)
+
+	session, err := m.GetRefundSession(ctx, qrReturnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.State != domain.RefundSessionAwaitingSelection {
+		return nil, fmt.Errorf("%s: session is %s: %w", op, session.State, domain.ErrRefundSessionState)
+	}
+
+	if session.Deadline != nil && time.Now().After(*session.Deadline) {
+		m.expire(log, *session)
+		return nil, fmt.Errorf("%s: session is expired: %w", op, domain.ErrRefundSessionState)
+	}
+
+	if err = validator.ValidateRefundSessionRefundRequest(*session, req); err != nil {
+		log.Warn("invalid refund session request", "error", err.Error())
+		return nil, err
+	}
+
+	// the refunding state is taken with a conditional update, so the operation is refunded only once
+	// even if the cashier submits it twice or from two instances
+	waiting := *session
+	refunding := *session
+	refunding.State = domain.RefundSessionRefunding
+	refunding.QrPaymentID = req.QrPaymentID
+	refunding.Amount = req.Amount
+	refunding.Deadline = nil
+	refunding.Error = ""
+
+	if err = m.storage.UpdateRefundSession(ctx, refunding, domain.RefundSessionAwaitingSelection); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// finish the bookkeeping even if the client goes away
+	storeCtx := context.WithoutCancel(ctx)
+
+	details, err := m.provider.GetPaymentDetails(ctx, req.QrPaymentID, session.DeviceToken)
+	if err != nil {
+		log.Warn("failed to get payment details", "error", err.Error())
+		m.release(storeCtx, log, waiting, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	refunding.AvailableReturnAmount = details.AvailableReturnAmount
+
+	if req.Amount-details.AvailableReturnAmount >= 0.005 {
+		err = &domain.RefundBalanceError{Requested: req.Amount, Remaining: details.AvailableReturnAmount}
+		waiting.AvailableReturnAmount = details.AvailableReturnAmount
+		m.release(storeCtx, log, waiting, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := m.provider.RefundPayment(ctx, domain.RefundRequest{
+		DeviceToken: session.DeviceToken,
+		QrPaymentID: req.QrPaymentID,
+		QrReturnID:  session.QrReturnID,
+		Amount:      req.Amount,
+	})
+	if err != nil {
+		if isRejected(err) {
+			waiting.AvailableReturnAmount = details.AvailableReturnAmount
+			m.release(storeCtx, log, waiting, err)
+		} else {
+			// the refund may have been made, the session stays refunding for a manual check
+			log.Error("refund outcome is unknown", "error", err.Error())
+			refunding.Error = err.Error()
+			m.update(storeCtx, log, refunding, domain.RefundSessionRefunding)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	completed := refunding
+	completed.State = domain.RefundSessionCompleted
+	completed.ReturnOperationID = result.ReturnOperationID
+	completed.UpdatedAt = time.Now()
+
+	m.update(storeCtx, log, completed, domain.RefundSessionRefunding)
+
+	log.Info("refund session completed", "returnOperationID", result.ReturnOperationID)
+
+	return &completed, nil
+}
+
+// release returns a session whose refund certainly was not made to awaiting_selection
+func (m *Manager) release(ctx context.Context, log *slog.Logger, session domain.RefundSession, cause error) {
+	session.Error = cause.Error()
+	m.update(ctx, log, session, domain.RefundSessionRefunding)
+}
+
+func (m *Manager) update(ctx context.Context, log *slog.Logger, session domain.RefundSession, fromState string) {
+	if err := m.storage.UpdateRefundSession(ctx, session, fromState); err != nil {
+		log.Error("failed to update refund session", "state", session.State, "error", err.Error())
+	}
+}
+
+// isRejected reports whether Kaspi certainly did not perform the refund
+func isRejected(err error) bool {
+	var valErr *validator.ValidationError
+	if errors.As(err, &valErr) || errors.Is(err, domain.ErrCircuitOpen) || errors.Is(err, domain.ErrRefundExceedsBalance) {
+		return true
+	}
+
+	_, ok := domain.IsKaspiError(err)
+	return ok
+}
+
+// watch starts the background goroutine of a waiting session unless it is already watched
+func (m *Manager) watch(session domain.RefundSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctx.Err() != nil {
+		return
+	}
+
+	if _, ok := m.watched[session.QrReturnID]; ok {
+		return
+	}
+
+	m.watched[session.QrReturnID] = struct{}{}
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			m.mu.Lock()
+			delete(m.watched, session.QrReturnID)
+			m.mu.Unlock()
+		}()
+
+		m.run(session)
+	}()
+}
+
+func (m *Manager) run(session domain.RefundSession) {
+	const op = "refundsession.run"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("qrReturnID", session.QrReturnID),
+	)
+
+	if session.State == domain.RefundSessionAwaitingScan {
+		next, ok := m.awaitScan(log, session)
+		if !ok {
+			return
+		}
+		session = next
+	}
+
+	if session.State == domain.RefundSessionAwaitingSelection {
+		m.awaitSelection(log, session)
+	}
+}
+
+// awaitScan polls the refund QR until the customer scans it, then loads their operations
+func (m *Manager) awaitScan(log *slog.Logger, session domain.RefundSession) (domain.RefundSession, bool) {
+	interval := time.Duration(session.QrRefundBehaviorOptions.QrCodeScanEventPollingInterval) * time.Second
+	if interval <= 0 {
+		interval = m.cfg.DefaultPollingInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return session, false
+		case <-ticker.C:
+		}
+
+		if session.Deadline != nil && time.Now().After(*session.Deadline) {
+			log.Info("refund QR was not scanned in time")
+			m.expire(log, session)
+			return session, false
+		}
+
+		if session.KaspiStatus != domain.PaymentStatusWait {
+			status, err := m.provider.GetRefundStatus(m.ctx, session.QrReturnID)
+			if err != nil {
+				log.Warn("failed to get refund status", "error", err.Error())
+				continue
+			}
+
+			switch status.Status {
+			case domain.PaymentStatusWait:
+				log.Debug("refund QR scanned")
+				session.KaspiStatus = status.Status
+			case domain.PaymentStatusError, domain.PaymentStatusExpired:
+				log.Info("refund QR is no longer usable", "status", status.Status)
+				m.finish(log, session, status.Status)
+				return session, false
+			default:
+				continue
+			}
+		}
+
+		operations, err := m.provider.GetCustomerOperations(m.ctx, domain.CustomerOperationsRequest{
+			DeviceToken: session.DeviceToken,
+			QrReturnID:  session.QrReturnID,
+			MaxResult:   session.MaxResult,
+		})
+		if err != nil {
+			log.Warn("failed to get customer operations", "error", err.Error())
+			continue
+		}
+
+		deadline := time.Now().Add(m.cfg.SelectionTimeout)
+
+		next := session
+		next.State = domain.RefundSessionAwaitingSelection
+		next.Operations = operations
+		next.Deadline = &deadline
+		next.UpdatedAt = time.Now()
+
+		if err = m.storage.UpdateRefundSession(m.ctx, next, domain.RefundSessionAwaitingScan); err != nil {
+			log.Error("failed to store customer operations", "error", err.Error())
+			if errors.Is(err, domain.ErrRefundSessionState) {
+				return session, false
+			}
+			continue
+		}
+
+		log.Info("customer operations available", "count", len(operations))
+
+		return next, true
+	}
+}
+
+// awaitSelection expires the session if the cashier has not chosen an operation in time
+func (m *Manager) awaitSelection(log *slog.Logger, session domain.RefundSession) {
+	if session.Deadline == nil {
+		return
+	}
+
+	timer := time.NewTimer(time.Until(*session.Deadline))
+	defer timer.Stop()
+
+	select {
+	case <-m.ctx.Done():
+	case <-timer.C:
+		m.expire(log, session)
+	}
+}
+
+// expire moves a waiting session to expired, a session that has moved on meanwhile is kept
+func (m *Manager) expire(log *slog.Logger, session domain.RefundSession) {
+	m.finish(log, session, "")
+}
+
+func (m *Manager) finish(log *slog.Logger, session domain.RefundSession, kaspiStatus string) {
+	fromState := session.State
+
+	session.State = domain.RefundSessionExpired
+	if kaspiStatus != "" {
+		session.KaspiStatus = kaspiStatus
+		if kaspiStatus == domain.PaymentStatusError {
+			session.State = domain.RefundSessionFailed
+		}
+	}
+	session.Deadline = nil
+
+	err := m.storage.UpdateRefundSession(context.WithoutCancel(m.ctx), session, fromState)
+	if err != nil && !errors.Is(err, domain.ErrRefundSessionState) {
+		log.Error("failed to finish refund session", "state", session.State, "error", err.Error())
+	}
+}
+
+func (m *Manager) scanTimeout(opts domain.QRRefundBehaviorOptions) time.Duration {
+	if opts.QrCodeScanWaitTimeout > 0 {
+		return time.Duration(opts.QrCodeScanWaitTimeout) * time.Second
+	}
+	return m.cfg.DefaultScanTimeout
+}
@@ -0,0 +1,384 @@
+package refundsession_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/refundsession"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type MockRefundProvider struct {
+	CreateRefundQRFunc        func(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error)
+	GetRefundStatusFunc       func(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error)
+	GetCustomerOperationsFunc func(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error)
+	GetPaymentDetailsFunc     func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error)
+	RefundPaymentFunc         func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error)
+}
+
+func (m *MockRefundProvider) CreateRefundQR(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error) {
+	if m.CreateRefundQRFunc != nil {
+		return m.CreateRefundQRFunc(ctx, req)
+	}
+	return &domain.QRRefundCreateResponse{
+		QrToken:    "51236903777280167836178166503744993984459",
+		ExpireDate: time.Now().Add(5 * time.Minute),
+		QrReturnID: 15,
+	}, nil
+}
+
+func (m *MockRefundProvider) GetRefundStatus(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error) {
+	return m.GetRefundStatusFunc(ctx, qrReturnID)
+}
+
+func (m *MockRefundProvider) GetCustomerOperations(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error) {
+	return m.GetCustomerOperationsFunc(ctx, req)
+}
+
+func (m *MockRefundProvider) GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+	return m.GetPaymentDetailsFunc(ctx, qrPaymentID, deviceToken)
+}
+
+func (m *MockRefundProvider) RefundPayment(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error) {
+	return m.RefundPaymentFunc(ctx, req)
+}
+
+func (m *MockRefundProvider) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	if deviceToken == "" && deviceID != "" {
+		return "token-" + deviceID, nil
+	}
+	return deviceToken, nil
+}
+
+// MemoryStorage keeps sessions in memory with the same conditional updates as the database
+type MemoryStorage struct {
+	mu       sync.Mutex
+	sessions map[int64]domain.RefundSession
+}
+
+func (m *MemoryStorage) SaveRefundSession(ctx context.Context, session domain.RefundSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sessions == nil {
+		m.sessions = make(map[int64]domain.RefundSession)
+	}
+	m.sessions[session.QrReturnID] = session
+
+	return nil
+}
+
+func (m *MemoryStorage) UpdateRefundSession(ctx context.Context, session domain.RefundSession, fromState string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.sessions[session.QrReturnID]
+	if !ok {
+		return storage.ErrRefundSessionNotFound
+	}
+	if current.State != fromState {
+		return domain.ErrRefundSessionState
+	}
+	m.sessions[session.QrReturnID] = session
+
+	return nil
+}
+
+func (m *MemoryStorage) RefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[qrReturnID]
+	if !ok {
+		return nil, storage.ErrRefundSessionNotFound
+	}
+
+	return &session, nil
+}
+
+func (m *MemoryStorage) WaitingRefundSessions(ctx context.Context) ([]domain.RefundSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sessions []domain.RefundSession
+	for _, session := range m.sessions {
+		if session.IsWaiting() {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (m *MemoryStorage) state(qrReturnID int64) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sessions[qrReturnID].State
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met in time")
+}
+
+func testConfig() refundsession.Config {
+	return refundsession.Config{
+		DefaultPollingInterval: 5 * time.Millisecond,
+		DefaultScanTimeout:     time.Second,
+		SelectionTimeout:       time.Second,
+	}
+}
+
+func scannedProvider() *MockRefundProvider {
+	return &MockRefundProvider{
+		GetRefundStatusFunc: func(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error) {
+			return &domain.RefundStatusResponse{Status: domain.PaymentStatusWait}, nil
+		},
+		GetCustomerOperationsFunc: func(ctx context.Context, req domain.CustomerOperationsRequest) ([]domain.CustomerOperation, error) {
+			return []domain.CustomerOperation{{QrPaymentID: 42, Amount: 200}}, nil
+		},
+		GetPaymentDetailsFunc: func(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
+			return &domain.PaymentDetailsResponse{QrPaymentID: qrPaymentID, TotalAmount: 200, AvailableReturnAmount: 150}, nil
+		},
+		RefundPaymentFunc: func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error) {
+			return &domain.RefundResponse{ReturnOperationID: 7}, nil
+		},
+	}
+}
+
+func TestRefundSession(t *testing.T) {
+	t.Run("drives the flow from scan to refund", func(t *testing.T) {
+		store := &MemoryStorage{}
+		provider := scannedProvider()
+
+		var refunded domain.RefundRequest
+		provider.RefundPaymentFunc = func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error) {
+			refunded = req
+			return &domain.RefundResponse{ReturnOperationID: 7}, nil
+		}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), provider, store, testConfig())
+		defer m.Stop()
+
+		session, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if session.State != domain.RefundSessionAwaitingScan || session.Deadline == nil {
+			t.Fatalf("Expected awaiting_scan with deadline, got %+v", session)
+		}
+
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionAwaitingSelection })
+
+		session, err = m.GetRefundSession(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(session.Operations) != 1 || session.Operations[0].QrPaymentID != 42 {
+			t.Fatalf("Expected customer operations, got %+v", session.Operations)
+		}
+
+		session, err = m.RefundSessionPayment(context.Background(), 15, domain.RefundSessionRefundRequest{QrPaymentID: 42, Amount: 100})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if session.State != domain.RefundSessionCompleted || session.ReturnOperationID != 7 {
+			t.Errorf("Expected completed session with operation 7, got %+v", session)
+		}
+
+		if refunded.QrReturnID != 15 || refunded.QrPaymentID != 42 || refunded.DeviceToken != "test-token" {
+			t.Errorf("Unexpected refund request: %+v", refunded)
+		}
+
+		if store.state(15) != domain.RefundSessionCompleted {
+			t.Errorf("Expected stored state completed, got %s", store.state(15))
+		}
+	})
+
+	t.Run("keeps the token resolved from the device ID", func(t *testing.T) {
+		store := &MemoryStorage{}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), scannedProvider(), store, testConfig())
+		defer m.Stop()
+
+		session, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceID: "POS-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if session.DeviceToken != "token-POS-1" {
+			t.Errorf("Expected resolved token token-POS-1, got %s", session.DeviceToken)
+		}
+	})
+
+	t.Run("fails like the refund QR in the enhanced scheme", func(t *testing.T) {
+		store := &MemoryStorage{}
+		provider := scannedProvider()
+		provider.CreateRefundQRFunc = func(ctx context.Context, req domain.QRRefundCreateRequest) (*domain.QRRefundCreateResponse, error) {
+			return nil, domain.ErrUnsupportedFeature
+		}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), provider, store, testConfig())
+		defer m.Stop()
+
+		_, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"})
+		if !errors.Is(err, domain.ErrUnsupportedFeature) {
+			t.Fatalf("Expected ErrUnsupportedFeature, got %v", err)
+		}
+
+		if len(store.sessions) != 0 {
+			t.Errorf("Expected no stored sessions, got %d", len(store.sessions))
+		}
+	})
+
+	t.Run("rejects second refund of the session", func(t *testing.T) {
+		store := &MemoryStorage{}
+
+		var calls int32
+		provider := scannedProvider()
+		provider.RefundPaymentFunc = func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return &domain.RefundResponse{ReturnOperationID: 7}, nil
+		}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), provider, store, testConfig())
+		defer m.Stop()
+
+		if _, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionAwaitingSelection })
+
+		req := domain.RefundSessionRefundRequest{QrPaymentID: 42, Amount: 100}
+		if _, err := m.RefundSessionPayment(context.Background(), 15, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err := m.RefundSessionPayment(context.Background(), 15, req)
+		if !errors.Is(err, domain.ErrRefundSessionState) {
+			t.Errorf("Expected ErrRefundSessionState, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 refund call, got %d", calls)
+		}
+	})
+
+	t.Run("keeps awaiting selection when amount exceeds available return amount", func(t *testing.T) {
+		store := &MemoryStorage{}
+		provider := scannedProvider()
+		provider.RefundPaymentFunc = func(ctx context.Context, req domain.RefundRequest) (*domain.RefundResponse, error) {
+			t.Error("Refund must not be called")
+			return nil, nil
+		}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), provider, store, testConfig())
+		defer m.Stop()
+
+		if _, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionAwaitingSelection })
+
+		_, err := m.RefundSessionPayment(context.Background(), 15, domain.RefundSessionRefundRequest{QrPaymentID: 42, Amount: 180})
+		if !errors.Is(err, domain.ErrRefundExceedsBalance) {
+			t.Fatalf("Expected ErrRefundExceedsBalance, got %v", err)
+		}
+
+		session, _ := m.GetRefundSession(context.Background(), 15)
+		if session.State != domain.RefundSessionAwaitingSelection || session.AvailableReturnAmount != 150 || session.Error == "" {
+			t.Errorf("Expected session awaiting selection with the error, got %+v", session)
+		}
+	})
+
+	t.Run("rejects operation that is not the customer's", func(t *testing.T) {
+		store := &MemoryStorage{}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), scannedProvider(), store, testConfig())
+		defer m.Stop()
+
+		if _, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionAwaitingSelection })
+
+		_, err := m.RefundSessionPayment(context.Background(), 15, domain.RefundSessionRefundRequest{QrPaymentID: 43, Amount: 100})
+		if err == nil {
+			t.Fatal("Expected validation error, got nil")
+		}
+
+		if store.state(15) != domain.RefundSessionAwaitingSelection {
+			t.Errorf("Expected state awaiting_selection, got %s", store.state(15))
+		}
+	})
+
+	t.Run("expires when QR is not scanned in time", func(t *testing.T) {
+		store := &MemoryStorage{}
+		provider := scannedProvider()
+		provider.GetRefundStatusFunc = func(ctx context.Context, qrReturnID int64) (*domain.RefundStatusResponse, error) {
+			return &domain.RefundStatusResponse{Status: domain.PaymentStatusCreated}, nil
+		}
+
+		cfg := testConfig()
+		cfg.DefaultScanTimeout = 30 * time.Millisecond
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), provider, store, cfg)
+		defer m.Stop()
+
+		if _, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionExpired })
+	})
+
+	t.Run("expires when operation is not chosen in time", func(t *testing.T) {
+		store := &MemoryStorage{}
+
+		cfg := testConfig()
+		cfg.SelectionTimeout = 30 * time.Millisecond
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), scannedProvider(), store, cfg)
+		defer m.Stop()
+
+		if _, err := m.CreateRefundSession(context.Background(), domain.RefundSessionCreateRequest{DeviceToken: "test-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionExpired })
+	})
+
+	t.Run("resumes waiting sessions on start", func(t *testing.T) {
+		deadline := time.Now().Add(time.Second)
+		store := &MemoryStorage{sessions: map[int64]domain.RefundSession{
+			15: {QrReturnID: 15, DeviceToken: "test-token", State: domain.RefundSessionAwaitingScan, Deadline: &deadline},
+		}}
+
+		m := refundsession.New(slogdiscard.NewDiscardLogger(), scannedProvider(), store, testConfig())
+		defer m.Stop()
+
+		if err := m.Start(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.state(15) == domain.RefundSessionAwaitingSelection })
+	})
+}
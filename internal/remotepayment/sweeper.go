@@ -0,0 +1,139 @@
+package remotepayment
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Storage interface {
+	PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error)
+}
+
+// Canceller cancels a single remote payment in Kaspi unless it was completed meanwhile
+type Canceller interface {
+	CancelOverdueRemotePayment(ctx context.Context, qrPaymentID int64) error
+}
+
+// Config holds how long a remote payment may wait for the customer and how often overdue payments are looked up
+type Config struct {
+	Timeout       time.Duration
+	SweepInterval time.Duration
+}
+
+// Sweeper periodically cancels remote payments that are pending for longer than the timeout.
+// The payment poller cancels payments it tracks on its own, the sweeper covers payments that
+// are not polled, e.g. after a restart or when the poller is disabled
+type Sweeper struct {
+	log       *slog.Logger
+	storage   Storage
+	canceller Canceller
+	cfg       Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+func New(log *slog.Logger, storage Storage, canceller Canceller, cfg Config) *Sweeper {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Minute
+	}
+	if cfg.SweepInterval <= 0 {
+		cfg.SweepInterval = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Sweeper{
+		log:       log,
+		storage:   storage,
+		canceller: canceller,
+		cfg:       cfg,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Start runs the sweep loop in the background
+func (s *Sweeper) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		s.run()
+	}()
+}
+
+// Stop stops the sweep loop and waits for the running sweep to finish
+func (s *Sweeper) Stop() {
+	s.log.Info("stopping remote payment sweeper", slog.String("op", "remotepayment.Stop"))
+
+	// cancel under the lock so that Start never adds to the wait group after Wait started
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Sweeper) run() {
+	ticker := time.NewTicker(s.cfg.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(s.ctx)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep cancels all remote payments pending for longer than the timeout and returns how many were canceled
+func (s *Sweeper) Sweep(ctx context.Context) int {
+	const op = "remotepayment.Sweep"
+
+	log := s.log.With(slog.String("op", op))
+
+	payments, err := s.storage.PendingRemotePayments(ctx, "", time.Now().Add(-s.cfg.Timeout))
+	if err != nil {
+		log.Error("failed to load overdue remote payments", "error", err.Error())
+		return 0
+	}
+
+	canceled := 0
+	for _, payment := range payments {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err = s.canceller.CancelOverdueRemotePayment(ctx, payment.QrPaymentID)
+		if err != nil {
+			log.Warn("failed to cancel overdue remote payment",
+				"qrPaymentID", payment.QrPaymentID,
+				"error", err.Error(),
+			)
+			continue
+		}
+		canceled++
+	}
+
+	if len(payments) > 0 {
+		log.Info("overdue remote payments swept", "found", len(payments), "canceled", canceled)
+	}
+
+	return canceled
+}
@@ -0,0 +1,121 @@
+package remotepayment_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/remotepayment"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"sync"
+	"testing"
+	"time"
+)
+
+type MockStorage struct {
+	PendingRemotePaymentsFunc func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error)
+}
+
+func (m *MockStorage) PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+	return m.PendingRemotePaymentsFunc(ctx, organizationBin, createdBefore)
+}
+
+type MockCanceller struct {
+	mu       sync.Mutex
+	canceled []int64
+	fail     map[int64]bool
+}
+
+func (m *MockCanceller) CancelOverdueRemotePayment(ctx context.Context, qrPaymentID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fail[qrPaymentID] {
+		return errors.New("kaspi is unavailable")
+	}
+	m.canceled = append(m.canceled, qrPaymentID)
+
+	return nil
+}
+
+func (m *MockCanceller) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.canceled)
+}
+
+func TestSweeper(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+
+	t.Run("cancels payments older than the timeout", func(t *testing.T) {
+		store := &MockStorage{
+			PendingRemotePaymentsFunc: func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+				if organizationBin != "" {
+					t.Errorf("Expected all organizations, got %s", organizationBin)
+				}
+
+				age := time.Since(createdBefore)
+				if age < 10*time.Minute || age > 11*time.Minute {
+					t.Errorf("Expected payments older than 10m, got %s", age)
+				}
+
+				return []domain.Payment{{QrPaymentID: 1}, {QrPaymentID: 2}, {QrPaymentID: 3}}, nil
+			},
+		}
+		canceller := &MockCanceller{fail: map[int64]bool{2: true}}
+
+		s := remotepayment.New(log, store, canceller, remotepayment.Config{Timeout: 10 * time.Minute})
+
+		canceled := s.Sweep(context.Background())
+		if canceled != 2 {
+			t.Errorf("Expected 2 canceled payments, got %d", canceled)
+		}
+
+		if len(canceller.canceled) != 2 || canceller.canceled[0] != 1 || canceller.canceled[1] != 3 {
+			t.Errorf("Unexpected canceled payments: %v", canceller.canceled)
+		}
+	})
+
+	t.Run("survives storage errors", func(t *testing.T) {
+		store := &MockStorage{
+			PendingRemotePaymentsFunc: func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+				return nil, errors.New("database is down")
+			},
+		}
+
+		s := remotepayment.New(log, store, &MockCanceller{}, remotepayment.Config{})
+
+		if canceled := s.Sweep(context.Background()); canceled != 0 {
+			t.Errorf("Expected no canceled payments, got %d", canceled)
+		}
+	})
+
+	t.Run("sweeps periodically until stopped", func(t *testing.T) {
+		store := &MockStorage{
+			PendingRemotePaymentsFunc: func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+				return []domain.Payment{{QrPaymentID: 1}}, nil
+			},
+		}
+		canceller := &MockCanceller{}
+
+		s := remotepayment.New(log, store, canceller, remotepayment.Config{SweepInterval: time.Millisecond})
+		s.Start()
+
+		deadline := time.Now().Add(2 * time.Second)
+		for canceller.count() < 3 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		s.Stop()
+
+		if canceller.count() < 3 {
+			t.Fatalf("Expected at least 3 sweeps, got %d", canceller.count())
+		}
+
+		stopped := canceller.count()
+		time.Sleep(20 * time.Millisecond)
+		if canceller.count() != stopped {
+			t.Error("Expected no sweeps after stop")
+		}
+	})
+}
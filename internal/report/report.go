@@ -0,0 +1,110 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"time"
+)
+
+// defaultMaxDays keeps a single report within a quarter
+const defaultMaxDays = 92
+
+type Storage interface {
+	SettlementEntries(ctx context.Context, from, to time.Time, tradePointID int64, organizationBin string) ([]domain.SettlementEntry, error)
+}
+
+// Config holds the time zone that splits operations into days and the longest allowed period
+type Config struct {
+	Location *time.Location
+	MaxDays  int
+}
+
+// Generator builds settlement reports from the payments and refunds recorded locally
+type Generator struct {
+	log     *slog.Logger
+	storage Storage
+	cfg     Config
+}
+
+func New(log *slog.Logger, storage Storage, cfg Config) *Generator {
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+	if cfg.MaxDays <= 0 {
+		cfg.MaxDays = defaultMaxDays
+	}
+
+	return &Generator{
+		log:     log,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// SettlementReport sums processed payments and succeeded refunds per day, trade point and device
+func (g *Generator) SettlementReport(ctx context.Context, req domain.SettlementReportRequest) (*domain.SettlementReport, error) {
+	const op = "report.SettlementReport"
+
+	from, to, err := validator.ValidateSettlementReportRequest(req, g.cfg.Location, g.cfg.MaxDays)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := g.storage.SettlementEntries(ctx, from, to.AddDate(0, 0, 1), req.TradePointID, req.OrganizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	g.log.Debug("settlement report generated",
+		slog.String("from", from.Format(time.DateOnly)),
+		slog.String("to", to.Format(time.DateOnly)),
+		slog.Int("entries", len(entries)),
+	)
+
+	return &domain.SettlementReport{
+		DateFrom:    from.Format(time.DateOnly),
+		DateTo:      to.Format(time.DateOnly),
+		Timezone:    g.cfg.Location.String(),
+		GeneratedAt: time.Now().In(g.cfg.Location),
+		Days:        buildSettlementReport(entries, from, to, g.cfg.Location),
+	}, nil
+}
+
+// ExportSettlementReport renders a settlement report as a JSON, CSV or XLSX file
+func (g *Generator) ExportSettlementReport(ctx context.Context, req domain.SettlementReportRequest, format string) (*domain.ReportFile, error) {
+	const op = "report.ExportSettlementReport"
+
+	if err := validator.ValidateReportFormat(format); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report, err := g.SettlementReport(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	file := &domain.ReportFile{
+		FileName: fmt.Sprintf("settlement-%s_%s.%s", report.DateFrom, report.DateTo, format),
+	}
+
+	switch format {
+	case domain.ReportFormatCSV:
+		file.ContentType = "text/csv"
+		file.Data, err = writeCSV(settlementRows(report))
+	case domain.ReportFormatXLSX:
+		file.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		file.Data, err = writeXLSX("Settlement", settlementRows(report))
+	default:
+		file.ContentType = "application/json"
+		file.Data, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
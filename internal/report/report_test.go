@@ -0,0 +1,256 @@
+package report_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/report"
+	"kaspi-api-wrapper/internal/validator"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+type MockStorage struct {
+	entries []domain.SettlementEntry
+	from    time.Time
+	to      time.Time
+}
+
+func (m *MockStorage) SettlementEntries(ctx context.Context, from, to time.Time, tradePointID int64, organizationBin string) ([]domain.SettlementEntry, error) {
+	m.from, m.to = from, to
+
+	var entries []domain.SettlementEntry
+	for _, entry := range m.entries {
+		if !entry.CreatedAt.Before(from) && entry.CreatedAt.Before(to) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func almaty(t *testing.T) *time.Location {
+	location, err := time.LoadLocation("Asia/Almaty")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	return location
+}
+
+func TestSettlementReport(t *testing.T) {
+	location := almaty(t)
+	log := slogdiscard.NewDiscardLogger()
+
+	storage := &MockStorage{entries: []domain.SettlementEntry{
+		// 23:30 on April 30 in Almaty, outside of the report
+		{Kind: domain.SettlementSale, QrPaymentID: 1, TradePointID: 1, DeviceID: "A", ProductType: "Gold", Amount: 500,
+			CreatedAt: time.Date(2026, 4, 30, 18, 30, 0, 0, time.UTC)},
+		// 00:30 on May 1 in Almaty, still April 30 in UTC
+		{Kind: domain.SettlementSale, QrPaymentID: 2, TradePointID: 1, DeviceID: "A", ProductType: "Gold", Amount: 100.1,
+			CreatedAt: time.Date(2026, 4, 30, 19, 30, 0, 0, time.UTC)},
+		{Kind: domain.SettlementSale, QrPaymentID: 3, TradePointID: 1, DeviceID: "B", PaymentMethods: []string{"Red"}, Amount: 200.2,
+			CreatedAt: time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)},
+		{Kind: domain.SettlementRefund, QrPaymentID: 2, TradePointID: 1, DeviceID: "A", ProductType: "Gold", Amount: 50,
+			CreatedAt: time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)},
+		{Kind: domain.SettlementSale, QrPaymentID: 4, TradePointID: 2, DeviceID: "C", PaymentMethods: []string{"Red", "Gold"}, Amount: 300,
+			CreatedAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)},
+	}}
+
+	generator := report.New(log, storage, report.Config{Location: location})
+
+	t.Run("aggregates days of the report time zone", func(t *testing.T) {
+		result, err := generator.SettlementReport(context.Background(), domain.SettlementReportRequest{
+			DateFrom: "2026-05-01",
+			DateTo:   "2026-05-02",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !storage.from.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, location)) || !storage.to.Equal(time.Date(2026, 5, 3, 0, 0, 0, 0, location)) {
+			t.Errorf("Unexpected period %s - %s", storage.from, storage.to)
+		}
+
+		if result.Timezone != "Asia/Almaty" || len(result.Days) != 2 {
+			t.Fatalf("Unexpected report: %+v", result)
+		}
+
+		day := result.Days[0]
+		if day.Date != "2026-05-01" || day.GrossSales != 600.3 || day.Refunds != 50 || day.Net != 550.3 ||
+			day.SalesCount != 3 || day.RefundsCount != 1 {
+			t.Errorf("Unexpected day totals: %+v", day.SettlementTotals)
+		}
+
+		var productTypes []string
+		for _, b := range day.ByProductType {
+			productTypes = append(productTypes, b.ProductType)
+		}
+		if strings.Join(productTypes, ",") != "Gold,Red,Unknown" {
+			t.Errorf("Unexpected product types %v", productTypes)
+		}
+
+		var paymentMethods []string
+		for _, b := range day.ByPaymentMethods {
+			paymentMethods = append(paymentMethods, strings.Join(b.PaymentMethods, "+"))
+		}
+		if strings.Join(paymentMethods, ",") != "Gold+Red,Red,Unknown" {
+			t.Errorf("Unexpected payment methods %v", paymentMethods)
+		}
+		if unknown := day.ByPaymentMethods[2]; unknown.GrossSales != 100.1 || unknown.Refunds != 50 {
+			t.Errorf("Unexpected totals of payments without methods: %+v", unknown.SettlementTotals)
+		}
+
+		if len(day.TradePoints) != 2 || day.TradePoints[0].TradePointID != 1 || day.TradePoints[1].TradePointID != 2 {
+			t.Fatalf("Unexpected trade points: %+v", day.TradePoints)
+		}
+
+		devices := day.TradePoints[0].Devices
+		if len(devices) != 2 || devices[0].DeviceID != "A" || devices[0].Net != 50.1 || devices[1].DeviceID != "B" {
+			t.Errorf("Unexpected devices: %+v", devices)
+		}
+
+		empty := result.Days[1]
+		if empty.Date != "2026-05-02" || empty.SalesCount != 0 || len(empty.TradePoints) != 0 {
+			t.Errorf("Expected empty day, got %+v", empty)
+		}
+	})
+
+	t.Run("defaults end date to start date", func(t *testing.T) {
+		result, err := generator.SettlementReport(context.Background(), domain.SettlementReportRequest{DateFrom: "2026-04-30"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result.Days) != 1 || result.Days[0].GrossSales != 500 {
+			t.Errorf("Unexpected report: %+v", result.Days)
+		}
+	})
+
+	t.Run("rejects invalid period", func(t *testing.T) {
+		requests := []domain.SettlementReportRequest{
+			{},
+			{DateFrom: "01.05.2026"},
+			{DateFrom: "2026-05-02", DateTo: "2026-05-01"},
+			{DateFrom: "2026-01-01", DateTo: "2026-12-31"},
+		}
+
+		for _, req := range requests {
+			_, err := generator.SettlementReport(context.Background(), req)
+
+			var validationErr *validator.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected validation error for %+v, got %v", req, err)
+			}
+		}
+	})
+}
+
+func TestExportSettlementReport(t *testing.T) {
+	location := almaty(t)
+	log := slogdiscard.NewDiscardLogger()
+
+	storage := &MockStorage{entries: []domain.SettlementEntry{
+		{Kind: domain.SettlementSale, QrPaymentID: 1, TradePointID: 1, DeviceID: "A", ProductType: "Gold",
+			PaymentMethods: []string{"Gold", "Red"}, Amount: 100, CreatedAt: time.Date(2026, 5, 1, 6, 0, 0, 0, time.UTC)},
+	}}
+
+	generator := report.New(log, storage, report.Config{Location: location})
+	req := domain.SettlementReportRequest{DateFrom: "2026-05-01"}
+
+	t.Run("exports CSV", func(t *testing.T) {
+		file, err := generator.ExportSettlementReport(context.Background(), req, domain.ReportFormatCSV)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if file.FileName != "settlement-2026-05-01_2026-05-01.csv" || file.ContentType != "text/csv" {
+			t.Errorf("Unexpected file %s of type %s", file.FileName, file.ContentType)
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(file.Data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+
+		// header, then day, trade point and device with a total, a Gold and a Gold Red row each
+		if len(records) != 10 {
+			t.Fatalf("Expected 10 records, got %d", len(records))
+		}
+
+		if strings.Join(records[7], ",") != "2026-05-01,device,1,A,All,All,100,0,100,1,0" {
+			t.Errorf("Unexpected device row %v", records[7])
+		}
+		if strings.Join(records[9], ",") != "2026-05-01,device,1,A,All,Gold Red,100,0,100,1,0" {
+			t.Errorf("Unexpected payment methods row %v", records[9])
+		}
+	})
+
+	t.Run("exports XLSX", func(t *testing.T) {
+		file, err := generator.ExportSettlementReport(context.Background(), req, domain.ReportFormatXLSX)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		archive, err := zip.NewReader(bytes.NewReader(file.Data), int64(len(file.Data)))
+		if err != nil {
+			t.Fatalf("Failed to open XLSX: %v", err)
+		}
+
+		parts := make(map[string]string)
+		for _, f := range archive.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("Failed to open %s: %v", f.Name, err)
+			}
+			data, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			parts[f.Name] = string(data)
+		}
+
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+			if _, ok := parts[name]; !ok {
+				t.Errorf("Missing part %s", name)
+			}
+		}
+
+		sheet := parts["xl/worksheets/sheet1.xml"]
+		if !strings.Contains(sheet, `<c r="A1" t="inlineStr"><is><t>Date</t></is></c>`) {
+			t.Errorf("Header is missing in sheet")
+		}
+		if !strings.Contains(sheet, `<c r="G2"><v>100</v></c>`) {
+			t.Errorf("Gross sales are not stored as a number")
+		}
+	})
+
+	t.Run("exports JSON", func(t *testing.T) {
+		file, err := generator.ExportSettlementReport(context.Background(), req, domain.ReportFormatJSON)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var result domain.SettlementReport
+		if err = json.Unmarshal(file.Data, &result); err != nil {
+			t.Fatalf("Failed to decode JSON: %v", err)
+		}
+
+		if len(result.Days) != 1 || result.Days[0].GrossSales != 100 {
+			t.Errorf("Unexpected report: %+v", result)
+		}
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		_, err := generator.ExportSettlementReport(context.Background(), req, "pdf")
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"kaspi-api-wrapper/internal/domain"
+	"strconv"
+	"strings"
+)
+
+// Levels of the flattened report rows
+const (
+	levelDay        = "day"
+	levelTradePoint = "tradepoint"
+	levelDevice     = "device"
+)
+
+// groupAll marks the ProductType and PaymentMethods columns of a row that is not broken down by them
+const groupAll = "All"
+
+var settlementHeader = []string{
+	"Date", "Level", "TradePointId", "DeviceId", "ProductType", "PaymentMethods",
+	"GrossSales", "Refunds", "Net", "SalesCount", "RefundsCount",
+}
+
+// cell is a spreadsheet value, numbers are kept apart so that XLSX stores them as numbers
+type cell struct {
+	text    string
+	number  float64
+	numeric bool
+}
+
+func textCell(text string) cell {
+	return cell{text: text}
+}
+
+func numberCell(number float64) cell {
+	return cell{text: strconv.FormatFloat(number, 'f', -1, 64), number: number, numeric: true}
+}
+
+// settlementRows flattens a report into a header and one row per summary, product type and
+// payment methods, a day is followed by its trade points and each trade point by its devices
+func settlementRows(report *domain.SettlementReport) [][]cell {
+	header := make([]cell, 0, len(settlementHeader))
+	for _, name := range settlementHeader {
+		header = append(header, textCell(name))
+	}
+
+	rows := [][]cell{header}
+	for _, day := range report.Days {
+		rows = appendSummary(rows, day.Date, levelDay, "", "", day.SettlementSummary)
+
+		for _, tradePoint := range day.TradePoints {
+			tradePointID := strconv.FormatInt(tradePoint.TradePointID, 10)
+			rows = appendSummary(rows, day.Date, levelTradePoint, tradePointID, "", tradePoint.SettlementSummary)
+
+			for _, device := range tradePoint.Devices {
+				rows = appendSummary(rows, day.Date, levelDevice, tradePointID, device.DeviceID, device.SettlementSummary)
+			}
+		}
+	}
+
+	return rows
+}
+
+func appendSummary(rows [][]cell, date, level, tradePointID, deviceID string, summary domain.SettlementSummary) [][]cell {
+	row := func(productType, paymentMethods string, totals domain.SettlementTotals) []cell {
+		return []cell{
+			textCell(date),
+			textCell(level),
+			textCell(tradePointID),
+			textCell(deviceID),
+			textCell(productType),
+			textCell(paymentMethods),
+			numberCell(totals.GrossSales),
+			numberCell(totals.Refunds),
+			numberCell(totals.Net),
+			numberCell(float64(totals.SalesCount)),
+			numberCell(float64(totals.RefundsCount)),
+		}
+	}
+
+	rows = append(rows, row(groupAll, groupAll, summary.SettlementTotals))
+	for _, breakdown := range summary.ByProductType {
+		rows = append(rows, row(breakdown.ProductType, groupAll, breakdown.SettlementTotals))
+	}
+	for _, breakdown := range summary.ByPaymentMethods {
+		rows = append(rows, row(groupAll, strings.Join(breakdown.PaymentMethods, " "), breakdown.SettlementTotals))
+	}
+
+	return rows
+}
+
+func writeCSV(rows [][]cell) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	for _, row := range rows {
+		record := make([]string, 0, len(row))
+		for _, c := range row {
+			record = append(record, c.text)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
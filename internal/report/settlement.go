@@ -0,0 +1,214 @@
+package report
+
+import (
+	"kaspi-api-wrapper/internal/domain"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// accumulator sums entries of a day, a trade point or a device
+type accumulator struct {
+	totals    domain.SettlementTotals
+	byProduct map[string]*domain.SettlementTotals
+	byMethods map[string]*domain.SettlementTotals
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{
+		byProduct: make(map[string]*domain.SettlementTotals),
+		byMethods: make(map[string]*domain.SettlementTotals),
+	}
+}
+
+func (a *accumulator) add(entry domain.SettlementEntry, productType, paymentMethods string) {
+	product, ok := a.byProduct[productType]
+	if !ok {
+		product = &domain.SettlementTotals{}
+		a.byProduct[productType] = product
+	}
+
+	methods, ok := a.byMethods[paymentMethods]
+	if !ok {
+		methods = &domain.SettlementTotals{}
+		a.byMethods[paymentMethods] = methods
+	}
+
+	for _, totals := range []*domain.SettlementTotals{&a.totals, product, methods} {
+		switch entry.Kind {
+		case domain.SettlementSale:
+			totals.GrossSales += entry.Amount
+			totals.SalesCount++
+		case domain.SettlementRefund:
+			totals.Refunds += entry.Amount
+			totals.RefundsCount++
+		}
+	}
+}
+
+func (a *accumulator) summary() domain.SettlementSummary {
+	summary := domain.SettlementSummary{
+		SettlementTotals: finish(a.totals),
+		ByProductType:    make([]domain.SettlementBreakdown, 0, len(a.byProduct)),
+		ByPaymentMethods: make([]domain.SettlementMethodsBreakdown, 0, len(a.byMethods)),
+	}
+
+	for productType, totals := range a.byProduct {
+		summary.ByProductType = append(summary.ByProductType, domain.SettlementBreakdown{
+			ProductType:      productType,
+			SettlementTotals: finish(*totals),
+		})
+	}
+
+	sort.Slice(summary.ByProductType, func(i, j int) bool {
+		return summary.ByProductType[i].ProductType < summary.ByProductType[j].ProductType
+	})
+
+	for paymentMethods, totals := range a.byMethods {
+		summary.ByPaymentMethods = append(summary.ByPaymentMethods, domain.SettlementMethodsBreakdown{
+			PaymentMethods:   strings.Split(paymentMethods, paymentMethodsSeparator),
+			SettlementTotals: finish(*totals),
+		})
+	}
+
+	sort.Slice(summary.ByPaymentMethods, func(i, j int) bool {
+		return strings.Join(summary.ByPaymentMethods[i].PaymentMethods, paymentMethodsSeparator) <
+			strings.Join(summary.ByPaymentMethods[j].PaymentMethods, paymentMethodsSeparator)
+	})
+
+	return summary
+}
+
+// finish rounds the sums to tiyn and computes the net amount
+func finish(totals domain.SettlementTotals) domain.SettlementTotals {
+	totals.GrossSales = round(totals.GrossSales)
+	totals.Refunds = round(totals.Refunds)
+	totals.Net = round(totals.GrossSales - totals.Refunds)
+	return totals
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+type tradePointAccumulator struct {
+	*accumulator
+	devices map[string]*accumulator
+}
+
+type dayAccumulator struct {
+	*accumulator
+	tradePoints map[int64]*tradePointAccumulator
+}
+
+// buildSettlementReport groups the entries by day in the location, then by trade point and device.
+// Every day of the period is listed, days without entries have zero totals
+func buildSettlementReport(entries []domain.SettlementEntry, from, to time.Time, location *time.Location) []domain.SettlementDay {
+	days := make(map[string]*dayAccumulator)
+
+	for _, entry := range entries {
+		date := entry.CreatedAt.In(location).Format(time.DateOnly)
+		productType := resolveProductType(entry)
+		paymentMethods := resolvePaymentMethods(entry)
+
+		day, ok := days[date]
+		if !ok {
+			day = &dayAccumulator{accumulator: newAccumulator(), tradePoints: make(map[int64]*tradePointAccumulator)}
+			days[date] = day
+		}
+
+		tradePoint, ok := day.tradePoints[entry.TradePointID]
+		if !ok {
+			tradePoint = &tradePointAccumulator{accumulator: newAccumulator(), devices: make(map[string]*accumulator)}
+			day.tradePoints[entry.TradePointID] = tradePoint
+		}
+
+		device, ok := tradePoint.devices[entry.DeviceID]
+		if !ok {
+			device = newAccumulator()
+			tradePoint.devices[entry.DeviceID] = device
+		}
+
+		day.add(entry, productType, paymentMethods)
+		tradePoint.add(entry, productType, paymentMethods)
+		device.add(entry, productType, paymentMethods)
+	}
+
+	var result []domain.SettlementDay
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format(time.DateOnly)
+
+		day, ok := days[key]
+		if !ok {
+			day = &dayAccumulator{accumulator: newAccumulator()}
+		}
+
+		result = append(result, domain.SettlementDay{
+			Date:              key,
+			SettlementSummary: day.summary(),
+			TradePoints:       buildTradePoints(day.tradePoints),
+		})
+	}
+
+	return result
+}
+
+func buildTradePoints(tradePoints map[int64]*tradePointAccumulator) []domain.SettlementTradePoint {
+	result := make([]domain.SettlementTradePoint, 0, len(tradePoints))
+
+	for tradePointID, tradePoint := range tradePoints {
+		devices := make([]domain.SettlementDevice, 0, len(tradePoint.devices))
+		for deviceID, device := range tradePoint.devices {
+			devices = append(devices, domain.SettlementDevice{
+				DeviceID:          deviceID,
+				SettlementSummary: device.summary(),
+			})
+		}
+		sort.Slice(devices, func(i, j int) bool {
+			return devices[i].DeviceID < devices[j].DeviceID
+		})
+
+		result = append(result, domain.SettlementTradePoint{
+			TradePointID:      tradePointID,
+			SettlementSummary: tradePoint.summary(),
+			Devices:           devices,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TradePointID < result[j].TradePointID
+	})
+
+	return result
+}
+
+// resolveProductType uses the product type reported by Kaspi for the payment. Payments that allowed
+// a single payment method can only have been paid with it
+func resolveProductType(entry domain.SettlementEntry) string {
+	if entry.ProductType != "" {
+		return entry.ProductType
+	}
+
+	if len(entry.PaymentMethods) == 1 {
+		return entry.PaymentMethods[0]
+	}
+
+	return domain.ProductTypeUnknown
+}
+
+// paymentMethodsSeparator joins the payment methods of a payment into a grouping key
+const paymentMethodsSeparator = ","
+
+// resolvePaymentMethods returns the sorted payment methods offered by the payment, so that payments
+// offering the same methods in another order are grouped together
+func resolvePaymentMethods(entry domain.SettlementEntry) string {
+	if len(entry.PaymentMethods) == 0 {
+		return domain.ProductTypeUnknown
+	}
+
+	methods := append([]string(nil), entry.PaymentMethods...)
+	sort.Strings(methods)
+
+	return strings.Join(methods, paymentMethodsSeparator)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerConfig configures the circuit breaker around the Kaspi HTTP client
+type BreakerConfig struct {
+	FailureThreshold int           // consecutive failures that open the breaker
+	OpenTimeout      time.Duration // time in open state before a trial call is allowed
+	HalfOpenMaxCalls int           // concurrent trial calls allowed in half-open state
+}
+
+// DefaultBreakerConfig returns the breaker configuration used when none is configured
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenMaxCalls: 1,
+	}
+}
+
+type endpointBreaker struct {
+	state    string
+	failures int
+	openedAt time.Time
+	inFlight int
+}
+
+// CircuitBreaker wraps HTTPClient and fails fast while an endpoint is unavailable
+type CircuitBreaker struct {
+	next HTTPClient
+	cfg  BreakerConfig
+	now  func() time.Time
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointBreaker
+}
+
+// NewCircuitBreaker creates a circuit breaker wrapping the given client
+func NewCircuitBreaker(next HTTPClient, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+
+	if cfg.HalfOpenMaxCalls < 1 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+
+	return &CircuitBreaker{
+		next:      next,
+		cfg:       cfg,
+		now:       time.Now,
+		endpoints: make(map[string]*endpointBreaker),
+	}
+}
+
+// Do implements HTTPClient
+func (b *CircuitBreaker) Do(req *http.Request) (*http.Response, error) {
+	key := endpointKey(req)
+
+	if !b.allow(key) {
+		return nil, fmt.Errorf("%s: %w", key, domain.ErrCircuitOpen)
+	}
+
+	resp, err := b.next.Do(req)
+	if err != nil {
+		// the caller gave up, this says nothing about Kaspi
+		if req.Context().Err() != nil {
+			b.release(key)
+			return nil, err
+		}
+
+		b.record(key, false)
+		return nil, err
+	}
+
+	failed, resp, err := isServerFailure(resp)
+	if err != nil {
+		b.record(key, false)
+		return nil, err
+	}
+
+	b.record(key, !failed)
+
+	return resp, nil
+}
+
+// States returns a snapshot of all endpoint breakers sorted by endpoint
+func (b *CircuitBreaker) States() []domain.CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]domain.CircuitBreakerState, 0, len(b.endpoints))
+	for key, e := range b.endpoints {
+		state := domain.CircuitBreakerState{
+			Endpoint: key,
+			State:    b.currentState(e),
+			Failures: e.failures,
+		}
+
+		if e.state != BreakerClosed {
+			openedAt := e.openedAt
+			state.OpenedAt = &openedAt
+		}
+
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Endpoint < states[j].Endpoint
+	})
+
+	return states
+}
+
+// currentState reports open breakers whose timeout has passed as half-open
+func (b *CircuitBreaker) currentState(e *endpointBreaker) string {
+	if e.state == BreakerOpen && b.now().Sub(e.openedAt) >= b.cfg.OpenTimeout {
+		return BreakerHalfOpen
+	}
+
+	return e.state
+}
+
+func (b *CircuitBreaker) allow(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.endpoints[key]
+	if !ok {
+		e = &endpointBreaker{state: BreakerClosed}
+		b.endpoints[key] = e
+	}
+
+	switch b.currentState(e) {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if e.inFlight >= b.cfg.HalfOpenMaxCalls {
+			return false
+		}
+		e.state = BreakerHalfOpen
+	}
+
+	e.inFlight++
+
+	return true
+}
+
+func (b *CircuitBreaker) release(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if e, ok := b.endpoints[key]; ok && e.inFlight > 0 {
+		e.inFlight--
+	}
+}
+
+func (b *CircuitBreaker) record(key string, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e := b.endpoints[key]
+	if e.inFlight > 0 {
+		e.inFlight--
+	}
+
+	if success {
+		e.state = BreakerClosed
+		e.failures = 0
+		return
+	}
+
+	e.failures++
+
+	if e.state == BreakerHalfOpen || e.failures >= b.cfg.FailureThreshold {
+		e.state = BreakerOpen
+		e.openedAt = b.now()
+	}
+}
+
+// isServerFailure checks for 5xx responses and the Kaspi -999 status,
+// the body is buffered and handed back unread to the caller
+func isServerFailure(resp *http.Response) (bool, *http.Response, error) {
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return true, resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false, nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var baseResp struct {
+		StatusCode int `json:"StatusCode"`
+	}
+	if json.Unmarshal(body, &baseResp) == nil && baseResp.StatusCode == -999 {
+		return true, resp, nil
+	}
+
+	return false, resp, nil
+}
+
+// endpointKey groups requests by method and path, numeric ids are collapsed
+// so that e.g. every /payment/status/{id} call shares one breaker
+func endpointKey(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+
+	return req.Method + " " + strings.Join(segments, "/")
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/service"
+	"kaspi-api-wrapper/internal/testutils"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	newRequest := func(path string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://test.com"+path, nil)
+		return req
+	}
+
+	t.Run("opens after consecutive failures and fails fast", func(t *testing.T) {
+		calls := 0
+		mockClient := &testutils.MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				calls++
+				return nil, errors.New("connection reset")
+			},
+		}
+
+		breaker := service.NewCircuitBreaker(mockClient, service.BreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      time.Minute,
+		})
+
+		for i := 0; i < 2; i++ {
+			if _, err := breaker.Do(newRequest("/payment/status/1")); err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+		}
+
+		_, err := breaker.Do(newRequest("/payment/status/2"))
+		if !errors.Is(err, domain.ErrCircuitOpen) {
+			t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("Expected 2 upstream calls, got %d", calls)
+		}
+
+		states := breaker.States()
+		if len(states) != 1 || states[0].State != service.BreakerOpen {
+			t.Fatalf("Expected one open breaker, got %+v", states)
+		}
+
+		if states[0].Endpoint != "GET /payment/status/{id}" {
+			t.Errorf("Expected endpoint GET /payment/status/{id}, got %s", states[0].Endpoint)
+		}
+	})
+
+	t.Run("counts failures per endpoint", func(t *testing.T) {
+		mockClient := &testutils.MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/partner/tradepoints" {
+					return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0}`), nil
+				}
+				return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -999, "Message": "Service unavailable"}`), nil
+			},
+		}
+
+		breaker := service.NewCircuitBreaker(mockClient, service.BreakerConfig{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Minute,
+		})
+
+		breaker.Do(newRequest("/payment/status/1"))
+
+		if _, err := breaker.Do(newRequest("/payment/status/1")); !errors.Is(err, domain.ErrCircuitOpen) {
+			t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+		}
+
+		if _, err := breaker.Do(newRequest("/partner/tradepoints")); err != nil {
+			t.Fatalf("Expected other endpoint to stay closed, got %v", err)
+		}
+	})
+
+	t.Run("closes after successful half-open call", func(t *testing.T) {
+		fail := true
+		mockClient := &testutils.MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if fail {
+					return testutils.NewMockResponse(http.StatusBadGateway, ``), nil
+				}
+				return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0}`), nil
+			},
+		}
+
+		breaker := service.NewCircuitBreaker(mockClient, service.BreakerConfig{
+			FailureThreshold: 1,
+			OpenTimeout:      10 * time.Millisecond,
+		})
+
+		breaker.Do(newRequest("/health/ping"))
+
+		time.Sleep(20 * time.Millisecond)
+
+		if states := breaker.States(); states[0].State != service.BreakerHalfOpen {
+			t.Fatalf("Expected half-open breaker, got %s", states[0].State)
+		}
+
+		fail = false
+		if _, err := breaker.Do(newRequest("/health/ping")); err != nil {
+			t.Fatalf("Expected trial call to pass, got %v", err)
+		}
+
+		if states := breaker.States(); states[0].State != service.BreakerClosed {
+			t.Errorf("Expected closed breaker, got %s", states[0].State)
+		}
+	})
+
+	t.Run("service reports circuit open without retrying", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("connection reset")
+		}
+
+		svc.EnableCircuitBreaker(service.BreakerConfig{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Minute,
+		})
+
+		_, err := svc.GetTradePoints(context.Background())
+		if !errors.Is(err, domain.ErrCircuitOpen) {
+			t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 upstream call, got %d", calls)
+		}
+
+		if states := svc.CircuitBreakerStates(); len(states) != 1 {
+			t.Errorf("Expected 1 breaker state, got %d", len(states))
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+)
+
+// DeviceRegistry reads back the devices saved on registration and marks deleted ones
+type DeviceRegistry interface {
+	Devices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	Device(ctx context.Context, deviceID string) (*domain.Device, error)
+	DeviceByToken(ctx context.Context, deviceToken string) (*domain.Device, error)
+	DeactivateDevice(ctx context.Context, deviceToken string) error
+}
+
+// ListDevices returns the devices registered through the wrapper, newest first
+func (s *KaspiService) ListDevices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	const op = "service.kaspi.ListDevices"
+
+	devices, err := s.deviceRegistry.Devices(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if devices == nil {
+		devices = []domain.Device{}
+	}
+
+	return devices, nil
+}
+
+// GetDevice returns a registered device by its ID
+func (s *KaspiService) GetDevice(ctx context.Context, deviceID string) (*domain.Device, error) {
+	const op = "service.kaspi.GetDevice"
+
+	if deviceID == "" {
+		return nil, &validator.ValidationError{
+			Field:   "deviceId",
+			Message: "device ID is required",
+			Err:     validator.ErrRequiredField,
+		}
+	}
+
+	device, err := s.deviceRegistry.Device(ctx, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return device, nil
+}
+
+// deactivateDevice soft deletes the stored device after it was deleted in Kaspi. The device is gone
+// in Kaspi at this point, so a storage failure is only logged instead of failing the request
+func (s *KaspiService) deactivateDevice(ctx context.Context, log *slog.Logger, deviceToken string) {
+	s.deviceTokens.forgetToken(deviceToken)
+
+	err := s.deviceRegistry.DeactivateDevice(ctx, deviceToken)
+	switch {
+	case err == nil:
+		log.Debug("device deactivated in database")
+	case errors.Is(err, storage.ErrDeviceNotFound):
+		// devices registered before the wrapper was used are not stored
+		log.Debug("deleted device is not stored")
+	default:
+		log.Error("failed to deactivate device in database", "error", err.Error())
+	}
+}
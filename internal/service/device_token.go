@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultDeviceTokenTTL is how long a token resolved from a DeviceId is reused without a storage lookup
+const DefaultDeviceTokenTTL = 5 * time.Minute
+
+// deviceTokenCache keeps the tokens of active devices by DeviceId and organization BIN,
+// the zero value is ready to use
+type deviceTokenCache struct {
+	mu      sync.Mutex
+	entries map[string]deviceTokenEntry
+}
+
+type deviceTokenEntry struct {
+	token   string
+	expires time.Time
+}
+
+func deviceTokenKey(deviceID, organizationBin string) string {
+	return deviceID + "|" + organizationBin
+}
+
+func (c *deviceTokenCache) get(key string, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if !now.Before(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+
+	return entry.token, true
+}
+
+func (c *deviceTokenCache) put(key, token string, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]deviceTokenEntry)
+	}
+	c.entries[key] = deviceTokenEntry{token: token, expires: expires}
+}
+
+// forgetDevice drops the cached token of a device that was registered again
+func (c *deviceTokenCache) forgetDevice(deviceID, organizationBin string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, deviceTokenKey(deviceID, organizationBin))
+}
+
+// forgetToken drops a deleted token, whatever DeviceId it was cached for
+func (c *deviceTokenCache) forgetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.token == token {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// SetDeviceTokenTTL sets how long resolved device tokens are cached, zero disables the cache
+func (s *KaspiService) SetDeviceTokenTTL(ttl time.Duration) {
+	s.deviceTokenTTL = ttl
+}
+
+// ResolveDeviceToken returns the token of the registered device. A non-empty deviceToken is returned
+// as is, so clients holding tokens keep working. Otherwise the device is looked up by its ID, standard
+// devices have no organization BIN and enhanced devices must belong to organizationBin
+func (s *KaspiService) ResolveDeviceToken(ctx context.Context, deviceToken, deviceID, organizationBin string) (string, error) {
+	const op = "service.kaspi.ResolveDeviceToken"
+
+	if deviceToken != "" || deviceID == "" {
+		return deviceToken, nil
+	}
+
+	if s.scheme == "enhanced" && organizationBin == "" {
+		return "", &validator.ValidationError{
+			Field:   "organizationBin",
+			Message: "organization BIN is required",
+			Err:     validator.ErrRequiredField,
+		}
+	}
+
+	key := deviceTokenKey(deviceID, organizationBin)
+	now := time.Now()
+
+	if token, ok := s.deviceTokens.get(key, now); ok {
+		return token, nil
+	}
+
+	device, err := s.deviceRegistry.Device(ctx, deviceID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceNotFound) {
+			return "", fmt.Errorf("%s: %s: %w", op, deviceID, domain.ErrDeviceNotRegistered)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if device.OrganizationBin != organizationBin {
+		return "", fmt.Errorf("%s: %s: %w", op, deviceID, domain.ErrDeviceNotRegistered)
+	}
+
+	if !device.Active {
+		return "", fmt.Errorf("%s: %s: %w", op, deviceID, domain.ErrDeviceInactive)
+	}
+
+	if s.deviceTokenTTL > 0 {
+		s.deviceTokens.put(key, device.DeviceToken, now.Add(s.deviceTokenTTL))
+	}
+
+	return device.DeviceToken, nil
+}
+
+// deviceAttr identifies the device of a request in logs, a token resolved from the DeviceId is never
+// logged and the DeviceId is logged instead
+func deviceAttr(deviceToken, deviceID string) slog.Attr {
+	if deviceToken == "" && deviceID != "" {
+		return slog.String("deviceId", deviceID)
+	}
+	return slog.String("deviceToken", deviceToken)
+}
+
+// remoteDeviceAttr is deviceAttr for the numeric tokens of remote payments
+func remoteDeviceAttr(deviceToken int64, deviceID string) slog.Attr {
+	if deviceToken == 0 && deviceID != "" {
+		return slog.String("deviceId", deviceID)
+	}
+	return slog.Int64("deviceToken", deviceToken)
+}
+
+// resolveDevice replaces the DeviceId of a request with the token of the device, so the DeviceId
+// is never sent to Kaspi
+func (s *KaspiService) resolveDevice(ctx context.Context, deviceToken, deviceID *string, organizationBin string) error {
+	token, err := s.ResolveDeviceToken(ctx, *deviceToken, *deviceID, organizationBin)
+	if err != nil {
+		return err
+	}
+
+	*deviceToken = token
+	*deviceID = ""
+
+	return nil
+}
+
+// resolveRemoteDevice is resolveDevice for the remote payment requests, which take numeric tokens
+func (s *KaspiService) resolveRemoteDevice(ctx context.Context, deviceToken *int64, deviceID *string, organizationBin string) error {
+	if *deviceToken != 0 || *deviceID == "" {
+		*deviceID = ""
+		return nil
+	}
+
+	token, err := s.ResolveDeviceToken(ctx, "", *deviceID, organizationBin)
+	if err != nil {
+		return err
+	}
+
+	numeric, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return &validator.ValidationError{
+			Field:   "deviceId",
+			Message: "device token of the device is not numeric and cannot be used for remote payments",
+			Err:     validator.ErrInvalidToken,
+		}
+	}
+
+	*deviceToken = numeric
+	*deviceID = ""
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/testutils"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// deviceLookup returns a storage that knows the devices by ID and counts the lookups
+func deviceLookup(lookups *int32, devices ...domain.Device) *MockStorage {
+	return &MockStorage{
+		DeviceFunc: func(ctx context.Context, deviceID string) (*domain.Device, error) {
+			atomic.AddInt32(lookups, 1)
+			for _, device := range devices {
+				if device.DeviceID == deviceID {
+					return &device, nil
+				}
+			}
+			return nil, storage.ErrDeviceNotFound
+		},
+	}
+}
+
+func TestResolveDeviceToken(t *testing.T) {
+	t.Run("sends token of the device to Kaspi", func(t *testing.T) {
+		var lookups int32
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic",
+			deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "test-token", Active: true}))
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			req.Body.Close()
+
+			var sent map[string]any
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Errorf("Failed to parse request body: %v", err)
+			}
+
+			if sent["DeviceToken"] != "test-token" {
+				t.Errorf("Expected DeviceToken test-token, got %v", sent["DeviceToken"])
+			}
+			if _, ok := sent["DeviceId"]; ok {
+				t.Errorf("DeviceId must not be sent to Kaspi")
+			}
+
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceID: "POS-1", Amount: 200}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		if lookups != 1 {
+			t.Errorf("Expected 1 device lookup with cache, got %d", lookups)
+		}
+	})
+
+	t.Run("prefers device token from the request", func(t *testing.T) {
+		var lookups int32
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", deviceLookup(&lookups))
+
+		token, err := svc.ResolveDeviceToken(context.Background(), "test-token", "POS-1", "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if token != "test-token" || lookups != 0 {
+			t.Errorf("Expected test-token without lookup, got %s after %d lookups", token, lookups)
+		}
+	})
+
+	t.Run("rejects unknown device", func(t *testing.T) {
+		var lookups int32
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", deviceLookup(&lookups))
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			t.Error("Kaspi must not be called")
+			return nil, nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceID: "POS-1", Amount: 200})
+		if !errors.Is(err, domain.ErrDeviceNotRegistered) {
+			t.Errorf("Expected ErrDeviceNotRegistered, got %v", err)
+		}
+	})
+
+	t.Run("rejects deleted device", func(t *testing.T) {
+		var lookups int32
+		deletedAt := time.Now()
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "standard",
+			deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "test-token", DeletedAt: &deletedAt}))
+
+		_, err := svc.CreateRefundQR(context.Background(), domain.QRRefundCreateRequest{DeviceID: "POS-1"})
+		if !errors.Is(err, domain.ErrDeviceInactive) {
+			t.Errorf("Expected ErrDeviceInactive, got %v", err)
+		}
+	})
+
+	t.Run("requires device of the organization in enhanced scheme", func(t *testing.T) {
+		var lookups int32
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "enhanced",
+			deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "test-token", OrganizationBin: "180340021791", Active: true}))
+
+		_, err := svc.ResolveDeviceToken(context.Background(), "", "POS-1", "123456789012")
+		if !errors.Is(err, domain.ErrDeviceNotRegistered) {
+			t.Errorf("Expected ErrDeviceNotRegistered, got %v", err)
+		}
+
+		token, err := svc.ResolveDeviceToken(context.Background(), "", "POS-1", "180340021791")
+		if err != nil || token != "test-token" {
+			t.Errorf("Expected test-token, got %q and %v", token, err)
+		}
+	})
+
+	t.Run("drops cached token of deleted device", func(t *testing.T) {
+		var lookups int32
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic",
+			deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "test-token", Active: true}))
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Message": "OK"}`), nil
+		}
+
+		if _, err := svc.ResolveDeviceToken(context.Background(), "", "POS-1", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := svc.DeleteDevice(context.Background(), "test-token"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, err := svc.ResolveDeviceToken(context.Background(), "", "POS-1", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if lookups != 2 {
+			t.Errorf("Expected 2 device lookups, got %d", lookups)
+		}
+	})
+
+	t.Run("resolves numeric token of remote payment", func(t *testing.T) {
+		var lookups int32
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "enhanced",
+			deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "9507", OrganizationBin: "180340021791", Active: true}))
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			req.Body.Close()
+
+			var sent domain.RemotePaymentRequest
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Errorf("Failed to parse request body: %v", err)
+			}
+
+			if sent.DeviceToken != 9507 || sent.DeviceID != "" {
+				t.Errorf("Unexpected remote payment request: %+v", sent)
+			}
+
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Message": "OK", "Data": {"QrPaymentId": 15}}`), nil
+		}
+
+		_, err := svc.CreateRemotePayment(context.Background(), domain.RemotePaymentRequest{
+			OrganizationBin: "180340021791",
+			Amount:          200,
+			PhoneNumber:     "87071234567",
+			DeviceID:        "POS-1",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+}
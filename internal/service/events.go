@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// recordPaymentStatus stores the payment status and publishes an event if it has changed
+func (s *KaspiService) recordPaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) error {
+	previousStatus, err := s.paymentStorage.UpdatePaymentStatus(ctx, qrPaymentID, status)
+	if err != nil {
+		return err
+	}
+
+	if previousStatus == status.Status || s.publisher == nil {
+		return nil
+	}
+
+	payment, err := s.paymentStorage.Payment(ctx, qrPaymentID)
+	if err != nil {
+		return err
+	}
+
+	eventType := domain.EventPaymentStatusChanged
+	if payment.Kind == domain.PaymentKindRemote {
+		eventType = domain.EventRemotePaymentStatusChanged
+	}
+
+	s.publish(ctx, eventType, qrPaymentID, previousStatus, status.Status, domain.NewPaymentEventData(*payment))
+
+	return nil
+}
+
+// recordRefundStatus stores the refund status and publishes an event if it has changed
+func (s *KaspiService) recordRefundStatus(ctx context.Context, qrReturnID int64, status string) error {
+	previousStatus, err := s.refundStorage.UpdateRefundStatus(ctx, qrReturnID, status)
+	if err != nil {
+		return err
+	}
+
+	if previousStatus == status || s.publisher == nil {
+		return nil
+	}
+
+	refund, err := s.refundStorage.RefundQR(ctx, qrReturnID)
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, domain.EventRefundStatusChanged, qrReturnID, previousStatus, status, domain.NewRefundEventData(*refund))
+
+	return nil
+}
+
+// publish hands the event over to the publisher, the status is already stored
+// so a failure is logged instead of being returned to the caller
+func (s *KaspiService) publish(ctx context.Context, eventType string, subjectID int64, previousStatus, status string, data any) {
+	const op = "service.kaspi.publish"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("type", eventType),
+		slog.Int64("subjectID", subjectID),
+	)
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Error("failed to encode event data", "error", err.Error())
+		return
+	}
+
+	// the event must be persisted even if the caller has already gone away
+	err = s.publisher.Publish(context.WithoutCancel(ctx), domain.WebhookEvent{
+		Type:           eventType,
+		SubjectID:      subjectID,
+		PreviousStatus: previousStatus,
+		Status:         status,
+		OccurredAt:     time.Now(),
+		Data:           payload,
+	})
+	if err != nil {
+		log.Error("failed to publish status change event", "error", err.Error())
+	}
+}
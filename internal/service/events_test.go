@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/testutils"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type MockEventPublisher struct {
+	events []domain.WebhookEvent
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event domain.WebhookEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestStatusChangeEvents(t *testing.T) {
+	t.Run("publishes payment status change", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				return domain.PaymentStatusWait, nil
+			},
+			PaymentFunc: func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+				return &domain.Payment{QrPaymentID: qrPaymentID, Kind: domain.PaymentKindQR, DeviceToken: "secret-token", Status: domain.PaymentStatusProcessed}, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store)
+		publisher := &MockEventPublisher{}
+		svc.SetEventPublisher(publisher)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"Status": "Processed"}}`), nil
+		}
+
+		_, err := svc.GetPaymentStatus(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(publisher.events) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(publisher.events))
+		}
+
+		event := publisher.events[0]
+		if event.Type != domain.EventPaymentStatusChanged || event.SubjectID != 15 ||
+			event.PreviousStatus != domain.PaymentStatusWait || event.Status != domain.PaymentStatusProcessed {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+
+		if strings.Contains(string(event.Data), "secret-token") || strings.Contains(string(event.Data), "DeviceToken") {
+			t.Errorf("Expected event data without the device token, got %s", event.Data)
+		}
+	})
+
+	t.Run("does not publish unchanged status", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				return status.Status, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store)
+		publisher := &MockEventPublisher{}
+		svc.SetEventPublisher(publisher)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"Status": "Wait"}}`), nil
+		}
+
+		_, err := svc.GetPaymentStatus(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(publisher.events) != 0 {
+			t.Errorf("Expected no events, got %d", len(publisher.events))
+		}
+	})
+
+	t.Run("publishes remote payment expiry", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				return domain.PaymentStatusCreated, nil
+			},
+			PaymentFunc: func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+				return &domain.Payment{QrPaymentID: qrPaymentID, Kind: domain.PaymentKindRemote}, nil
+			},
+		}
+
+		svc, _ := setupTestServiceWithStorage(log, "enhanced", store)
+		publisher := &MockEventPublisher{}
+		svc.SetEventPublisher(publisher)
+
+		if err := svc.ExpirePayment(context.Background(), 15); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(publisher.events) != 1 || publisher.events[0].Type != domain.EventRemotePaymentStatusChanged ||
+			publisher.events[0].Status != domain.PaymentStatusExpired {
+			t.Errorf("Unexpected events: %+v", publisher.events)
+		}
+	})
+
+	t.Run("publishes refund status change", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			UpdateRefundStatusFunc: func(ctx context.Context, qrReturnID int64, status string) (string, error) {
+				return domain.PaymentStatusCreated, nil
+			},
+			RefundQRFunc: func(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error) {
+				return &domain.RefundQR{QrReturnID: qrReturnID, DeviceToken: "secret-token", Status: "Wait"}, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "standard", store)
+		publisher := &MockEventPublisher{}
+		svc.SetEventPublisher(publisher)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"Status": "Wait"}}`), nil
+		}
+
+		_, err := svc.GetRefundStatus(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(publisher.events) != 1 || publisher.events[0].Type != domain.EventRefundStatusChanged {
+			t.Fatalf("Unexpected events: %+v", publisher.events)
+		}
+
+		if strings.Contains(string(publisher.events[0].Data), "secret-token") {
+			t.Errorf("Expected event data without the device token, got %s", publisher.events[0].Data)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+)
+
+// keyedMutex serializes work per key, the zero value is ready to use
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks the key and returns a function that unlocks it
+func (m *keyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyedLock)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		m.locks[key] = lock
+	}
+	lock.refs++
+	m.mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		m.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(m.locks, key)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// lockExternalID serializes payment creation for the ExternalId within its device or organization,
+// so concurrent duplicates wait for the first one and then get the payment it created. Duplicates
+// of this instance wait in process, the storage lock covers the other instances sharing the database.
+// The lookup and the save of the payment must use the returned context, it carries the storage lock
+func (s *KaspiService) lockExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (context.Context, func(), error) {
+	scope := "device:" + deviceToken
+	if organizationBin != "" {
+		scope = "organization:" + organizationBin
+	}
+
+	key := strings.Join([]string{kind, scope, externalID}, "|")
+
+	unlock := s.externalIDLocks.Lock(key)
+
+	lockedCtx, release, err := s.paymentStorage.LockExternalID(ctx, key)
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+
+	return lockedCtx, func() {
+		release()
+		unlock()
+	}, nil
+}
+
+// livePayment returns a payment created earlier for the ExternalId that can still be paid,
+// or nil if there is none and a new one has to be created
+func (s *KaspiService) livePayment(ctx context.Context, log *slog.Logger, kind, externalID, deviceToken, organizationBin string, amount float64) (*domain.Payment, error) {
+	payment, err := s.paymentStorage.LivePaymentByExternalID(ctx, kind, externalID, deviceToken, organizationBin)
+	if err != nil {
+		if errors.Is(err, storage.ErrPaymentNotFound) {
+			return nil, nil
+		}
+		log.Error("failed to look up payment by external ID", "externalId", externalID, "error", err.Error())
+		return nil, err
+	}
+
+	if math.Abs(payment.Amount-amount) >= 0.005 {
+		log.Warn("external ID is used by a live payment with a different amount",
+			"externalId", externalID,
+			"qrPaymentID", payment.QrPaymentID,
+			"amount", payment.Amount,
+		)
+		return nil, domain.ErrExternalIDInUse
+	}
+
+	log.Info("returning live payment created earlier for external ID",
+		"externalId", externalID,
+		"qrPaymentID", payment.QrPaymentID,
+	)
+
+	return payment, nil
+}
+
+// GetPaymentsByExternalID returns all stored payments created with the ExternalId, newest first
+func (s *KaspiService) GetPaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	const op = "service.kaspi.GetPaymentsByExternalID"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("externalId", externalID),
+	)
+
+	if err := validator.ValidateExternalID(externalID); err != nil {
+		log.Warn("invalid external ID", "error", err.Error())
+		return nil, err
+	}
+
+	payments, err := s.paymentStorage.PaymentsByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrPaymentNotFound)
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,288 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/testutils"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const qrCreateResponseBody = `{
+	"StatusCode": 0,
+	"Message": "OK",
+	"Data": {
+		"QrToken": "51236903777280167836178166503744993984459",
+		"ExpireDate": "2030-05-16T10:30:00+06:00",
+		"QrPaymentId": 15,
+		"PaymentMethods": ["Gold", "Red", "Loan"],
+		"QrPaymentBehaviorOptions": {
+			"StatusPollingInterval": 5,
+			"QrCodeScanWaitTimeout": 180,
+			"PaymentConfirmationTimeout": 65
+		}
+	}
+}`
+
+// memoryPaymentStorage keeps saved payments so that later lookups see them
+type memoryPaymentStorage struct {
+	mu       sync.Mutex
+	payments []domain.Payment
+}
+
+func (m *memoryPaymentStorage) mock() *MockStorage {
+	return &MockStorage{
+		SavePaymentFunc: func(ctx context.Context, payment domain.Payment) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.payments = append(m.payments, payment)
+			return nil
+		},
+		LivePaymentFunc: func(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			for i := len(m.payments) - 1; i >= 0; i-- {
+				p := m.payments[i]
+				if p.Kind == kind && p.ExternalID == externalID && p.DeviceToken == deviceToken &&
+					p.OrganizationBin == organizationBin && p.IsLive(time.Now()) {
+					return &p, nil
+				}
+			}
+			return nil, storage.ErrPaymentNotFound
+		},
+	}
+}
+
+func TestCreateQRExternalIDDeduplication(t *testing.T) {
+	t.Run("returns live QR instead of creating a new one", func(t *testing.T) {
+		log := setupTestLogger()
+		store := &memoryPaymentStorage{}
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store.mock())
+
+		var calls int32
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		req := domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"}
+
+		first, err := svc.CreateQR(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		second, err := svc.CreateQR(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 Kaspi call, got %d", calls)
+		}
+
+		if second.QrToken != first.QrToken || second.QrPaymentID != first.QrPaymentID {
+			t.Errorf("Expected the same QR, got %+v and %+v", first, second)
+		}
+
+		if second.QrPaymentBehaviorOptions != first.QrPaymentBehaviorOptions {
+			t.Errorf("Expected behavior options %+v, got %+v", first.QrPaymentBehaviorOptions, second.QrPaymentBehaviorOptions)
+		}
+	})
+
+	t.Run("creates one QR for concurrent duplicates", func(t *testing.T) {
+		log := setupTestLogger()
+		store := &memoryPaymentStorage{}
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store.mock())
+
+		var calls int32
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		req := domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := svc.CreateQR(context.Background(), req); err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("Expected 1 Kaspi call, got %d", calls)
+		}
+	})
+
+	t.Run("creates one QR for duplicates on different instances", func(t *testing.T) {
+		log := setupTestLogger()
+		store := &memoryPaymentStorage{}
+
+		// the storage lock stands in for the database lock shared by the instances
+		var dbLock sync.Mutex
+		var calls int32
+
+		req := domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			mock := store.mock()
+			mock.LockExternalIDFunc = func(ctx context.Context, key string) (context.Context, func(), error) {
+				dbLock.Lock()
+				return ctx, dbLock.Unlock, nil
+			}
+
+			svc, mockClient := setupTestServiceWithStorage(log, "basic", mock)
+			mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := svc.CreateQR(context.Background(), req); err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("Expected 1 Kaspi call, got %d", calls)
+		}
+	})
+
+	t.Run("fails when the ExternalId cannot be locked", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", &MockStorage{
+			LockExternalIDFunc: func(ctx context.Context, key string) (context.Context, func(), error) {
+				return nil, nil, errors.New("connection refused")
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			t.Error("Kaspi must not be called")
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"})
+		if err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("rejects live ExternalId with a different amount", func(t *testing.T) {
+		log := setupTestLogger()
+		store := &memoryPaymentStorage{}
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store.mock())
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceToken: "test-token", Amount: 300.00, ExternalID: "ORDER-15"})
+		if !errors.Is(err, domain.ErrExternalIDInUse) {
+			t.Errorf("Expected ErrExternalIDInUse, got %v", err)
+		}
+	})
+
+	t.Run("creates new QR for another device", func(t *testing.T) {
+		log := setupTestLogger()
+		store := &memoryPaymentStorage{}
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store.mock())
+
+		var calls int32
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		for _, token := range []string{"token-1", "token-2"} {
+			_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceToken: token, Amount: 200.00, ExternalID: "ORDER-15"})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		if calls != 2 {
+			t.Errorf("Expected 2 Kaspi calls, got %d", calls)
+		}
+	})
+
+	t.Run("fails when stored payments cannot be checked", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", &MockStorage{
+			LivePaymentFunc: func(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+				return nil, errors.New("connection refused")
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			t.Error("Kaspi must not be called")
+			return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{DeviceToken: "test-token", Amount: 200.00, ExternalID: "ORDER-15"})
+		if err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
+func TestGetPaymentsByExternalID(t *testing.T) {
+	t.Run("returns stored payments", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{
+			PaymentsByExternalFunc: func(ctx context.Context, externalID string) ([]domain.Payment, error) {
+				return []domain.Payment{{QrPaymentID: 15, ExternalID: externalID}}, nil
+			},
+		})
+
+		payments, err := svc.GetPaymentsByExternalID(context.Background(), "ORDER-15")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(payments) != 1 || payments[0].QrPaymentID != 15 {
+			t.Errorf("Unexpected payments: %+v", payments)
+		}
+	})
+
+	t.Run("returns not found without payments", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{})
+
+		_, err := svc.GetPaymentsByExternalID(context.Background(), "ORDER-15")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rejects empty external ID", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{})
+
+		if _, err := svc.GetPaymentsByExternalID(context.Background(), " "); err == nil {
+			t.Error("Expected validation error, got nil")
+		}
+	})
+}
@@ -32,8 +32,26 @@ type KaspiService struct {
 	baseURLEnh   string
 	httpClient   HTTPClient
 	apiKey       string
+	retryPolicy  RetryPolicy
+	breaker      *CircuitBreaker
 
-	deviceSaver DeviceSaver
+	deviceSaver    DeviceSaver
+	deviceRegistry DeviceRegistry
+	organizations  OrganizationRegistry
+	tradePoints    TradePointCache
+	paymentStorage PaymentStorage
+	refundStorage  RefundStorage
+	tracker        PaymentTracker
+	publisher      EventPublisher
+
+	externalIDLocks keyedMutex
+
+	deviceTokens   deviceTokenCache
+	deviceTokenTTL time.Duration
+
+	remotePaymentTimeout time.Duration
+
+	tradePointStaleAfter time.Duration
 }
 
 // TLSConfig for scheme 2 & 3
@@ -49,6 +67,46 @@ type DeviceSaver interface {
 	SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error
 }
 
+type PaymentStorage interface {
+	SavePayment(ctx context.Context, payment domain.Payment) error
+	UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error)
+	Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error)
+	LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error)
+	LockExternalID(ctx context.Context, key string) (context.Context, func(), error)
+	PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error)
+	PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error)
+	ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error)
+}
+
+type RefundStorage interface {
+	SaveRefundQR(ctx context.Context, refund domain.RefundQR) error
+	UpdateRefundStatus(ctx context.Context, qrReturnID int64, status string) (string, error)
+	RefundQR(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error)
+	ReserveRefund(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error)
+	CompleteRefund(ctx context.Context, id int64, returnOperationID int64) error
+	FailRefund(ctx context.Context, id int64, reason string) error
+}
+
+// PaymentTracker follows created payments until they reach a terminal status
+type PaymentTracker interface {
+	Track(qrPaymentID int64, opts domain.PollingOptions)
+}
+
+// EventPublisher is notified about every payment and refund status change
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.WebhookEvent) error
+}
+
+// Storage combines all storage dependencies of the service
+type Storage interface {
+	DeviceSaver
+	DeviceRegistry
+	OrganizationRegistry
+	TradePointCache
+	PaymentStorage
+	RefundStorage
+}
+
 func NewKaspiService(log *slog.Logger,
 	scheme string,
 	baseURLBasic string,
@@ -57,7 +115,7 @@ func NewKaspiService(log *slog.Logger,
 	apiKey string,
 	tlsConfig *TLSConfig,
 
-	deviceSaver DeviceSaver,
+	store Storage,
 ) *KaspiService {
 	var httpClient *http.Client
 	var err error
@@ -92,8 +150,17 @@ func NewKaspiService(log *slog.Logger,
 		baseURLEnh:   baseURLEnh,
 		httpClient:   httpClient,
 		apiKey:       apiKey,
+		retryPolicy:  DefaultRetryPolicy(),
 
-		deviceSaver: deviceSaver,
+		deviceTokenTTL:       DefaultDeviceTokenTTL,
+		tradePointStaleAfter: DefaultTradePointStaleAfter,
+
+		deviceSaver:    store,
+		deviceRegistry: store,
+		organizations:  store,
+		tradePoints:    store,
+		paymentStorage: store,
+		refundStorage:  store,
 	}
 }
 
@@ -174,6 +241,36 @@ func (s *KaspiService) SetHTTPClient(client HTTPClient) {
 	s.httpClient = client
 }
 
+// SetRetryPolicy sets the retry policy for outbound Kaspi calls
+func (s *KaspiService) SetRetryPolicy(policy RetryPolicy) {
+	s.retryPolicy = policy
+}
+
+// EnableCircuitBreaker wraps the current HTTP client with a circuit breaker
+func (s *KaspiService) EnableCircuitBreaker(cfg BreakerConfig) {
+	s.breaker = NewCircuitBreaker(s.httpClient, cfg)
+	s.httpClient = s.breaker
+}
+
+// CircuitBreakerStates returns the state of every endpoint breaker
+func (s *KaspiService) CircuitBreakerStates() []domain.CircuitBreakerState {
+	if s.breaker == nil {
+		return []domain.CircuitBreakerState{}
+	}
+
+	return s.breaker.States()
+}
+
+// SetPaymentTracker sets the tracker that is notified about every created payment
+func (s *KaspiService) SetPaymentTracker(tracker PaymentTracker) {
+	s.tracker = tracker
+}
+
+// SetEventPublisher sets the publisher that receives status change events
+func (s *KaspiService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
 // GetBaseURL retrieves the base URL based on the current scheme
 func (s *KaspiService) GetBaseURL() string {
 	switch s.scheme {
@@ -200,78 +297,7 @@ func (s *KaspiService) Request(ctx context.Context, method, path string, body, r
 		slog.String("url", url),
 	)
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
-		}
-		reqBody = bytes.NewBuffer(jsonData)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Request-ID", generateRequestID())
-
-	// Api-Key for request via first scheme
-	if s.scheme == "basic" {
-		req.Header.Set("Api-Key", s.apiKey)
-	} else {
-		log.Debug("using certificate auth", "scheme", s.scheme)
-	}
-
-	log.Debug("sending request")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	log.Debug("received response", "status", resp.Status, "body", string(respBody))
-
-	var baseResp domain.BaseResponse
-	err = json.Unmarshal(respBody, &baseResp)
-	if err != nil {
-		fmt.Println(respBody)
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if baseResp.StatusCode != 0 {
-		kaspiErr := &domain.KaspiError{
-			StatusCode: baseResp.StatusCode,
-			Message:    baseResp.Message,
-		}
-
-		if kaspiErr.StatusCode == -10000 {
-			log.Error("certificate authentication failed - check your client certificate setup")
-		}
-
-		return kaspiErr
-	}
-
-	if result != nil && baseResp.Data != nil {
-		dataJSON, err := json.Marshal(baseResp.Data)
-		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
-		}
-
-		err = json.Unmarshal(dataJSON, result)
-		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
-		}
-	}
-
-	return nil
+	return s.doWithRetry(ctx, log, op, method, url, body, result)
 }
 
 // request makes a general request to the Kaspi API
@@ -296,13 +322,65 @@ func (s *KaspiService) requestV02(ctx context.Context, method, path string, body
 		slog.String("version", version),
 	)
 
-	var reqBody io.Reader
+	return s.doWithRetry(ctx, log, op, method, url, body, result)
+}
+
+// doWithRetry sends the request and repeats it according to the retry policy
+func (s *KaspiService) doWithRetry(ctx context.Context, log *slog.Logger, op, method, url string, body, result any) error {
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("%s:%w", op, err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	maxAttempts := s.retryPolicy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.do(ctx, log, op, method, url, jsonData, result)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= maxAttempts || !shouldRetry(ctx, method, err) {
+			return err
+		}
+
+		delay := s.retryPolicy.backoff(attempt)
+
+		// do not sleep past the caller's deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			log.Warn("retry skipped, context deadline too close", "attempt", attempt, "error", err.Error())
+			return err
+		}
+
+		log.Warn("request failed, retrying",
+			"attempt", attempt,
+			"max_attempts", maxAttempts,
+			"delay", delay,
+			"error", err.Error(),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// do performs a single request to the Kaspi API
+func (s *KaspiService) do(ctx context.Context, log *slog.Logger, op, method, url string, jsonData []byte, result any) error {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
@@ -313,6 +391,7 @@ func (s *KaspiService) requestV02(ctx context.Context, method, path string, body
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Request-ID", generateRequestID())
 
+	// Api-Key for request via first scheme
 	if s.scheme == "basic" {
 		req.Header.Set("Api-Key", s.apiKey)
 	} else {
@@ -323,13 +402,13 @@ func (s *KaspiService) requestV02(ctx context.Context, method, path string, body
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
+		return fmt.Errorf("%s:%w", op, &transportError{err: err})
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
+		return fmt.Errorf("%s:%w", op, &transportError{err: err})
 	}
 
 	log.Debug("received response", "status", resp.Status, "body", string(respBody))
@@ -370,7 +449,8 @@ func (s *KaspiService) requestV02(ctx context.Context, method, path string, body
 
 //////// 	Device service methods	////////
 
-// GetTradePoints retrieves list of trade points from Kaspi API (2.2.2)
+// GetTradePoints returns the trade points of the partner (2.2.2) from the trade point cache, the
+// cache is synced with Kaspi when it is empty or stale
 func (s *KaspiService) GetTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
 	const op = "service.kaspi.GetTradePoints"
 
@@ -384,10 +464,7 @@ func (s *KaspiService) GetTradePoints(ctx context.Context) ([]domain.TradePoint,
 
 	log.Debug("getting all trade points")
 
-	path := "/partner/tradepoints"
-
-	var result []domain.TradePoint
-	err := s.request(ctx, http.MethodGet, path, nil, &result)
+	result, err := s.cachedTradePoints(ctx, "")
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, err)
 	}
@@ -416,6 +493,11 @@ func (s *KaspiService) RegisterDevice(ctx context.Context, req domain.DeviceRegi
 		return nil, err
 	}
 
+	if err := s.checkTradePoint(ctx, "", req.TradePointID); err != nil {
+		log.Warn("trade point check failed", "error", err.Error())
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
 	log.Debug("registering new device")
 
 	path := "/device/register"
@@ -447,6 +529,8 @@ func (s *KaspiService) RegisterDevice(ctx context.Context, req domain.DeviceRegi
 
 	log.Debug("device saved to database successfully")
 
+	s.deviceTokens.forgetDevice(req.DeviceID, "")
+
 	return &result, nil
 }
 
@@ -483,6 +567,8 @@ func (s *KaspiService) DeleteDevice(ctx context.Context, deviceToken string) err
 
 	log.Debug("device deleted successfully")
 
+	s.deactivateDevice(ctx, log, deviceToken)
+
 	return nil
 }
 
@@ -497,9 +583,14 @@ func (s *KaspiService) CreateQR(ctx context.Context, req domain.QRCreateRequest)
 		return nil, domain.ErrUnsupportedFeature
 	}
 
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Float64("amount", req.Amount),
 	)
 
@@ -508,6 +599,23 @@ func (s *KaspiService) CreateQR(ctx context.Context, req domain.QRCreateRequest)
 		return nil, err
 	}
 
+	if req.ExternalID != "" {
+		lockedCtx, unlock, err := s.lockExternalID(ctx, domain.PaymentKindQR, req.ExternalID, req.DeviceToken, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer unlock()
+		ctx = lockedCtx
+
+		existing, err := s.livePayment(ctx, log, domain.PaymentKindQR, req.ExternalID, req.DeviceToken, "", req.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if existing != nil {
+			return existing.QRCreateResponse(), nil
+		}
+	}
+
 	log.Debug("creating QR token for payment")
 
 	path := "/qr/create"
@@ -520,6 +628,21 @@ func (s *KaspiService) CreateQR(ctx context.Context, req domain.QRCreateRequest)
 
 	log.Debug("QR token created successfully")
 
+	s.savePayment(ctx, log, domain.Payment{
+		QrPaymentID:                result.QrPaymentID,
+		Kind:                       domain.PaymentKindQR,
+		ExternalID:                 req.ExternalID,
+		DeviceToken:                req.DeviceToken,
+		Amount:                     req.Amount,
+		ExpireDate:                 result.ExpireDate,
+		PaymentMethods:             result.PaymentMethods,
+		Status:                     domain.PaymentStatusCreated,
+		QrToken:                    result.QrToken,
+		StatusPollingInterval:      result.QrPaymentBehaviorOptions.StatusPollingInterval,
+		ScanWaitTimeout:            result.QrPaymentBehaviorOptions.QrCodeScanWaitTimeout,
+		PaymentConfirmationTimeout: result.QrPaymentBehaviorOptions.PaymentConfirmationTimeout,
+	}, result.QrPaymentBehaviorOptions.PollingOptions())
+
 	return &result, nil
 }
 
@@ -531,9 +654,14 @@ func (s *KaspiService) CreatePaymentLink(ctx context.Context, req domain.Payment
 		return nil, domain.ErrUnsupportedFeature
 	}
 
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Float64("amount", req.Amount),
 	)
 
@@ -542,6 +670,23 @@ func (s *KaspiService) CreatePaymentLink(ctx context.Context, req domain.Payment
 		return nil, err
 	}
 
+	if req.ExternalID != "" {
+		lockedCtx, unlock, err := s.lockExternalID(ctx, domain.PaymentKindLink, req.ExternalID, req.DeviceToken, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer unlock()
+		ctx = lockedCtx
+
+		existing, err := s.livePayment(ctx, log, domain.PaymentKindLink, req.ExternalID, req.DeviceToken, "", req.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if existing != nil {
+			return existing.PaymentLinkCreateResponse(), nil
+		}
+	}
+
 	log.Debug("creating payment link")
 
 	path := "/qr/create-link"
@@ -554,6 +699,21 @@ func (s *KaspiService) CreatePaymentLink(ctx context.Context, req domain.Payment
 
 	log.Debug("payment link created successfully")
 
+	s.savePayment(ctx, log, domain.Payment{
+		QrPaymentID:                result.PaymentID,
+		Kind:                       domain.PaymentKindLink,
+		ExternalID:                 req.ExternalID,
+		DeviceToken:                req.DeviceToken,
+		Amount:                     req.Amount,
+		ExpireDate:                 result.ExpireDate,
+		PaymentMethods:             result.PaymentMethods,
+		Status:                     domain.PaymentStatusCreated,
+		PaymentLink:                result.PaymentLink,
+		StatusPollingInterval:      result.PaymentBehaviorOptions.StatusPollingInterval,
+		ScanWaitTimeout:            result.PaymentBehaviorOptions.LinkActivationWaitTimeout,
+		PaymentConfirmationTimeout: result.PaymentBehaviorOptions.PaymentConfirmationTimeout,
+	}, result.PaymentBehaviorOptions.PollingOptions())
+
 	return &result, nil
 }
 
@@ -586,7 +746,57 @@ func (s *KaspiService) GetPaymentStatus(ctx context.Context, qrPaymentID int64)
 
 	log.Debug("payment status retrieved successfully", "status", result.Status)
 
+	err = s.recordPaymentStatus(ctx, qrPaymentID, result)
+	if err != nil && !errors.Is(err, storage.ErrPaymentNotFound) {
+		log.Error("failed to update payment status in database", "error", err.Error())
+	}
+
 	return &result, nil
 }
 
+// ExpirePayment marks a payment that was not completed in time as expired,
+// remote payments are canceled in Kaspi instead when automatic cancel is enabled
+func (s *KaspiService) ExpirePayment(ctx context.Context, qrPaymentID int64) error {
+	const op = "service.kaspi.ExpirePayment"
+
+	if s.remotePaymentTimeout > 0 {
+		payment, err := s.paymentStorage.Payment(ctx, qrPaymentID)
+		if err != nil && !errors.Is(err, storage.ErrPaymentNotFound) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if payment != nil && payment.Kind == domain.PaymentKindRemote {
+			err = s.CancelOverdueRemotePayment(ctx, qrPaymentID)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			return nil
+		}
+	}
+
+	err := s.recordPaymentStatus(ctx, qrPaymentID, domain.PaymentStatusResponse{
+		Status: domain.PaymentStatusExpired,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// savePayment records a created payment and starts tracking it, the payment already
+// exists in Kaspi so a storage failure is logged instead of being returned to the caller
+func (s *KaspiService) savePayment(ctx context.Context, log *slog.Logger, payment domain.Payment, opts domain.PollingOptions) {
+	log.Debug("saving payment to database", "qrPaymentID", payment.QrPaymentID)
+
+	err := s.paymentStorage.SavePayment(ctx, payment)
+	if err != nil {
+		log.Error("failed to save payment to database", "qrPaymentID", payment.QrPaymentID, "error", err.Error())
+	}
+
+	if s.tracker != nil {
+		s.tracker.Track(payment.QrPaymentID, opts)
+	}
+}
+
 //////// 	End of payment service	methods	////////
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 /*
@@ -32,7 +33,8 @@ type DeviceSaverEnhanced interface {
 	SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error
 }
 
-// GetTradePointsEnhanced gets a list of trade points in the enhanced scheme (4.2.2)
+// GetTradePointsEnhanced returns the trade points of an organization in the enhanced scheme (4.2.2)
+// from the trade point cache, the cache is synced with Kaspi when it is empty or stale
 func (s *KaspiService) GetTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
 	const op = "service.kaspi.GetTradePointsEnhanced"
 
@@ -49,12 +51,13 @@ func (s *KaspiService) GetTradePointsEnhanced(ctx context.Context, organizationB
 		}
 	}
 
-	log.Debug("getting trade points (enhanced)")
+	if err := s.resolveOrganization(ctx, &organizationBin, "", ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	path := fmt.Sprintf("/partner/tradepoints/%s", organizationBin)
+	log.Debug("getting trade points (enhanced)")
 
-	var result []domain.TradePoint
-	err := s.request(ctx, http.MethodGet, path, nil, &result)
+	result, err := s.cachedTradePoints(ctx, organizationBin)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -68,6 +71,10 @@ func (s *KaspiService) GetTradePointsEnhanced(ctx context.Context, organizationB
 func (s *KaspiService) RegisterDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceRegisterRequest) (*domain.DeviceRegisterResponse, error) {
 	const op = "service.kaspi.RegisterDeviceEnhanced"
 
+	if err := s.resolveOrganization(ctx, &req.OrganizationBin, "", req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("deviceID", req.DeviceID),
@@ -80,6 +87,11 @@ func (s *KaspiService) RegisterDeviceEnhanced(ctx context.Context, req domain.En
 		return nil, err
 	}
 
+	if err := s.checkTradePoint(ctx, req.OrganizationBin, req.TradePointID); err != nil {
+		log.Warn("trade point check failed", "error", err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Debug("registering device (enhanced)")
 
 	path := "/device/register"
@@ -111,6 +123,8 @@ func (s *KaspiService) RegisterDeviceEnhanced(ctx context.Context, req domain.En
 
 	log.Debug("device saved to database successfully (enhanced)")
 
+	s.deviceTokens.forgetDevice(req.DeviceID, req.OrganizationBin)
+
 	return &result, nil
 }
 
@@ -118,6 +132,10 @@ func (s *KaspiService) RegisterDeviceEnhanced(ctx context.Context, req domain.En
 func (s *KaspiService) DeleteDeviceEnhanced(ctx context.Context, req domain.EnhancedDeviceDeleteRequest) error {
 	const op = "service.kaspi.DeleteDeviceEnhanced"
 
+	if err := s.resolveOrganization(ctx, &req.OrganizationBin, req.DeviceToken, ""); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("deviceToken", req.DeviceToken),
@@ -140,6 +158,8 @@ func (s *KaspiService) DeleteDeviceEnhanced(ctx context.Context, req domain.Enha
 
 	log.Debug("device deleted successfully (enhanced)")
 
+	s.deactivateDevice(ctx, log, req.DeviceToken)
+
 	return nil
 }
 
@@ -151,9 +171,18 @@ func (s *KaspiService) DeleteDeviceEnhanced(ctx context.Context, req domain.Enha
 func (s *KaspiService) CreateQREnhanced(ctx context.Context, req domain.EnhancedQRCreateRequest) (*domain.QRCreateResponse, error) {
 	const op = "service.kaspi.CreateQREnhanced"
 
+	if err := s.resolveOrganization(ctx, &req.OrganizationBin, req.DeviceToken, req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, req.OrganizationBin); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Float64("amount", req.Amount),
 		slog.String("organizationBin", req.OrganizationBin),
 	)
@@ -163,6 +192,23 @@ func (s *KaspiService) CreateQREnhanced(ctx context.Context, req domain.Enhanced
 		return nil, err
 	}
 
+	if req.ExternalID != "" {
+		lockedCtx, unlock, err := s.lockExternalID(ctx, domain.PaymentKindQR, req.ExternalID, req.DeviceToken, req.OrganizationBin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer unlock()
+		ctx = lockedCtx
+
+		existing, err := s.livePayment(ctx, log, domain.PaymentKindQR, req.ExternalID, req.DeviceToken, req.OrganizationBin, req.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if existing != nil {
+			return existing.QRCreateResponse(), nil
+		}
+	}
+
 	log.Debug("creating QR (enhanced)")
 
 	path := "/qr/create"
@@ -175,6 +221,22 @@ func (s *KaspiService) CreateQREnhanced(ctx context.Context, req domain.Enhanced
 
 	log.Debug("QR created successfully (enhanced)")
 
+	s.savePayment(ctx, log, domain.Payment{
+		QrPaymentID:                result.QrPaymentID,
+		Kind:                       domain.PaymentKindQR,
+		ExternalID:                 req.ExternalID,
+		DeviceToken:                req.DeviceToken,
+		OrganizationBin:            req.OrganizationBin,
+		Amount:                     req.Amount,
+		ExpireDate:                 result.ExpireDate,
+		PaymentMethods:             result.PaymentMethods,
+		Status:                     domain.PaymentStatusCreated,
+		QrToken:                    result.QrToken,
+		StatusPollingInterval:      result.QrPaymentBehaviorOptions.StatusPollingInterval,
+		ScanWaitTimeout:            result.QrPaymentBehaviorOptions.QrCodeScanWaitTimeout,
+		PaymentConfirmationTimeout: result.QrPaymentBehaviorOptions.PaymentConfirmationTimeout,
+	}, result.QrPaymentBehaviorOptions.PollingOptions())
+
 	return &result, nil
 }
 
@@ -182,9 +244,18 @@ func (s *KaspiService) CreateQREnhanced(ctx context.Context, req domain.Enhanced
 func (s *KaspiService) CreatePaymentLinkEnhanced(ctx context.Context, req domain.EnhancedPaymentLinkCreateRequest) (*domain.PaymentLinkCreateResponse, error) {
 	const op = "service.kaspi.CreatePaymentLinkEnhanced"
 
+	if err := s.resolveOrganization(ctx, &req.OrganizationBin, req.DeviceToken, req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, req.OrganizationBin); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Float64("amount", req.Amount),
 		slog.String("organizationBin", req.OrganizationBin),
 	)
@@ -194,6 +265,23 @@ func (s *KaspiService) CreatePaymentLinkEnhanced(ctx context.Context, req domain
 		return nil, err
 	}
 
+	if req.ExternalID != "" {
+		lockedCtx, unlock, err := s.lockExternalID(ctx, domain.PaymentKindLink, req.ExternalID, req.DeviceToken, req.OrganizationBin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer unlock()
+		ctx = lockedCtx
+
+		existing, err := s.livePayment(ctx, log, domain.PaymentKindLink, req.ExternalID, req.DeviceToken, req.OrganizationBin, req.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if existing != nil {
+			return existing.PaymentLinkCreateResponse(), nil
+		}
+	}
+
 	log.Debug("creating payment link (enhanced)")
 
 	path := "/qr/create-link"
@@ -206,6 +294,22 @@ func (s *KaspiService) CreatePaymentLinkEnhanced(ctx context.Context, req domain
 
 	log.Debug("payment link created successfully (enhanced)")
 
+	s.savePayment(ctx, log, domain.Payment{
+		QrPaymentID:                result.PaymentID,
+		Kind:                       domain.PaymentKindLink,
+		ExternalID:                 req.ExternalID,
+		DeviceToken:                req.DeviceToken,
+		OrganizationBin:            req.OrganizationBin,
+		Amount:                     req.Amount,
+		ExpireDate:                 result.ExpireDate,
+		PaymentMethods:             result.PaymentMethods,
+		Status:                     domain.PaymentStatusCreated,
+		PaymentLink:                result.PaymentLink,
+		StatusPollingInterval:      result.PaymentBehaviorOptions.StatusPollingInterval,
+		ScanWaitTimeout:            result.PaymentBehaviorOptions.LinkActivationWaitTimeout,
+		PaymentConfirmationTimeout: result.PaymentBehaviorOptions.PaymentConfirmationTimeout,
+	}, result.PaymentBehaviorOptions.PollingOptions())
+
 	return &result, nil
 }
 
@@ -217,9 +321,18 @@ func (s *KaspiService) CreatePaymentLinkEnhanced(ctx context.Context, req domain
 func (s *KaspiService) RefundPaymentEnhanced(ctx context.Context, req domain.EnhancedRefundRequest) (*domain.RefundResponse, error) {
 	const op = "service.kaspi.RefundPaymentEnhanced"
 
+	if err := s.resolveOrganization(ctx, &req.OrganizationBin, req.DeviceToken, req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, req.OrganizationBin); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Int64("qrPaymentID", req.QrPaymentID),
 		slog.Float64("amount", req.Amount),
 	)
@@ -229,12 +342,24 @@ func (s *KaspiService) RefundPaymentEnhanced(ctx context.Context, req domain.Enh
 		return nil, err
 	}
 
+	// a refund without the customer is only checked against the local ledger
+	refund, err := s.reserveRefund(ctx, log, domain.Refund{
+		QrPaymentID:     req.QrPaymentID,
+		Amount:          req.Amount,
+		DeviceToken:     req.DeviceToken,
+		OrganizationBin: req.OrganizationBin,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Debug("initiating payment refund (enhanced)")
 
 	path := "/payment/return"
 
 	var result domain.RefundResponse
-	err := s.request(ctx, http.MethodPost, path, req, &result)
+	err = s.request(ctx, http.MethodPost, path, req, &result)
+	s.recordRefundResult(ctx, log, refund, &result, err)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -282,9 +407,18 @@ func (s *KaspiService) CreateRemotePayment(ctx context.Context, req domain.Remot
 
 	const op = "service.kaspi.CreateRemotePayment"
 
+	if err := s.resolveRemoteOrganization(ctx, &req.OrganizationBin, req.DeviceToken, req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device := remoteDeviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveRemoteDevice(ctx, &req.DeviceToken, &req.DeviceID, req.OrganizationBin); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.Int64("deviceToken", req.DeviceToken),
+		device,
 		slog.Float64("amount", req.Amount),
 	)
 
@@ -305,6 +439,19 @@ func (s *KaspiService) CreateRemotePayment(ctx context.Context, req domain.Remot
 
 	log.Debug("remote payment request created successfully")
 
+	s.savePayment(ctx, log, domain.Payment{
+		QrPaymentID:     result.QrPaymentID,
+		Kind:            domain.PaymentKindRemote,
+		DeviceToken:     strconv.FormatInt(req.DeviceToken, 10),
+		OrganizationBin: req.OrganizationBin,
+		Amount:          req.Amount,
+		Status:          domain.PaymentStatusCreated,
+		PhoneNumber:     domain.MaskPhoneNumber(req.PhoneNumber),
+		Comment:         req.Comment,
+		// kept so that polling resumed after a restart cancels the payment on time
+		ScanWaitTimeout: int(s.remotePaymentTimeout / time.Second),
+	}, domain.PollingOptions{ScanTimeout: s.remotePaymentTimeout})
+
 	return &result, nil
 }
 
@@ -316,9 +463,18 @@ func (s *KaspiService) CancelRemotePayment(ctx context.Context, req domain.Remot
 
 	const op = "service.kaspi.CancelRemotePayment"
 
+	if err := s.resolveRemoteOrganization(ctx, &req.OrganizationBin, req.DeviceToken, req.DeviceID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device := remoteDeviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveRemoteDevice(ctx, &req.DeviceToken, &req.DeviceID, req.OrganizationBin); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.Int64("deviceToken", req.DeviceToken),
+		device,
 		slog.Int64("qrPaymentID", req.QrPaymentID),
 	)
 
@@ -337,7 +493,16 @@ func (s *KaspiService) CancelRemotePayment(ctx context.Context, req domain.Remot
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Debug("remote payment request canceled successfully")
+	log.Debug("remote payment request canceled successfully", "status", result.Status)
+
+	if result.Status == domain.RemotePaymentStatusCanceled {
+		err = s.recordPaymentStatus(ctx, req.QrPaymentID, domain.PaymentStatusResponse{
+			Status: domain.PaymentStatusCanceled,
+		})
+		if err != nil && !errors.Is(err, storage.ErrPaymentNotFound) {
+			log.Error("failed to update payment status in database", "error", err.Error())
+		}
+	}
 
 	return &result, nil
 }
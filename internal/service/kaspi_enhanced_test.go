@@ -96,7 +96,7 @@ func TestRegisterDeviceEnhanced(t *testing.T) {
 		var savedOrganizationBin string
 		saveDeviceEnhancedCalled := false
 
-		mockSaver := &MockDeviceSaver{
+		mockSaver := &MockStorage{
 			SaveDeviceEnhancedFunc: func(ctx context.Context, deviceID, deviceToken string, tradePointID int64, organizationBin string) error {
 				saveDeviceEnhancedCalled = true
 				savedDeviceID = deviceID
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
 	"kaspi-api-wrapper/internal/validator"
 	"log/slog"
 	"net/http"
@@ -23,9 +25,14 @@ func (s *KaspiService) CreateRefundQR(ctx context.Context, req domain.QRRefundCr
 
 	const op = "service.kaspi.CreateRefundQR"
 
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 	)
 
 	if err := validator.ValidateQRRefundCreateRequest(req); err != nil {
@@ -45,6 +52,18 @@ func (s *KaspiService) CreateRefundQR(ctx context.Context, req domain.QRRefundCr
 
 	log.Debug("QR token for refund created successfully")
 
+	// the refund QR already exists in Kaspi, so a storage failure is only logged
+	err = s.refundStorage.SaveRefundQR(ctx, domain.RefundQR{
+		QrReturnID:  result.QrReturnID,
+		DeviceToken: req.DeviceToken,
+		ExternalID:  req.ExternalID,
+		ExpireDate:  result.ExpireDate,
+		Status:      domain.PaymentStatusCreated,
+	})
+	if err != nil {
+		log.Error("failed to save refund QR to database", "qrReturnID", result.QrReturnID, "error", err.Error())
+	}
+
 	return &result, nil
 }
 
@@ -85,6 +104,11 @@ func (s *KaspiService) GetRefundStatus(ctx context.Context, qrReturnID int64) (*
 
 	log.Debug("customer operations retrieved successfully", "status", result.Status)
 
+	err = s.recordRefundStatus(ctx, qrReturnID, result.Status)
+	if err != nil && !errors.Is(err, storage.ErrRefundNotFound) {
+		log.Error("failed to update refund status in database", "error", err.Error())
+	}
+
 	return &result, nil
 }
 
@@ -100,9 +124,14 @@ func (s *KaspiService) GetCustomerOperations(ctx context.Context, req domain.Cus
 
 	const op = "service.kaspi.GetCustomerOperations"
 
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Int64("qrReturnID", req.QrReturnID),
 	)
 
@@ -126,22 +155,17 @@ func (s *KaspiService) GetCustomerOperations(ctx context.Context, req domain.Cus
 	return result, nil
 }
 
-// GetPaymentDetails gets the details of a payment (3.4.4)
+// GetPaymentDetails gets the details of a payment (3.4.4, 4.4 in the enhanced scheme)
 func (s *KaspiService) GetPaymentDetails(ctx context.Context, qrPaymentID int64, deviceToken string) (*domain.PaymentDetailsResponse, error) {
 	if s.scheme == "basic" {
 		return nil, fmt.Errorf("refund functionality is not available in basic scheme")
 	}
 
-	if s.scheme == "enhanced" {
-		return nil, domain.ErrUnsupportedFeature
-	}
-
 	const op = "service.kaspi.GetPaymentDetails"
 
 	log := s.log.With(
 		slog.String("op", op),
 		slog.Int64("qrPaymentID", qrPaymentID),
-		slog.String("deviceToken", deviceToken),
 	)
 
 	if err := validator.ValidatePaymentDetailsRequest(qrPaymentID, deviceToken); err != nil {
@@ -176,9 +200,14 @@ func (s *KaspiService) RefundPayment(ctx context.Context, req domain.RefundReque
 		return nil, domain.ErrUnsupportedFeature
 	}
 
+	device := deviceAttr(req.DeviceToken, req.DeviceID)
+	if err := s.resolveDevice(ctx, &req.DeviceToken, &req.DeviceID, ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log := s.log.With(
 		slog.String("op", op),
-		slog.String("deviceToken", req.DeviceToken),
+		device,
 		slog.Int64("qrPaymentID", req.QrPaymentID),
 		slog.Float64("amount", req.Amount),
 	)
@@ -188,12 +217,31 @@ func (s *KaspiService) RefundPayment(ctx context.Context, req domain.RefundReque
 		return nil, err
 	}
 
+	var availableReturnAmount *float64
+	details, err := s.GetPaymentDetails(ctx, req.QrPaymentID, req.DeviceToken)
+	if err != nil {
+		log.Warn("failed to get payment details, checking refund against local ledger only", "error", err.Error())
+	} else {
+		availableReturnAmount = &details.AvailableReturnAmount
+	}
+
+	refund, err := s.reserveRefund(ctx, log, domain.Refund{
+		QrPaymentID: req.QrPaymentID,
+		QrReturnID:  req.QrReturnID,
+		Amount:      req.Amount,
+		DeviceToken: req.DeviceToken,
+	}, availableReturnAmount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	log.Debug("initiating payment refund")
 
 	path := "/payment/return"
 
 	var result domain.RefundResponse
-	err := s.request(ctx, http.MethodPost, path, req, &result)
+	err = s.request(ctx, http.MethodPost, path, req, &result)
+	s.recordRefundResult(ctx, log, refund, &result, err)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
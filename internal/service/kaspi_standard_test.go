@@ -234,6 +234,32 @@ func TestGetPaymentDetails(t *testing.T) {
 			t.Errorf("Expected AvailableReturnAmount 11.00, got %f", details.AvailableReturnAmount)
 		}
 	})
+
+	t.Run("gets payment details in the enhanced scheme", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "enhanced")
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/payment/details" {
+				t.Errorf("Expected URL path /payment/details, got %s", req.URL.Path)
+			}
+
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Message": "OK",
+				"Data": {"QrPaymentId": 123, "TotalAmount": 11.00, "AvailableReturnAmount": 5.00}
+			}`), nil
+		}
+
+		details, err := svc.GetPaymentDetails(context.Background(), 123, "test-token")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if details.AvailableReturnAmount != 5.00 {
+			t.Errorf("Expected AvailableReturnAmount 5.00, got %f", details.AvailableReturnAmount)
+		}
+	})
 }
 
 func TestRefundPayment(t *testing.T) {
@@ -242,6 +268,10 @@ func TestRefundPayment(t *testing.T) {
 		svc, mockClient := setupTestService(log, "standard")
 
 		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/payment/details" {
+				return testutils.NewMockResponse(http.StatusOK, paymentDetailsResponseBody), nil
+			}
+
 			if req.URL.Path != "/payment/return" {
 				t.Errorf("Expected URL path /payment/return, got %s", req.URL.Path)
 			}
@@ -10,6 +10,7 @@ import (
 	"kaspi-api-wrapper/internal/service"
 	"kaspi-api-wrapper/internal/storage"
 	"kaspi-api-wrapper/internal/testutils"
+	"kaspi-api-wrapper/internal/validator"
 	"log/slog"
 	"net/http"
 	"os"
@@ -25,9 +26,7 @@ func setupTestLogger() *slog.Logger {
 }
 
 func setupTestService(log *slog.Logger, scheme string) (*service.KaspiService, *testutils.MockHTTPClient) {
-	mockClient := &testutils.MockHTTPClient{}
-
-	mockSaver := &MockDeviceSaver{
+	mockSaver := &MockStorage{
 		SaveDeviceFunc: func(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error {
 			return nil
 		},
@@ -36,6 +35,12 @@ func setupTestService(log *slog.Logger, scheme string) (*service.KaspiService, *
 		},
 	}
 
+	return setupTestServiceWithStorage(log, scheme, mockSaver)
+}
+
+func setupTestServiceWithStorage(log *slog.Logger, scheme string, store *MockStorage) (*service.KaspiService, *testutils.MockHTTPClient) {
+	mockClient := &testutils.MockHTTPClient{}
+
 	svc := service.NewKaspiService(
 		log,
 		scheme,
@@ -44,7 +49,7 @@ func setupTestService(log *slog.Logger, scheme string) (*service.KaspiService, *
 		"https://test.com",
 		"test-handlers-key",
 		nil,
-		mockSaver,
+		store,
 	)
 
 	svc.SetHTTPClient(mockClient)
@@ -52,25 +57,232 @@ func setupTestService(log *slog.Logger, scheme string) (*service.KaspiService, *
 	return svc, mockClient
 }
 
-type MockDeviceSaver struct {
-	SaveDeviceFunc         func(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error
-	SaveDeviceEnhancedFunc func(ctx context.Context, deviceID, deviceToken string, tradePointID int64, organizationBin string) error
+type MockStorage struct {
+	SaveDeviceFunc          func(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error
+	SaveDeviceEnhancedFunc  func(ctx context.Context, deviceID, deviceToken string, tradePointID int64, organizationBin string) error
+	DevicesFunc             func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	DeviceFunc              func(ctx context.Context, deviceID string) (*domain.Device, error)
+	DeviceByTokenFunc       func(ctx context.Context, deviceToken string) (*domain.Device, error)
+	DeactivateDeviceFunc    func(ctx context.Context, deviceToken string) error
+	OrganizationFunc        func(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	SyncTradePointsFunc     func(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error)
+	TradePointsFunc         func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	TradePointFunc          func(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error)
+	SavePaymentFunc         func(ctx context.Context, payment domain.Payment) error
+	UpdatePaymentStatusFunc func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error)
+	PaymentFunc             func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error)
+	LockExternalIDFunc      func(ctx context.Context, key string) (context.Context, func(), error)
+	LivePaymentFunc         func(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error)
+	PaymentsByExternalFunc  func(ctx context.Context, externalID string) ([]domain.Payment, error)
+	PendingRemoteFunc       func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error)
+	ListPaymentsFunc        func(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error)
+	SaveRefundQRFunc        func(ctx context.Context, refund domain.RefundQR) error
+	UpdateRefundStatusFunc  func(ctx context.Context, qrReturnID int64, status string) (string, error)
+	RefundQRFunc            func(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error)
+	ReserveRefundFunc       func(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error)
+	CompleteRefundFunc      func(ctx context.Context, id int64, returnOperationID int64) error
+	FailRefundFunc          func(ctx context.Context, id int64, reason string) error
 }
 
-func (m *MockDeviceSaver) SaveDevice(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error {
+func (m *MockStorage) SaveDevice(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error {
 	if m.SaveDeviceFunc != nil {
 		return m.SaveDeviceFunc(ctx, deviceID, deviceToken, tradePointID)
 	}
 	return nil
 }
 
-func (m *MockDeviceSaver) SaveDeviceEnhanced(ctx context.Context, deviceID, deviceToken string, tradePointID int64, organizationBin string) error {
+func (m *MockStorage) SaveDeviceEnhanced(ctx context.Context, deviceID, deviceToken string, tradePointID int64, organizationBin string) error {
 	if m.SaveDeviceEnhancedFunc != nil {
 		return m.SaveDeviceEnhancedFunc(ctx, deviceID, deviceToken, tradePointID, organizationBin)
 	}
 	return nil
 }
 
+func (m *MockStorage) Devices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	if m.DevicesFunc != nil {
+		return m.DevicesFunc(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockStorage) Device(ctx context.Context, deviceID string) (*domain.Device, error) {
+	if m.DeviceFunc != nil {
+		return m.DeviceFunc(ctx, deviceID)
+	}
+	return nil, storage.ErrDeviceNotFound
+}
+
+func (m *MockStorage) DeviceByToken(ctx context.Context, deviceToken string) (*domain.Device, error) {
+	if m.DeviceByTokenFunc != nil {
+		return m.DeviceByTokenFunc(ctx, deviceToken)
+	}
+	return nil, storage.ErrDeviceNotFound
+}
+
+// Organization treats every BIN as a registered, enabled organization unless OrganizationFunc is set
+func (m *MockStorage) Organization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	if m.OrganizationFunc != nil {
+		return m.OrganizationFunc(ctx, organizationBin)
+	}
+	return &domain.Organization{OrganizationBin: organizationBin, Enabled: true}, nil
+}
+
+func (m *MockStorage) SaveOrganization(ctx context.Context, organization domain.Organization) error {
+	return nil
+}
+
+func (m *MockStorage) UpdateOrganization(ctx context.Context, organization domain.Organization) error {
+	return nil
+}
+
+func (m *MockStorage) Organizations(ctx context.Context) ([]domain.Organization, error) {
+	return nil, nil
+}
+
+func (m *MockStorage) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	return nil
+}
+
+func (m *MockStorage) SyncTradePoints(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error) {
+	if m.SyncTradePointsFunc != nil {
+		return m.SyncTradePointsFunc(ctx, organizationBin, tradePoints, syncedAt)
+	}
+	for i := range tradePoints {
+		tradePoints[i].OrganizationBin = organizationBin
+		tradePoints[i].Enabled = true
+		tradePoints[i].SyncedAt = syncedAt
+	}
+	return tradePoints, nil
+}
+
+func (m *MockStorage) TradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	if m.TradePointsFunc != nil {
+		return m.TradePointsFunc(ctx, organizationBin)
+	}
+	return nil, nil
+}
+
+func (m *MockStorage) TradePoint(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error) {
+	if m.TradePointFunc != nil {
+		return m.TradePointFunc(ctx, organizationBin, tradePointID)
+	}
+	return &domain.TradePoint{TradePointID: tradePointID, OrganizationBin: organizationBin, Enabled: true}, nil
+}
+
+func (m *MockStorage) UpdateTradePoint(ctx context.Context, tradePoint domain.TradePoint) error {
+	return nil
+}
+
+func (m *MockStorage) DeactivateDevice(ctx context.Context, deviceToken string) error {
+	if m.DeactivateDeviceFunc != nil {
+		return m.DeactivateDeviceFunc(ctx, deviceToken)
+	}
+	return nil
+}
+
+func (m *MockStorage) SavePayment(ctx context.Context, payment domain.Payment) error {
+	if m.SavePaymentFunc != nil {
+		return m.SavePaymentFunc(ctx, payment)
+	}
+	return nil
+}
+
+func (m *MockStorage) UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+	if m.UpdatePaymentStatusFunc != nil {
+		return m.UpdatePaymentStatusFunc(ctx, qrPaymentID, status)
+	}
+	return "", storage.ErrPaymentNotFound
+}
+
+func (m *MockStorage) Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+	if m.PaymentFunc != nil {
+		return m.PaymentFunc(ctx, qrPaymentID)
+	}
+	return nil, storage.ErrPaymentNotFound
+}
+
+func (m *MockStorage) LockExternalID(ctx context.Context, key string) (context.Context, func(), error) {
+	if m.LockExternalIDFunc != nil {
+		return m.LockExternalIDFunc(ctx, key)
+	}
+	return ctx, func() {}, nil
+}
+
+func (m *MockStorage) LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+	if m.LivePaymentFunc != nil {
+		return m.LivePaymentFunc(ctx, kind, externalID, deviceToken, organizationBin)
+	}
+	return nil, storage.ErrPaymentNotFound
+}
+
+func (m *MockStorage) PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	if m.PaymentsByExternalFunc != nil {
+		return m.PaymentsByExternalFunc(ctx, externalID)
+	}
+	return nil, nil
+}
+
+func (m *MockStorage) PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+	if m.PendingRemoteFunc != nil {
+		return m.PendingRemoteFunc(ctx, organizationBin, createdBefore)
+	}
+	return nil, nil
+}
+
+func (m *MockStorage) ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	if m.ListPaymentsFunc != nil {
+		return m.ListPaymentsFunc(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockStorage) SaveRefundQR(ctx context.Context, refund domain.RefundQR) error {
+	if m.SaveRefundQRFunc != nil {
+		return m.SaveRefundQRFunc(ctx, refund)
+	}
+	return nil
+}
+
+func (m *MockStorage) UpdateRefundStatus(ctx context.Context, qrReturnID int64, status string) (string, error) {
+	if m.UpdateRefundStatusFunc != nil {
+		return m.UpdateRefundStatusFunc(ctx, qrReturnID, status)
+	}
+	return "", storage.ErrRefundNotFound
+}
+
+func (m *MockStorage) RefundQR(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error) {
+	if m.RefundQRFunc != nil {
+		return m.RefundQRFunc(ctx, qrReturnID)
+	}
+	return nil, storage.ErrRefundNotFound
+}
+
+func (m *MockStorage) ReserveRefund(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error) {
+	if m.ReserveRefundFunc != nil {
+		return m.ReserveRefundFunc(ctx, refund, check)
+	}
+	if err := check(domain.RefundBalance{}); err != nil {
+		return nil, err
+	}
+	refund.ID = 1
+	refund.Status = domain.RefundStatusPending
+	return &refund, nil
+}
+
+func (m *MockStorage) CompleteRefund(ctx context.Context, id int64, returnOperationID int64) error {
+	if m.CompleteRefundFunc != nil {
+		return m.CompleteRefundFunc(ctx, id, returnOperationID)
+	}
+	return nil
+}
+
+func (m *MockStorage) FailRefund(ctx context.Context, id int64, reason string) error {
+	if m.FailRefundFunc != nil {
+		return m.FailRefundFunc(ctx, id, reason)
+	}
+	return nil
+}
+
 func TestGetBaseURL(t *testing.T) {
 	t.Run("returns basic URL for basic scheme", func(t *testing.T) {
 		log := setupTestLogger()
@@ -316,7 +528,7 @@ func TestRegisterDevice(t *testing.T) {
 		var savedTradePointID int64
 		saveDeviceCalled := false
 
-		mockSaver := &MockDeviceSaver{
+		mockSaver := &MockStorage{
 			SaveDeviceFunc: func(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error {
 				saveDeviceCalled = true
 				savedDeviceID = deviceID
@@ -416,7 +628,7 @@ func TestRegisterDevice(t *testing.T) {
 	t.Run("handles device already exists error", func(t *testing.T) {
 		log := setupTestLogger()
 
-		mockSaver := &MockDeviceSaver{
+		mockSaver := &MockStorage{
 			SaveDeviceFunc: func(ctx context.Context, deviceID, deviceToken string, tradePointID int64) error {
 				return storage.ErrDeviceExists
 			},
@@ -538,6 +750,103 @@ func TestDeleteDevice(t *testing.T) {
 	})
 }
 
+func TestDeviceRegistry(t *testing.T) {
+	t.Run("deactivates device deleted in Kaspi", func(t *testing.T) {
+		var deactivated string
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{
+			DeactivateDeviceFunc: func(ctx context.Context, deviceToken string) error {
+				deactivated = deviceToken
+				return nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Message": "OK"}`), nil
+		}
+
+		if err := svc.DeleteDevice(context.Background(), "test-token"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if deactivated != "test-token" {
+			t.Errorf("Expected test-token to be deactivated, got %q", deactivated)
+		}
+	})
+
+	t.Run("keeps device when Kaspi rejects deletion", func(t *testing.T) {
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{
+			DeactivateDeviceFunc: func(ctx context.Context, deviceToken string) error {
+				t.Error("Device must not be deactivated")
+				return nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -1501, "Message": "Device not found"}`), nil
+		}
+
+		if err := svc.DeleteDevice(context.Background(), "test-token"); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("ignores storage failure after deletion", func(t *testing.T) {
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{
+			DeactivateDeviceFunc: func(ctx context.Context, deviceToken string) error {
+				return errors.New("connection refused")
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Message": "OK"}`), nil
+		}
+
+		if err := svc.DeleteDevice(context.Background(), "test-token"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("lists devices", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{
+			DevicesFunc: func(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+				if filter.TradePointID != 7 {
+					t.Errorf("Expected trade point 7, got %d", filter.TradePointID)
+				}
+				return nil, nil
+			},
+		})
+
+		devices, err := svc.ListDevices(context.Background(), domain.DeviceFilter{TradePointID: 7})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if devices == nil || len(devices) != 0 {
+			t.Errorf("Expected empty list, got %v", devices)
+		}
+	})
+
+	t.Run("returns not found for unknown device", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{})
+
+		_, err := svc.GetDevice(context.Background(), "unknown")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("requires device ID", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", &MockStorage{})
+
+		_, err := svc.GetDevice(context.Background(), "")
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
+
 //////// 	End of device operations testing		////////
 
 //////// 	Payment operations testing		////////
@@ -736,3 +1045,166 @@ func TestGetPaymentStatus(t *testing.T) {
 }
 
 //////// 	End of payment operations testing		////////
+
+type MockPaymentTracker struct {
+	qrPaymentID int64
+	opts        domain.PollingOptions
+}
+
+func (m *MockPaymentTracker) Track(qrPaymentID int64, opts domain.PollingOptions) {
+	m.qrPaymentID = qrPaymentID
+	m.opts = opts
+}
+
+func TestPaymentTracking(t *testing.T) {
+	t.Run("saves created QR payment", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var saved domain.Payment
+		store := &MockStorage{
+			SavePaymentFunc: func(ctx context.Context, payment domain.Payment) error {
+				saved = payment
+				return nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Data": {
+					"QrToken": "token",
+					"ExpireDate": "2023-05-16T10:30:00+06:00",
+					"QrPaymentId": 15,
+					"PaymentMethods": ["Gold", "Red"]
+				}
+			}`), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{
+			DeviceToken: "test-token",
+			Amount:      200,
+			ExternalID:  "order-1",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if saved.QrPaymentID != 15 || saved.ExternalID != "order-1" || saved.DeviceToken != "test-token" {
+			t.Errorf("Unexpected saved payment: %+v", saved)
+		}
+
+		if saved.Kind != domain.PaymentKindQR || saved.Status != domain.PaymentStatusCreated {
+			t.Errorf("Expected kind qr with status %s, got %s/%s", domain.PaymentStatusCreated, saved.Kind, saved.Status)
+		}
+
+		if len(saved.PaymentMethods) != 2 || saved.ExpireDate.IsZero() {
+			t.Errorf("Expected payment methods and expire date to be saved, got %+v", saved)
+		}
+	})
+
+	t.Run("starts tracking created payment", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+
+		tracker := &MockPaymentTracker{}
+		svc.SetPaymentTracker(tracker)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Data": {
+					"QrToken": "token",
+					"QrPaymentId": 15,
+					"QrPaymentBehaviorOptions": {
+						"StatusPollingInterval": 5,
+						"QrCodeScanWaitTimeout": 180,
+						"PaymentConfirmationTimeout": 65
+					}
+				}
+			}`), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{
+			DeviceToken: "test-token",
+			Amount:      200,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if tracker.qrPaymentID != 15 {
+			t.Fatalf("Expected payment 15 to be tracked, got %d", tracker.qrPaymentID)
+		}
+
+		expected := domain.PollingOptions{
+			Interval:            5 * time.Second,
+			ScanTimeout:         180 * time.Second,
+			ConfirmationTimeout: 65 * time.Second,
+		}
+		if tracker.opts != expected {
+			t.Errorf("Expected polling options %+v, got %+v", expected, tracker.opts)
+		}
+	})
+
+	t.Run("storage failure does not fail QR creation", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			SavePaymentFunc: func(ctx context.Context, payment domain.Payment) error {
+				return errors.New("db is down")
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"QrToken": "token", "QrPaymentId": 15}}`), nil
+		}
+
+		resp, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{
+			DeviceToken: "test-token",
+			Amount:      200,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.QrPaymentID != 15 {
+			t.Errorf("Expected QrPaymentID 15, got %d", resp.QrPaymentID)
+		}
+	})
+
+	t.Run("updates stored status", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var updatedID int64
+		var updated domain.PaymentStatusResponse
+		store := &MockStorage{
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				updatedID = qrPaymentID
+				updated = status
+				return domain.PaymentStatusWait, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "basic", store)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Data": {"Status": "Processed", "TransactionId": "35134863", "ProductType": "Gold"}
+			}`), nil
+		}
+
+		_, err := svc.GetPaymentStatus(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if updatedID != 15 || updated.Status != "Processed" || updated.TransactionID != "35134863" {
+			t.Errorf("Unexpected status update for %d: %+v", updatedID, updated)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"strconv"
+	"time"
+)
+
+// OrganizationRegistry keeps the organizations the wrapper operates in the enhanced scheme
+type OrganizationRegistry interface {
+	SaveOrganization(ctx context.Context, organization domain.Organization) error
+	UpdateOrganization(ctx context.Context, organization domain.Organization) error
+	Organization(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	Organizations(ctx context.Context) ([]domain.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationBin string) error
+}
+
+// ListOrganizations returns the registered organizations ordered by BIN
+func (s *KaspiService) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	const op = "service.kaspi.ListOrganizations"
+
+	organizations, err := s.organizations.Organizations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if organizations == nil {
+		organizations = []domain.Organization{}
+	}
+
+	return organizations, nil
+}
+
+// GetOrganization returns a registered organization by its BIN
+func (s *KaspiService) GetOrganization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	const op = "service.kaspi.GetOrganization"
+
+	if err := validator.ValidateOrganizationBin(organizationBin); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizations.Organization(ctx, organizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return organization, nil
+}
+
+// CreateOrganization registers an organization, it is enabled unless the request disables it
+func (s *KaspiService) CreateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	const op = "service.kaspi.CreateOrganization"
+
+	if err := validator.ValidateOrganizationRequest(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	organization := domain.Organization{
+		OrganizationBin: req.OrganizationBin,
+		Name:            req.Name,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+		Settings:        req.Settings,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if organization.Settings == nil {
+		organization.Settings = map[string]string{}
+	}
+
+	if err := s.organizations.SaveOrganization(ctx, organization); err != nil {
+		if errors.Is(err, storage.ErrOrganizationExists) {
+			return nil, fmt.Errorf("%s: %s: %w", op, req.OrganizationBin, domain.ErrOrganizationExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &organization, nil
+}
+
+// UpdateOrganization replaces the name and settings of a registered organization, the organization
+// keeps its state unless the request sets Enabled
+func (s *KaspiService) UpdateOrganization(ctx context.Context, req domain.OrganizationRequest) (*domain.Organization, error) {
+	const op = "service.kaspi.UpdateOrganization"
+
+	if err := validator.ValidateOrganizationRequest(req); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizations.Organization(ctx, req.OrganizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	organization.Name = req.Name
+	organization.Settings = req.Settings
+	if organization.Settings == nil {
+		organization.Settings = map[string]string{}
+	}
+	if req.Enabled != nil {
+		organization.Enabled = *req.Enabled
+	}
+	organization.UpdatedAt = time.Now()
+
+	if err = s.organizations.UpdateOrganization(ctx, *organization); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization removes a registered organization, its devices stay in the registry but
+// enhanced requests for them are rejected until the organization is registered again
+func (s *KaspiService) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	const op = "service.kaspi.DeleteOrganization"
+
+	if err := validator.ValidateOrganizationBin(organizationBin); err != nil {
+		return err
+	}
+
+	if err := s.organizations.DeleteOrganization(ctx, organizationBin); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// resolveOrganization checks that the organization of an enhanced request is registered and enabled.
+// An empty BIN is filled in from the device registry when the device of the request belongs to an
+// organization, a BIN that stays empty is left to the request validation
+func (s *KaspiService) resolveOrganization(ctx context.Context, organizationBin *string, deviceToken, deviceID string) error {
+	const op = "service.kaspi.resolveOrganization"
+
+	if *organizationBin == "" {
+		bin, err := s.deviceOrganization(ctx, deviceToken, deviceID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		*organizationBin = bin
+	}
+
+	if *organizationBin == "" {
+		return nil
+	}
+
+	organization, err := s.organizations.Organization(ctx, *organizationBin)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrganizationNotFound) {
+			return fmt.Errorf("%s: %s: %w", op, *organizationBin, domain.ErrOrganizationNotRegistered)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !organization.Enabled {
+		return fmt.Errorf("%s: %s: %w", op, *organizationBin, domain.ErrOrganizationDisabled)
+	}
+
+	return nil
+}
+
+// resolveRemoteOrganization is resolveOrganization for the remote payment requests, which take numeric tokens
+func (s *KaspiService) resolveRemoteOrganization(ctx context.Context, organizationBin *string, deviceToken int64, deviceID string) error {
+	var token string
+	if deviceToken != 0 {
+		token = strconv.FormatInt(deviceToken, 10)
+	}
+
+	return s.resolveOrganization(ctx, organizationBin, token, deviceID)
+}
+
+// deviceOrganization returns the BIN of the enhanced device the request is made for, the token
+// takes precedence over the DeviceId as it does in ResolveDeviceToken
+func (s *KaspiService) deviceOrganization(ctx context.Context, deviceToken, deviceID string) (string, error) {
+	var device *domain.Device
+	var err error
+
+	switch {
+	case deviceToken != "":
+		device, err = s.deviceRegistry.DeviceByToken(ctx, deviceToken)
+	case deviceID != "":
+		device, err = s.deviceRegistry.Device(ctx, deviceID)
+	default:
+		return "", nil
+	}
+
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return device.OrganizationBin, nil
+}
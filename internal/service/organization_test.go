@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/testutils"
+	"kaspi-api-wrapper/internal/validator"
+	"net/http"
+	"testing"
+)
+
+// organizationLookup returns a storage that knows the organizations and the devices
+func organizationLookup(organizations []domain.Organization, devices ...domain.Device) *MockStorage {
+	var lookups int32
+	store := deviceLookup(&lookups, devices...)
+
+	store.DeviceByTokenFunc = func(ctx context.Context, deviceToken string) (*domain.Device, error) {
+		for _, device := range devices {
+			if device.DeviceToken == deviceToken {
+				return &device, nil
+			}
+		}
+		return nil, storage.ErrDeviceNotFound
+	}
+	store.OrganizationFunc = func(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+		for _, organization := range organizations {
+			if organization.OrganizationBin == organizationBin {
+				return &organization, nil
+			}
+		}
+		return nil, storage.ErrOrganizationNotFound
+	}
+
+	return store
+}
+
+func TestEnhancedOrganizations(t *testing.T) {
+	organizations := []domain.Organization{
+		{OrganizationBin: "180340021791", Name: "Shop", Enabled: true},
+		{OrganizationBin: "123456789012", Name: "Closed shop", Enabled: false},
+	}
+	device := domain.Device{
+		DeviceID:        "POS-1",
+		DeviceToken:     "test-token",
+		Scheme:          domain.DeviceSchemeEnhanced,
+		OrganizationBin: "180340021791",
+		Active:          true,
+	}
+
+	t.Run("fills in BIN of the registered device", func(t *testing.T) {
+		for _, req := range []domain.EnhancedQRCreateRequest{
+			{DeviceID: "POS-1", Amount: 200},
+			{DeviceToken: "test-token", Amount: 200},
+		} {
+			svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "enhanced", organizationLookup(organizations, device))
+
+			mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(r.Body)
+				r.Body.Close()
+
+				var sent map[string]any
+				if err := json.Unmarshal(body, &sent); err != nil {
+					t.Errorf("Failed to parse request body: %v", err)
+				}
+
+				if sent["OrganizationBin"] != "180340021791" || sent["DeviceToken"] != "test-token" {
+					t.Errorf("Expected BIN 180340021791 and token test-token, got %v", sent)
+				}
+
+				return testutils.NewMockResponse(http.StatusOK, qrCreateResponseBody), nil
+			}
+
+			if _, err := svc.CreateQREnhanced(context.Background(), req); err != nil {
+				t.Errorf("Expected no error for %+v, got %v", req, err)
+			}
+		}
+	})
+
+	t.Run("rejects unknown organization", func(t *testing.T) {
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "enhanced", organizationLookup(organizations, device))
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			t.Error("Kaspi must not be called")
+			return nil, nil
+		}
+
+		_, err := svc.CreateQREnhanced(context.Background(), domain.EnhancedQRCreateRequest{
+			DeviceToken:     "other-token",
+			Amount:          200,
+			OrganizationBin: "000000000000",
+		})
+		if !errors.Is(err, domain.ErrOrganizationNotRegistered) {
+			t.Errorf("Expected ErrOrganizationNotRegistered, got %v", err)
+		}
+
+		_, err = svc.GetTradePointsEnhanced(context.Background(), "000000000000")
+		if !errors.Is(err, domain.ErrOrganizationNotRegistered) {
+			t.Errorf("Expected ErrOrganizationNotRegistered for trade points, got %v", err)
+		}
+	})
+
+	t.Run("rejects disabled organization", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "enhanced", organizationLookup(organizations, device))
+
+		_, err := svc.RegisterDeviceEnhanced(context.Background(), domain.EnhancedDeviceRegisterRequest{
+			DeviceID:        "POS-2",
+			TradePointID:    1,
+			OrganizationBin: "123456789012",
+		})
+		if !errors.Is(err, domain.ErrOrganizationDisabled) {
+			t.Errorf("Expected ErrOrganizationDisabled, got %v", err)
+		}
+	})
+
+	t.Run("requires BIN of an unknown device", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "enhanced", organizationLookup(organizations, device))
+
+		_, err := svc.CreateQREnhanced(context.Background(), domain.EnhancedQRCreateRequest{DeviceToken: "other-token", Amount: 200})
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+}
+
+func TestCreateOrganization(t *testing.T) {
+	t.Run("enables organization by default", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "enhanced", &MockStorage{})
+
+		organization, err := svc.CreateOrganization(context.Background(), domain.OrganizationRequest{
+			OrganizationBin: "180340021791",
+			Name:            "Shop",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !organization.Enabled || organization.Settings == nil || organization.CreatedAt.IsZero() {
+			t.Errorf("Expected enabled organization with empty settings, got %+v", organization)
+		}
+	})
+
+	t.Run("rejects invalid BIN", func(t *testing.T) {
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "enhanced", &MockStorage{})
+
+		_, err := svc.CreateOrganization(context.Background(), domain.OrganizationRequest{
+			OrganizationBin: "1803400217",
+			Name:            "Shop",
+		})
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Err != validator.ErrInvalidOrgBin {
+			t.Errorf("Expected ErrInvalidOrgBin, got %v", err)
+		}
+	})
+}
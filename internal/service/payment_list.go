@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+)
+
+const (
+	defaultPaymentListLimit = 20
+	maxPaymentListLimit     = 100
+)
+
+// ListPayments returns a page of stored payments matching the filters. Pages are keyed by the
+// sort value and the QrPaymentId of the last payment, so new payments never shift later pages
+func (s *KaspiService) ListPayments(ctx context.Context, req domain.PaymentListRequest) (*domain.PaymentList, error) {
+	const op = "service.kaspi.ListPayments"
+
+	log := s.log.With(slog.String("op", op))
+
+	if req.SortBy == "" {
+		req.SortBy = domain.PaymentSortCreatedAt
+	}
+	if req.SortOrder == "" {
+		req.SortOrder = domain.SortOrderDesc
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultPaymentListLimit
+	}
+
+	if err := validator.ValidatePaymentListRequest(req, maxPaymentListLimit); err != nil {
+		log.Warn("invalid payment list request", "error", err.Error())
+		return nil, err
+	}
+
+	// payments keep the token of their device, so a DeviceId filter matches by its token
+	req.DeviceToken = ""
+	if req.DeviceID != "" {
+		token, err := s.ResolveDeviceToken(ctx, "", req.DeviceID, req.OrganizationBin)
+		if err != nil {
+			log.Warn("failed to resolve device of payment list", "error", err.Error())
+			return nil, err
+		}
+		req.DeviceToken = token
+	}
+
+	filter := domain.PaymentFilter{PaymentListRequest: req}
+
+	if req.Cursor != "" {
+		cursor, err := decodePaymentCursor(req.Cursor)
+		if err != nil || cursor.SortBy != req.SortBy || cursor.SortOrder != req.SortOrder {
+			log.Warn("invalid payment list cursor")
+			return nil, &validator.ValidationError{
+				Field:   "cursor",
+				Message: "cursor is invalid or belongs to another sort order",
+				Err:     validator.ErrInvalidValue,
+			}
+		}
+		filter.After = cursor
+	}
+
+	// one more payment tells whether there is a next page
+	filter.Limit = req.Limit + 1
+
+	payments, err := s.paymentStorage.ListPayments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	list := &domain.PaymentList{Items: payments}
+	if list.Items == nil {
+		list.Items = []domain.Payment{}
+	}
+
+	if len(payments) > req.Limit {
+		list.Items = payments[:req.Limit]
+
+		last := list.Items[req.Limit-1]
+		list.NextCursor, err = encodePaymentCursor(domain.PaymentCursor{
+			SortBy:      req.SortBy,
+			SortOrder:   req.SortOrder,
+			CreatedAt:   last.CreatedAt,
+			Amount:      last.Amount,
+			QrPaymentID: last.QrPaymentID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return list, nil
+}
+
+func encodePaymentCursor(cursor domain.PaymentCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePaymentCursor(value string) (*domain.PaymentCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor domain.PaymentCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
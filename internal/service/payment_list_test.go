@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"testing"
+	"time"
+)
+
+func TestListPayments(t *testing.T) {
+	created := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	payments := func(ids ...int64) []domain.Payment {
+		result := make([]domain.Payment, 0, len(ids))
+		for _, id := range ids {
+			result = append(result, domain.Payment{QrPaymentID: id, Amount: float64(id) * 100, CreatedAt: created.Add(-time.Duration(id) * time.Minute)})
+		}
+		return result
+	}
+
+	t.Run("applies defaults and returns a cursor for the next page", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var filters []domain.PaymentFilter
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{
+			ListPaymentsFunc: func(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+				filters = append(filters, filter)
+				if filter.After == nil {
+					return payments(1, 2, 3), nil
+				}
+				return payments(3), nil
+			},
+		})
+
+		list, err := svc.ListPayments(context.Background(), domain.PaymentListRequest{Limit: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if filters[0].SortBy != domain.PaymentSortCreatedAt || filters[0].SortOrder != domain.SortOrderDesc {
+			t.Errorf("Expected newest first by default, got %s %s", filters[0].SortBy, filters[0].SortOrder)
+		}
+		if filters[0].Limit != 3 {
+			t.Errorf("Expected one extra payment to be requested, got limit %d", filters[0].Limit)
+		}
+
+		if len(list.Items) != 2 || list.NextCursor == "" {
+			t.Fatalf("Expected 2 payments and a cursor, got %d and %q", len(list.Items), list.NextCursor)
+		}
+
+		list, err = svc.ListPayments(context.Background(), domain.PaymentListRequest{Limit: 2, Cursor: list.NextCursor})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		after := filters[1].After
+		if after == nil || after.QrPaymentID != 2 || !after.CreatedAt.Equal(created.Add(-2*time.Minute)) {
+			t.Errorf("Expected the cursor to point after payment 2, got %+v", after)
+		}
+
+		if len(list.Items) != 1 || list.NextCursor != "" {
+			t.Errorf("Expected the last page, got %d payments and cursor %q", len(list.Items), list.NextCursor)
+		}
+	})
+
+	t.Run("filters by the token of the device", func(t *testing.T) {
+		var lookups int32
+		mockStorage := deviceLookup(&lookups, domain.Device{DeviceID: "POS-1", DeviceToken: "test-token", Active: true})
+
+		var filter domain.PaymentFilter
+		mockStorage.ListPaymentsFunc = func(ctx context.Context, f domain.PaymentFilter) ([]domain.Payment, error) {
+			filter = f
+			return nil, nil
+		}
+
+		svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", mockStorage)
+
+		if _, err := svc.ListPayments(context.Background(), domain.PaymentListRequest{DeviceID: "POS-1", DeviceToken: "other-token"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if filter.DeviceToken != "test-token" {
+			t.Errorf("Expected payments of test-token, got %q", filter.DeviceToken)
+		}
+
+		_, err := svc.ListPayments(context.Background(), domain.PaymentListRequest{DeviceID: "POS-2"})
+		if !errors.Is(err, domain.ErrDeviceNotRegistered) {
+			t.Errorf("Expected ErrDeviceNotRegistered, got %v", err)
+		}
+	})
+
+	t.Run("returns an empty page", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{})
+
+		list, err := svc.ListPayments(context.Background(), domain.PaymentListRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if list.Items == nil || len(list.Items) != 0 || list.NextCursor != "" {
+			t.Errorf("Expected an empty page, got %+v", list)
+		}
+	})
+
+	t.Run("rejects a cursor of another sort order", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{
+			ListPaymentsFunc: func(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+				return payments(1, 2), nil
+			},
+		})
+
+		list, err := svc.ListPayments(context.Background(), domain.PaymentListRequest{Limit: 1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = svc.ListPayments(context.Background(), domain.PaymentListRequest{
+			Limit:  1,
+			SortBy: domain.PaymentSortAmount,
+			Cursor: list.NextCursor,
+		})
+
+		var valErr *validator.ValidationError
+		if !errors.As(err, &valErr) || valErr.Field != "cursor" {
+			t.Errorf("Expected cursor validation error, got %v", err)
+		}
+	})
+
+	t.Run("validates filters", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "basic", &MockStorage{})
+
+		minAmount, maxAmount := 500.0, 100.0
+
+		tests := map[string]domain.PaymentListRequest{
+			"status":    {Statuses: []string{"Paid"}},
+			"maxAmount": {MinAmount: &minAmount, MaxAmount: &maxAmount},
+			"to":        {From: created, To: created.Add(-time.Hour)},
+			"search":    {Search: "ab"},
+			"sortBy":    {SortBy: "Status"},
+			"sortOrder": {SortOrder: "up"},
+			"limit":     {Limit: 101},
+			"cursor":    {Cursor: "not a cursor"},
+		}
+
+		for field, req := range tests {
+			_, err := svc.ListPayments(context.Background(), req)
+
+			var valErr *validator.ValidationError
+			if !errors.As(err, &valErr) || valErr.Field != field {
+				t.Errorf("Expected validation error of %s, got %v", field, err)
+			}
+		}
+	})
+}
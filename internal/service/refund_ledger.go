@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"math"
+)
+
+type initiatorKey struct{}
+
+// WithInitiator attaches the person or system that initiated the request, it is recorded in the refund ledger
+func WithInitiator(ctx context.Context, initiator string) context.Context {
+	return context.WithValue(ctx, initiatorKey{}, initiator)
+}
+
+func initiatorFrom(ctx context.Context) string {
+	initiator, _ := ctx.Value(initiatorKey{}).(string)
+	return initiator
+}
+
+// reserveRefund records a pending refund after checking it against the remaining balance, the balance is
+// limited by the stored payment amount and by AvailableReturnAmount from Kaspi when they are known
+func (s *KaspiService) reserveRefund(ctx context.Context, log *slog.Logger, refund domain.Refund, availableReturnAmount *float64) (*domain.Refund, error) {
+	refund.Initiator = initiatorFrom(ctx)
+
+	check := func(balance domain.RefundBalance) error {
+		var limits []float64
+
+		if balance.PaymentAmount > 0 {
+			limits = append(limits, balance.PaymentAmount-balance.Succeeded-balance.Pending)
+		}
+
+		// succeeded refunds are already excluded from AvailableReturnAmount
+		if availableReturnAmount != nil {
+			limits = append(limits, *availableReturnAmount-balance.Pending)
+		}
+
+		for _, remaining := range limits {
+			if refund.Amount-remaining >= 0.005 {
+				log.Warn("refund exceeds remaining balance",
+					"remaining", remaining,
+					"succeeded", balance.Succeeded,
+					"pending", balance.Pending,
+				)
+				return &domain.RefundBalanceError{
+					Requested: refund.Amount,
+					Remaining: math.Max(remaining, 0),
+				}
+			}
+		}
+
+		return nil
+	}
+
+	reserved, err := s.refundStorage.ReserveRefund(ctx, refund, check)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRefundExceedsBalance) {
+			log.Error("failed to reserve refund in ledger", "error", err.Error())
+		}
+		return nil, err
+	}
+
+	return reserved, nil
+}
+
+// recordRefundResult stores the Kaspi outcome of a reserved refund. A refund rejected by Kaspi is released,
+// while a refund with an unknown outcome, e.g. a timeout, stays pending so the amount is not refunded twice.
+// It keeps counting against the balance until its status is resolved after checking the operation with Kaspi
+func (s *KaspiService) recordRefundResult(ctx context.Context, log *slog.Logger, refund *domain.Refund, result *domain.RefundResponse, refundErr error) {
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	switch {
+	case refundErr == nil:
+		err = s.refundStorage.CompleteRefund(ctx, refund.ID, result.ReturnOperationID)
+	case isRejected(refundErr):
+		err = s.refundStorage.FailRefund(ctx, refund.ID, refundErr.Error())
+	default:
+		log.Warn("refund outcome is unknown, keeping it pending", "refundId", refund.ID, "error", refundErr.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to record refund result in ledger", "refundId", refund.ID, "error", err.Error())
+	}
+}
+
+// isRejected reports whether the refund certainly was not performed by Kaspi
+func isRejected(err error) bool {
+	if errors.Is(err, domain.ErrCircuitOpen) {
+		return true
+	}
+
+	_, ok := domain.IsKaspiError(err)
+	return ok
+}
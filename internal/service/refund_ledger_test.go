@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/service"
+	"kaspi-api-wrapper/internal/testutils"
+	"net/http"
+	"testing"
+)
+
+const paymentDetailsResponseBody = `{
+	"StatusCode": 0,
+	"Message": "OK",
+	"Data": {
+		"QrPaymentId": 15,
+		"TotalAmount": 200.00,
+		"AvailableReturnAmount": 150.00,
+		"TransactionDate": "2030-05-16T10:30:00+06:00"
+	}
+}`
+
+func TestRefundLedger(t *testing.T) {
+	t.Run("rejects refund above available return amount before calling Kaspi", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestServiceWithStorage(log, "standard", &MockStorage{})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/payment/return" {
+				t.Error("Kaspi refund must not be called")
+			}
+			return testutils.NewMockResponse(http.StatusOK, paymentDetailsResponseBody), nil
+		}
+
+		_, err := svc.RefundPayment(context.Background(), domain.RefundRequest{
+			DeviceToken: "test-token",
+			QrPaymentID: 15,
+			QrReturnID:  15,
+			Amount:      150.01,
+		})
+
+		var balanceErr *domain.RefundBalanceError
+		if !errors.As(err, &balanceErr) || !errors.Is(err, domain.ErrRefundExceedsBalance) {
+			t.Fatalf("Expected RefundBalanceError, got %v", err)
+		}
+
+		if balanceErr.Remaining != 150.00 {
+			t.Errorf("Expected remaining 150.00, got %.2f", balanceErr.Remaining)
+		}
+	})
+
+	t.Run("counts succeeded and pending refunds of stored payment", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", &MockStorage{
+			ReserveRefundFunc: func(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error) {
+				if err := check(domain.RefundBalance{PaymentAmount: 200, Succeeded: 120, Pending: 50}); err != nil {
+					return nil, err
+				}
+				return &refund, nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			t.Error("Kaspi must not be called")
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"ReturnOperationId": 7}}`), nil
+		}
+
+		_, err := svc.RefundPaymentEnhanced(context.Background(), domain.EnhancedRefundRequest{
+			DeviceToken:     "test-token",
+			QrPaymentID:     15,
+			Amount:          40,
+			OrganizationBin: "180340021791",
+		})
+
+		var balanceErr *domain.RefundBalanceError
+		if !errors.As(err, &balanceErr) {
+			t.Fatalf("Expected RefundBalanceError, got %v", err)
+		}
+
+		if balanceErr.Remaining != 30 {
+			t.Errorf("Expected remaining 30.00, got %.2f", balanceErr.Remaining)
+		}
+	})
+
+	t.Run("completes refund with return operation and initiator", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var reserved domain.Refund
+		var completedID, returnOperationID int64
+		svc, mockClient := setupTestServiceWithStorage(log, "standard", &MockStorage{
+			ReserveRefundFunc: func(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error) {
+				if err := check(domain.RefundBalance{}); err != nil {
+					return nil, err
+				}
+				reserved = refund
+				refund.ID = 42
+				return &refund, nil
+			},
+			CompleteRefundFunc: func(ctx context.Context, id int64, operationID int64) error {
+				completedID, returnOperationID = id, operationID
+				return nil
+			},
+			FailRefundFunc: func(ctx context.Context, id int64, reason string) error {
+				t.Error("Refund must not be failed")
+				return nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/payment/details" {
+				return testutils.NewMockResponse(http.StatusOK, paymentDetailsResponseBody), nil
+			}
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"ReturnOperationId": 7}}`), nil
+		}
+
+		ctx := service.WithInitiator(context.Background(), "cashier-1")
+		_, err := svc.RefundPayment(ctx, domain.RefundRequest{
+			DeviceToken: "test-token",
+			QrPaymentID: 15,
+			QrReturnID:  15,
+			Amount:      100,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if reserved.Initiator != "cashier-1" || reserved.Amount != 100 || reserved.QrPaymentID != 15 {
+			t.Errorf("Unexpected reserved refund: %+v", reserved)
+		}
+
+		if completedID != 42 || returnOperationID != 7 {
+			t.Errorf("Expected refund 42 completed with operation 7, got %d and %d", completedID, returnOperationID)
+		}
+	})
+
+	t.Run("releases refund rejected by Kaspi", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var failedID int64
+		svc, mockClient := setupTestServiceWithStorage(log, "standard", &MockStorage{
+			CompleteRefundFunc: func(ctx context.Context, id int64, operationID int64) error {
+				t.Error("Refund must not be completed")
+				return nil
+			},
+			FailRefundFunc: func(ctx context.Context, id int64, reason string) error {
+				failedID = id
+				return nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/payment/details" {
+				return testutils.NewMockResponse(http.StatusOK, paymentDetailsResponseBody), nil
+			}
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -99000005, "Message": "Refund amount exceeds"}`), nil
+		}
+
+		_, err := svc.RefundPayment(context.Background(), domain.RefundRequest{
+			DeviceToken: "test-token",
+			QrPaymentID: 15,
+			QrReturnID:  15,
+			Amount:      100,
+		})
+		if _, ok := domain.IsKaspiError(err); !ok {
+			t.Fatalf("Expected KaspiError, got %v", err)
+		}
+
+		if failedID != 1 {
+			t.Errorf("Expected refund 1 to be failed, got %d", failedID)
+		}
+	})
+
+	t.Run("keeps refund pending when outcome is unknown", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", &MockStorage{
+			CompleteRefundFunc: func(ctx context.Context, id int64, operationID int64) error {
+				t.Error("Refund must not be completed")
+				return nil
+			},
+			FailRefundFunc: func(ctx context.Context, id int64, reason string) error {
+				t.Error("Refund must not be failed")
+				return nil
+			},
+		})
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection reset")
+		}
+
+		_, err := svc.RefundPaymentEnhanced(context.Background(), domain.EnhancedRefundRequest{
+			DeviceToken:     "test-token",
+			QrPaymentID:     15,
+			Amount:          100,
+			OrganizationBin: "180340021791",
+		})
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
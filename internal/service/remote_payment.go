@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// SetRemotePaymentTimeout enables automatic cancel of remote payments that were not paid in time
+func (s *KaspiService) SetRemotePaymentTimeout(timeout time.Duration) {
+	s.remotePaymentTimeout = timeout
+}
+
+// GetPendingRemotePayments returns remote payments of the organization that still wait for the customer
+func (s *KaspiService) GetPendingRemotePayments(ctx context.Context, organizationBin string) ([]domain.Payment, error) {
+	if s.scheme != "enhanced" {
+		return nil, fmt.Errorf("remote payment functionality is only available in enhanced scheme")
+	}
+
+	const op = "service.kaspi.GetPendingRemotePayments"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("organizationBin", organizationBin),
+	)
+
+	if organizationBin == "" {
+		return nil, &validator.ValidationError{
+			Field:   "OrganizationBin",
+			Message: "OrganizationBin is required",
+			Err:     validator.ErrRequiredField,
+		}
+	}
+
+	if err := s.resolveOrganization(ctx, &organizationBin, "", ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("getting pending remote payments")
+
+	payments, err := s.paymentStorage.PendingRemotePayments(ctx, organizationBin, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if payments == nil {
+		payments = []domain.Payment{}
+	}
+
+	return payments, nil
+}
+
+// CancelOverdueRemotePayment cancels a remote payment that was not paid in time. The status is
+// refreshed first so that a payment the customer has just completed is never canceled
+func (s *KaspiService) CancelOverdueRemotePayment(ctx context.Context, qrPaymentID int64) error {
+	const op = "service.kaspi.CancelOverdueRemotePayment"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("qrPaymentID", qrPaymentID),
+	)
+
+	payment, err := s.paymentStorage.Payment(ctx, qrPaymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if payment.Kind != domain.PaymentKindRemote || !payment.IsPending() {
+		return nil
+	}
+
+	status, err := s.GetPaymentStatus(ctx, qrPaymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if domain.IsTerminalPaymentStatus(status.Status) {
+		log.Debug("remote payment completed before cancel", "status", status.Status)
+		return nil
+	}
+
+	deviceToken, err := strconv.ParseInt(payment.DeviceToken, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s: invalid device token: %w", op, err)
+	}
+
+	log.Info("canceling overdue remote payment")
+
+	_, err = s.CancelRemotePayment(ctx, domain.RemotePaymentCancelRequest{
+		OrganizationBin: payment.OrganizationBin,
+		QrPaymentID:     qrPaymentID,
+		DeviceToken:     deviceToken,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/testutils"
+	"kaspi-api-wrapper/internal/validator"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const remotePaymentCanceledResponseBody = `{
+	"StatusCode": 0,
+	"Message": "OK",
+	"Data": {
+		"Status": "RemotePaymentCanceled"
+	}
+}`
+
+func pendingRemotePayment() *domain.Payment {
+	return &domain.Payment{
+		QrPaymentID:     15,
+		Kind:            domain.PaymentKindRemote,
+		DeviceToken:     "2",
+		OrganizationBin: "180340021791",
+		Amount:          100,
+		Status:          domain.PaymentStatusWait,
+	}
+}
+
+func TestMaskPhoneNumber(t *testing.T) {
+	tests := map[string]string{
+		"87071234567":  "8707*****67",
+		"+77071234567": "+770******67",
+		"123456":       "******",
+		"":             "",
+	}
+
+	for phone, expected := range tests {
+		if masked := domain.MaskPhoneNumber(phone); masked != expected {
+			t.Errorf("Expected %q to be masked as %q, got %q", phone, expected, masked)
+		}
+	}
+}
+
+func TestRemotePaymentTracking(t *testing.T) {
+	t.Run("saves remote payment with masked phone number", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var saved domain.Payment
+		store := &MockStorage{
+			SavePaymentFunc: func(ctx context.Context, payment domain.Payment) error {
+				saved = payment
+				return nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", store)
+		svc.SetRemotePaymentTimeout(3 * time.Minute)
+
+		tracker := &MockPaymentTracker{}
+		svc.SetPaymentTracker(tracker)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Message": "OK",
+				"Data": {
+					"QrPaymentId": 15
+				}
+			}`), nil
+		}
+
+		_, err := svc.CreateRemotePayment(context.Background(), domain.RemotePaymentRequest{
+			OrganizationBin: "180340021791",
+			Amount:          100.00,
+			PhoneNumber:     "87071234567",
+			DeviceToken:     2,
+			Comment:         "Test payment",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if saved.Kind != domain.PaymentKindRemote || saved.DeviceToken != "2" || saved.Amount != 100 {
+			t.Errorf("Unexpected saved payment: %+v", saved)
+		}
+
+		if saved.PhoneNumber != "8707*****67" {
+			t.Errorf("Expected masked phone number, got %s", saved.PhoneNumber)
+		}
+
+		if saved.Comment != "Test payment" {
+			t.Errorf("Expected comment to be saved, got %s", saved.Comment)
+		}
+
+		if tracker.qrPaymentID != 15 || tracker.opts.ScanTimeout != 3*time.Minute {
+			t.Errorf("Expected payment 15 tracked with the cancel timeout, got %d %+v", tracker.qrPaymentID, tracker.opts)
+		}
+	})
+
+	t.Run("records canceled status", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var recorded string
+		store := &MockStorage{
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				recorded = status.Status
+				return domain.PaymentStatusWait, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", store)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, remotePaymentCanceledResponseBody), nil
+		}
+
+		_, err := svc.CancelRemotePayment(context.Background(), domain.RemotePaymentCancelRequest{
+			OrganizationBin: "180340021791",
+			QrPaymentID:     15,
+			DeviceToken:     2,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if recorded != domain.PaymentStatusCanceled {
+			t.Errorf("Expected status %s, got %s", domain.PaymentStatusCanceled, recorded)
+		}
+	})
+}
+
+func TestCancelOverdueRemotePayment(t *testing.T) {
+	t.Run("expiring a remote payment cancels it in Kaspi", func(t *testing.T) {
+		log := setupTestLogger()
+
+		var recorded []string
+		store := &MockStorage{
+			PaymentFunc: func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+				return pendingRemotePayment(), nil
+			},
+			UpdatePaymentStatusFunc: func(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+				recorded = append(recorded, status.Status)
+				return domain.PaymentStatusWait, nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", store)
+		svc.SetRemotePaymentTimeout(5 * time.Minute)
+
+		var paths []string
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path)
+
+			if req.URL.Path == "/payment/status/15" {
+				return testutils.NewMockResponse(http.StatusOK, `{
+					"StatusCode": 0,
+					"Message": "OK",
+					"Data": {
+						"Status": "Wait"
+					}
+				}`), nil
+			}
+
+			return testutils.NewMockResponse(http.StatusOK, remotePaymentCanceledResponseBody), nil
+		}
+
+		err := svc.ExpirePayment(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(paths) != 2 || paths[1] != "/remote/cancel" {
+			t.Errorf("Expected status check and cancel, got %v", paths)
+		}
+
+		if len(recorded) != 2 || recorded[1] != domain.PaymentStatusCanceled {
+			t.Errorf("Expected payment to be canceled, got %v", recorded)
+		}
+	})
+
+	t.Run("does not cancel a payment completed meanwhile", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			PaymentFunc: func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+				return pendingRemotePayment(), nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", store)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/payment/status/15" {
+				t.Errorf("Unexpected request to %s", req.URL.Path)
+			}
+
+			return testutils.NewMockResponse(http.StatusOK, `{
+				"StatusCode": 0,
+				"Message": "OK",
+				"Data": {
+					"Status": "Processed"
+				}
+			}`), nil
+		}
+
+		err := svc.CancelOverdueRemotePayment(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("keeps the payment pending when the cancel fails", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			PaymentFunc: func(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+				return pendingRemotePayment(), nil
+			},
+		}
+
+		svc, mockClient := setupTestServiceWithStorage(log, "enhanced", store)
+		svc.SetRemotePaymentTimeout(5 * time.Minute)
+
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		err := svc.ExpirePayment(context.Background(), 15)
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
+
+func TestGetPendingRemotePayments(t *testing.T) {
+	t.Run("returns pending payments of the organization", func(t *testing.T) {
+		log := setupTestLogger()
+
+		store := &MockStorage{
+			PendingRemoteFunc: func(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+				if organizationBin != "180340021791" {
+					t.Errorf("Expected BIN 180340021791, got %s", organizationBin)
+				}
+
+				if !createdBefore.IsZero() {
+					t.Errorf("Expected payments of any age, got %s", createdBefore)
+				}
+
+				return []domain.Payment{*pendingRemotePayment()}, nil
+			},
+		}
+
+		svc, _ := setupTestServiceWithStorage(log, "enhanced", store)
+
+		payments, err := svc.GetPendingRemotePayments(context.Background(), "180340021791")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(payments) != 1 || payments[0].QrPaymentID != 15 {
+			t.Errorf("Unexpected payments: %+v", payments)
+		}
+	})
+
+	t.Run("requires organization BIN", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, _ := setupTestServiceWithStorage(log, "enhanced", &MockStorage{})
+
+		_, err := svc.GetPendingRemotePayments(context.Background(), "")
+
+		var validationErr *validator.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
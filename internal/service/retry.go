@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"kaspi-api-wrapper/internal/domain"
+)
+
+// RetryPolicy describes how outbound Kaspi calls are retried
+type RetryPolicy struct {
+	MaxAttempts int           // total number of attempts, 1 disables retries
+	BaseDelay   time.Duration // delay before the first retry
+	MaxDelay    time.Duration // upper bound for a single delay
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
+}
+
+// backoff returns exponential delay with full jitter for the given attempt (starting from 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// transportError wraps an error returned by the HTTP client itself
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isConnectError reports whether the request failed before any bytes were sent
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// shouldRetry decides whether a failed call may be repeated.
+// Connection errors are always safe since nothing reached Kaspi, other failures
+// only for reads: Kaspi has no idempotency keys of its own so a repeated money
+// operation could be executed twice.
+func shouldRetry(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, domain.ErrCircuitOpen) {
+		return false
+	}
+
+	if isConnectError(err) {
+		return true
+	}
+
+	if method != http.MethodGet {
+		return false
+	}
+
+	if kaspiErr, ok := domain.IsKaspiError(err); ok {
+		// Service temporarily unavailable
+		return kaspiErr.StatusCode == -999
+	}
+
+	var tErr *transportError
+	return errors.As(err, &tErr)
+}
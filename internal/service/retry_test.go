@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/service"
+	"kaspi-api-wrapper/internal/testutils"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() service.RetryPolicy {
+	return service.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestRequestRetry(t *testing.T) {
+	t.Run("retries read on service unavailable", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -999, "Message": "Service unavailable"}`), nil
+			}
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"Status": "Wait"}}`), nil
+		}
+
+		status, err := svc.GetPaymentStatus(context.Background(), 15)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if status.Status != "Wait" {
+			t.Errorf("Expected status Wait, got %s", status.Status)
+		}
+
+		if calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("connection reset")
+		}
+
+		_, err := svc.GetTradePoints(context.Background())
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+
+		if calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("does not retry refund on service unavailable", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "standard")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/payment/details" {
+				return testutils.NewMockResponse(http.StatusOK, paymentDetailsResponseBody), nil
+			}
+			calls++
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -999, "Message": "Service unavailable"}`), nil
+		}
+
+		_, err := svc.RefundPayment(context.Background(), domain.RefundRequest{
+			DeviceToken: "test-token",
+			QrPaymentID: 15,
+			QrReturnID:  15,
+			Amount:      100,
+		})
+
+		kaspiErr, ok := domain.IsKaspiError(err)
+		if !ok || kaspiErr.StatusCode != -999 {
+			t.Fatalf("Expected KaspiError -999, got %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("retries money operation on connection error", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			}
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"QrToken": "token", "QrPaymentId": 15}}`), nil
+		}
+
+		resp, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{
+			DeviceToken: "test-token",
+			Amount:      200,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if resp.QrPaymentID != 15 {
+			t.Errorf("Expected QrPaymentID 15, got %d", resp.QrPaymentID)
+		}
+
+		if calls != 2 {
+			t.Errorf("Expected 2 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("does not retry money operation failed after the request was sent", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(fastRetryPolicy())
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+			}
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"QrToken": "token", "QrPaymentId": 15}}`), nil
+		}
+
+		_, err := svc.CreateQR(context.Background(), domain.QRCreateRequest{
+			DeviceToken: "test-token",
+			Amount:      200,
+		})
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("does not retry past context deadline", func(t *testing.T) {
+		log := setupTestLogger()
+		svc, mockClient := setupTestService(log, "basic")
+		svc.SetRetryPolicy(service.RetryPolicy{
+			MaxAttempts: 5,
+			BaseDelay:   time.Second,
+			MaxDelay:    time.Second,
+		})
+
+		calls := 0
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("connection reset")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := svc.GetTradePoints(ctx)
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected retries to stop at deadline, took %s", elapsed)
+		}
+	})
+}
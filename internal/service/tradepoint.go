@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DefaultTradePointStaleAfter is how long the cached trade points are served without a sync with
+// Kaspi before they are reported as stale
+const DefaultTradePointStaleAfter = 2 * time.Hour
+
+// TradePointCache keeps the trade points returned by Kaspi with the metadata of the wrapper
+type TradePointCache interface {
+	SyncTradePoints(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error)
+	TradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	TradePoint(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error)
+	UpdateTradePoint(ctx context.Context, tradePoint domain.TradePoint) error
+}
+
+// SetTradePointStaleAfter sets how long the cached trade points stay fresh, zero never marks them stale
+func (s *KaspiService) SetTradePointStaleAfter(staleAfter time.Duration) {
+	s.tradePointStaleAfter = staleAfter
+}
+
+// RefreshTradePoints syncs the cached trade points of the basic and standard schemes with Kaspi
+func (s *KaspiService) RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
+	const op = "service.kaspi.RefreshTradePoints"
+
+	if s.scheme == "enhanced" {
+		return nil, domain.ErrUnsupportedFeature
+	}
+
+	tradePoints, err := s.syncTradePoints(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tradePoints, nil
+}
+
+// RefreshTradePointsEnhanced syncs the cached trade points of an organization with Kaspi
+func (s *KaspiService) RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	const op = "service.kaspi.RefreshTradePointsEnhanced"
+
+	if err := validator.ValidateOrganizationBin(organizationBin); err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveOrganization(ctx, &organizationBin, "", ""); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tradePoints, err := s.syncTradePoints(ctx, organizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tradePoints, nil
+}
+
+// UpdateTradePoint replaces the metadata of a cached trade point. OrganizationBin of the request
+// is only used in the enhanced scheme
+func (s *KaspiService) UpdateTradePoint(ctx context.Context, req domain.TradePointUpdateRequest) (*domain.TradePoint, error) {
+	const op = "service.kaspi.UpdateTradePoint"
+
+	if err := validator.ValidateTradePointUpdateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if s.scheme == "enhanced" {
+		if err := validator.ValidateOrganizationBin(req.OrganizationBin); err != nil {
+			return nil, err
+		}
+
+		if err := s.resolveOrganization(ctx, &req.OrganizationBin, "", ""); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	} else {
+		req.OrganizationBin = ""
+	}
+
+	tradePoint, err := s.tradePoints.TradePoint(ctx, req.OrganizationBin, req.TradePointID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tradePoint.Address = req.Address
+	tradePoint.Timezone = req.Timezone
+	tradePoint.MinAmount = req.MinAmount
+	tradePoint.MaxAmount = req.MaxAmount
+	if req.Enabled != nil {
+		tradePoint.Enabled = *req.Enabled
+	}
+
+	if err = s.tradePoints.UpdateTradePoint(ctx, *tradePoint); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tradePoint.Stale = s.tradePointStale(tradePoint.SyncedAt, time.Now())
+
+	return tradePoint, nil
+}
+
+// cachedTradePoints returns the cached trade points of the organization. The cache is synced first
+// when it is empty or stale, a stale cache is still returned when Kaspi is not available
+func (s *KaspiService) cachedTradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	tradePoints, err := s.tradePoints.TradePoints(ctx, organizationBin)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tradePoints) == 0 {
+		return s.syncTradePoints(ctx, organizationBin)
+	}
+
+	s.markStaleTradePoints(tradePoints)
+	if !tradePoints[0].Stale {
+		return tradePoints, nil
+	}
+
+	synced, err := s.syncTradePoints(ctx, organizationBin)
+	if err != nil {
+		s.log.Warn("failed to sync stale trade points, returning the cache",
+			slog.String("organizationBin", organizationBin),
+			slog.String("error", err.Error()),
+		)
+		return tradePoints, nil
+	}
+
+	return synced, nil
+}
+
+// syncTradePoints fetches the trade points of the organization from Kaspi and stores them in the cache
+func (s *KaspiService) syncTradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	path := "/partner/tradepoints"
+	if organizationBin != "" {
+		path = fmt.Sprintf("/partner/tradepoints/%s", organizationBin)
+	}
+
+	var result []domain.TradePoint
+	if err := s.request(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+
+	tradePoints, err := s.tradePoints.SyncTradePoints(ctx, organizationBin, result, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if tradePoints == nil {
+		tradePoints = []domain.TradePoint{}
+	}
+	s.markStaleTradePoints(tradePoints)
+
+	return tradePoints, nil
+}
+
+// checkTradePoint checks that the trade point of a device registration is known to Kaspi and enabled.
+// A trade point missing from the cache may have been added since the last sync, so the cache is
+// synced before the trade point is rejected, at most once per staleness window
+func (s *KaspiService) checkTradePoint(ctx context.Context, organizationBin string, tradePointID int64) error {
+	tradePoint, err := s.tradePoints.TradePoint(ctx, organizationBin, tradePointID)
+	if errors.Is(err, storage.ErrTradePointNotFound) && s.tradePointSyncDue(ctx, organizationBin) {
+		if _, err = s.syncTradePoints(ctx, organizationBin); err != nil {
+			return err
+		}
+		tradePoint, err = s.tradePoints.TradePoint(ctx, organizationBin, tradePointID)
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrTradePointNotFound):
+		return fmt.Errorf("%d: %w", tradePointID, domain.ErrTradePointNotFound)
+	case err != nil:
+		return err
+	case !tradePoint.Enabled:
+		return fmt.Errorf("%d: %w", tradePointID, domain.ErrTradePointDisabled)
+	}
+
+	return nil
+}
+
+// tradePointSyncDue reports whether no cached trade point of the organization was synced within the
+// staleness window, so registrations with unknown trade points don't query Kaspi every time
+func (s *KaspiService) tradePointSyncDue(ctx context.Context, organizationBin string) bool {
+	window := s.tradePointStaleAfter
+	if window == 0 {
+		window = DefaultTradePointStaleAfter
+	}
+
+	tradePoints, err := s.tradePoints.TradePoints(ctx, organizationBin)
+	if err != nil {
+		return true
+	}
+
+	now := time.Now()
+	for _, tradePoint := range tradePoints {
+		if now.Sub(tradePoint.SyncedAt) < window {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *KaspiService) markStaleTradePoints(tradePoints []domain.TradePoint) {
+	now := time.Now()
+	for i := range tradePoints {
+		tradePoints[i].Stale = s.tradePointStale(tradePoints[i].SyncedAt, now)
+	}
+}
+
+func (s *KaspiService) tradePointStale(syncedAt, now time.Time) bool {
+	return s.tradePointStaleAfter > 0 && now.Sub(syncedAt) > s.tradePointStaleAfter
+}
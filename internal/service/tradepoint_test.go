@@ -0,0 +1,216 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/testutils"
+	"kaspi-api-wrapper/internal/validator"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const tradePointsResponseBody = `{
+	"StatusCode": 0,
+	"Message": "OK",
+	"Data": [{"TradePointId": 1, "TradePointName": "Store 1"}]
+}`
+
+// tradePointCache returns a storage with the cached trade points of the basic and standard schemes
+func tradePointCache(tradePoints ...domain.TradePoint) *MockStorage {
+	return &MockStorage{
+		TradePointsFunc: func(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+			return append([]domain.TradePoint(nil), tradePoints...), nil
+		},
+		TradePointFunc: func(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error) {
+			for _, tradePoint := range tradePoints {
+				if tradePoint.TradePointID == tradePointID {
+					return &tradePoint, nil
+				}
+			}
+			return nil, storage.ErrTradePointNotFound
+		},
+	}
+}
+
+func TestGetTradePointsCache(t *testing.T) {
+	t.Run("serves fresh cache without calling Kaspi", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 1, TradePointName: "Store 1", Enabled: true, SyncedAt: time.Now()})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			t.Errorf("Unexpected Kaspi call %s", r.URL.Path)
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		tradePoints, err := svc.GetTradePoints(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tradePoints) != 1 || tradePoints[0].Stale {
+			t.Errorf("Expected 1 fresh trade point, got %+v", tradePoints)
+		}
+	})
+
+	t.Run("returns stale cache when Kaspi is not available", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 1, TradePointName: "Store 1", Enabled: true, SyncedAt: time.Now().Add(-3 * time.Hour)})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": -1501, "Message": "Device not found"}`), nil
+		}
+
+		tradePoints, err := svc.GetTradePoints(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tradePoints) != 1 || !tradePoints[0].Stale {
+			t.Errorf("Expected 1 stale trade point, got %+v", tradePoints)
+		}
+	})
+
+	t.Run("syncs stale cache", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 1, TradePointName: "Old name", Enabled: true, SyncedAt: time.Now().Add(-3 * time.Hour)})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		var synced bool
+		store.SyncTradePointsFunc = func(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error) {
+			synced = true
+			tradePoints[0].SyncedAt = syncedAt
+			return tradePoints, nil
+		}
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		tradePoints, err := svc.GetTradePoints(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !synced || len(tradePoints) != 1 || tradePoints[0].TradePointName != "Store 1" || tradePoints[0].Stale {
+			t.Errorf("Expected synced trade points, got %+v", tradePoints)
+		}
+	})
+
+	t.Run("refreshes trade points of an organization", func(t *testing.T) {
+		svc, mockClient := setupTestService(setupTestLogger(), "enhanced")
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/partner/tradepoints/180340021791" {
+				t.Errorf("Expected URL path /partner/tradepoints/180340021791, got %s", r.URL.Path)
+			}
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		tradePoints, err := svc.RefreshTradePointsEnhanced(context.Background(), "180340021791")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tradePoints) != 1 || tradePoints[0].OrganizationBin != "180340021791" {
+			t.Errorf("Expected 1 trade point of the organization, got %+v", tradePoints)
+		}
+	})
+}
+
+func TestRegisterDeviceTradePoint(t *testing.T) {
+	req := domain.DeviceRegisterRequest{DeviceID: "POS-1", TradePointID: 2}
+
+	t.Run("rejects trade point unknown to Kaspi", func(t *testing.T) {
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", tradePointCache())
+
+		var paths []string
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			paths = append(paths, r.URL.Path)
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		_, err := svc.RegisterDevice(context.Background(), req)
+		if !errors.Is(err, domain.ErrTradePointNotFound) {
+			t.Fatalf("Expected ErrTradePointNotFound, got %v", err)
+		}
+		if len(paths) != 1 || paths[0] != "/partner/tradepoints" {
+			t.Errorf("Expected only the trade point sync, got calls %v", paths)
+		}
+	})
+
+	t.Run("does not sync a fresh cache again for an unknown trade point", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 1, Enabled: true, SyncedAt: time.Now()})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			t.Errorf("Unexpected Kaspi call %s", r.URL.Path)
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		if _, err := svc.RegisterDevice(context.Background(), req); !errors.Is(err, domain.ErrTradePointNotFound) {
+			t.Fatalf("Expected ErrTradePointNotFound, got %v", err)
+		}
+	})
+
+	t.Run("syncs a cache older than the staleness window for an unknown trade point", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 1, Enabled: true, SyncedAt: time.Now().Add(-3 * time.Hour)})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		var paths []string
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			paths = append(paths, r.URL.Path)
+			return testutils.NewMockResponse(http.StatusOK, tradePointsResponseBody), nil
+		}
+
+		if _, err := svc.RegisterDevice(context.Background(), req); !errors.Is(err, domain.ErrTradePointNotFound) {
+			t.Fatalf("Expected ErrTradePointNotFound, got %v", err)
+		}
+		if len(paths) != 1 || paths[0] != "/partner/tradepoints" {
+			t.Errorf("Expected only the trade point sync, got calls %v", paths)
+		}
+	})
+
+	t.Run("rejects disabled trade point", func(t *testing.T) {
+		store := tradePointCache(domain.TradePoint{TradePointID: 2, Enabled: false, SyncedAt: time.Now()})
+		svc, mockClient := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+		mockClient.DoFunc = func(r *http.Request) (*http.Response, error) {
+			t.Errorf("Unexpected Kaspi call %s", r.URL.Path)
+			return testutils.NewMockResponse(http.StatusOK, `{"StatusCode": 0, "Data": {"DeviceToken": "token"}}`), nil
+		}
+
+		if _, err := svc.RegisterDevice(context.Background(), req); !errors.Is(err, domain.ErrTradePointDisabled) {
+			t.Fatalf("Expected ErrTradePointDisabled, got %v", err)
+		}
+	})
+}
+
+func TestUpdateTradePoint(t *testing.T) {
+	store := tradePointCache(domain.TradePoint{TradePointID: 1, TradePointName: "Store 1", Enabled: true, SyncedAt: time.Now()})
+	svc, _ := setupTestServiceWithStorage(setupTestLogger(), "basic", store)
+
+	tradePoint, err := svc.UpdateTradePoint(context.Background(), domain.TradePointUpdateRequest{
+		TradePointID: 1,
+		Address:      "Abay 1",
+		Timezone:     "Asia/Almaty",
+		MaxAmount:    50000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tradePoint.Address != "Abay 1" || tradePoint.Timezone != "Asia/Almaty" || !tradePoint.Enabled || tradePoint.MaxAmount != 50000 {
+		t.Errorf("Unexpected trade point %+v", tradePoint)
+	}
+
+	var validationErr *validator.ValidationError
+	_, err = svc.UpdateTradePoint(context.Background(), domain.TradePointUpdateRequest{TradePointID: 1, Timezone: "Almaty"})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error for an unknown timezone, got %v", err)
+	}
+
+	_, err = svc.UpdateTradePoint(context.Background(), domain.TradePointUpdateRequest{TradePointID: 1, MinAmount: 100, MaxAmount: 50})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error for min amount above max amount, got %v", err)
+	}
+
+	if _, err = svc.UpdateTradePoint(context.Background(), domain.TradePointUpdateRequest{TradePointID: 3}); !errors.Is(err, storage.ErrTradePointNotFound) {
+		t.Errorf("Expected ErrTradePointNotFound, got %v", err)
+	}
+}
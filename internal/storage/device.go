@@ -0,0 +1,25 @@
+package storage
+
+import "kaspi-api-wrapper/internal/domain"
+
+// ReplacesDevice decides how a registration of device is stored over the existing device with the
+// same ID. A deleted device, or one registered in the other scheme, is replaced with the new token.
+// An active device stays as is when it is registered again in its trade point and organization,
+// in another one it is in use and ErrDeviceExists is returned
+func ReplacesDevice(existing, device domain.Device) (bool, error) {
+	if existing.DeletedAt != nil {
+		// device was deleted in Kaspi and is registered again, possibly in another trade point
+		return true, nil
+	}
+
+	if existing.TradePointID != device.TradePointID {
+		return false, ErrDeviceExists
+	}
+
+	if existing.Scheme == domain.DeviceSchemeEnhanced && device.Scheme == domain.DeviceSchemeEnhanced &&
+		existing.OrganizationBin != device.OrganizationBin {
+		return false, ErrDeviceExists
+	}
+
+	return existing.Scheme != device.Scheme, nil
+}
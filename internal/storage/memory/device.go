@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+// SaveDevice saves device of the basic and standard schemes
+func (s *Storage) SaveDevice(ctx context.Context, deviceID string, deviceToken string, tradePointID int64) error {
+	return s.saveDevice(domain.Device{
+		DeviceID:     deviceID,
+		DeviceToken:  deviceToken,
+		TradePointID: tradePointID,
+		Scheme:       domain.DeviceSchemeStandard,
+	})
+}
+
+// SaveDeviceEnhanced saves device of the enhanced scheme
+func (s *Storage) SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error {
+	return s.saveDevice(domain.Device{
+		DeviceID:        deviceID,
+		DeviceToken:     deviceToken,
+		TradePointID:    tradePointID,
+		Scheme:          domain.DeviceSchemeEnhanced,
+		OrganizationBin: organizationBin,
+	})
+}
+
+func (s *Storage) saveDevice(device domain.Device) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device.CreatedAt = time.Now()
+
+	if existing, ok := s.devices[device.DeviceID]; ok {
+		replace, err := storage.ReplacesDevice(*existing, device)
+		if err != nil || !replace {
+			return err
+		}
+
+		s.devices[device.DeviceID] = &device
+		return nil
+	}
+
+	// a token registered under another ID moves to the new one, as on conflict in the SQL backends
+	if existing := s.deviceByToken(device.DeviceToken); existing != nil {
+		delete(s.devices, existing.DeviceID)
+		existing.DeviceID = device.DeviceID
+		existing.TradePointID = device.TradePointID
+		existing.Scheme = device.Scheme
+		existing.OrganizationBin = device.OrganizationBin
+		existing.DeletedAt = nil
+		s.devices[device.DeviceID] = existing
+		return nil
+	}
+
+	s.devices[device.DeviceID] = &device
+
+	return nil
+}
+
+// Devices returns the registered devices matching the filter, newest first
+func (s *Storage) Devices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var devices []domain.Device
+	for _, device := range s.devices {
+		if filter.TradePointID != 0 && device.TradePointID != filter.TradePointID {
+			continue
+		}
+		if filter.OrganizationBin != "" && device.OrganizationBin != filter.OrganizationBin {
+			continue
+		}
+		if !filter.IncludeDeleted && device.DeletedAt != nil {
+			continue
+		}
+		devices = append(devices, copyDevice(device))
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		if !devices[i].CreatedAt.Equal(devices[j].CreatedAt) {
+			return devices[i].CreatedAt.After(devices[j].CreatedAt)
+		}
+		return devices[i].DeviceID < devices[j].DeviceID
+	})
+
+	return devices, nil
+}
+
+// Device returns a registered device by its ID
+func (s *Storage) Device(ctx context.Context, deviceID string) (*domain.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, ok := s.devices[deviceID]
+	if !ok {
+		return nil, storage.ErrDeviceNotFound
+	}
+
+	device := copyDevice(found)
+	return &device, nil
+}
+
+// DeviceByToken returns a registered device by its token
+func (s *Storage) DeviceByToken(ctx context.Context, deviceToken string) (*domain.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := s.deviceByToken(deviceToken)
+	if found == nil {
+		return nil, storage.ErrDeviceNotFound
+	}
+
+	device := copyDevice(found)
+	return &device, nil
+}
+
+// DeactivateDevice marks the device with the token as deleted, a device that is already
+// deleted keeps its original deletion time
+func (s *Storage) DeactivateDevice(ctx context.Context, deviceToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var affected int
+
+	for _, device := range s.devices {
+		if device.DeviceToken == deviceToken && device.DeletedAt == nil {
+			deletedAt := now
+			device.DeletedAt = &deletedAt
+			affected++
+		}
+	}
+
+	if affected == 0 {
+		return storage.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// deviceByToken returns the device with the token
+func (s *Storage) deviceByToken(deviceToken string) *domain.Device {
+	for _, device := range s.devices {
+		if device.DeviceToken == deviceToken {
+			return device
+		}
+	}
+	return nil
+}
+
+func copyDevice(device *domain.Device) domain.Device {
+	c := *device
+	if device.DeletedAt != nil {
+		deletedAt := *device.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	c.Active = c.DeletedAt == nil
+	return c
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// AcquireIdempotencyKey locks the key for a new request. A key is free if it has never been used,
+// its record is older than expiredBefore or its in-progress lock is older than staleBefore.
+// If the key is taken, the existing record is returned with acquired set to false.
+func (s *Storage) AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, expiredBefore, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.idempotencyKeys[key]; ok {
+		expired := existing.CreatedAt.Before(expiredBefore)
+		stale := existing.Status == domain.IdempotencyStatusInProgress && existing.UpdatedAt.Before(staleBefore)
+
+		if !expired && !stale {
+			record := copyIdempotencyRecord(existing)
+			return &record, false, nil
+		}
+	}
+
+	now := time.Now()
+	s.idempotencyKeys[key] = &domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      domain.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	record := copyIdempotencyRecord(s.idempotencyKeys[key])
+	return &record, true, nil
+}
+
+// IdempotencyRecord returns the stored record of the key
+func (s *Storage) IdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.idempotencyKeys[key]
+	if !ok {
+		return nil, storage.ErrIdempotencyKeyNotFound
+	}
+
+	record := copyIdempotencyRecord(existing)
+	return &record, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request holding the key
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key string, responseStatus int, responseBody []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.idempotencyKeys[key]; ok {
+		record.Status = domain.IdempotencyStatusCompleted
+		record.ResponseStatus = responseStatus
+		record.ResponseBody = append([]byte(nil), responseBody...)
+		record.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey removes an in-progress key so that the request can be retried
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.idempotencyKeys[key]; ok && record.Status == domain.IdempotencyStatusInProgress {
+		delete(s.idempotencyKeys, key)
+	}
+
+	return nil
+}
+
+func copyIdempotencyRecord(record *domain.IdempotencyRecord) domain.IdempotencyRecord {
+	c := *record
+	c.ResponseBody = append([]byte(nil), record.ResponseBody...)
+	return c
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"kaspi-api-wrapper/internal/domain"
+	"sync"
+)
+
+// Storage keeps everything in process memory. It is meant for tests and demos, all data is lost
+// when the process stops and several instances don't share it
+type Storage struct {
+	mu sync.Mutex
+
+	devices       map[string]*domain.Device
+	organizations map[string]*domain.Organization
+	tradePoints   map[tradePointKey]*cachedTradePoint
+
+	payments      map[int64]*domain.Payment
+	refundQRs     map[int64]*domain.RefundQR
+	refunds       []*domain.Refund
+	sessions      map[int64]*domain.RefundSession
+	runs          []*domain.ReconciliationRun
+	discrepancies []domain.ReconciliationDiscrepancy
+
+	webhookEvents     map[string]*webhookEvent
+	webhookDeliveries []*webhookDelivery
+
+	idempotencyKeys map[string]*domain.IdempotencyRecord
+}
+
+// New creates an empty in-memory storage
+func New() *Storage {
+	return &Storage{
+		devices:         make(map[string]*domain.Device),
+		organizations:   make(map[string]*domain.Organization),
+		tradePoints:     make(map[tradePointKey]*cachedTradePoint),
+		payments:        make(map[int64]*domain.Payment),
+		refundQRs:       make(map[int64]*domain.RefundQR),
+		sessions:        make(map[int64]*domain.RefundSession),
+		webhookEvents:   make(map[string]*webhookEvent),
+		idempotencyKeys: make(map[string]*domain.IdempotencyRecord),
+	}
+}
+
+// Stop implements storage.Storage, there is nothing to release
+func (s *Storage) Stop() error {
+	return nil
+}
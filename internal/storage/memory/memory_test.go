@@ -0,0 +1,14 @@
+package memory_test
+
+import (
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/storage/memory"
+	"kaspi-api-wrapper/internal/storage/storagetest"
+	"testing"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return memory.New()
+	})
+}
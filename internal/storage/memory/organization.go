@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"maps"
+	"sort"
+)
+
+// SaveOrganization registers a new organization
+func (s *Storage) SaveOrganization(ctx context.Context, organization domain.Organization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.organizations[organization.OrganizationBin]; ok {
+		return storage.ErrOrganizationExists
+	}
+
+	saved := copyOrganization(&organization)
+	s.organizations[organization.OrganizationBin] = &saved
+
+	return nil
+}
+
+// UpdateOrganization replaces the name, state and settings of a registered organization
+func (s *Storage) UpdateOrganization(ctx context.Context, organization domain.Organization) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.organizations[organization.OrganizationBin]
+	if !ok {
+		return storage.ErrOrganizationNotFound
+	}
+
+	updated := copyOrganization(&organization)
+	updated.CreatedAt = existing.CreatedAt
+	s.organizations[organization.OrganizationBin] = &updated
+
+	return nil
+}
+
+// Organization returns a registered organization by its BIN
+func (s *Storage) Organization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, ok := s.organizations[organizationBin]
+	if !ok {
+		return nil, storage.ErrOrganizationNotFound
+	}
+
+	organization := copyOrganization(found)
+	return &organization, nil
+}
+
+// Organizations returns the registered organizations ordered by BIN
+func (s *Storage) Organizations(ctx context.Context) ([]domain.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var organizations []domain.Organization
+	for _, organization := range s.organizations {
+		organizations = append(organizations, copyOrganization(organization))
+	}
+
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].OrganizationBin < organizations[j].OrganizationBin
+	})
+
+	return organizations, nil
+}
+
+// DeleteOrganization removes a registered organization
+func (s *Storage) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.organizations[organizationBin]; !ok {
+		return storage.ErrOrganizationNotFound
+	}
+
+	delete(s.organizations, organizationBin)
+
+	return nil
+}
+
+func copyOrganization(organization *domain.Organization) domain.Organization {
+	c := *organization
+	c.Settings = maps.Clone(organization.Settings)
+	if c.Settings == nil {
+		c.Settings = map[string]string{}
+	}
+	return c
+}
@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+// SavePayment saves a newly created payment, the trade point is resolved from the stored device
+func (s *Storage) SavePayment(ctx context.Context, payment domain.Payment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.payments[payment.QrPaymentID]; ok {
+		return nil
+	}
+
+	if payment.TradePointID == 0 {
+		if device := s.deviceByToken(payment.DeviceToken); device != nil {
+			payment.TradePointID = device.TradePointID
+		}
+	}
+
+	// the outcome of the payment is only known from status updates
+	payment.TransactionID = ""
+	payment.ProductType = ""
+	payment.LoanOfferName = ""
+	payment.LoanTerm = 0
+
+	now := time.Now()
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	stored := copyPayment(&payment)
+	s.payments[payment.QrPaymentID] = &stored
+
+	return nil
+}
+
+// UpdatePaymentStatus stores the latest status returned by Kaspi and returns the status it replaced,
+// the update time only moves when the status changes
+func (s *Storage) UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, ok := s.payments[qrPaymentID]
+	if !ok {
+		return "", storage.ErrPaymentNotFound
+	}
+
+	previousStatus := payment.Status
+	if previousStatus != status.Status {
+		payment.UpdatedAt = time.Now()
+	}
+
+	payment.Status = status.Status
+	if status.TransactionID != "" {
+		payment.TransactionID = status.TransactionID
+	}
+	if status.ProductType != "" {
+		payment.ProductType = status.ProductType
+	}
+	if status.LoanOfferName != "" {
+		payment.LoanOfferName = status.LoanOfferName
+	}
+	if status.LoanTerm != 0 {
+		payment.LoanTerm = status.LoanTerm
+	}
+
+	return previousStatus, nil
+}
+
+// Payment returns a stored payment by its QrPaymentId
+func (s *Storage) Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, ok := s.payments[qrPaymentID]
+	if !ok {
+		return nil, storage.ErrPaymentNotFound
+	}
+
+	c := copyPayment(payment)
+	return &c, nil
+}
+
+// LockExternalID does nothing, the in-memory storage is used by a single instance
+// and the service already serializes the key within the process
+func (s *Storage) LockExternalID(ctx context.Context, key string) (context.Context, func(), error) {
+	return ctx, func() {}, nil
+}
+
+// LivePaymentByExternalID returns the latest payment of the kind with the ExternalId that can still
+// be paid, payments are scoped by organization BIN when it is given and by device otherwise
+func (s *Storage) LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	payments := s.selectPayments(func(p *domain.Payment) bool {
+		if p.ExternalID != externalID || p.Kind != kind || !p.IsLive(now) {
+			return false
+		}
+		if organizationBin != "" {
+			return p.OrganizationBin == organizationBin
+		}
+		return p.DeviceToken == deviceToken
+	}, newestFirst)
+
+	if len(payments) == 0 {
+		return nil, storage.ErrPaymentNotFound
+	}
+
+	return &payments[0], nil
+}
+
+// PaymentsByExternalID returns all payments with the ExternalId, newest first
+func (s *Storage) PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.selectPayments(func(p *domain.Payment) bool {
+		return p.ExternalID == externalID
+	}, newestFirst), nil
+}
+
+// PendingRemotePayments returns remote payments still waiting for the customer that were created
+// before the given time, oldest first. An empty BIN selects all organizations and a zero time
+// selects payments of any age
+func (s *Storage) PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.selectPayments(func(p *domain.Payment) bool {
+		if p.Kind != domain.PaymentKindRemote {
+			return false
+		}
+		if p.Status != domain.PaymentStatusCreated && p.Status != domain.PaymentStatusWait {
+			return false
+		}
+		if organizationBin != "" && p.OrganizationBin != organizationBin {
+			return false
+		}
+		return createdBefore.IsZero() || p.CreatedAt.Before(createdBefore)
+	}, oldestFirst), nil
+}
+
+// PendingPayments returns payments of any kind still waiting for the customer, oldest first
+func (s *Storage) PendingPayments(ctx context.Context) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.selectPayments(func(p *domain.Payment) bool {
+		return p.Status == domain.PaymentStatusCreated || p.Status == domain.PaymentStatusWait
+	}, oldestFirst), nil
+}
+
+// PaymentsCreatedBetween returns payments created in the period, oldest first
+func (s *Storage) PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.selectPayments(func(p *domain.Payment) bool {
+		return within(p.CreatedAt, from, to)
+	}, oldestFirst), nil
+}
+
+// selectPayments returns copies of the matching payments sorted with less
+func (s *Storage) selectPayments(match func(p *domain.Payment) bool, less func(a, b *domain.Payment) bool) []domain.Payment {
+	var selected []*domain.Payment
+	for _, payment := range s.payments {
+		if match(payment) {
+			selected = append(selected, payment)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return less(selected[i], selected[j])
+	})
+
+	var payments []domain.Payment
+	for _, payment := range selected {
+		payments = append(payments, copyPayment(payment))
+	}
+
+	return payments
+}
+
+func oldestFirst(a, b *domain.Payment) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.QrPaymentID < b.QrPaymentID
+}
+
+func newestFirst(a, b *domain.Payment) bool {
+	return oldestFirst(b, a)
+}
+
+// within reports whether t is in the period, from is inclusive and to is exclusive
+func within(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+func copyPayment(payment *domain.Payment) domain.Payment {
+	c := *payment
+	c.PaymentMethods = append([]string{}, payment.PaymentMethods...)
+	return c
+}
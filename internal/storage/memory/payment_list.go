@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ListPayments returns up to filter.Limit payments matching the filter in the requested order,
+// starting after the cursor position
+func (s *Storage) ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	search := strings.ToLower(strings.TrimSpace(filter.Search))
+	byAmount := filter.SortBy == domain.PaymentSortAmount
+	ascending := filter.SortOrder == domain.SortOrderAsc
+
+	// compare orders payments by the sort column and then by QrPaymentId in ascending order
+	compare := func(p *domain.Payment, createdAt time.Time, amount float64, qrPaymentID int64) int {
+		var c int
+		if byAmount {
+			c = cmp.Compare(p.Amount, amount)
+		} else {
+			c = p.CreatedAt.Compare(createdAt)
+		}
+		if c == 0 {
+			c = cmp.Compare(p.QrPaymentID, qrPaymentID)
+		}
+		return c
+	}
+
+	less := func(a, b *domain.Payment) bool {
+		c := compare(a, b.CreatedAt, b.Amount, b.QrPaymentID)
+		if ascending {
+			return c < 0
+		}
+		return c > 0
+	}
+
+	payments := s.selectPayments(func(p *domain.Payment) bool {
+		if filter.TradePointID != 0 && p.TradePointID != filter.TradePointID {
+			return false
+		}
+		if filter.DeviceToken != "" && p.DeviceToken != filter.DeviceToken {
+			return false
+		}
+		if filter.OrganizationBin != "" && p.OrganizationBin != filter.OrganizationBin {
+			return false
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, p.Status) {
+			return false
+		}
+		if filter.MinAmount != nil && p.Amount < *filter.MinAmount {
+			return false
+		}
+		if filter.MaxAmount != nil && p.Amount > *filter.MaxAmount {
+			return false
+		}
+		if !filter.From.IsZero() && p.CreatedAt.Before(filter.From) {
+			return false
+		}
+		if !filter.To.IsZero() && !p.CreatedAt.Before(filter.To) {
+			return false
+		}
+		if filter.ExternalID != "" && p.ExternalID != filter.ExternalID {
+			return false
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(p.ExternalID), search) &&
+			!strings.Contains(strings.ToLower(p.TransactionID), search) {
+			return false
+		}
+		if filter.After != nil {
+			c := compare(p, filter.After.CreatedAt, filter.After.Amount, filter.After.QrPaymentID)
+			if ascending && c <= 0 || !ascending && c >= 0 {
+				return false
+			}
+		}
+		return true
+	}, less)
+
+	if len(payments) > filter.Limit {
+		payments = payments[:filter.Limit]
+	}
+
+	return payments, nil
+}
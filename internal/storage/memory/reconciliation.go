@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// CreateReconciliationRun saves a started run and returns it with its ID, a scheduled run of a period
+// is created once and storage.ErrReconciliationRunExists is returned for a repeated one
+func (s *Storage) CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) (*domain.ReconciliationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if run.Trigger == domain.ReconciliationTriggerScheduled {
+		for _, stored := range s.runs {
+			if stored.Trigger == run.Trigger && stored.From.Equal(run.From) && stored.To.Equal(run.To) {
+				return nil, storage.ErrReconciliationRunExists
+			}
+		}
+	}
+
+	run.ID = int64(len(s.runs) + 1)
+	run.PaymentsChecked = 0
+	run.RefundsChecked = 0
+	run.Discrepancies = 0
+	run.Error = ""
+	run.FinishedAt = nil
+	run.HeartbeatAt = run.StartedAt
+
+	stored := run
+	s.runs = append(s.runs, &stored)
+
+	return &run, nil
+}
+
+// FinishReconciliationRun stores the outcome and the counters of a run
+func (s *Storage) FinishReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if run.ID < 1 || run.ID > int64(len(s.runs)) {
+		return storage.ErrReconciliationRunNotFound
+	}
+
+	stored := s.runs[run.ID-1]
+	stored.Status = run.Status
+	stored.PaymentsChecked = run.PaymentsChecked
+	stored.RefundsChecked = run.RefundsChecked
+	stored.Discrepancies = run.Discrepancies
+	stored.Error = run.Error
+	stored.FinishedAt = nil
+	if run.FinishedAt != nil {
+		finishedAt := *run.FinishedAt
+		stored.FinishedAt = &finishedAt
+	}
+
+	return nil
+}
+
+// RenewReconciliationRun extends the lease of a running run
+func (s *Storage) RenewReconciliationRun(ctx context.Context, id int64, heartbeatAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > int64(len(s.runs)) {
+		return storage.ErrReconciliationRunNotFound
+	}
+
+	s.runs[id-1].HeartbeatAt = heartbeatAt
+
+	return nil
+}
+
+// InterruptReconciliationRuns fails runs of the owner that were still running when it stopped, and runs
+// of other instances whose lease was last renewed before staleBefore
+func (s *Storage) InterruptReconciliationRuns(ctx context.Context, owner string, staleBefore time.Time, reason string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var affected int64
+	for _, run := range s.runs {
+		if run.Status != domain.ReconciliationRunning {
+			continue
+		}
+		if run.Owner != owner && !run.HeartbeatAt.Before(staleBefore) {
+			continue
+		}
+
+		finishedAt := time.Now()
+		run.Status = domain.ReconciliationFailed
+		run.Error = reason
+		run.FinishedAt = &finishedAt
+		affected++
+	}
+
+	return affected, nil
+}
+
+// SaveReconciliationDiscrepancy records a mismatch found by a run
+func (s *Storage) SaveReconciliationDiscrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if discrepancy.RunID < 1 || discrepancy.RunID > int64(len(s.runs)) {
+		return storage.ErrReconciliationRunNotFound
+	}
+
+	discrepancy.ID = int64(len(s.discrepancies) + 1)
+	s.discrepancies = append(s.discrepancies, discrepancy)
+
+	return nil
+}
+
+// ReconciliationRun returns a run by its ID
+func (s *Storage) ReconciliationRun(ctx context.Context, id int64) (*domain.ReconciliationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > int64(len(s.runs)) {
+		return nil, storage.ErrReconciliationRunNotFound
+	}
+
+	run := copyReconciliationRun(s.runs[id-1])
+	return &run, nil
+}
+
+// ReconciliationRuns returns the latest runs, newest first
+func (s *Storage) ReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var runs []domain.ReconciliationRun
+	for i := len(s.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		runs = append(runs, copyReconciliationRun(s.runs[i]))
+	}
+
+	return runs, nil
+}
+
+// ReconciliationDiscrepancies returns the discrepancies of a run in the order they were found
+func (s *Storage) ReconciliationDiscrepancies(ctx context.Context, runID int64) ([]domain.ReconciliationDiscrepancy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var discrepancies []domain.ReconciliationDiscrepancy
+	for _, discrepancy := range s.discrepancies {
+		if discrepancy.RunID == runID {
+			discrepancies = append(discrepancies, discrepancy)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+func copyReconciliationRun(run *domain.ReconciliationRun) domain.ReconciliationRun {
+	c := *run
+	if run.FinishedAt != nil {
+		finishedAt := *run.FinishedAt
+		c.FinishedAt = &finishedAt
+	}
+	return c
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+// SaveRefundQR saves a newly created refund QR
+func (s *Storage) SaveRefundQR(ctx context.Context, refund domain.RefundQR) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.refundQRs[refund.QrReturnID]; ok {
+		return nil
+	}
+
+	now := time.Now()
+	refund.CreatedAt = now
+	refund.UpdatedAt = now
+	s.refundQRs[refund.QrReturnID] = &refund
+
+	return nil
+}
+
+// UpdateRefundStatus stores the latest refund status returned by Kaspi and returns the status it replaced
+func (s *Storage) UpdateRefundStatus(ctx context.Context, qrReturnID int64, status string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refund, ok := s.refundQRs[qrReturnID]
+	if !ok {
+		return "", storage.ErrRefundNotFound
+	}
+
+	previousStatus := refund.Status
+	refund.Status = status
+	refund.UpdatedAt = time.Now()
+
+	return previousStatus, nil
+}
+
+// RefundQR returns a stored refund QR by its QrReturnId
+func (s *Storage) RefundQR(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refund, ok := s.refundQRs[qrReturnID]
+	if !ok {
+		return nil, storage.ErrRefundNotFound
+	}
+
+	c := *refund
+	return &c, nil
+}
+
+// RefundQRsCreatedBetween returns refund QRs created in the period, oldest first
+func (s *Storage) RefundQRsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.RefundQR, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var refunds []domain.RefundQR
+	for _, refund := range s.refundQRs {
+		if within(refund.CreatedAt, from, to) {
+			refunds = append(refunds, *refund)
+		}
+	}
+
+	sort.Slice(refunds, func(i, j int) bool {
+		if !refunds[i].CreatedAt.Equal(refunds[j].CreatedAt) {
+			return refunds[i].CreatedAt.Before(refunds[j].CreatedAt)
+		}
+		return refunds[i].QrReturnID < refunds[j].QrReturnID
+	})
+
+	return refunds, nil
+}
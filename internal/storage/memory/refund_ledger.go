@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+// ReserveRefund records a pending refund, the storage lock serializes refunds and check sees the
+// balance including every earlier succeeded or pending refund
+func (s *Storage) ReserveRefund(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var balance domain.RefundBalance
+
+	if payment, ok := s.payments[refund.QrPaymentID]; ok {
+		balance.PaymentAmount = payment.Amount
+	}
+
+	for _, r := range s.refunds {
+		if r.QrPaymentID != refund.QrPaymentID {
+			continue
+		}
+
+		switch {
+		case r.Status == domain.RefundStatusSucceeded:
+			balance.Succeeded += r.Amount
+		case r.Status == domain.RefundStatusPending:
+			balance.Pending += r.Amount
+		}
+	}
+
+	if err := check(balance); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	refund.ID = int64(len(s.refunds) + 1)
+	refund.ReturnOperationID = 0
+	refund.Error = ""
+	refund.Status = domain.RefundStatusPending
+	refund.CreatedAt = now
+	refund.UpdatedAt = now
+
+	stored := refund
+	s.refunds = append(s.refunds, &stored)
+
+	return &refund, nil
+}
+
+// CompleteRefund marks a pending refund as accepted by Kaspi
+func (s *Storage) CompleteRefund(ctx context.Context, id int64, returnOperationID int64) error {
+	return s.finishRefund(id, domain.RefundStatusSucceeded, returnOperationID, "")
+}
+
+// FailRefund marks a pending refund as rejected, it no longer counts against the balance
+func (s *Storage) FailRefund(ctx context.Context, id int64, reason string) error {
+	return s.finishRefund(id, domain.RefundStatusFailed, 0, reason)
+}
+
+func (s *Storage) finishRefund(id int64, status string, returnOperationID int64, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > int64(len(s.refunds)) {
+		return storage.ErrRefundNotFound
+	}
+
+	refund := s.refunds[id-1]
+	refund.Status = status
+	refund.ReturnOperationID = returnOperationID
+	refund.Error = reason
+	refund.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RefundTotals returns the sum of all succeeded refunds of every payment that had a refund
+// succeed in the period
+func (s *Storage) RefundTotals(ctx context.Context, from, to time.Time) ([]domain.RefundTotal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inPeriod := make(map[int64]bool)
+	for _, refund := range s.refunds {
+		if refund.Status == domain.RefundStatusSucceeded && within(refund.UpdatedAt, from, to) {
+			inPeriod[refund.QrPaymentID] = true
+		}
+	}
+
+	totals := make(map[int64]*domain.RefundTotal)
+	for _, refund := range s.refunds {
+		if refund.Status != domain.RefundStatusSucceeded || !inPeriod[refund.QrPaymentID] {
+			continue
+		}
+
+		total, ok := totals[refund.QrPaymentID]
+		if !ok {
+			total = &domain.RefundTotal{QrPaymentID: refund.QrPaymentID}
+			totals[refund.QrPaymentID] = total
+		}
+
+		total.Amount += refund.Amount
+		if refund.DeviceToken > total.DeviceToken {
+			total.DeviceToken = refund.DeviceToken
+		}
+	}
+
+	var result []domain.RefundTotal
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QrPaymentID < result[j].QrPaymentID
+	})
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+// SaveRefundSession saves a newly started refund session
+func (s *Storage) SaveRefundSession(ctx context.Context, session domain.RefundSession) error {
+	const op = "storage.memory.SaveRefundSession"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[session.QrReturnID]; ok {
+		return fmt.Errorf("%s:refund session %d already exists", op, session.QrReturnID)
+	}
+
+	now := time.Now()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	stored := copyRefundSession(&session)
+	s.sessions[session.QrReturnID] = &stored
+
+	return nil
+}
+
+// UpdateRefundSession stores the progress of a session that is still in fromState,
+// domain.ErrRefundSessionState is returned if another step has moved it on meanwhile
+func (s *Storage) UpdateRefundSession(ctx context.Context, session domain.RefundSession, fromState string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.sessions[session.QrReturnID]
+	if !ok {
+		return storage.ErrRefundSessionNotFound
+	}
+
+	if stored.State != fromState {
+		return domain.ErrRefundSessionState
+	}
+
+	update := copyRefundSession(&session)
+
+	stored.State = update.State
+	stored.KaspiStatus = update.KaspiStatus
+	stored.Deadline = update.Deadline
+	stored.Operations = update.Operations
+	stored.QrPaymentID = update.QrPaymentID
+	stored.Amount = update.Amount
+	stored.AvailableReturnAmount = update.AvailableReturnAmount
+	stored.ReturnOperationID = update.ReturnOperationID
+	stored.Error = update.Error
+	stored.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RefundSession returns a refund session by its QrReturnId
+func (s *Storage) RefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[qrReturnID]
+	if !ok {
+		return nil, storage.ErrRefundSessionNotFound
+	}
+
+	c := copyRefundSession(session)
+	return &c, nil
+}
+
+// WaitingRefundSessions returns sessions that wait for the customer or the cashier,
+// they are resumed after a restart
+func (s *Storage) WaitingRefundSessions(ctx context.Context) ([]domain.RefundSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sessions []domain.RefundSession
+	for _, session := range s.sessions {
+		if session.IsWaiting() {
+			sessions = append(sessions, copyRefundSession(session))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+		}
+		return sessions[i].QrReturnID < sessions[j].QrReturnID
+	})
+
+	return sessions, nil
+}
+
+func copyRefundSession(session *domain.RefundSession) domain.RefundSession {
+	c := *session
+	if session.Deadline != nil {
+		deadline := *session.Deadline
+		c.Deadline = &deadline
+	}
+	c.Operations = append([]domain.CustomerOperation{}, session.Operations...)
+	return c
+}
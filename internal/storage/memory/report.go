@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"sort"
+	"time"
+)
+
+// SettlementEntries returns processed payments and succeeded refunds created in the period, oldest first.
+// Refunds are attributed to the trade point and product type of the refunded payment
+func (s *Storage) SettlementEntries(ctx context.Context, from, to time.Time, tradePointID int64, organizationBin string) ([]domain.SettlementEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deviceID := func(deviceToken string) string {
+		if device := s.deviceByToken(deviceToken); device != nil {
+			return device.DeviceID
+		}
+		return ""
+	}
+
+	var entries []domain.SettlementEntry
+
+	for _, p := range s.payments {
+		if p.Status != domain.PaymentStatusProcessed || !within(p.CreatedAt, from, to) {
+			continue
+		}
+		if tradePointID != 0 && p.TradePointID != tradePointID {
+			continue
+		}
+		if organizationBin != "" && p.OrganizationBin != organizationBin {
+			continue
+		}
+
+		entries = append(entries, domain.SettlementEntry{
+			Kind:           domain.SettlementSale,
+			QrPaymentID:    p.QrPaymentID,
+			TradePointID:   p.TradePointID,
+			DeviceID:       deviceID(p.DeviceToken),
+			ProductType:    p.ProductType,
+			PaymentMethods: append([]string{}, p.PaymentMethods...),
+			Amount:         p.Amount,
+			CreatedAt:      p.CreatedAt,
+		})
+	}
+
+	for _, r := range s.refunds {
+		if r.Status != domain.RefundStatusSucceeded || !within(r.CreatedAt, from, to) {
+			continue
+		}
+
+		entry := domain.SettlementEntry{
+			Kind:           domain.SettlementRefund,
+			QrPaymentID:    r.QrPaymentID,
+			DeviceID:       deviceID(r.DeviceToken),
+			PaymentMethods: []string{},
+			Amount:         r.Amount,
+			CreatedAt:      r.CreatedAt,
+		}
+
+		bin := r.OrganizationBin
+		if p, ok := s.payments[r.QrPaymentID]; ok {
+			entry.TradePointID = p.TradePointID
+			entry.ProductType = p.ProductType
+			entry.PaymentMethods = append([]string{}, p.PaymentMethods...)
+			if bin == "" {
+				bin = p.OrganizationBin
+			}
+		}
+
+		if tradePointID != 0 && entry.TradePointID != tradePointID {
+			continue
+		}
+		if organizationBin != "" && bin != organizationBin {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].QrPaymentID < entries[j].QrPaymentID
+	})
+
+	return entries, nil
+}
+
+// AccountingDocuments returns processed payments and succeeded refunds created in the period, oldest first.
+// Refunds carry the identifiers and the trade point of the refunded payment
+func (s *Storage) AccountingDocuments(ctx context.Context, from, to time.Time, tradePointID int64) ([]domain.AccountingDocument, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var documents []domain.AccountingDocument
+
+	for _, p := range s.payments {
+		if p.Status != domain.PaymentStatusProcessed || !within(p.CreatedAt, from, to) {
+			continue
+		}
+		if tradePointID != 0 && p.TradePointID != tradePointID {
+			continue
+		}
+
+		documents = append(documents, domain.AccountingDocument{
+			Kind:            domain.SettlementSale,
+			QrPaymentID:     p.QrPaymentID,
+			ExternalID:      p.ExternalID,
+			TransactionID:   p.TransactionID,
+			TradePointID:    p.TradePointID,
+			OrganizationBin: p.OrganizationBin,
+			Amount:          p.Amount,
+			CreatedAt:       p.CreatedAt,
+		})
+	}
+
+	for _, r := range s.refunds {
+		if r.Status != domain.RefundStatusSucceeded || !within(r.CreatedAt, from, to) {
+			continue
+		}
+
+		doc := domain.AccountingDocument{
+			Kind:            domain.SettlementRefund,
+			RefundID:        r.ID,
+			QrPaymentID:     r.QrPaymentID,
+			OrganizationBin: r.OrganizationBin,
+			Amount:          r.Amount,
+			CreatedAt:       r.CreatedAt,
+		}
+
+		if p, ok := s.payments[r.QrPaymentID]; ok {
+			doc.ExternalID = p.ExternalID
+			doc.TransactionID = p.TransactionID
+			doc.TradePointID = p.TradePointID
+			if doc.OrganizationBin == "" {
+				doc.OrganizationBin = p.OrganizationBin
+			}
+		}
+
+		if tradePointID != 0 && doc.TradePointID != tradePointID {
+			continue
+		}
+
+		documents = append(documents, doc)
+	}
+
+	sort.Slice(documents, func(i, j int) bool {
+		a, b := documents[i], documents[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.QrPaymentID != b.QrPaymentID {
+			return a.QrPaymentID < b.QrPaymentID
+		}
+		return a.RefundID < b.RefundID
+	})
+
+	return documents, nil
+}
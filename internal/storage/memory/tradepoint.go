@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+type tradePointKey struct {
+	organizationBin string
+	tradePointID    int64
+}
+
+type cachedTradePoint struct {
+	tradePoint domain.TradePoint
+	removed    bool
+}
+
+// SyncTradePoints stores the trade points returned by Kaspi for the organization and returns the
+// cached ones. Trade points missing from the list are hidden, their metadata is kept in case they
+// come back
+func (s *Storage) SyncTradePoints(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	returned := make(map[int64]bool, len(tradePoints))
+	for _, tradePoint := range tradePoints {
+		returned[tradePoint.TradePointID] = true
+
+		key := tradePointKey{organizationBin: organizationBin, tradePointID: tradePoint.TradePointID}
+		cached, ok := s.tradePoints[key]
+		if !ok {
+			cached = &cachedTradePoint{tradePoint: domain.TradePoint{
+				TradePointID:    tradePoint.TradePointID,
+				OrganizationBin: organizationBin,
+				Enabled:         true,
+			}}
+			s.tradePoints[key] = cached
+		}
+
+		cached.tradePoint.TradePointName = tradePoint.TradePointName
+		cached.tradePoint.SyncedAt = syncedAt
+		cached.removed = false
+	}
+
+	for key, cached := range s.tradePoints {
+		if key.organizationBin == organizationBin && !returned[key.tradePointID] {
+			cached.removed = true
+		}
+	}
+
+	return s.cachedTradePoints(organizationBin), nil
+}
+
+// TradePoints returns the cached trade points of the organization ordered by ID
+func (s *Storage) TradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cachedTradePoints(organizationBin), nil
+}
+
+// TradePoint returns a cached trade point of the organization
+func (s *Storage) TradePoint(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.tradePoints[tradePointKey{organizationBin: organizationBin, tradePointID: tradePointID}]
+	if !ok || cached.removed {
+		return nil, storage.ErrTradePointNotFound
+	}
+
+	tradePoint := cached.tradePoint
+	return &tradePoint, nil
+}
+
+// UpdateTradePoint replaces the metadata of a cached trade point
+func (s *Storage) UpdateTradePoint(ctx context.Context, tradePoint domain.TradePoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.tradePoints[tradePointKey{organizationBin: tradePoint.OrganizationBin, tradePointID: tradePoint.TradePointID}]
+	if !ok || cached.removed {
+		return storage.ErrTradePointNotFound
+	}
+
+	cached.tradePoint.Address = tradePoint.Address
+	cached.tradePoint.Timezone = tradePoint.Timezone
+	cached.tradePoint.Enabled = tradePoint.Enabled
+	cached.tradePoint.MinAmount = tradePoint.MinAmount
+	cached.tradePoint.MaxAmount = tradePoint.MaxAmount
+
+	return nil
+}
+
+func (s *Storage) cachedTradePoints(organizationBin string) []domain.TradePoint {
+	var tradePoints []domain.TradePoint
+	for key, cached := range s.tradePoints {
+		if key.organizationBin == organizationBin && !cached.removed {
+			tradePoints = append(tradePoints, cached.tradePoint)
+		}
+	}
+
+	sort.Slice(tradePoints, func(i, j int) bool {
+		return tradePoints[i].TradePointID < tradePoints[j].TradePointID
+	})
+
+	return tradePoints
+}
@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"sort"
+	"time"
+)
+
+type webhookEvent struct {
+	payload   []byte
+	createdAt time.Time
+}
+
+type webhookDelivery struct {
+	id            int64
+	eventID       string
+	url           string
+	status        string
+	attempts      int
+	nextAttemptAt time.Time
+	lastError     string
+	deliveredAt   *time.Time
+}
+
+// SaveWebhookEvent saves an event together with a pending delivery for every URL
+func (s *Storage) SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error {
+	const op = "storage.memory.SaveWebhookEvent"
+
+	// events are stored as JSON like in the SQL backends, so they are delivered exactly as they would be there
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhookEvents[event.ID]; ok {
+		return fmt.Errorf("%s:webhook event %s already exists", op, event.ID)
+	}
+
+	now := time.Now()
+	s.webhookEvents[event.ID] = &webhookEvent{payload: payload, createdAt: now}
+
+	for _, url := range urls {
+		s.webhookDeliveries = append(s.webhookDeliveries, &webhookDelivery{
+			id:            int64(len(s.webhookDeliveries) + 1),
+			eventID:       event.ID,
+			url:           url,
+			status:        domain.DeliveryStatusPending,
+			nextAttemptAt: now,
+		})
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries returns pending deliveries that are due and leases them until leaseUntil
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	const op = "storage.memory.ClaimWebhookDeliveries"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*webhookDelivery
+	for _, delivery := range s.webhookDeliveries {
+		if delivery.status == domain.DeliveryStatusPending && !delivery.nextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].nextAttemptAt.Before(due[j].nextAttemptAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	var deliveries []domain.WebhookDelivery
+	for _, delivery := range due {
+		delivery.nextAttemptAt = leaseUntil
+
+		claimed := domain.WebhookDelivery{
+			ID:       delivery.id,
+			URL:      delivery.url,
+			Attempts: delivery.attempts,
+		}
+
+		if err := json.Unmarshal(s.webhookEvents[delivery.eventID].payload, &claimed.Event); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		deliveries = append(deliveries, claimed)
+	}
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered marks a delivery as successfully delivered
+func (s *Storage) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delivery := s.webhookDelivery(deliveryID); delivery != nil {
+		deliveredAt := time.Now()
+		delivery.status = domain.DeliveryStatusDelivered
+		delivery.attempts++
+		delivery.lastError = ""
+		delivery.deliveredAt = &deliveredAt
+	}
+
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt, the delivery is either rescheduled or moved to dead letters
+func (s *Storage) MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delivery := s.webhookDelivery(deliveryID); delivery != nil {
+		delivery.status = domain.DeliveryStatusPending
+		if dead {
+			delivery.status = domain.DeliveryStatusDead
+		}
+		delivery.attempts++
+		delivery.nextAttemptAt = nextAttemptAt
+		delivery.lastError = lastError
+	}
+
+	return nil
+}
+
+// ReplayWebhookDeliveries puts deliveries of the matching events back into the queue
+// regardless of their current status and returns the number of requeued deliveries
+func (s *Storage) ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var affected int64
+
+	for _, delivery := range s.webhookDeliveries {
+		event := s.webhookEvents[delivery.eventID]
+
+		if filter.EventID != "" && delivery.eventID != filter.EventID {
+			continue
+		}
+		if !filter.Since.IsZero() && event.createdAt.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !event.createdAt.Before(filter.Until) {
+			continue
+		}
+
+		delivery.status = domain.DeliveryStatusPending
+		delivery.attempts = 0
+		delivery.nextAttemptAt = now
+		delivery.lastError = ""
+		delivery.deliveredAt = nil
+		affected++
+	}
+
+	if affected == 0 && filter.EventID != "" {
+		return 0, storage.ErrWebhookEventNotFound
+	}
+
+	return affected, nil
+}
+
+func (s *Storage) webhookDelivery(deliveryID int64) *webhookDelivery {
+	if deliveryID < 1 || deliveryID > int64(len(s.webhookDeliveries)) {
+		return nil
+	}
+	return s.webhookDeliveries[deliveryID-1]
+}
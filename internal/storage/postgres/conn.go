@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// the session lock of an ExternalId, the two key form doesn't collide with the other advisory locks
+const (
+	lockExternalID   = `SELECT pg_advisory_lock(hashtext('payments_external_id'), hashtext($1))`
+	unlockExternalID = `SELECT pg_advisory_unlock(hashtext('payments_external_id'), hashtext($1))`
+)
+
+// lockedConnKey carries the connection holding the lock of an ExternalId
+type lockedConnKey struct{}
+
+// querier is the part of *sql.DB and *sql.Conn the queries under a lock need
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the connection holding the lock taken with ctx, or the pool without a lock
+func (s *Storage) conn(ctx context.Context) querier {
+	if conn, ok := ctx.Value(lockedConnKey{}).(*sql.Conn); ok {
+		return conn
+	}
+
+	return s.db
+}
+
+// discardConn closes the connection instead of returning it to the pool, e.g. when it may still
+// hold a session lock
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"strings"
+	"time"
+)
+
+const deviceColumns = `device_id, device_token, tradepoint_id, scheme, COALESCE(organization_bin, ''), created_at, deleted_at`
+
+// saveDevice registers the device in a single transaction, registrations of the same device ID are
+// serialized with an advisory lock because the row to lock may not exist yet
+func (s *Storage) saveDevice(ctx context.Context, op string, device domain.Device) error {
+	storedToken, tokenIndex, err := s.sealToken(device.DeviceToken)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	var organizationBin sql.NullString
+	if device.Scheme == domain.DeviceSchemeEnhanced {
+		organizationBin = sql.NullString{String: device.OrganizationBin, Valid: true}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('devices'), hashtext($1))`, device.DeviceID); err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	existing, err := s.scanDevice(tx.QueryRowContext(ctx, `SELECT `+deviceColumns+` FROM devices WHERE device_id = $1`, device.DeviceID))
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// a token stored under another ID moves to the new one
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO devices (device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (device_token_hmac) DO UPDATE
+			SET device_id = $1, tradepoint_id = $4, scheme = $5, organization_bin = $6, deleted_at = NULL
+		`, device.DeviceID, storedToken, tokenIndex, device.TradePointID, device.Scheme, organizationBin, time.Now())
+	case err != nil:
+		return fmt.Errorf("%s:%w", op, err)
+	default:
+		var replace bool
+		if replace, err = storage.ReplacesDevice(*existing, device); err != nil || !replace {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE devices
+			SET device_token = $2, device_token_hmac = $3, tradepoint_id = $4, scheme = $5, organization_bin = $6,
+				created_at = $7, deleted_at = NULL
+			WHERE device_id = $1
+		`, device.DeviceID, storedToken, tokenIndex, device.TradePointID, device.Scheme, organizationBin, time.Now())
+	}
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// Devices returns the registered devices matching the filter, newest first
+func (s *Storage) Devices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error) {
+	const op = "storage.postgres.Devices"
+
+	var conditions []string
+	var args []any
+
+	if filter.TradePointID != 0 {
+		args = append(args, filter.TradePointID)
+		conditions = append(conditions, fmt.Sprintf("tradepoint_id = $%d", len(args)))
+	}
+	if filter.OrganizationBin != "" {
+		args = append(args, filter.OrganizationBin)
+		conditions = append(conditions, fmt.Sprintf("organization_bin = $%d", len(args)))
+	}
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	query := `SELECT ` + deviceColumns + ` FROM devices`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, device_id`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var devices []domain.Device
+	for rows.Next() {
+		device, err := s.scanDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		devices = append(devices, *device)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return devices, nil
+}
+
+// Device returns a registered device by its ID
+func (s *Storage) Device(ctx context.Context, deviceID string) (*domain.Device, error) {
+	const op = "storage.postgres.Device"
+
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE device_id = $1`
+
+	device, err := s.scanDevice(s.db.QueryRowContext(ctx, query, deviceID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrDeviceNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return device, nil
+}
+
+// DeviceByToken returns a registered device by its token
+func (s *Storage) DeviceByToken(ctx context.Context, deviceToken string) (*domain.Device, error) {
+	const op = "storage.postgres.DeviceByToken"
+
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE device_token_hmac = $1`
+
+	device, err := s.scanDevice(s.db.QueryRowContext(ctx, query, s.tokenIndex(deviceToken)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrDeviceNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return device, nil
+}
+
+// DeactivateDevice marks the device with the token as deleted, a device that is already
+// deleted keeps its original deletion time
+func (s *Storage) DeactivateDevice(ctx context.Context, deviceToken string) error {
+	const op = "storage.postgres.DeactivateDevice"
+
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE devices SET deleted_at = $2 WHERE device_token_hmac = $1 AND deleted_at IS NULL`,
+		s.tokenIndex(deviceToken), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) scanDevice(row rowScanner) (*domain.Device, error) {
+	var device domain.Device
+	var deletedAt sql.NullTime
+
+	err := row.Scan(
+		&device.DeviceID,
+		&device.DeviceToken,
+		&device.TradePointID,
+		&device.Scheme,
+		&device.OrganizationBin,
+		&device.CreatedAt,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if device.DeviceToken, err = s.openToken(device.DeviceToken); err != nil {
+		return nil, fmt.Errorf("device %s: %w", device.DeviceID, err)
+	}
+
+	device.CreatedAt = fromTimestamp(device.CreatedAt)
+	if deletedAt.Valid {
+		restored := fromTimestamp(deletedAt.Time)
+		device.DeletedAt = &restored
+	}
+	device.Active = device.DeletedAt == nil
+
+	return &device, nil
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/tokencrypt"
+)
+
+// errTokenKeyMissing is returned when an encrypted token is read without a master key
+var errTokenKeyMissing = errors.New("device token is encrypted but DEVICE_TOKEN_KEY is not set")
+
+// recordTokenTables keep the token of the device a record was made for next to the device registry,
+// devices_merge_conflicts keeps the devices archived by the merge of the device tables
+var recordTokenTables = []string{"payments", "refunds", "refund_qrs", "refund_sessions", "devices_merge_conflicts"}
+
+// SetTokenCipher enables encryption of device tokens, without a cipher tokens are stored in plaintext
+func (s *Storage) SetTokenCipher(tokens *tokencrypt.Cipher) {
+	s.tokens = tokens
+}
+
+// sealToken returns the stored form of the token and its lookup index
+func (s *Storage) sealToken(token string) (string, string, error) {
+	if s.tokens == nil {
+		return token, tokencrypt.PlainIndex(token), nil
+	}
+
+	sealed, err := s.tokens.Seal(token)
+	if err != nil {
+		return "", "", err
+	}
+
+	return sealed, s.tokens.Index(token), nil
+}
+
+// plainToken stores the token without encryption, it is used to decrypt the tables
+func plainToken(token string) (string, string, error) {
+	return token, tokencrypt.PlainIndex(token), nil
+}
+
+// openToken returns the token of its stored form, plaintext tokens are returned as is
+func (s *Storage) openToken(stored string) (string, error) {
+	if !tokencrypt.IsSealed(stored) {
+		return stored, nil
+	}
+
+	if s.tokens == nil {
+		return "", errTokenKeyMissing
+	}
+
+	return s.tokens.Open(stored)
+}
+
+// tokenIndex returns the index a device token is looked up by
+func (s *Storage) tokenIndex(token string) string {
+	if s.tokens == nil {
+		return tokencrypt.PlainIndex(token)
+	}
+
+	return s.tokens.Index(token)
+}
+
+// EncryptDeviceTokens encrypts the tokens still stored in plaintext, in the device registry and in the
+// records made for the devices, and returns how many rows were encrypted. It does nothing without a cipher
+func (s *Storage) EncryptDeviceTokens(ctx context.Context) (int, error) {
+	const op = "storage.postgres.EncryptDeviceTokens"
+
+	if s.tokens == nil {
+		return 0, nil
+	}
+
+	return s.rewriteDeviceTokens(ctx, op, s.sealToken, func(stored string) bool {
+		return !tokencrypt.IsSealed(stored)
+	})
+}
+
+// RotateDeviceTokens encrypts every token that is not encrypted with the current master key, including
+// plaintext ones, and indexes it with the current key
+func (s *Storage) RotateDeviceTokens(ctx context.Context) (int, error) {
+	const op = "storage.postgres.RotateDeviceTokens"
+
+	if s.tokens == nil {
+		return 0, fmt.Errorf("%s:%w", op, errTokenKeyMissing)
+	}
+
+	return s.rewriteDeviceTokens(ctx, op, s.sealToken, func(stored string) bool {
+		return !s.tokens.Current(stored)
+	})
+}
+
+// DecryptDeviceTokens stores every encrypted token in plaintext again, before the encryption migration is reverted
+func (s *Storage) DecryptDeviceTokens(ctx context.Context) (int, error) {
+	const op = "storage.postgres.DecryptDeviceTokens"
+
+	return s.rewriteDeviceTokens(ctx, op, plainToken, tokencrypt.IsSealed)
+}
+
+// rewriteDeviceTokens stores the selected tokens of the devices and their records in a new form in a single
+// transaction, the device rows are locked so that concurrent rewrites of several instances don't interfere
+func (s *Storage) rewriteDeviceTokens(ctx context.Context, op string, store func(token string) (string, string, error), selected func(stored string) bool) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT device_id, device_token FROM devices FOR UPDATE`)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	stored := make(map[string]string)
+	for rows.Next() {
+		var deviceID, token string
+		if err = rows.Scan(&deviceID, &token); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s:%w", op, err)
+		}
+		if selected(token) {
+			stored[deviceID] = token
+		}
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	for deviceID, value := range stored {
+		token, err := s.openToken(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s:device %s: %w", op, deviceID, err)
+		}
+
+		value, index, err := store(token)
+		if err != nil {
+			return 0, fmt.Errorf("%s:device %s: %w", op, deviceID, err)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE devices SET device_token = $2, device_token_hmac = $3 WHERE device_id = $1`,
+			deviceID, value, index,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("%s:device %s: %w", op, deviceID, err)
+		}
+	}
+
+	rewritten := len(stored)
+	for _, table := range recordTokenTables {
+		count, err := s.rewriteRecordTokens(ctx, tx, table, store, selected)
+		if err != nil {
+			return 0, fmt.Errorf("%s:%s: %w", op, table, err)
+		}
+		rewritten += count
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return rewritten, nil
+}
+
+// rewriteRecordTokens stores the selected tokens of the table in a new form and returns how many rows
+// changed. Rows are updated per stored value, the records of a device share the new form of its token
+func (s *Storage) rewriteRecordTokens(ctx context.Context, tx *sql.Tx, table string, store func(token string) (string, string, error), selected func(stored string) bool) (int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT device_token FROM `+table)
+	if err != nil {
+		return 0, err
+	}
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if selected(value) {
+			values = append(values, value)
+		}
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	type storedToken struct{ value, index string }
+	tokens := make(map[string]storedToken)
+
+	var rewritten int
+	for _, value := range values {
+		token, err := s.openToken(value)
+		if err != nil {
+			return 0, err
+		}
+
+		stored, ok := tokens[token]
+		if !ok {
+			if stored.value, stored.index, err = store(token); err != nil {
+				return 0, err
+			}
+			tokens[token] = stored
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`UPDATE `+table+` SET device_token = $2, device_token_hmac = $3 WHERE device_token = $1`,
+			value, stored.value, stored.index,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		rewritten += int(affected)
+	}
+
+	return rewritten, nil
+}
+
+// deviceIDsByIndex maps the token indexes of all stored devices to their IDs, records are
+// indexed with the same key as the devices
+func (s *Storage) deviceIDsByIndex(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT device_id, device_token_hmac FROM devices`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deviceIDs := make(map[string]string)
+	for rows.Next() {
+		var deviceID, index string
+		if err = rows.Scan(&deviceID, &index); err != nil {
+			return nil, err
+		}
+
+		deviceIDs[index] = deviceID
+	}
+
+	return deviceIDs, rows.Err()
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// AcquireIdempotencyKey locks the key for a new request. A key is free if it has never been used,
+// its record is older than expiredBefore or its in-progress lock is older than staleBefore.
+// If the key is taken, the existing record is returned with acquired set to false.
+func (s *Storage) AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, expiredBefore, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+	const op = "storage.postgres.AcquireIdempotencyKey"
+
+	now := time.Now()
+
+	query := `
+		INSERT INTO idempotency_keys (key, fingerprint, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+		    status = EXCLUDED.status,
+		    response_status = 0,
+		    response_body = NULL,
+		    created_at = EXCLUDED.created_at,
+		    updated_at = EXCLUDED.updated_at
+		WHERE idempotency_keys.created_at < $5
+		   OR (idempotency_keys.status = $3 AND idempotency_keys.updated_at < $6)
+		RETURNING key
+	`
+
+	var acquiredKey string
+	err := s.db.QueryRowContext(ctx, query, key, fingerprint, domain.IdempotencyStatusInProgress, now, toTimestamp(expiredBefore), toTimestamp(staleBefore)).
+		Scan(&acquiredKey)
+	if err == nil {
+		return &domain.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint,
+			Status:      domain.IdempotencyStatusInProgress,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}, true, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("%s:%w", op, err)
+	}
+
+	record, err := s.IdempotencyRecord(ctx, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return record, false, nil
+}
+
+// IdempotencyRecord returns the stored record of the key
+func (s *Storage) IdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	const op = "storage.postgres.IdempotencyRecord"
+
+	query := `
+		SELECT key, fingerprint, status, response_status, response_body, created_at, updated_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	var record domain.IdempotencyRecord
+	err := s.db.QueryRowContext(ctx, query, key).Scan(
+		&record.Key,
+		&record.Fingerprint,
+		&record.Status,
+		&record.ResponseStatus,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	record.CreatedAt = fromTimestamp(record.CreatedAt)
+	record.UpdatedAt = fromTimestamp(record.UpdatedAt)
+
+	return &record, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request holding the key
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key string, responseStatus int, responseBody []byte) error {
+	const op = "storage.postgres.CompleteIdempotencyKey"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET status = $2, response_status = $3, response_body = $4, updated_at = $5
+		WHERE key = $1
+	`, key, domain.IdempotencyStatusCompleted, responseStatus, responseBody, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey removes an in-progress key so that the request can be retried
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	const op = "storage.postgres.ReleaseIdempotencyKey"
+
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE key = $1 AND status = $2
+	`, key, domain.IdempotencyStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"kaspi-api-wrapper/internal/migrator"
+	"kaspi-api-wrapper/migrations"
+)
+
+// the session lock taken by migrators, the two key form doesn't collide with the per payment
+// locks of the refund ledger
+const (
+	lockMigrations   = `SELECT pg_advisory_lock(hashtext('schema_migrations'), 0)`
+	unlockMigrations = `SELECT pg_advisory_unlock(hashtext('schema_migrations'), 0)`
+)
+
+// Migrator returns the migrator of the embedded migrations. Instances starting together wait
+// for each other on an advisory lock, so every migration is applied once
+func (s *Storage) Migrator() (*migrator.Migrator, error) {
+	return migrator.New(s.db, migrations.FS, advisoryLock)
+}
+
+func advisoryLock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	if _, err := conn.ExecContext(ctx, lockMigrations); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		// the context may be canceled already, the lock is released anyway
+		_, err := conn.ExecContext(context.Background(), unlockMigrations)
+		return err
+	}, nil
+}
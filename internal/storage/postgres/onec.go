@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"time"
+)
+
+// AccountingDocuments returns processed payments and succeeded refunds created in the period, oldest first.
+// Refunds carry the identifiers and the trade point of the refunded payment
+func (s *Storage) AccountingDocuments(ctx context.Context, from, to time.Time, tradePointID int64) ([]domain.AccountingDocument, error) {
+	const op = "storage.postgres.AccountingDocuments"
+
+	query := `
+		SELECT $4::TEXT, 0::BIGINT, p.qr_payment_id, p.external_id, p.transaction_id,
+		       COALESCE(p.tradepoint_id, 0), p.organization_bin, p.amount, p.created_at
+		FROM payments p
+		WHERE p.status = $6 AND p.created_at >= $1 AND p.created_at < $2
+		  AND ($3::BIGINT = 0 OR p.tradepoint_id = $3)
+
+		UNION ALL
+
+		SELECT $5::TEXT, r.id, r.qr_payment_id, COALESCE(p.external_id, ''), COALESCE(p.transaction_id, ''),
+		       COALESCE(p.tradepoint_id, 0), COALESCE(NULLIF(r.organization_bin, ''), p.organization_bin, ''), r.amount, r.created_at
+		FROM refunds r
+		LEFT JOIN payments p ON p.qr_payment_id = r.qr_payment_id
+		WHERE r.status = $7 AND r.created_at >= $1 AND r.created_at < $2
+		  AND ($3::BIGINT = 0 OR p.tradepoint_id = $3)
+
+		ORDER BY 9, 3, 2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query,
+		toTimestamp(from),
+		toTimestamp(to),
+		tradePointID,
+		domain.SettlementSale,
+		domain.SettlementRefund,
+		domain.PaymentStatusProcessed,
+		domain.RefundStatusSucceeded,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var documents []domain.AccountingDocument
+	for rows.Next() {
+		var doc domain.AccountingDocument
+		err = rows.Scan(
+			&doc.Kind,
+			&doc.RefundID,
+			&doc.QrPaymentID,
+			&doc.ExternalID,
+			&doc.TransactionID,
+			&doc.TradePointID,
+			&doc.OrganizationBin,
+			&doc.Amount,
+			&doc.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		doc.CreatedAt = fromTimestamp(doc.CreatedAt)
+		documents = append(documents, doc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return documents, nil
+}
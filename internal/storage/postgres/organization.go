@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+)
+
+const organizationColumns = `organization_bin, name, enabled, settings, created_at, updated_at`
+
+// SaveOrganization registers a new organization
+func (s *Storage) SaveOrganization(ctx context.Context, organization domain.Organization) error {
+	const op = "storage.postgres.SaveOrganization"
+
+	settings, err := json.Marshal(settingsOrEmpty(organization.Settings))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	query := `
+		INSERT INTO organizations (` + organizationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organization_bin) DO NOTHING
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		organization.OrganizationBin,
+		organization.Name,
+		organization.Enabled,
+		settings,
+		toTimestamp(organization.CreatedAt),
+		toTimestamp(organization.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrOrganizationExists
+	}
+
+	return nil
+}
+
+// UpdateOrganization replaces the name, state and settings of a registered organization
+func (s *Storage) UpdateOrganization(ctx context.Context, organization domain.Organization) error {
+	const op = "storage.postgres.UpdateOrganization"
+
+	settings, err := json.Marshal(settingsOrEmpty(organization.Settings))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	query := `
+		UPDATE organizations
+		SET name = $2, enabled = $3, settings = $4, updated_at = $5
+		WHERE organization_bin = $1
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		organization.OrganizationBin,
+		organization.Name,
+		organization.Enabled,
+		settings,
+		toTimestamp(organization.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// Organization returns a registered organization by its BIN
+func (s *Storage) Organization(ctx context.Context, organizationBin string) (*domain.Organization, error) {
+	const op = "storage.postgres.Organization"
+
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE organization_bin = $1`
+
+	organization, err := scanOrganization(s.db.QueryRowContext(ctx, query, organizationBin))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return organization, nil
+}
+
+// Organizations returns the registered organizations ordered by BIN
+func (s *Storage) Organizations(ctx context.Context) ([]domain.Organization, error) {
+	const op = "storage.postgres.Organizations"
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+organizationColumns+` FROM organizations ORDER BY organization_bin`)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var organizations []domain.Organization
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		organizations = append(organizations, *organization)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return organizations, nil
+}
+
+// DeleteOrganization removes a registered organization
+func (s *Storage) DeleteOrganization(ctx context.Context, organizationBin string) error {
+	const op = "storage.postgres.DeleteOrganization"
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM organizations WHERE organization_bin = $1`, organizationBin)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+func scanOrganization(row rowScanner) (*domain.Organization, error) {
+	var organization domain.Organization
+	var settings []byte
+
+	err := row.Scan(
+		&organization.OrganizationBin,
+		&organization.Name,
+		&organization.Enabled,
+		&settings,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.CreatedAt = fromTimestamp(organization.CreatedAt)
+	organization.UpdatedAt = fromTimestamp(organization.UpdatedAt)
+
+	if err = json.Unmarshal(settings, &organization.Settings); err != nil {
+		return nil, fmt.Errorf("organization %s settings: %w", organization.OrganizationBin, err)
+	}
+	organization.Settings = settingsOrEmpty(organization.Settings)
+
+	return &organization, nil
+}
+
+func settingsOrEmpty(settings map[string]string) map[string]string {
+	if settings == nil {
+		return map[string]string{}
+	}
+	return settings
+}
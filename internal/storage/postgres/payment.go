@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+const paymentColumns = `
+	qr_payment_id, kind, external_id, device_token, COALESCE(tradepoint_id, 0), organization_bin,
+	amount, expire_date, payment_methods, status, transaction_id, product_type,
+	loan_offer_name, loan_term, qr_token, payment_link, status_polling_interval,
+	scan_wait_timeout, confirmation_timeout, phone_number, comment, created_at, updated_at
+`
+
+// SavePayment saves a newly created payment, the trade point is resolved from the stored device
+func (s *Storage) SavePayment(ctx context.Context, payment domain.Payment) error {
+	const op = "storage.postgres.SavePayment"
+
+	query := `
+		INSERT INTO payments (
+			qr_payment_id, kind, external_id, device_token, tradepoint_id, organization_bin,
+			amount, expire_date, payment_methods, status, qr_token, payment_link,
+			status_polling_interval, scan_wait_timeout, confirmation_timeout, phone_number, comment,
+			created_at, updated_at, device_token_hmac
+		)
+		VALUES (
+			$1, $2, $3, $4,
+			COALESCE(NULLIF($5::BIGINT, 0), (SELECT tradepoint_id FROM devices WHERE device_token_hmac = $19)),
+			$6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $18, $19
+		)
+		ON CONFLICT (qr_payment_id) DO NOTHING
+	`
+
+	storedToken, tokenIndex, err := s.sealToken(payment.DeviceToken)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	var expireDate sql.NullTime
+	if !payment.ExpireDate.IsZero() {
+		expireDate = sql.NullTime{Time: toTimestamp(payment.ExpireDate), Valid: true}
+	}
+
+	paymentMethods := payment.PaymentMethods
+	if paymentMethods == nil {
+		paymentMethods = []string{}
+	}
+
+	_, err = s.conn(ctx).ExecContext(ctx, query,
+		payment.QrPaymentID,
+		payment.Kind,
+		payment.ExternalID,
+		storedToken,
+		payment.TradePointID,
+		payment.OrganizationBin,
+		payment.Amount,
+		expireDate,
+		pq.Array(paymentMethods),
+		payment.Status,
+		payment.QrToken,
+		payment.PaymentLink,
+		payment.StatusPollingInterval,
+		payment.ScanWaitTimeout,
+		payment.PaymentConfirmationTimeout,
+		payment.PhoneNumber,
+		payment.Comment,
+		time.Now(),
+		tokenIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// UpdatePaymentStatus stores the latest status returned by Kaspi and returns the status it replaced,
+// the update time only moves when the status changes
+func (s *Storage) UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+	const op = "storage.postgres.UpdatePaymentStatus"
+
+	query := `
+		WITH previous AS (
+			SELECT qr_payment_id, status FROM payments WHERE qr_payment_id = $1 FOR UPDATE
+		)
+		UPDATE payments p
+		SET status = $2,
+		    transaction_id = COALESCE(NULLIF($3, ''), p.transaction_id),
+		    product_type = COALESCE(NULLIF($4, ''), p.product_type),
+		    loan_offer_name = COALESCE(NULLIF($5, ''), p.loan_offer_name),
+		    loan_term = COALESCE(NULLIF($6, 0), p.loan_term),
+		    updated_at = CASE WHEN previous.status = $2 THEN p.updated_at ELSE $7 END
+		FROM previous
+		WHERE p.qr_payment_id = previous.qr_payment_id
+		RETURNING previous.status
+	`
+
+	var previousStatus string
+	err := s.db.QueryRowContext(ctx, query,
+		qrPaymentID,
+		status.Status,
+		status.TransactionID,
+		status.ProductType,
+		status.LoanOfferName,
+		status.LoanTerm,
+		time.Now(),
+	).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", storage.ErrPaymentNotFound
+		}
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	return previousStatus, nil
+}
+
+// Payment returns a stored payment by its QrPaymentId
+func (s *Storage) Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+	const op = "storage.postgres.Payment"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE qr_payment_id = $1`
+
+	payment, err := s.scanPayment(s.db.QueryRowContext(ctx, query, qrPaymentID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payment, nil
+}
+
+// LockExternalID serializes payment creation for the key across every instance sharing the
+// database. The advisory lock is held by a dedicated connection until the returned function is
+// called. The lookup and the insert of the payment run on that connection with the returned
+// context, so a request holding the lock never waits for another connection of the pool
+func (s *Storage) LockExternalID(ctx context.Context, key string) (context.Context, func(), error) {
+	const op = "storage.postgres.LockExternalID"
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if _, err = conn.ExecContext(ctx, lockExternalID, key); err != nil {
+		// a canceled wait may have been granted the lock meanwhile
+		discardConn(conn)
+		return nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	unlock := func() {
+		// the context may be canceled already, the lock is released anyway
+		if _, err := conn.ExecContext(context.Background(), unlockExternalID, key); err != nil {
+			discardConn(conn)
+			return
+		}
+		conn.Close()
+	}
+
+	return context.WithValue(ctx, lockedConnKey{}, conn), unlock, nil
+}
+
+// LivePaymentByExternalID returns the latest payment of the kind with the ExternalId that can still
+// be paid, payments are scoped by organization BIN when it is given and by device otherwise
+func (s *Storage) LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+	const op = "storage.postgres.LivePaymentByExternalID"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE external_id = $1 AND kind = $2
+		  AND status IN ($3, $4)
+		  AND (expire_date IS NULL OR expire_date > $5)
+		  AND CASE WHEN $7 <> '' THEN organization_bin = $7 ELSE device_token_hmac = $6 END
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	payment, err := s.scanPayment(s.conn(ctx).QueryRowContext(ctx, query,
+		externalID,
+		kind,
+		domain.PaymentStatusCreated,
+		domain.PaymentStatusWait,
+		time.Now(),
+		s.tokenIndex(deviceToken),
+		organizationBin,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payment, nil
+}
+
+// PaymentsByExternalID returns all payments with the ExternalId, newest first
+func (s *Storage) PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	const op = "storage.postgres.PaymentsByExternalID"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE external_id = $1 ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PendingRemotePayments returns remote payments still waiting for the customer that were created
+// before the given time, oldest first. An empty BIN selects all organizations and a zero time
+// selects payments of any age
+func (s *Storage) PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+	const op = "storage.postgres.PendingRemotePayments"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE kind = $1 AND status IN ($2, $3)
+		  AND ($4::TEXT = '' OR organization_bin = $4)
+		  AND ($5::TIMESTAMP IS NULL OR created_at < $5)
+		ORDER BY created_at
+	`
+
+	var before sql.NullTime
+	if !createdBefore.IsZero() {
+		before = sql.NullTime{Time: toTimestamp(createdBefore), Valid: true}
+	}
+
+	rows, err := s.db.QueryContext(ctx, query,
+		domain.PaymentKindRemote,
+		domain.PaymentStatusCreated,
+		domain.PaymentStatusWait,
+		organizationBin,
+		before,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PendingPayments returns payments of any kind still waiting for the customer, oldest first
+func (s *Storage) PendingPayments(ctx context.Context) ([]domain.Payment, error) {
+	const op = "storage.postgres.PendingPayments"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE status IN ($1, $2)
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, domain.PaymentStatusCreated, domain.PaymentStatusWait)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PaymentsCreatedBetween returns payments created in the period, oldest first
+func (s *Storage) PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error) {
+	const op = "storage.postgres.PaymentsCreatedBetween"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, toTimestamp(from), toTimestamp(to))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// scanPayments scans and closes rows selected with paymentColumns
+func (s *Storage) scanPayments(rows *sql.Rows) ([]domain.Payment, error) {
+	defer rows.Close()
+
+	var payments []domain.Payment
+	for rows.Next() {
+		payment, err := s.scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPayment scans a row selected with paymentColumns
+func (s *Storage) scanPayment(row rowScanner) (*domain.Payment, error) {
+	var payment domain.Payment
+	var expireDate sql.NullTime
+
+	err := row.Scan(
+		&payment.QrPaymentID,
+		&payment.Kind,
+		&payment.ExternalID,
+		&payment.DeviceToken,
+		&payment.TradePointID,
+		&payment.OrganizationBin,
+		&payment.Amount,
+		&expireDate,
+		pq.Array(&payment.PaymentMethods),
+		&payment.Status,
+		&payment.TransactionID,
+		&payment.ProductType,
+		&payment.LoanOfferName,
+		&payment.LoanTerm,
+		&payment.QrToken,
+		&payment.PaymentLink,
+		&payment.StatusPollingInterval,
+		&payment.ScanWaitTimeout,
+		&payment.PaymentConfirmationTimeout,
+		&payment.PhoneNumber,
+		&payment.Comment,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.DeviceToken, err = s.openToken(payment.DeviceToken); err != nil {
+		return nil, fmt.Errorf("payment %d: %w", payment.QrPaymentID, err)
+	}
+
+	if expireDate.Valid {
+		payment.ExpireDate = fromTimestamp(expireDate.Time)
+	}
+	payment.CreatedAt = fromTimestamp(payment.CreatedAt)
+	payment.UpdatedAt = fromTimestamp(payment.UpdatedAt)
+
+	return &payment, nil
+}
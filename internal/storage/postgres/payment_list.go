@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"kaspi-api-wrapper/internal/domain"
+	"strings"
+)
+
+// likeEscaper makes user input match literally in a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListPayments returns up to filter.Limit payments matching the filter in the requested order,
+// starting after the cursor position
+func (s *Storage) ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	const op = "storage.postgres.ListPayments"
+
+	var conditions []string
+	var args []any
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.TradePointID != 0 {
+		conditions = append(conditions, "tradepoint_id = "+arg(filter.TradePointID))
+	}
+	if filter.DeviceToken != "" {
+		conditions = append(conditions, "device_token_hmac = "+arg(s.tokenIndex(filter.DeviceToken)))
+	}
+	if filter.OrganizationBin != "" {
+		conditions = append(conditions, "organization_bin = "+arg(filter.OrganizationBin))
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status = ANY("+arg(pq.Array(filter.Statuses))+")")
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "amount >= "+arg(*filter.MinAmount))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "amount <= "+arg(*filter.MaxAmount))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(toTimestamp(filter.From)))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(toTimestamp(filter.To)))
+	}
+	if filter.ExternalID != "" {
+		conditions = append(conditions, "external_id = "+arg(filter.ExternalID))
+	}
+	if filter.Search != "" {
+		pattern := arg("%" + likeEscaper.Replace(strings.TrimSpace(filter.Search)) + "%")
+		conditions = append(conditions, "(external_id ILIKE "+pattern+" OR transaction_id ILIKE "+pattern+")")
+	}
+
+	column := "created_at"
+	if filter.SortBy == domain.PaymentSortAmount {
+		column = "amount"
+	}
+
+	direction, comparison := "DESC", "<"
+	if filter.SortOrder == domain.SortOrderAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		var value any = toTimestamp(filter.After.CreatedAt)
+		if filter.SortBy == domain.PaymentSortAmount {
+			value = filter.After.Amount
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, qr_payment_id) %s (%s, %s)",
+			column, comparison, arg(value), arg(filter.After.QrPaymentID)))
+	}
+
+	query := `SELECT ` + paymentColumns + ` FROM payments`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, qr_payment_id %s LIMIT %s", column, direction, direction, arg(filter.Limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
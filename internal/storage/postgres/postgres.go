@@ -3,16 +3,17 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	_ "github.com/lib/pq"
-	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/tokencrypt"
 	"time"
 )
 
 // Storage represents a PostgreSQL storage implementation
 type Storage struct {
-	db *sql.DB
+	db     *sql.DB
+	tokens *tokencrypt.Cipher
 }
 
 // New initializes a new Storage instance by connecting to the PostgreSQL database
@@ -44,101 +45,27 @@ func (s *Storage) Stop() error {
 	return s.db.Close()
 }
 
-// SaveDevice saves device in the database
+// SaveDevice saves device of the basic and standard schemes
 func (s *Storage) SaveDevice(ctx context.Context, deviceID string, deviceToken string, tradePointID int64) error {
 	const op = "storage.postgres.SaveDevice"
 
-	// check whether device id already exists
-	var existingTradePointID int64
-	var existingDeviceToken string
-
-	checkQuery := `
-		SELECT tradepoint_id, device_token
-		FROM devices
-		WHERE device_id = $1
-		LIMIT 1
-	`
-
-	err := s.db.QueryRowContext(ctx, checkQuery, deviceID).Scan(&existingTradePointID, &existingDeviceToken)
-
-	if err == nil {
-		if existingTradePointID != tradePointID {
-			// device already in use in another tradepoint
-			return storage.ErrDeviceExists
-		}
-
-		return nil
-	} else if !errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	insertQuery := `
-		INSERT INTO devices (device_id, device_token, tradepoint_id, created_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (device_token) DO UPDATE 
-		SET device_id = $1, tradepoint_id = $3
-	`
-
-	_, err = s.db.ExecContext(ctx, insertQuery,
-		deviceID,
-		deviceToken,
-		tradePointID,
-		time.Now(),
-	)
-
-	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	return nil
+	return s.saveDevice(ctx, op, domain.Device{
+		DeviceID:     deviceID,
+		DeviceToken:  deviceToken,
+		TradePointID: tradePointID,
+		Scheme:       domain.DeviceSchemeStandard,
+	})
 }
 
-// SaveDeviceEnhanced saves device in the database
+// SaveDeviceEnhanced saves device of the enhanced scheme
 func (s *Storage) SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error {
 	const op = "storage.postgres.SaveDeviceEnhanced"
 
-	// check whether device id already exists
-	var existingTradePointID int64
-	var existingDeviceToken string
-
-	checkQuery := `
-		SELECT tradepoint_id, device_token
-		FROM devices_enhanced
-		WHERE device_id = $1
-		LIMIT 1
-	`
-
-	err := s.db.QueryRowContext(ctx, checkQuery, deviceID).Scan(&existingTradePointID, &existingDeviceToken)
-
-	if err == nil {
-		if existingTradePointID != tradePointID {
-			// device already in use in another tradepoint
-			return storage.ErrDeviceExists
-		}
-
-		return nil
-	} else if !errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	insertQuery := `
-		INSERT INTO devices_enhanced (device_id, device_token, tradepoint_id, organization_bin, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (device_token) DO UPDATE 
-		SET device_id = $1, tradepoint_id = $3
-	`
-
-	_, err = s.db.ExecContext(ctx, insertQuery,
-		deviceID,
-		deviceToken,
-		tradePointID,
-		organizationBin,
-		time.Now(),
-	)
-
-	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
-	}
-
-	return nil
+	return s.saveDevice(ctx, op, domain.Device{
+		DeviceID:        deviceID,
+		DeviceToken:     deviceToken,
+		TradePointID:    tradePointID,
+		Scheme:          domain.DeviceSchemeEnhanced,
+		OrganizationBin: organizationBin,
+	})
 }
@@ -0,0 +1,197 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/storage/postgres"
+	"kaspi-api-wrapper/internal/storage/storagetest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStorage runs against the database in TEST_POSTGRES_DSN, the embedded migrations are applied
+// and its tables are truncated before every test
+func TestStorage(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return openStorage(t, dsn)
+	})
+}
+
+// TestStorageInLocalZone runs the storage tests with the service in a zone other than UTC, the
+// TIMESTAMP columns hold its wall clock and stored times must keep their instant
+func TestStorageInLocalZone(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	local := time.Local
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return openStorage(t, dsn)
+	})
+}
+
+// openStorage returns a storage with the embedded migrations applied and its tables truncated
+func openStorage(t *testing.T, dsn string) *postgres.Storage {
+	t.Helper()
+
+	s, err := postgres.New(dsn)
+	if err != nil {
+		t.Fatalf("postgres.New: %v", err)
+	}
+
+	m, err := s.Migrator()
+	if err != nil {
+		t.Fatalf("Migrator: %v", err)
+	}
+	if _, err = m.Up(context.Background()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`TRUNCATE devices, organizations, payments, refund_qrs, refunds, refund_sessions,
+		webhook_events, webhook_deliveries, idempotency_keys, reconciliation_runs, reconciliation_discrepancies, tradepoints
+		RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+
+	return s
+}
+
+// TestLockExternalID checks that the ExternalId lock is shared by instances using the same database
+func TestLockExternalID(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	first, err := postgres.New(dsn)
+	if err != nil {
+		t.Fatalf("postgres.New: %v", err)
+	}
+	second, err := postgres.New(dsn)
+	if err != nil {
+		t.Fatalf("postgres.New: %v", err)
+	}
+
+	_, unlock, err := first.LockExternalID(context.Background(), "qr|device:1|order-1")
+	if err != nil {
+		t.Fatalf("LockExternalID: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, _, err = second.LockExternalID(ctx, "qr|device:1|order-1"); err == nil {
+		t.Fatal("Expected the second instance to wait for the lock")
+	}
+
+	_, other, err := second.LockExternalID(context.Background(), "qr|device:1|order-2")
+	if err != nil {
+		t.Fatalf("LockExternalID of another key: %v", err)
+	}
+	other()
+
+	unlock()
+
+	_, again, err := second.LockExternalID(context.Background(), "qr|device:1|order-1")
+	if err != nil {
+		t.Fatalf("LockExternalID after unlock: %v", err)
+	}
+	again()
+}
+
+// TestLockExternalIDConcurrentCreates checks that more concurrent creates than the pool has
+// connections finish, the lookup and the insert run on the connection holding the lock
+func TestLockExternalIDConcurrentCreates(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	s := openStorage(t, dsn)
+
+	if err := s.SaveDevice(context.Background(), "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// twice the pool size, every ExternalId is created by three requests
+	const creates = 60
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int64
+	)
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			externalID := fmt.Sprintf("order-%d", i%(creates/3))
+
+			lockedCtx, unlock, err := s.LockExternalID(ctx, "qr|device:token-1|"+externalID)
+			if err != nil {
+				t.Errorf("LockExternalID: %v", err)
+				return
+			}
+			defer unlock()
+
+			_, err = s.LivePaymentByExternalID(lockedCtx, domain.PaymentKindQR, externalID, "token-1", "")
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, storage.ErrPaymentNotFound) {
+				t.Errorf("LivePaymentByExternalID: %v", err)
+				return
+			}
+
+			// the Kaspi call
+			time.Sleep(20 * time.Millisecond)
+
+			err = s.SavePayment(lockedCtx, domain.Payment{
+				QrPaymentID: created.Add(1),
+				Kind:        domain.PaymentKindQR,
+				ExternalID:  externalID,
+				DeviceToken: "token-1",
+				Amount:      100,
+				ExpireDate:  time.Now().Add(time.Hour),
+				Status:      domain.PaymentStatusCreated,
+			})
+			if err != nil {
+				t.Errorf("SavePayment: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		t.Fatal("Expected the creates to finish before the timeout")
+	}
+	if got := created.Load(); got != creates/3 {
+		t.Errorf("created %d payments, want %d", got, creates/3)
+	}
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// SaveRefundQR saves a newly created refund QR
+func (s *Storage) SaveRefundQR(ctx context.Context, refund domain.RefundQR) error {
+	const op = "storage.postgres.SaveRefundQR"
+
+	query := `
+		INSERT INTO refund_qrs (qr_return_id, device_token, device_token_hmac, external_id, expire_date, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (qr_return_id) DO NOTHING
+	`
+
+	storedToken, tokenIndex, err := s.sealToken(refund.DeviceToken)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	var expireDate sql.NullTime
+	if !refund.ExpireDate.IsZero() {
+		expireDate = sql.NullTime{Time: toTimestamp(refund.ExpireDate), Valid: true}
+	}
+
+	_, err = s.db.ExecContext(ctx, query,
+		refund.QrReturnID,
+		storedToken,
+		tokenIndex,
+		refund.ExternalID,
+		expireDate,
+		refund.Status,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateRefundStatus stores the latest refund status returned by Kaspi and returns the status it replaced
+func (s *Storage) UpdateRefundStatus(ctx context.Context, qrReturnID int64, status string) (string, error) {
+	const op = "storage.postgres.UpdateRefundStatus"
+
+	query := `
+		WITH previous AS (
+			SELECT qr_return_id, status FROM refund_qrs WHERE qr_return_id = $1 FOR UPDATE
+		)
+		UPDATE refund_qrs r
+		SET status = $2, updated_at = $3
+		FROM previous
+		WHERE r.qr_return_id = previous.qr_return_id
+		RETURNING previous.status
+	`
+
+	var previousStatus string
+	err := s.db.QueryRowContext(ctx, query, qrReturnID, status, time.Now()).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", storage.ErrRefundNotFound
+		}
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	return previousStatus, nil
+}
+
+// RefundQR returns a stored refund QR by its QrReturnId
+func (s *Storage) RefundQR(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error) {
+	const op = "storage.postgres.RefundQR"
+
+	query := `
+		SELECT qr_return_id, device_token, external_id, expire_date, status, created_at, updated_at
+		FROM refund_qrs
+		WHERE qr_return_id = $1
+	`
+
+	refund, err := s.scanRefundQR(s.db.QueryRowContext(ctx, query, qrReturnID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrRefundNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return refund, nil
+}
+
+// RefundQRsCreatedBetween returns refund QRs created in the period, oldest first
+func (s *Storage) RefundQRsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.RefundQR, error) {
+	const op = "storage.postgres.RefundQRsCreatedBetween"
+
+	query := `
+		SELECT qr_return_id, device_token, external_id, expire_date, status, created_at, updated_at
+		FROM refund_qrs
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, toTimestamp(from), toTimestamp(to))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var refunds []domain.RefundQR
+	for rows.Next() {
+		refund, err := s.scanRefundQR(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		refunds = append(refunds, *refund)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return refunds, nil
+}
+
+func (s *Storage) scanRefundQR(row rowScanner) (*domain.RefundQR, error) {
+	var refund domain.RefundQR
+	var expireDate sql.NullTime
+
+	err := row.Scan(
+		&refund.QrReturnID,
+		&refund.DeviceToken,
+		&refund.ExternalID,
+		&expireDate,
+		&refund.Status,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.DeviceToken, err = s.openToken(refund.DeviceToken); err != nil {
+		return nil, fmt.Errorf("refund QR %d: %w", refund.QrReturnID, err)
+	}
+
+	if expireDate.Valid {
+		refund.ExpireDate = fromTimestamp(expireDate.Time)
+	}
+	refund.CreatedAt = fromTimestamp(refund.CreatedAt)
+	refund.UpdatedAt = fromTimestamp(refund.UpdatedAt)
+
+	return &refund, nil
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// ReserveRefund records a pending refund, refunds of the same payment are serialized with an
+// advisory lock and check sees the balance including every earlier succeeded or pending refund
+func (s *Storage) ReserveRefund(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error) {
+	const op = "storage.postgres.ReserveRefund"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('refunds'), hashtext($1::text))`, refund.QrPaymentID); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	var balance domain.RefundBalance
+
+	err = tx.QueryRowContext(ctx, `SELECT amount FROM payments WHERE qr_payment_id = $1`, refund.QrPaymentID).
+		Scan(&balance.PaymentAmount)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE status = $2), 0),
+		       COALESCE(SUM(amount) FILTER (WHERE status = $3), 0)
+		FROM refunds
+		WHERE qr_payment_id = $1
+	`, refund.QrPaymentID, domain.RefundStatusSucceeded, domain.RefundStatusPending).
+		Scan(&balance.Succeeded, &balance.Pending)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = check(balance); err != nil {
+		return nil, err
+	}
+
+	storedToken, tokenIndex, err := s.sealToken(refund.DeviceToken)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	now := time.Now()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO refunds (
+			qr_payment_id, qr_return_id, amount, device_token, device_token_hmac, organization_bin, initiator,
+			status, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		RETURNING id
+	`,
+		refund.QrPaymentID,
+		refund.QrReturnID,
+		refund.Amount,
+		storedToken,
+		tokenIndex,
+		refund.OrganizationBin,
+		refund.Initiator,
+		domain.RefundStatusPending,
+		now,
+	).Scan(&refund.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	refund.Status = domain.RefundStatusPending
+	refund.CreatedAt = now
+	refund.UpdatedAt = now
+
+	return &refund, nil
+}
+
+// CompleteRefund marks a pending refund as accepted by Kaspi
+func (s *Storage) CompleteRefund(ctx context.Context, id int64, returnOperationID int64) error {
+	const op = "storage.postgres.CompleteRefund"
+
+	return s.finishRefund(ctx, op, id, domain.RefundStatusSucceeded, returnOperationID, "")
+}
+
+// FailRefund marks a pending refund as rejected, it no longer counts against the balance
+func (s *Storage) FailRefund(ctx context.Context, id int64, reason string) error {
+	const op = "storage.postgres.FailRefund"
+
+	return s.finishRefund(ctx, op, id, domain.RefundStatusFailed, 0, reason)
+}
+
+func (s *Storage) finishRefund(ctx context.Context, op string, id int64, status string, returnOperationID int64, reason string) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE refunds
+		SET status = $2, return_operation_id = $3, error = $4, updated_at = $5
+		WHERE id = $1
+	`, id, status, returnOperationID, reason, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrRefundNotFound
+	}
+
+	return nil
+}
+
+// RefundTotals returns the sum of all succeeded refunds of every payment that had a refund
+// succeed in the period
+func (s *Storage) RefundTotals(ctx context.Context, from, to time.Time) ([]domain.RefundTotal, error) {
+	const op = "storage.postgres.RefundTotals"
+
+	query := `
+		SELECT qr_payment_id, MAX(device_token), SUM(amount)
+		FROM refunds
+		WHERE status = $1 AND qr_payment_id IN (
+			SELECT qr_payment_id FROM refunds WHERE status = $1 AND updated_at >= $2 AND updated_at < $3
+		)
+		GROUP BY qr_payment_id
+		ORDER BY qr_payment_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, domain.RefundStatusSucceeded, toTimestamp(from), toTimestamp(to))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var totals []domain.RefundTotal
+	for rows.Next() {
+		var total domain.RefundTotal
+		if err = rows.Scan(&total.QrPaymentID, &total.DeviceToken, &total.Amount); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		if total.DeviceToken, err = s.openToken(total.DeviceToken); err != nil {
+			return nil, fmt.Errorf("%s:payment %d: %w", op, total.QrPaymentID, err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,16 @@
+package postgres
+
+import "time"
+
+// TIMESTAMP columns hold the wall clock of the service time zone, as written with time.Now().
+// Postgres drops the offset of TIMESTAMP parameters and the driver returns the values as UTC
+
+// toTimestamp converts a bound given in any time zone, e.g. an Asia/Almaty day, to the stored wall clock
+func toTimestamp(t time.Time) time.Time {
+	return t.In(time.Local)
+}
+
+// fromTimestamp restores the instant of a stored wall clock
+func fromTimestamp(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
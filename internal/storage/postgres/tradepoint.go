@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+const tradePointColumns = `tradepoint_id, name, organization_bin, address, timezone, enabled, min_amount, max_amount, synced_at`
+
+// SyncTradePoints stores the trade points returned by Kaspi for the organization and returns the
+// cached ones. Trade points missing from the list are marked removed, their metadata is kept in
+// case they come back
+func (s *Storage) SyncTradePoints(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error) {
+	const op = "storage.postgres.SyncTradePoints"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('tradepoints'), hashtext($1))`, organizationBin); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	for _, tradePoint := range tradePoints {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO tradepoints (organization_bin, tradepoint_id, name, synced_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (organization_bin, tradepoint_id) DO UPDATE
+			SET name = $3, synced_at = $4, removed = FALSE
+		`, organizationBin, tradePoint.TradePointID, tradePoint.TradePointName, toTimestamp(syncedAt))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tradepoints SET removed = TRUE
+		WHERE organization_bin = $1 AND synced_at <> $2 AND NOT removed
+	`, organizationBin, toTimestamp(syncedAt))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	cached, err := queryTradePoints(ctx, tx, organizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return cached, nil
+}
+
+// TradePoints returns the cached trade points of the organization ordered by ID
+func (s *Storage) TradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	const op = "storage.postgres.TradePoints"
+
+	tradePoints, err := queryTradePoints(ctx, s.db, organizationBin)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return tradePoints, nil
+}
+
+// TradePoint returns a cached trade point of the organization
+func (s *Storage) TradePoint(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error) {
+	const op = "storage.postgres.TradePoint"
+
+	query := `
+		SELECT ` + tradePointColumns + ` FROM tradepoints
+		WHERE organization_bin = $1 AND tradepoint_id = $2 AND NOT removed
+	`
+
+	tradePoint, err := scanTradePoint(s.db.QueryRowContext(ctx, query, organizationBin, tradePointID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrTradePointNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return tradePoint, nil
+}
+
+// UpdateTradePoint replaces the metadata of a cached trade point
+func (s *Storage) UpdateTradePoint(ctx context.Context, tradePoint domain.TradePoint) error {
+	const op = "storage.postgres.UpdateTradePoint"
+
+	query := `
+		UPDATE tradepoints
+		SET address = $3, timezone = $4, enabled = $5, min_amount = $6, max_amount = $7
+		WHERE organization_bin = $1 AND tradepoint_id = $2 AND NOT removed
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		tradePoint.OrganizationBin,
+		tradePoint.TradePointID,
+		tradePoint.Address,
+		tradePoint.Timezone,
+		tradePoint.Enabled,
+		tradePoint.MinAmount,
+		tradePoint.MaxAmount,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrTradePointNotFound
+	}
+
+	return nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func queryTradePoints(ctx context.Context, db queryer, organizationBin string) ([]domain.TradePoint, error) {
+	query := `
+		SELECT ` + tradePointColumns + ` FROM tradepoints
+		WHERE organization_bin = $1 AND NOT removed
+		ORDER BY tradepoint_id
+	`
+
+	rows, err := db.QueryContext(ctx, query, organizationBin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tradePoints []domain.TradePoint
+	for rows.Next() {
+		tradePoint, err := scanTradePoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		tradePoints = append(tradePoints, *tradePoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tradePoints, nil
+}
+
+func scanTradePoint(row rowScanner) (*domain.TradePoint, error) {
+	var tradePoint domain.TradePoint
+
+	err := row.Scan(
+		&tradePoint.TradePointID,
+		&tradePoint.TradePointName,
+		&tradePoint.OrganizationBin,
+		&tradePoint.Address,
+		&tradePoint.Timezone,
+		&tradePoint.Enabled,
+		&tradePoint.MinAmount,
+		&tradePoint.MaxAmount,
+		&tradePoint.SyncedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tradePoint.SyncedAt = fromTimestamp(tradePoint.SyncedAt)
+
+	return &tradePoint, nil
+}
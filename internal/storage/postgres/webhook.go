@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// SaveWebhookEvent saves an event together with a pending delivery for every URL
+func (s *Storage) SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error {
+	const op = "storage.postgres.SaveWebhookEvent"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_events (id, type, subject_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, event.ID, event.Type, event.SubjectID, payload, now)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	for _, url := range urls {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (event_id, url, status, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $4)
+		`, event.ID, url, domain.DeliveryStatusPending, now)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries returns pending deliveries that are due and leases them until leaseUntil,
+// so that concurrent instances do not send the same delivery twice
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	const op = "storage.postgres.ClaimWebhookDeliveries"
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM webhook_events e
+		WHERE d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		AND e.id = d.event_id
+		RETURNING d.id, d.url, d.attempts, e.payload
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, toTimestamp(now), toTimestamp(leaseUntil), domain.DeliveryStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var payload []byte
+
+		if err = rows.Scan(&delivery.ID, &delivery.URL, &delivery.Attempts, &payload); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		if err = json.Unmarshal(payload, &delivery.Event); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered marks a delivery as successfully delivered
+func (s *Storage) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	const op = "storage.postgres.MarkWebhookDelivered"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_error = '', delivered_at = $3
+		WHERE id = $1
+	`, deliveryID, domain.DeliveryStatusDelivered, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt, the delivery is either rescheduled or moved to dead letters
+func (s *Storage) MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error {
+	const op = "storage.postgres.MarkWebhookFailed"
+
+	status := domain.DeliveryStatusPending
+	if dead {
+		status = domain.DeliveryStatusDead
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, next_attempt_at = $3, last_error = $4
+		WHERE id = $1
+	`, deliveryID, status, toTimestamp(nextAttemptAt), lastError)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ReplayWebhookDeliveries puts deliveries of the matching events back into the queue
+// regardless of their current status and returns the number of requeued deliveries
+func (s *Storage) ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	const op = "storage.postgres.ReplayWebhookDeliveries"
+
+	var since, until sql.NullTime
+	if !filter.Since.IsZero() {
+		since = sql.NullTime{Time: toTimestamp(filter.Since), Valid: true}
+	}
+	if !filter.Until.IsZero() {
+		until = sql.NullTime{Time: toTimestamp(filter.Until), Valid: true}
+	}
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET status = $1, attempts = 0, next_attempt_at = $2, last_error = '', delivered_at = NULL
+		FROM webhook_events e
+		WHERE e.id = d.event_id
+		  AND (NULLIF($3, '') IS NULL OR e.id = $3)
+		  AND ($4::TIMESTAMP IS NULL OR e.created_at >= $4)
+		  AND ($5::TIMESTAMP IS NULL OR e.created_at < $5)
+	`
+
+	res, err := s.db.ExecContext(ctx, query, domain.DeliveryStatusPending, time.Now(), filter.EventID, since, until)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 && filter.EventID != "" {
+		return 0, storage.ErrWebhookEventNotFound
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS refund_sessions;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS refund_qrs;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS devices_enhanced;
+DROP TABLE IF EXISTS devices;
@@ -0,0 +1,174 @@
+-- the schema of the Postgres migrations in SQLite types: arrays and JSONB are stored as JSON text
+-- and TIMESTAMP columns hold UTC times, so that they compare as text in the order of the instants.
+-- The tables may exist already in files created before the schema was versioned
+CREATE TABLE IF NOT EXISTS devices (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL UNIQUE,
+    tradepoint_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS devices_enhanced (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL UNIQUE,
+    tradepoint_id INTEGER NOT NULL,
+    organization_bin TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS devices_tradepoint_id_idx ON devices (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_enhanced_tradepoint_id_idx ON devices_enhanced (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_enhanced_organization_bin_idx ON devices_enhanced (organization_bin);
+
+CREATE TABLE IF NOT EXISTS payments (
+    qr_payment_id INTEGER PRIMARY KEY,
+    kind TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    device_token TEXT NOT NULL,
+    tradepoint_id INTEGER,
+    organization_bin TEXT NOT NULL DEFAULT '',
+    amount REAL NOT NULL,
+    expire_date TIMESTAMP,
+    payment_methods TEXT NOT NULL DEFAULT '[]',
+    status TEXT NOT NULL,
+    transaction_id TEXT NOT NULL DEFAULT '',
+    product_type TEXT NOT NULL DEFAULT '',
+    loan_offer_name TEXT NOT NULL DEFAULT '',
+    loan_term INTEGER NOT NULL DEFAULT 0,
+    qr_token TEXT NOT NULL DEFAULT '',
+    payment_link TEXT NOT NULL DEFAULT '',
+    status_polling_interval INTEGER NOT NULL DEFAULT 0,
+    scan_wait_timeout INTEGER NOT NULL DEFAULT 0,
+    confirmation_timeout INTEGER NOT NULL DEFAULT 0,
+    phone_number TEXT NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS payments_external_id_idx ON payments (external_id, kind, created_at);
+CREATE INDEX IF NOT EXISTS payments_created_at_id_idx ON payments (created_at, qr_payment_id);
+CREATE INDEX IF NOT EXISTS payments_amount_id_idx ON payments (amount, qr_payment_id);
+CREATE INDEX IF NOT EXISTS payments_status_idx ON payments (status);
+
+CREATE TABLE IF NOT EXISTS refund_qrs (
+    qr_return_id INTEGER PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    expire_date TIMESTAMP,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refund_qrs_created_at_idx ON refund_qrs (created_at);
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id INTEGER PRIMARY KEY,
+    qr_payment_id INTEGER NOT NULL,
+    qr_return_id INTEGER NOT NULL DEFAULT 0,
+    return_operation_id INTEGER NOT NULL DEFAULT 0,
+    amount REAL NOT NULL,
+    device_token TEXT NOT NULL,
+    organization_bin TEXT NOT NULL DEFAULT '',
+    initiator TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refunds_qr_payment_id_idx ON refunds (qr_payment_id, status);
+
+CREATE TABLE IF NOT EXISTS refund_sessions (
+    qr_return_id INTEGER PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    max_result INTEGER NOT NULL DEFAULT 0,
+    qr_token TEXT NOT NULL,
+    expire_date TIMESTAMP,
+    polling_interval INTEGER NOT NULL DEFAULT 0,
+    scan_wait_timeout INTEGER NOT NULL DEFAULT 0,
+    state TEXT NOT NULL,
+    kaspi_status TEXT NOT NULL DEFAULT '',
+    deadline TIMESTAMP,
+    operations TEXT NOT NULL DEFAULT '[]',
+    qr_payment_id INTEGER NOT NULL DEFAULT 0,
+    amount REAL NOT NULL DEFAULT 0,
+    available_return_amount REAL NOT NULL DEFAULT 0,
+    return_operation_id INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refund_sessions_state_idx ON refund_sessions (state);
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    subject_id INTEGER NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES webhook_events (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+
+    UNIQUE (event_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    status TEXT NOT NULL,
+    response_status INTEGER NOT NULL DEFAULT 0,
+    response_body BLOB,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
+
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id INTEGER PRIMARY KEY,
+    "trigger" TEXT NOT NULL,
+    period_from TIMESTAMP NOT NULL,
+    period_to TIMESTAMP NOT NULL,
+    status TEXT NOT NULL,
+    payments_checked INTEGER NOT NULL DEFAULT 0,
+    refunds_checked INTEGER NOT NULL DEFAULT 0,
+    discrepancies INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies (
+    id INTEGER PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES reconciliation_runs (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    qr_payment_id INTEGER NOT NULL DEFAULT 0,
+    qr_return_id INTEGER NOT NULL DEFAULT 0,
+    local_value TEXT NOT NULL DEFAULT '',
+    remote_value TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS reconciliation_discrepancies_run_id_idx ON reconciliation_discrepancies (run_id);
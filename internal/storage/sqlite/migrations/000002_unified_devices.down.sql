@@ -0,0 +1,38 @@
+CREATE TABLE devices_standard (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL UNIQUE,
+    tradepoint_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE devices_enhanced (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL UNIQUE,
+    tradepoint_id INTEGER NOT NULL,
+    organization_bin TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+INSERT INTO devices_standard (device_id, device_token, device_token_hmac, tradepoint_id, created_at, deleted_at)
+SELECT device_id, device_token, device_token_hmac, tradepoint_id, created_at, deleted_at
+FROM devices
+WHERE scheme = 'standard';
+
+INSERT INTO devices_enhanced (device_id, device_token, device_token_hmac, tradepoint_id, organization_bin, created_at, deleted_at)
+SELECT device_id, device_token, device_token_hmac, tradepoint_id, organization_bin, created_at, deleted_at
+FROM devices
+WHERE scheme = 'enhanced';
+
+DROP TABLE devices;
+
+ALTER TABLE devices_standard RENAME TO devices;
+
+CREATE INDEX devices_tradepoint_id_idx ON devices (tradepoint_id);
+CREATE INDEX devices_enhanced_tradepoint_id_idx ON devices_enhanced (tradepoint_id);
+CREATE INDEX devices_enhanced_organization_bin_idx ON devices_enhanced (organization_bin);
+
+DROP TABLE IF EXISTS devices_merge_conflicts;
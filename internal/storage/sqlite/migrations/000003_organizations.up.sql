@@ -0,0 +1,18 @@
+-- organizations the wrapper operates in the enhanced scheme, enhanced requests for other BINs are rejected
+CREATE TABLE IF NOT EXISTS organizations (
+    organization_bin TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    settings TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- the BINs already in use are registered under their own name, so existing deployments keep working
+INSERT OR IGNORE INTO organizations (organization_bin, name, created_at, updated_at)
+SELECT organization_bin, organization_bin, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+FROM (
+    SELECT organization_bin FROM devices WHERE organization_bin IS NOT NULL
+    UNION
+    SELECT organization_bin FROM payments WHERE organization_bin <> ''
+);
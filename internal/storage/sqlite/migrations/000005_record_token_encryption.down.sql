@@ -0,0 +1,8 @@
+-- encrypted tokens have to be decrypted before with `go run ./cmd/api rotate-device-token-key -decrypt`,
+-- and the service must run without DEVICE_TOKEN_KEY afterwards
+DROP INDEX IF EXISTS payments_device_token_hmac_created_at_idx;
+
+ALTER TABLE refund_sessions DROP COLUMN device_token_hmac;
+ALTER TABLE refund_qrs DROP COLUMN device_token_hmac;
+ALTER TABLE refunds DROP COLUMN device_token_hmac;
+ALTER TABLE payments DROP COLUMN device_token_hmac;
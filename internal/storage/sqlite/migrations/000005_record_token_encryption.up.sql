@@ -0,0 +1,9 @@
+-- payments, refunds, refund QRs and refund sessions keep the token of their device, it is encrypted
+-- like the device registry and looked up by its index. SQLite has no SHA-256, the storage indexes the
+-- plaintext tokens of the existing rows after the migration
+ALTER TABLE payments ADD COLUMN device_token_hmac TEXT NOT NULL DEFAULT '';
+ALTER TABLE refunds ADD COLUMN device_token_hmac TEXT NOT NULL DEFAULT '';
+ALTER TABLE refund_qrs ADD COLUMN device_token_hmac TEXT NOT NULL DEFAULT '';
+ALTER TABLE refund_sessions ADD COLUMN device_token_hmac TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS payments_device_token_hmac_created_at_idx ON payments (device_token_hmac, created_at);
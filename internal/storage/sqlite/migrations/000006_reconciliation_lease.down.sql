@@ -0,0 +1,2 @@
+ALTER TABLE reconciliation_runs DROP COLUMN heartbeat_at;
+ALTER TABLE reconciliation_runs DROP COLUMN owner;
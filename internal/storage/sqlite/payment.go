@@ -0,0 +1,366 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+const paymentColumns = `
+	qr_payment_id, kind, external_id, device_token, COALESCE(tradepoint_id, 0), organization_bin,
+	amount, expire_date, payment_methods, status, transaction_id, product_type,
+	loan_offer_name, loan_term, qr_token, payment_link, status_polling_interval,
+	scan_wait_timeout, confirmation_timeout, phone_number, comment, created_at, updated_at
+`
+
+// SavePayment saves a newly created payment, the trade point is resolved from the stored device
+func (s *Storage) SavePayment(ctx context.Context, payment domain.Payment) error {
+	const op = "storage.sqlite.SavePayment"
+
+	query := `
+		INSERT INTO payments (
+			qr_payment_id, kind, external_id, device_token, tradepoint_id, organization_bin,
+			amount, expire_date, payment_methods, status, qr_token, payment_link,
+			status_polling_interval, scan_wait_timeout, confirmation_timeout, phone_number, comment,
+			created_at, updated_at, device_token_hmac
+		)
+		VALUES (
+			?1, ?2, ?3, ?4,
+			COALESCE(NULLIF(?5, 0), (SELECT tradepoint_id FROM devices WHERE device_token_hmac = ?19)),
+			?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?18, ?19
+		)
+		ON CONFLICT (qr_payment_id) DO NOTHING
+	`
+
+	paymentMethods, err := marshalPaymentMethods(payment.PaymentMethods)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	storedToken, tokenIndex, err := s.sealToken(payment.DeviceToken)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, query,
+		payment.QrPaymentID,
+		payment.Kind,
+		payment.ExternalID,
+		storedToken,
+		payment.TradePointID,
+		payment.OrganizationBin,
+		payment.Amount,
+		nullTime(payment.ExpireDate),
+		paymentMethods,
+		payment.Status,
+		payment.QrToken,
+		payment.PaymentLink,
+		payment.StatusPollingInterval,
+		payment.ScanWaitTimeout,
+		payment.PaymentConfirmationTimeout,
+		payment.PhoneNumber,
+		payment.Comment,
+		utc(time.Now()),
+		tokenIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// UpdatePaymentStatus stores the latest status returned by Kaspi and returns the status it replaced,
+// the update time only moves when the status changes
+func (s *Storage) UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error) {
+	const op = "storage.sqlite.UpdatePaymentStatus"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	var previousStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM payments WHERE qr_payment_id = ?`, qrPaymentID).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", storage.ErrPaymentNotFound
+		}
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	query := `
+		UPDATE payments
+		SET status = ?2,
+		    transaction_id = COALESCE(NULLIF(?3, ''), transaction_id),
+		    product_type = COALESCE(NULLIF(?4, ''), product_type),
+		    loan_offer_name = COALESCE(NULLIF(?5, ''), loan_offer_name),
+		    loan_term = COALESCE(NULLIF(?6, 0), loan_term),
+		    updated_at = CASE WHEN status = ?2 THEN updated_at ELSE ?7 END
+		WHERE qr_payment_id = ?1
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		qrPaymentID,
+		status.Status,
+		status.TransactionID,
+		status.ProductType,
+		status.LoanOfferName,
+		status.LoanTerm,
+		utc(time.Now()),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", fmt.Errorf("%s:%w", op, err)
+	}
+
+	return previousStatus, nil
+}
+
+// Payment returns a stored payment by its QrPaymentId
+func (s *Storage) Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error) {
+	const op = "storage.sqlite.Payment"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE qr_payment_id = ?`
+
+	payment, err := s.scanPayment(s.db.QueryRowContext(ctx, query, qrPaymentID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payment, nil
+}
+
+// LockExternalID does nothing, the SQLite storage is used by a single instance
+// and the service already serializes the key within the process
+func (s *Storage) LockExternalID(ctx context.Context, key string) (context.Context, func(), error) {
+	return ctx, func() {}, nil
+}
+
+// LivePaymentByExternalID returns the latest payment of the kind with the ExternalId that can still
+// be paid, payments are scoped by organization BIN when it is given and by device otherwise
+func (s *Storage) LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error) {
+	const op = "storage.sqlite.LivePaymentByExternalID"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE external_id = ?1 AND kind = ?2
+		  AND status IN (?3, ?4)
+		  AND (expire_date IS NULL OR expire_date > ?5)
+		  AND CASE WHEN ?7 <> '' THEN organization_bin = ?7 ELSE device_token_hmac = ?6 END
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	payment, err := s.scanPayment(s.db.QueryRowContext(ctx, query,
+		externalID,
+		kind,
+		domain.PaymentStatusCreated,
+		domain.PaymentStatusWait,
+		utc(time.Now()),
+		s.tokenIndex(deviceToken),
+		organizationBin,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payment, nil
+}
+
+// PaymentsByExternalID returns all payments with the ExternalId, newest first
+func (s *Storage) PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error) {
+	const op = "storage.sqlite.PaymentsByExternalID"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE external_id = ? ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PendingRemotePayments returns remote payments still waiting for the customer that were created
+// before the given time, oldest first. An empty BIN selects all organizations and a zero time
+// selects payments of any age
+func (s *Storage) PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error) {
+	const op = "storage.sqlite.PendingRemotePayments"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE kind = ?1 AND status IN (?2, ?3)
+		  AND (?4 = '' OR organization_bin = ?4)
+		  AND (?5 IS NULL OR created_at < ?5)
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query,
+		domain.PaymentKindRemote,
+		domain.PaymentStatusCreated,
+		domain.PaymentStatusWait,
+		organizationBin,
+		nullTime(createdBefore),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PendingPayments returns payments of any kind still waiting for the customer, oldest first
+func (s *Storage) PendingPayments(ctx context.Context) ([]domain.Payment, error) {
+	const op = "storage.sqlite.PendingPayments"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE status IN (?1, ?2)
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, domain.PaymentStatusCreated, domain.PaymentStatusWait)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// PaymentsCreatedBetween returns payments created in the period, oldest first
+func (s *Storage) PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error) {
+	const op = "storage.sqlite.PaymentsCreatedBetween"
+
+	query := `SELECT ` + paymentColumns + ` FROM payments
+		WHERE created_at >= ? AND created_at < ?
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, utc(from), utc(to))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	payments, err := s.scanPayments(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return payments, nil
+}
+
+// marshalPaymentMethods stores payment methods as a JSON array, SQLite has no array type
+func marshalPaymentMethods(paymentMethods []string) (string, error) {
+	if paymentMethods == nil {
+		paymentMethods = []string{}
+	}
+
+	data, err := json.Marshal(paymentMethods)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// scanPayments scans and closes rows selected with paymentColumns
+func (s *Storage) scanPayments(rows *sql.Rows) ([]domain.Payment, error) {
+	defer rows.Close()
+
+	var payments []domain.Payment
+	for rows.Next() {
+		payment, err := s.scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPayment scans a row selected with paymentColumns
+func (s *Storage) scanPayment(row rowScanner) (*domain.Payment, error) {
+	var payment domain.Payment
+	var expireDate sql.NullTime
+	var paymentMethods string
+
+	err := row.Scan(
+		&payment.QrPaymentID,
+		&payment.Kind,
+		&payment.ExternalID,
+		&payment.DeviceToken,
+		&payment.TradePointID,
+		&payment.OrganizationBin,
+		&payment.Amount,
+		&expireDate,
+		&paymentMethods,
+		&payment.Status,
+		&payment.TransactionID,
+		&payment.ProductType,
+		&payment.LoanOfferName,
+		&payment.LoanTerm,
+		&payment.QrToken,
+		&payment.PaymentLink,
+		&payment.StatusPollingInterval,
+		&payment.ScanWaitTimeout,
+		&payment.PaymentConfirmationTimeout,
+		&payment.PhoneNumber,
+		&payment.Comment,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal([]byte(paymentMethods), &payment.PaymentMethods); err != nil {
+		return nil, fmt.Errorf("payment %d: %w", payment.QrPaymentID, err)
+	}
+
+	if payment.DeviceToken, err = s.openToken(payment.DeviceToken); err != nil {
+		return nil, fmt.Errorf("payment %d: %w", payment.QrPaymentID, err)
+	}
+
+	if expireDate.Valid {
+		payment.ExpireDate = expireDate.Time
+	}
+
+	return &payment, nil
+}
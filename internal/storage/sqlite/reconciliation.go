@@ -0,0 +1,302 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+const reconciliationRunColumns = `
+	id, "trigger", period_from, period_to, status, payments_checked, refunds_checked,
+	discrepancies, error, started_at, finished_at, owner, heartbeat_at
+`
+
+// CreateReconciliationRun saves a started run and returns it with its ID. A scheduled run of a period is
+// created once, the transaction holds the only connection and storage.ErrReconciliationRunExists is
+// returned for a repeated one
+func (s *Storage) CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) (*domain.ReconciliationRun, error) {
+	const op = "storage.sqlite.CreateReconciliationRun"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	if run.Trigger == domain.ReconciliationTriggerScheduled {
+		var exists bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM reconciliation_runs
+				WHERE "trigger" = ?1 AND period_from = ?2 AND period_to = ?3
+			)
+		`, run.Trigger, utc(run.From), utc(run.To)).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		if exists {
+			return nil, storage.ErrReconciliationRunExists
+		}
+	}
+
+	run.HeartbeatAt = run.StartedAt
+
+	query := `
+		INSERT INTO reconciliation_runs ("trigger", period_from, period_to, status, started_at, owner, heartbeat_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?5)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(ctx, query,
+		run.Trigger,
+		utc(run.From),
+		utc(run.To),
+		run.Status,
+		utc(run.StartedAt),
+		run.Owner,
+	).Scan(&run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return &run, nil
+}
+
+// RenewReconciliationRun extends the lease of a running run
+func (s *Storage) RenewReconciliationRun(ctx context.Context, id int64, heartbeatAt time.Time) error {
+	const op = "storage.sqlite.RenewReconciliationRun"
+
+	res, err := s.db.ExecContext(ctx, `UPDATE reconciliation_runs SET heartbeat_at = ?2 WHERE id = ?1`,
+		id, utc(heartbeatAt))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrReconciliationRunNotFound
+	}
+
+	return nil
+}
+
+// FinishReconciliationRun stores the outcome and the counters of a run
+func (s *Storage) FinishReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error {
+	const op = "storage.sqlite.FinishReconciliationRun"
+
+	query := `
+		UPDATE reconciliation_runs
+		SET status = ?2, payments_checked = ?3, refunds_checked = ?4, discrepancies = ?5,
+		    error = ?6, finished_at = ?7
+		WHERE id = ?1
+	`
+
+	res, err := s.db.ExecContext(ctx, query,
+		run.ID,
+		run.Status,
+		run.PaymentsChecked,
+		run.RefundsChecked,
+		run.Discrepancies,
+		run.Error,
+		nullTimePtr(run.FinishedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrReconciliationRunNotFound
+	}
+
+	return nil
+}
+
+// InterruptReconciliationRuns fails runs of the owner that were still running when it stopped, and runs
+// of other instances whose lease was last renewed before staleBefore
+func (s *Storage) InterruptReconciliationRuns(ctx context.Context, owner string, staleBefore time.Time, reason string) (int64, error) {
+	const op = "storage.sqlite.InterruptReconciliationRuns"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE reconciliation_runs
+		SET status = ?2, error = ?3, finished_at = ?4
+		WHERE status = ?1 AND (owner = ?5 OR heartbeat_at < ?6)
+	`, domain.ReconciliationRunning, domain.ReconciliationFailed, reason, utc(time.Now()), owner, utc(staleBefore))
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return affected, nil
+}
+
+// SaveReconciliationDiscrepancy records a mismatch found by a run
+func (s *Storage) SaveReconciliationDiscrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) error {
+	const op = "storage.sqlite.SaveReconciliationDiscrepancy"
+
+	query := `
+		INSERT INTO reconciliation_discrepancies (
+			run_id, kind, entity, qr_payment_id, qr_return_id, local_value, remote_value, message, created_at
+		)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		discrepancy.RunID,
+		discrepancy.Kind,
+		discrepancy.Entity,
+		discrepancy.QrPaymentID,
+		discrepancy.QrReturnID,
+		discrepancy.LocalValue,
+		discrepancy.RemoteValue,
+		discrepancy.Message,
+		utc(discrepancy.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ReconciliationRun returns a run by its ID
+func (s *Storage) ReconciliationRun(ctx context.Context, id int64) (*domain.ReconciliationRun, error) {
+	const op = "storage.sqlite.ReconciliationRun"
+
+	query := `SELECT ` + reconciliationRunColumns + ` FROM reconciliation_runs WHERE id = ?1`
+
+	run, err := scanReconciliationRun(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrReconciliationRunNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return run, nil
+}
+
+// ReconciliationRuns returns the latest runs, newest first
+func (s *Storage) ReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error) {
+	const op = "storage.sqlite.ReconciliationRuns"
+
+	query := `SELECT ` + reconciliationRunColumns + ` FROM reconciliation_runs ORDER BY id DESC LIMIT ?1`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var runs []domain.ReconciliationRun
+	for rows.Next() {
+		run, err := scanReconciliationRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		runs = append(runs, *run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return runs, nil
+}
+
+// ReconciliationDiscrepancies returns the discrepancies of a run in the order they were found
+func (s *Storage) ReconciliationDiscrepancies(ctx context.Context, runID int64) ([]domain.ReconciliationDiscrepancy, error) {
+	const op = "storage.sqlite.ReconciliationDiscrepancies"
+
+	query := `
+		SELECT id, run_id, kind, entity, qr_payment_id, qr_return_id, local_value, remote_value, message, created_at
+		FROM reconciliation_discrepancies
+		WHERE run_id = ?1
+		ORDER BY id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, runID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var discrepancies []domain.ReconciliationDiscrepancy
+	for rows.Next() {
+		var d domain.ReconciliationDiscrepancy
+		err = rows.Scan(
+			&d.ID,
+			&d.RunID,
+			&d.Kind,
+			&d.Entity,
+			&d.QrPaymentID,
+			&d.QrReturnID,
+			&d.LocalValue,
+			&d.RemoteValue,
+			&d.Message,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return discrepancies, nil
+}
+
+// scanReconciliationRun scans a row selected with reconciliationRunColumns
+func scanReconciliationRun(row rowScanner) (*domain.ReconciliationRun, error) {
+	var run domain.ReconciliationRun
+	var finishedAt sql.NullTime
+
+	err := row.Scan(
+		&run.ID,
+		&run.Trigger,
+		&run.From,
+		&run.To,
+		&run.Status,
+		&run.PaymentsChecked,
+		&run.RefundsChecked,
+		&run.Discrepancies,
+		&run.Error,
+		&run.StartedAt,
+		&finishedAt,
+		&run.Owner,
+		&run.HeartbeatAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+const refundSessionColumns = `
+	qr_return_id, device_token, external_id, max_result, qr_token, expire_date, polling_interval, scan_wait_timeout,
+	state, kaspi_status, deadline, operations, qr_payment_id, amount, available_return_amount, return_operation_id,
+	error, created_at, updated_at`
+
+// SaveRefundSession saves a newly started refund session
+func (s *Storage) SaveRefundSession(ctx context.Context, session domain.RefundSession) error {
+	const op = "storage.sqlite.SaveRefundSession"
+
+	operations, err := json.Marshal(operationsOrEmpty(session.Operations))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	storedToken, tokenIndex, err := s.sealToken(session.DeviceToken)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	query := `
+		INSERT INTO refund_sessions (` + refundSessionColumns + `, device_token_hmac)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17, ?18, ?18, ?19)
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		session.QrReturnID,
+		storedToken,
+		session.ExternalID,
+		session.MaxResult,
+		session.QrToken,
+		nullTime(session.ExpireDate),
+		session.QrRefundBehaviorOptions.QrCodeScanEventPollingInterval,
+		session.QrRefundBehaviorOptions.QrCodeScanWaitTimeout,
+		session.State,
+		session.KaspiStatus,
+		nullTimePtr(session.Deadline),
+		string(operations),
+		session.QrPaymentID,
+		session.Amount,
+		session.AvailableReturnAmount,
+		session.ReturnOperationID,
+		session.Error,
+		utc(time.Now()),
+		tokenIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateRefundSession stores the progress of a session that is still in fromState,
+// domain.ErrRefundSessionState is returned if another step has moved it on meanwhile
+func (s *Storage) UpdateRefundSession(ctx context.Context, session domain.RefundSession, fromState string) error {
+	const op = "storage.sqlite.UpdateRefundSession"
+
+	operations, err := json.Marshal(operationsOrEmpty(session.Operations))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	query := `
+		UPDATE refund_sessions
+		SET state = ?3, kaspi_status = ?4, deadline = ?5, operations = ?6, qr_payment_id = ?7, amount = ?8,
+			available_return_amount = ?9, return_operation_id = ?10, error = ?11, updated_at = ?12
+		WHERE qr_return_id = ?1 AND state = ?2
+	`
+
+	result, err := s.db.ExecContext(ctx, query,
+		session.QrReturnID,
+		fromState,
+		session.State,
+		session.KaspiStatus,
+		nullTimePtr(session.Deadline),
+		string(operations),
+		session.QrPaymentID,
+		session.Amount,
+		session.AvailableReturnAmount,
+		session.ReturnOperationID,
+		session.Error,
+		utc(time.Now()),
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM refund_sessions WHERE qr_return_id = ?1)`, session.QrReturnID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	if !exists {
+		return storage.ErrRefundSessionNotFound
+	}
+
+	return domain.ErrRefundSessionState
+}
+
+// RefundSession returns a refund session by its QrReturnId
+func (s *Storage) RefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error) {
+	const op = "storage.sqlite.RefundSession"
+
+	query := `SELECT ` + refundSessionColumns + ` FROM refund_sessions WHERE qr_return_id = ?1`
+
+	session, err := s.scanRefundSession(s.db.QueryRowContext(ctx, query, qrReturnID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrRefundSessionNotFound
+		}
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return session, nil
+}
+
+// WaitingRefundSessions returns sessions that wait for the customer or the cashier,
+// they are resumed after a restart
+func (s *Storage) WaitingRefundSessions(ctx context.Context) ([]domain.RefundSession, error) {
+	const op = "storage.sqlite.WaitingRefundSessions"
+
+	query := `
+		SELECT ` + refundSessionColumns + `
+		FROM refund_sessions
+		WHERE state IN (?1, ?2)
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, domain.RefundSessionAwaitingScan, domain.RefundSessionAwaitingSelection)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var sessions []domain.RefundSession
+	for rows.Next() {
+		session, err := s.scanRefundSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return sessions, nil
+}
+
+func (s *Storage) scanRefundSession(row rowScanner) (*domain.RefundSession, error) {
+	var session domain.RefundSession
+	var expireDate, deadline sql.NullTime
+	var operations []byte
+
+	err := row.Scan(
+		&session.QrReturnID,
+		&session.DeviceToken,
+		&session.ExternalID,
+		&session.MaxResult,
+		&session.QrToken,
+		&expireDate,
+		&session.QrRefundBehaviorOptions.QrCodeScanEventPollingInterval,
+		&session.QrRefundBehaviorOptions.QrCodeScanWaitTimeout,
+		&session.State,
+		&session.KaspiStatus,
+		&deadline,
+		&operations,
+		&session.QrPaymentID,
+		&session.Amount,
+		&session.AvailableReturnAmount,
+		&session.ReturnOperationID,
+		&session.Error,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.DeviceToken, err = s.openToken(session.DeviceToken); err != nil {
+		return nil, fmt.Errorf("refund session %d: %w", session.QrReturnID, err)
+	}
+
+	if expireDate.Valid {
+		session.ExpireDate = expireDate.Time
+	}
+
+	if deadline.Valid {
+		session.Deadline = &deadline.Time
+	}
+
+	if err = json.Unmarshal(operations, &session.Operations); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func operationsOrEmpty(operations []domain.CustomerOperation) []domain.CustomerOperation {
+	if operations == nil {
+		return []domain.CustomerOperation{}
+	}
+	return operations
+}
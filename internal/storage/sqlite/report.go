@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"time"
+)
+
+// SettlementEntries returns processed payments and succeeded refunds created in the period, oldest first.
+// Refunds are attributed to the trade point and product type of the refunded payment. Device tokens may be
+// encrypted, so entries are mapped to device IDs by the token index after the query
+func (s *Storage) SettlementEntries(ctx context.Context, from, to time.Time, tradePointID int64, organizationBin string) ([]domain.SettlementEntry, error) {
+	const op = "storage.sqlite.SettlementEntries"
+
+	query := `
+		SELECT ?5, p.qr_payment_id, COALESCE(p.tradepoint_id, 0), p.device_token_hmac,
+		       p.product_type, p.payment_methods, p.amount, p.created_at
+		FROM payments p
+		WHERE p.status = ?7 AND p.created_at >= ?1 AND p.created_at < ?2
+		  AND (?3 = 0 OR p.tradepoint_id = ?3)
+		  AND (?4 = '' OR p.organization_bin = ?4)
+
+		UNION ALL
+
+		SELECT ?6, r.qr_payment_id, COALESCE(p.tradepoint_id, 0), r.device_token_hmac,
+		       COALESCE(p.product_type, ''), COALESCE(p.payment_methods, '[]'), r.amount, r.created_at
+		FROM refunds r
+		LEFT JOIN payments p ON p.qr_payment_id = r.qr_payment_id
+		WHERE r.status = ?8 AND r.created_at >= ?1 AND r.created_at < ?2
+		  AND (?3 = 0 OR p.tradepoint_id = ?3)
+		  AND (?4 = '' OR COALESCE(NULLIF(r.organization_bin, ''), p.organization_bin) = ?4)
+
+		ORDER BY 8, 2
+	`
+
+	deviceIDs, err := s.deviceIDsByIndex(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query,
+		utc(from),
+		utc(to),
+		tradePointID,
+		organizationBin,
+		domain.SettlementSale,
+		domain.SettlementRefund,
+		domain.PaymentStatusProcessed,
+		domain.RefundStatusSucceeded,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []domain.SettlementEntry
+	for rows.Next() {
+		var tokenIndex, paymentMethods string
+		var entry domain.SettlementEntry
+		err = rows.Scan(
+			&entry.Kind,
+			&entry.QrPaymentID,
+			&entry.TradePointID,
+			&tokenIndex,
+			&entry.ProductType,
+			&paymentMethods,
+			&entry.Amount,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		if err = json.Unmarshal([]byte(paymentMethods), &entry.PaymentMethods); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+		entry.DeviceID = deviceIDs[tokenIndex]
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return entries, nil
+}
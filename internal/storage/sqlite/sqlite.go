@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"io/fs"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/migrator"
+	"kaspi-api-wrapper/internal/tokencrypt"
+	"time"
+)
+
+// the schema of the Postgres migrations in SQLite types, versioned the same way
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Storage represents a SQLite storage implementation. The whole database is a single file,
+// it suits a single instance, e.g. a small shop with one cash register
+type Storage struct {
+	db     *sql.DB
+	tokens *tokencrypt.Cipher
+}
+
+// New opens the SQLite database file at path and applies the pending migrations, the file
+// belongs to a single instance, so there is nobody to race with
+func New(path string) (*Storage, error) {
+	const op = "storage.sqlite.New"
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	// SQLite has a single writer, one connection serializes transactions instead of failing them as busy
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := &Storage{db: db}
+
+	m, err := s.Migrator()
+	if err == nil {
+		_, err = m.Up(ctx)
+	}
+	if err == nil {
+		err = s.indexPlainTokens(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return s, nil
+}
+
+// Migrator returns the migrator of the embedded migrations
+func (s *Storage) Migrator() (*migrator.Migrator, error) {
+	dir, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrator.New(s.db, dir, nil)
+}
+
+// Stop closes the database connection and releases any open resources.
+func (s *Storage) Stop() error {
+	return s.db.Close()
+}
+
+// SaveDevice saves device of the basic and standard schemes
+func (s *Storage) SaveDevice(ctx context.Context, deviceID string, deviceToken string, tradePointID int64) error {
+	const op = "storage.sqlite.SaveDevice"
+
+	return s.saveDevice(ctx, op, domain.Device{
+		DeviceID:     deviceID,
+		DeviceToken:  deviceToken,
+		TradePointID: tradePointID,
+		Scheme:       domain.DeviceSchemeStandard,
+	})
+}
+
+// SaveDeviceEnhanced saves device of the enhanced scheme
+func (s *Storage) SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error {
+	const op = "storage.sqlite.SaveDeviceEnhanced"
+
+	return s.saveDevice(ctx, op, domain.Device{
+		DeviceID:        deviceID,
+		DeviceToken:     deviceToken,
+		TradePointID:    tradePointID,
+		Scheme:          domain.DeviceSchemeEnhanced,
+		OrganizationBin: organizationBin,
+	})
+}
@@ -0,0 +1,347 @@
+package sqlite_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/migrator"
+	"kaspi-api-wrapper/internal/storage"
+	"kaspi-api-wrapper/internal/storage/sqlite"
+	"kaspi-api-wrapper/internal/storage/storagetest"
+	"kaspi-api-wrapper/internal/tokencrypt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func open(t *testing.T) *sqlite.Storage {
+	s, err := sqlite.New(filepath.Join(t.TempDir(), "kaspi.db"))
+	if err != nil {
+		t.Fatalf("sqlite.New: %v", err)
+	}
+	return s
+}
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return open(t)
+	})
+}
+
+func TestStorageWithEncryptedTokens(t *testing.T) {
+	tokens, err := tokencrypt.New(bytes.Repeat([]byte{1}, tokencrypt.KeySize))
+	if err != nil {
+		t.Fatalf("tokencrypt.New: %v", err)
+	}
+
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		s := open(t)
+		s.SetTokenCipher(tokens)
+		return s
+	})
+}
+
+func TestRotateDeviceTokens(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	defer s.Stop()
+
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+
+	first, _ := tokencrypt.New(bytes.Repeat([]byte{1}, tokencrypt.KeySize))
+	second, _ := tokencrypt.New(bytes.Repeat([]byte{2}, tokencrypt.KeySize), bytes.Repeat([]byte{1}, tokencrypt.KeySize))
+
+	s.SetTokenCipher(first)
+	if n, err := s.EncryptDeviceTokens(ctx); err != nil || n != 1 {
+		t.Fatalf("EncryptDeviceTokens = %d, %v", n, err)
+	}
+
+	s.SetTokenCipher(second)
+	if n, err := s.RotateDeviceTokens(ctx); err != nil || n != 1 {
+		t.Fatalf("RotateDeviceTokens = %d, %v", n, err)
+	}
+
+	// the token is found by the index of the current key
+	if err := s.DeactivateDevice(ctx, "token-1"); err != nil {
+		t.Fatalf("DeactivateDevice: %v", err)
+	}
+
+	if n, err := s.DecryptDeviceTokens(ctx); err != nil || n != 1 {
+		t.Fatalf("DecryptDeviceTokens = %d, %v", n, err)
+	}
+
+	s.SetTokenCipher(nil)
+	device, err := s.Device(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if device.DeviceToken != "token-1" {
+		t.Errorf("DeviceToken = %q, want token-1", device.DeviceToken)
+	}
+}
+
+// TestUnifiedDevicesMigration fills the device tables of schema version 1 and opens the file,
+// which merges them into one
+func TestUnifiedDevicesMigration(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kaspi.db")
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	schema, err := os.ReadFile("migrations/000001_schema.up.sql")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	m, err := migrator.New(db, fstest.MapFS{"000001_schema.up.sql": {Data: schema}}, nil)
+	if err != nil {
+		t.Fatalf("migrator.New: %v", err)
+	}
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// device-2 was registered in both schemes, its active enhanced row is kept
+	_, err = db.Exec(`
+		INSERT INTO devices (device_id, device_token, device_token_hmac, tradepoint_id, created_at, deleted_at) VALUES
+			('device-1', 'token-1', 'index-1', 10, '2024-01-01 10:00:00', NULL),
+			('device-2', 'token-2', 'index-2', 20, '2024-01-01 10:00:00', '2024-01-02 10:00:00');
+		INSERT INTO devices_enhanced (device_id, device_token, device_token_hmac, tradepoint_id, organization_bin, created_at) VALUES
+			('device-2', 'token-3', 'index-3', 30, '123456789012', '2024-01-03 10:00:00'),
+			('device-3', 'token-4', 'index-4', 40, '123456789012', '2024-01-04 10:00:00');
+	`)
+	if err != nil {
+		t.Fatalf("insert devices: %v", err)
+	}
+
+	s, err := sqlite.New(path)
+	if err != nil {
+		t.Fatalf("sqlite.New: %v", err)
+	}
+	defer s.Stop()
+
+	devices, err := s.Devices(ctx, domain.DeviceFilter{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+
+	want := map[string]domain.Device{
+		"device-1": {DeviceToken: "token-1", TradePointID: 10, Scheme: domain.DeviceSchemeStandard},
+		"device-2": {DeviceToken: "token-3", TradePointID: 30, Scheme: domain.DeviceSchemeEnhanced, OrganizationBin: "123456789012"},
+		"device-3": {DeviceToken: "token-4", TradePointID: 40, Scheme: domain.DeviceSchemeEnhanced, OrganizationBin: "123456789012"},
+	}
+	if len(devices) != len(want) {
+		t.Fatalf("Devices = %+v", devices)
+	}
+	for _, device := range devices {
+		w := want[device.DeviceID]
+		if device.DeviceToken != w.DeviceToken || device.TradePointID != w.TradePointID ||
+			device.Scheme != w.Scheme || device.OrganizationBin != w.OrganizationBin || !device.Active {
+			t.Errorf("Device %s = %+v, want %+v", device.DeviceID, device, w)
+		}
+	}
+
+	// the deleted standard row of device-2 is archived instead of dropped
+	var deviceID, token, scheme string
+	err = db.QueryRow(`SELECT device_id, device_token, scheme FROM devices_merge_conflicts`).Scan(&deviceID, &token, &scheme)
+	if err != nil {
+		t.Fatalf("select devices_merge_conflicts: %v", err)
+	}
+	if deviceID != "device-2" || token != "token-2" || scheme != domain.DeviceSchemeStandard {
+		t.Errorf("archived device = %s, %s, %s, want device-2, token-2, standard", deviceID, token, scheme)
+	}
+}
+
+func TestOrganizationsMigration(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kaspi.db")
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	migrations := fstest.MapFS{}
+	for _, name := range []string{"000001_schema.up.sql", "000002_unified_devices.up.sql"} {
+		data, err := os.ReadFile("migrations/" + name)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		migrations[name] = &fstest.MapFile{Data: data}
+	}
+
+	m, err := migrator.New(db, migrations, nil)
+	if err != nil {
+		t.Fatalf("migrator.New: %v", err)
+	}
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// the BINs of enhanced devices and of payments are registered, standard devices have none
+	_, err = db.Exec(`
+		INSERT INTO devices (device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at) VALUES
+			('device-1', 'token-1', 'index-1', 10, 'standard', NULL, '2024-01-01 10:00:00'),
+			('device-2', 'token-2', 'index-2', 20, 'enhanced', '123456789012', '2024-01-01 10:00:00');
+		INSERT INTO payments (qr_payment_id, kind, device_token, organization_bin, amount, status, created_at, updated_at) VALUES
+			(1, 'qr', 'token-1', '', 100, 'Wait', '2024-01-01 10:00:00', '2024-01-01 10:00:00'),
+			(2, 'qr', 'token-3', '210987654321', 100, 'Wait', '2024-01-01 10:00:00', '2024-01-01 10:00:00');
+	`)
+	if err != nil {
+		t.Fatalf("insert devices and payments: %v", err)
+	}
+
+	s, err := sqlite.New(path)
+	if err != nil {
+		t.Fatalf("sqlite.New: %v", err)
+	}
+	defer s.Stop()
+
+	organizations, err := s.Organizations(ctx)
+	if err != nil {
+		t.Fatalf("Organizations: %v", err)
+	}
+	if len(organizations) != 2 {
+		t.Fatalf("Organizations = %+v", organizations)
+	}
+	for i, bin := range []string{"123456789012", "210987654321"} {
+		organization := organizations[i]
+		if organization.OrganizationBin != bin || organization.Name != bin || !organization.Enabled || organization.CreatedAt.IsZero() {
+			t.Errorf("Organization = %+v, want an enabled organization %s", organization, bin)
+		}
+	}
+}
+
+// TestRecordTokenEncryption stores a payment of schema version 4 with a plaintext token, opens the file,
+// which indexes it, and encrypts it together with the device registry
+func TestRecordTokenEncryption(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kaspi.db")
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	migrations := fstest.MapFS{}
+	for _, name := range []string{
+		"000001_schema.up.sql", "000002_unified_devices.up.sql", "000003_organizations.up.sql", "000004_tradepoints.up.sql",
+	} {
+		data, err := os.ReadFile("migrations/" + name)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		migrations[name] = &fstest.MapFile{Data: data}
+	}
+
+	m, err := migrator.New(db, migrations, nil)
+	if err != nil {
+		t.Fatalf("migrator.New: %v", err)
+	}
+	if _, err = m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO payments (qr_payment_id, kind, device_token, organization_bin, amount, status, created_at, updated_at) VALUES
+			(1, 'qr', 'token-1', '', 100, 'Wait', '2024-01-01 10:00:00', '2024-01-01 10:00:00');
+	`)
+	if err != nil {
+		t.Fatalf("insert payments: %v", err)
+	}
+
+	s, err := sqlite.New(path)
+	if err != nil {
+		t.Fatalf("sqlite.New: %v", err)
+	}
+	defer s.Stop()
+
+	filter := domain.PaymentFilter{
+		PaymentListRequest: domain.PaymentListRequest{DeviceToken: "token-1", Limit: 10},
+	}
+
+	// the plaintext token is indexed when the file is opened
+	payments, err := s.ListPayments(ctx, filter)
+	if err != nil {
+		t.Fatalf("ListPayments: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("ListPayments = %+v, want payment 1", payments)
+	}
+
+	if err = s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+
+	tokens, _ := tokencrypt.New(bytes.Repeat([]byte{1}, tokencrypt.KeySize))
+	s.SetTokenCipher(tokens)
+
+	if n, err := s.EncryptDeviceTokens(ctx); err != nil || n != 2 {
+		t.Fatalf("EncryptDeviceTokens = %d, %v", n, err)
+	}
+
+	var stored string
+	if err = db.QueryRow(`SELECT device_token FROM payments WHERE qr_payment_id = 1`).Scan(&stored); err != nil {
+		t.Fatalf("select payment: %v", err)
+	}
+	if !tokencrypt.IsSealed(stored) {
+		t.Errorf("stored token = %q, want it encrypted", stored)
+	}
+
+	// the payment is found by the index of the key and read in plaintext
+	payments, err = s.ListPayments(ctx, filter)
+	if err != nil {
+		t.Fatalf("ListPayments: %v", err)
+	}
+	if len(payments) != 1 || payments[0].DeviceToken != "token-1" {
+		t.Errorf("ListPayments = %+v, want payment 1 with token-1", payments)
+	}
+}
+
+// TestPendingRefundKeepsBalance checks that a refund with an unknown outcome keeps blocking its
+// amount however long ago it was reserved
+func TestPendingRefundKeepsBalance(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kaspi.db")
+
+	s, err := sqlite.New(path)
+	if err != nil {
+		t.Fatalf("sqlite.New: %v", err)
+	}
+
+	refund := domain.Refund{QrPaymentID: 1, Amount: 300, DeviceToken: "token-1"}
+	if _, err = s.ReserveRefund(ctx, refund, func(domain.RefundBalance) error { return nil }); err != nil {
+		t.Fatalf("ReserveRefund: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	if _, err = db.Exec(`UPDATE refunds SET created_at = ?`, time.Now().UTC().Add(-24*time.Hour)); err != nil {
+		t.Fatalf("backdate refund: %v", err)
+	}
+
+	_, err = s.ReserveRefund(ctx, refund, func(balance domain.RefundBalance) error {
+		if balance.Pending != 300 {
+			t.Errorf("Pending = %v, want 300 of the day old refund", balance.Pending)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReserveRefund: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TIMESTAMP columns hold UTC times formatted by the driver, so that comparing them as text
+// orders them by the instant. The driver parses them back as UTC
+
+// utc converts a time bound in any time zone to the stored form
+func utc(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// nullTime stores a zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func nullTimePtr(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return nullTime(*t)
+}
@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"time"
+)
+
+// SaveWebhookEvent saves an event together with a pending delivery for every URL
+func (s *Storage) SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error {
+	const op = "storage.sqlite.SaveWebhookEvent"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	now := utc(time.Now())
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_events (id, type, subject_id, payload, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5)
+	`, event.ID, event.Type, event.SubjectID, string(payload), now)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	for _, url := range urls {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (event_id, url, status, next_attempt_at, created_at)
+			VALUES (?1, ?2, ?3, ?4, ?4)
+		`, event.ID, url, domain.DeliveryStatusPending, now)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries returns pending deliveries that are due and leases them until leaseUntil.
+// RETURNING can't read the events table joined to an UPDATE in SQLite, so the due deliveries are
+// selected first and leased in the same transaction
+func (s *Storage) ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	const op = "storage.sqlite.ClaimWebhookDeliveries"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT d.id, d.url, d.attempts, e.payload
+		FROM webhook_deliveries d
+		JOIN webhook_events e ON e.id = d.event_id
+		WHERE d.status = ?2 AND d.next_attempt_at <= ?1
+		ORDER BY d.next_attempt_at
+		LIMIT ?3
+	`, utc(now), domain.DeliveryStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var payload []byte
+
+		if err = rows.Scan(&delivery.ID, &delivery.URL, &delivery.Attempts, &payload); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		if err = json.Unmarshal(payload, &delivery.Event); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	for _, delivery := range deliveries {
+		_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries SET next_attempt_at = ?2 WHERE id = ?1`, delivery.ID, utc(leaseUntil))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered marks a delivery as successfully delivered
+func (s *Storage) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	const op = "storage.sqlite.MarkWebhookDelivered"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?2, attempts = attempts + 1, last_error = '', delivered_at = ?3
+		WHERE id = ?1
+	`, deliveryID, domain.DeliveryStatusDelivered, utc(time.Now()))
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt, the delivery is either rescheduled or moved to dead letters
+func (s *Storage) MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error {
+	const op = "storage.sqlite.MarkWebhookFailed"
+
+	status := domain.DeliveryStatusPending
+	if dead {
+		status = domain.DeliveryStatusDead
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?2, attempts = attempts + 1, next_attempt_at = ?3, last_error = ?4
+		WHERE id = ?1
+	`, deliveryID, status, utc(nextAttemptAt), lastError)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, err)
+	}
+
+	return nil
+}
+
+// ReplayWebhookDeliveries puts deliveries of the matching events back into the queue
+// regardless of their current status and returns the number of requeued deliveries
+func (s *Storage) ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	const op = "storage.sqlite.ReplayWebhookDeliveries"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?1, attempts = 0, next_attempt_at = ?2, last_error = '', delivered_at = NULL
+		WHERE event_id IN (
+			SELECT id FROM webhook_events
+			WHERE (?3 = '' OR id = ?3)
+			  AND (?4 IS NULL OR created_at >= ?4)
+			  AND (?5 IS NULL OR created_at < ?5)
+		)
+	`
+
+	res, err := s.db.ExecContext(ctx, query,
+		domain.DeliveryStatusPending,
+		utc(time.Now()),
+		filter.EventID,
+		nullTime(filter.Since),
+		nullTime(filter.Until),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if affected == 0 && filter.EventID != "" {
+		return 0, storage.ErrWebhookEventNotFound
+	}
+
+	return affected, nil
+}
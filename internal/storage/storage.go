@@ -1,8 +1,161 @@
 package storage
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/migrator"
+	"kaspi-api-wrapper/internal/tokencrypt"
+	"time"
+)
 
 var (
-	ErrDeviceExists = errors.New("device already in use in another tradepoint")
-	//ErrTradePointNotFound = errors.New("tradepoint not found")
+	ErrDeviceExists          = errors.New("device already in use in another tradepoint")
+	ErrDeviceNotFound        = fmt.Errorf("device %w", domain.ErrNotFound)
+	ErrOrganizationExists    = errors.New("organization already exists")
+	ErrOrganizationNotFound  = fmt.Errorf("organization %w", domain.ErrNotFound)
+	ErrPaymentNotFound       = fmt.Errorf("payment %w", domain.ErrNotFound)
+	ErrRefundNotFound        = fmt.Errorf("refund %w", domain.ErrNotFound)
+	ErrRefundSessionNotFound = fmt.Errorf("refund session %w", domain.ErrNotFound)
+	ErrTradePointNotFound    = fmt.Errorf("trade point %w", domain.ErrNotFound)
+	ErrWebhookEventNotFound  = fmt.Errorf("webhook event %w", domain.ErrNotFound)
+
+	ErrReconciliationRunExists   = errors.New("scheduled reconciliation run of the period already exists")
+	ErrReconciliationRunNotFound = fmt.Errorf("reconciliation run %w", domain.ErrNotFound)
+
+	ErrIdempotencyKeyNotFound = fmt.Errorf("idempotency key %w", domain.ErrNotFound)
+)
+
+// Backends selected with STORAGE_BACKEND
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+	BackendMemory   = "memory"
 )
+
+// DeviceStorage keeps the devices registered through the wrapper in both schemes
+type DeviceStorage interface {
+	SaveDevice(ctx context.Context, deviceID string, deviceToken string, tradePointID int64) error
+	SaveDeviceEnhanced(ctx context.Context, deviceID string, deviceToken string, tradePointID int64, organizationBin string) error
+	Devices(ctx context.Context, filter domain.DeviceFilter) ([]domain.Device, error)
+	Device(ctx context.Context, deviceID string) (*domain.Device, error)
+	DeviceByToken(ctx context.Context, deviceToken string) (*domain.Device, error)
+	DeactivateDevice(ctx context.Context, deviceToken string) error
+}
+
+// OrganizationStorage keeps the organizations registered for the enhanced scheme
+type OrganizationStorage interface {
+	SaveOrganization(ctx context.Context, organization domain.Organization) error
+	UpdateOrganization(ctx context.Context, organization domain.Organization) error
+	Organization(ctx context.Context, organizationBin string) (*domain.Organization, error)
+	Organizations(ctx context.Context) ([]domain.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationBin string) error
+}
+
+// TradePointStorage caches the trade points returned by Kaspi together with the metadata kept by
+// the wrapper. Trade points of the basic and standard schemes have an empty organization BIN
+type TradePointStorage interface {
+	SyncTradePoints(ctx context.Context, organizationBin string, tradePoints []domain.TradePoint, syncedAt time.Time) ([]domain.TradePoint, error)
+	TradePoints(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+	TradePoint(ctx context.Context, organizationBin string, tradePointID int64) (*domain.TradePoint, error)
+	UpdateTradePoint(ctx context.Context, tradePoint domain.TradePoint) error
+}
+
+// PaymentStorage keeps created payments with their latest status
+type PaymentStorage interface {
+	SavePayment(ctx context.Context, payment domain.Payment) error
+	UpdatePaymentStatus(ctx context.Context, qrPaymentID int64, status domain.PaymentStatusResponse) (string, error)
+	Payment(ctx context.Context, qrPaymentID int64) (*domain.Payment, error)
+	LivePaymentByExternalID(ctx context.Context, kind, externalID, deviceToken, organizationBin string) (*domain.Payment, error)
+	LockExternalID(ctx context.Context, key string) (context.Context, func(), error)
+	PaymentsByExternalID(ctx context.Context, externalID string) ([]domain.Payment, error)
+	PendingRemotePayments(ctx context.Context, organizationBin string, createdBefore time.Time) ([]domain.Payment, error)
+	PendingPayments(ctx context.Context) ([]domain.Payment, error)
+	PaymentsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.Payment, error)
+	ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error)
+}
+
+// RefundStorage keeps refund QRs and the refund ledger
+type RefundStorage interface {
+	SaveRefundQR(ctx context.Context, refund domain.RefundQR) error
+	UpdateRefundStatus(ctx context.Context, qrReturnID int64, status string) (string, error)
+	RefundQR(ctx context.Context, qrReturnID int64) (*domain.RefundQR, error)
+	RefundQRsCreatedBetween(ctx context.Context, from, to time.Time) ([]domain.RefundQR, error)
+	ReserveRefund(ctx context.Context, refund domain.Refund, check func(balance domain.RefundBalance) error) (*domain.Refund, error)
+	CompleteRefund(ctx context.Context, id int64, returnOperationID int64) error
+	FailRefund(ctx context.Context, id int64, reason string) error
+	RefundTotals(ctx context.Context, from, to time.Time) ([]domain.RefundTotal, error)
+}
+
+// RefundSessionStorage keeps the state of refund sessions so they survive a restart
+type RefundSessionStorage interface {
+	SaveRefundSession(ctx context.Context, session domain.RefundSession) error
+	UpdateRefundSession(ctx context.Context, session domain.RefundSession, fromState string) error
+	RefundSession(ctx context.Context, qrReturnID int64) (*domain.RefundSession, error)
+	WaitingRefundSessions(ctx context.Context) ([]domain.RefundSession, error)
+}
+
+// ReconciliationStorage keeps reconciliation runs and the discrepancies they found
+type ReconciliationStorage interface {
+	CreateReconciliationRun(ctx context.Context, run domain.ReconciliationRun) (*domain.ReconciliationRun, error)
+	FinishReconciliationRun(ctx context.Context, run domain.ReconciliationRun) error
+	RenewReconciliationRun(ctx context.Context, id int64, heartbeatAt time.Time) error
+	InterruptReconciliationRuns(ctx context.Context, owner string, staleBefore time.Time, reason string) (int64, error)
+	SaveReconciliationDiscrepancy(ctx context.Context, discrepancy domain.ReconciliationDiscrepancy) error
+	ReconciliationRun(ctx context.Context, id int64) (*domain.ReconciliationRun, error)
+	ReconciliationRuns(ctx context.Context, limit int) ([]domain.ReconciliationRun, error)
+	ReconciliationDiscrepancies(ctx context.Context, runID int64) ([]domain.ReconciliationDiscrepancy, error)
+}
+
+// ReportStorage reads the processed payments and succeeded refunds of a period for reports and exports
+type ReportStorage interface {
+	SettlementEntries(ctx context.Context, from, to time.Time, tradePointID int64, organizationBin string) ([]domain.SettlementEntry, error)
+	AccountingDocuments(ctx context.Context, from, to time.Time, tradePointID int64) ([]domain.AccountingDocument, error)
+}
+
+// WebhookStorage is the outbox of webhook events and their deliveries
+type WebhookStorage interface {
+	SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error
+	ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryID int64) error
+	MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error
+	ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error)
+}
+
+// IdempotencyStorage keeps idempotency keys with the responses they replay
+type IdempotencyStorage interface {
+	AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, expiredBefore, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error)
+	IdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, responseStatus int, responseBody []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+// Storage is implemented by every backend, the consumers depend on the narrow interfaces they need
+type Storage interface {
+	DeviceStorage
+	OrganizationStorage
+	TradePointStorage
+	PaymentStorage
+	RefundStorage
+	RefundSessionStorage
+	ReconciliationStorage
+	ReportStorage
+	WebhookStorage
+	IdempotencyStorage
+
+	Stop() error
+}
+
+// TokenEncrypter is implemented by backends that persist device tokens and can encrypt them at rest
+type TokenEncrypter interface {
+	SetTokenCipher(tokens *tokencrypt.Cipher)
+	EncryptDeviceTokens(ctx context.Context) (int, error)
+	RotateDeviceTokens(ctx context.Context) (int, error)
+	DecryptDeviceTokens(ctx context.Context) (int, error)
+}
+
+// Migratable is implemented by backends with a versioned schema
+type Migratable interface {
+	Migrator() (*migrator.Migrator, error)
+}
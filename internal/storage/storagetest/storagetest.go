@@ -0,0 +1,1182 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/storage"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Run runs the conformance suite every storage backend has to pass, open returns an empty storage
+// and is called once per test
+func Run(t *testing.T, open func(t *testing.T) storage.Storage) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s storage.Storage)
+	}{
+		{"Devices", testDevices},
+		{"DeviceReactivation", testDeviceReactivation},
+		{"DeviceSchemes", testDeviceSchemes},
+		{"ConcurrentDeviceRegistration", testConcurrentDeviceRegistration},
+		{"Organizations", testOrganizations},
+		{"TradePoints", testTradePoints},
+		{"Payments", testPayments},
+		{"PaymentsByExternalID", testPaymentsByExternalID},
+		{"ListPayments", testListPayments},
+		{"RefundQRs", testRefundQRs},
+		{"RefundLedger", testRefundLedger},
+		{"RefundSessions", testRefundSessions},
+		{"Reconciliation", testReconciliation},
+		{"Reports", testReports},
+		{"Webhooks", testWebhooks},
+		{"Idempotency", testIdempotency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := open(t)
+			t.Cleanup(func() { s.Stop() })
+
+			tt.run(t, s)
+		})
+	}
+}
+
+func testDevices(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice in the same trade point: %v", err)
+	}
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 11); !errors.Is(err, storage.ErrDeviceExists) {
+		t.Fatalf("SaveDevice in another trade point: got %v, want ErrDeviceExists", err)
+	}
+	if err := s.SaveDeviceEnhanced(ctx, "device-2", "token-2", 20, "123456789012"); err != nil {
+		t.Fatalf("SaveDeviceEnhanced: %v", err)
+	}
+
+	device, err := s.Device(ctx, "device-2")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if device.DeviceToken != "token-2" || device.TradePointID != 20 || device.OrganizationBin != "123456789012" ||
+		device.Scheme != domain.DeviceSchemeEnhanced || !device.Active {
+		t.Errorf("Device = %+v", device)
+	}
+
+	if _, err = s.Device(ctx, "unknown"); !errors.Is(err, storage.ErrDeviceNotFound) {
+		t.Errorf("Device of an unknown ID: got %v, want ErrDeviceNotFound", err)
+	}
+
+	device, err = s.DeviceByToken(ctx, "token-2")
+	if err != nil {
+		t.Fatalf("DeviceByToken: %v", err)
+	}
+	if device.DeviceID != "device-2" || device.OrganizationBin != "123456789012" {
+		t.Errorf("DeviceByToken = %+v", device)
+	}
+	if _, err = s.DeviceByToken(ctx, "unknown"); !errors.Is(err, storage.ErrDeviceNotFound) {
+		t.Errorf("DeviceByToken of an unknown token: got %v, want ErrDeviceNotFound", err)
+	}
+
+	devices, err := s.Devices(ctx, domain.DeviceFilter{TradePointID: 10})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+	if len(devices) != 1 || devices[0].DeviceID != "device-1" || devices[0].OrganizationBin != "" || devices[0].Scheme != domain.DeviceSchemeStandard {
+		t.Errorf("Devices of trade point 10 = %+v", devices)
+	}
+
+	devices, err = s.Devices(ctx, domain.DeviceFilter{OrganizationBin: "123456789012"})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+	if len(devices) != 1 || devices[0].DeviceID != "device-2" {
+		t.Errorf("Devices of the organization = %+v", devices)
+	}
+
+	if err = s.DeactivateDevice(ctx, "token-1"); err != nil {
+		t.Fatalf("DeactivateDevice: %v", err)
+	}
+	if err = s.DeactivateDevice(ctx, "unknown"); !errors.Is(err, storage.ErrDeviceNotFound) {
+		t.Errorf("DeactivateDevice of an unknown token: got %v, want ErrDeviceNotFound", err)
+	}
+
+	devices, err = s.Devices(ctx, domain.DeviceFilter{})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+	if len(devices) != 1 || devices[0].DeviceID != "device-2" {
+		t.Errorf("active Devices = %+v", devices)
+	}
+
+	devices, err = s.Devices(ctx, domain.DeviceFilter{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("Devices including deleted = %+v", devices)
+	}
+
+	device, err = s.Device(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if device.Active || device.DeletedAt == nil {
+		t.Errorf("deactivated Device = %+v", device)
+	}
+}
+
+func testDeviceReactivation(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+	if err := s.DeactivateDevice(ctx, "token-1"); err != nil {
+		t.Fatalf("DeactivateDevice: %v", err)
+	}
+
+	// a deleted device may be registered again in another trade point
+	if err := s.SaveDevice(ctx, "device-1", "token-2", 11); err != nil {
+		t.Fatalf("SaveDevice of a deleted device: %v", err)
+	}
+
+	device, err := s.Device(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if !device.Active || device.DeviceToken != "token-2" || device.TradePointID != 11 {
+		t.Errorf("reactivated Device = %+v", device)
+	}
+}
+
+func testDeviceSchemes(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	if err := s.SaveDeviceEnhanced(ctx, "device-1", "token-1", 10, "123456789012"); err != nil {
+		t.Fatalf("SaveDeviceEnhanced: %v", err)
+	}
+	if err := s.SaveDeviceEnhanced(ctx, "device-1", "token-1", 10, "123456789012"); err != nil {
+		t.Fatalf("SaveDeviceEnhanced in the same organization: %v", err)
+	}
+	if err := s.SaveDeviceEnhanced(ctx, "device-1", "token-1", 10, "210987654321"); !errors.Is(err, storage.ErrDeviceExists) {
+		t.Fatalf("SaveDeviceEnhanced in another organization: got %v, want ErrDeviceExists", err)
+	}
+
+	// the device is registered in the standard scheme in its trade point and takes the new token
+	if err := s.SaveDevice(ctx, "device-1", "token-2", 10); err != nil {
+		t.Fatalf("SaveDevice of an enhanced device: %v", err)
+	}
+
+	device, err := s.Device(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if device.Scheme != domain.DeviceSchemeStandard || device.OrganizationBin != "" || device.DeviceToken != "token-2" {
+		t.Errorf("Device registered in the standard scheme = %+v", device)
+	}
+
+	devices, err := s.Devices(ctx, domain.DeviceFilter{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Devices: %v", err)
+	}
+	if len(devices) != 1 {
+		t.Errorf("Devices = %+v, want a single row per device ID", devices)
+	}
+}
+
+func testConcurrentDeviceRegistration(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	// the same device registered in several trade points at once ends up in exactly one of them
+	const registrations = 8
+	errs := make([]error, registrations)
+
+	var wg sync.WaitGroup
+	for i := range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.SaveDevice(ctx, "device-1", fmt.Sprintf("token-%d", i), int64(10+i))
+		}()
+	}
+	wg.Wait()
+
+	var saved int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			saved++
+		case !errors.Is(err, storage.ErrDeviceExists):
+			t.Errorf("SaveDevice: got %v, want nil or ErrDeviceExists", err)
+		}
+	}
+	if saved != 1 {
+		t.Fatalf("%d registrations succeeded, want 1", saved)
+	}
+
+	device, err := s.Device(ctx, "device-1")
+	if err != nil {
+		t.Fatalf("Device: %v", err)
+	}
+	if device.DeviceToken != fmt.Sprintf("token-%d", device.TradePointID-10) {
+		t.Errorf("Device = %+v, the token of another registration", device)
+	}
+}
+
+func testOrganizations(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	organization := domain.Organization{
+		OrganizationBin: "210987654321",
+		Name:            "Shop",
+		Enabled:         true,
+		Settings:        map[string]string{"region": "Almaty"},
+		CreatedAt:       created,
+		UpdatedAt:       created,
+	}
+	if err := s.SaveOrganization(ctx, organization); err != nil {
+		t.Fatalf("SaveOrganization: %v", err)
+	}
+	if err := s.SaveOrganization(ctx, organization); !errors.Is(err, storage.ErrOrganizationExists) {
+		t.Fatalf("SaveOrganization of a registered BIN: got %v, want ErrOrganizationExists", err)
+	}
+	if err := s.SaveOrganization(ctx, domain.Organization{OrganizationBin: "123456789012", Name: "Cafe", CreatedAt: created, UpdatedAt: created}); err != nil {
+		t.Fatalf("SaveOrganization: %v", err)
+	}
+
+	got, err := s.Organization(ctx, "210987654321")
+	if err != nil {
+		t.Fatalf("Organization: %v", err)
+	}
+	if got.Name != "Shop" || !got.Enabled || got.Settings["region"] != "Almaty" || !sameInstant(got.CreatedAt, created) {
+		t.Errorf("Organization = %+v", got)
+	}
+
+	if _, err = s.Organization(ctx, "000000000000"); !errors.Is(err, storage.ErrOrganizationNotFound) {
+		t.Errorf("Organization of an unknown BIN: got %v, want ErrOrganizationNotFound", err)
+	}
+
+	updated := time.Now().Truncate(time.Second)
+	organization.Name = "Shop 2"
+	organization.Enabled = false
+	organization.Settings = nil
+	organization.CreatedAt = updated
+	organization.UpdatedAt = updated
+	if err = s.UpdateOrganization(ctx, organization); err != nil {
+		t.Fatalf("UpdateOrganization: %v", err)
+	}
+	if err = s.UpdateOrganization(ctx, domain.Organization{OrganizationBin: "000000000000", UpdatedAt: updated}); !errors.Is(err, storage.ErrOrganizationNotFound) {
+		t.Errorf("UpdateOrganization of an unknown BIN: got %v, want ErrOrganizationNotFound", err)
+	}
+
+	got, err = s.Organization(ctx, "210987654321")
+	if err != nil {
+		t.Fatalf("Organization: %v", err)
+	}
+	if got.Name != "Shop 2" || got.Enabled || len(got.Settings) != 0 || got.Settings == nil ||
+		!sameInstant(got.CreatedAt, created) || !sameInstant(got.UpdatedAt, updated) {
+		t.Errorf("updated Organization = %+v", got)
+	}
+
+	organizations, err := s.Organizations(ctx)
+	if err != nil {
+		t.Fatalf("Organizations: %v", err)
+	}
+	if len(organizations) != 2 || organizations[0].OrganizationBin != "123456789012" || organizations[1].OrganizationBin != "210987654321" {
+		t.Errorf("Organizations = %+v", organizations)
+	}
+
+	if err = s.DeleteOrganization(ctx, "123456789012"); err != nil {
+		t.Fatalf("DeleteOrganization: %v", err)
+	}
+	if err = s.DeleteOrganization(ctx, "123456789012"); !errors.Is(err, storage.ErrOrganizationNotFound) {
+		t.Errorf("DeleteOrganization of a deleted BIN: got %v, want ErrOrganizationNotFound", err)
+	}
+	if _, err = s.Organization(ctx, "123456789012"); !errors.Is(err, storage.ErrOrganizationNotFound) {
+		t.Errorf("Organization of a deleted BIN: got %v, want ErrOrganizationNotFound", err)
+	}
+}
+
+func testTradePoints(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	synced := time.Now().Add(-time.Hour)
+
+	cached, err := s.SyncTradePoints(ctx, "", []domain.TradePoint{
+		{TradePointID: 2, TradePointName: "Mall"},
+		{TradePointID: 1, TradePointName: "Market"},
+	}, synced)
+	if err != nil {
+		t.Fatalf("SyncTradePoints: %v", err)
+	}
+	if len(cached) != 2 || cached[0].TradePointID != 1 || cached[1].TradePointID != 2 ||
+		!cached[0].Enabled || !sameInstant(cached[0].SyncedAt, synced) {
+		t.Fatalf("synced TradePoints = %+v", cached)
+	}
+
+	if _, err = s.SyncTradePoints(ctx, "210987654321", []domain.TradePoint{{TradePointID: 1, TradePointName: "Branch"}}, synced); err != nil {
+		t.Fatalf("SyncTradePoints of an organization: %v", err)
+	}
+
+	tradePoint := cached[1]
+	tradePoint.Address = "Abay 1"
+	tradePoint.Timezone = "Asia/Almaty"
+	tradePoint.Enabled = false
+	tradePoint.MinAmount = 100
+	tradePoint.MaxAmount = 50000
+	if err = s.UpdateTradePoint(ctx, tradePoint); err != nil {
+		t.Fatalf("UpdateTradePoint: %v", err)
+	}
+	if err = s.UpdateTradePoint(ctx, domain.TradePoint{TradePointID: 3}); !errors.Is(err, storage.ErrTradePointNotFound) {
+		t.Errorf("UpdateTradePoint of an unknown trade point: got %v, want ErrTradePointNotFound", err)
+	}
+
+	// the sync renames the trade points Kaspi returns, hides the others and keeps the metadata
+	resynced := time.Now()
+	cached, err = s.SyncTradePoints(ctx, "", []domain.TradePoint{{TradePointID: 2, TradePointName: "Mall 2"}}, resynced)
+	if err != nil {
+		t.Fatalf("SyncTradePoints: %v", err)
+	}
+	if len(cached) != 1 || cached[0].TradePointName != "Mall 2" || cached[0].Address != "Abay 1" || cached[0].Timezone != "Asia/Almaty" ||
+		cached[0].Enabled || cached[0].MinAmount != 100 || cached[0].MaxAmount != 50000 || !sameInstant(cached[0].SyncedAt, resynced) {
+		t.Fatalf("resynced TradePoints = %+v", cached)
+	}
+
+	if _, err = s.TradePoint(ctx, "", 1); !errors.Is(err, storage.ErrTradePointNotFound) {
+		t.Errorf("TradePoint no longer returned by Kaspi: got %v, want ErrTradePointNotFound", err)
+	}
+
+	got, err := s.TradePoint(ctx, "210987654321", 1)
+	if err != nil {
+		t.Fatalf("TradePoint of an organization: %v", err)
+	}
+	if got.TradePointName != "Branch" || got.OrganizationBin != "210987654321" || !got.Enabled {
+		t.Errorf("TradePoint of an organization = %+v", got)
+	}
+
+	if _, err = s.SyncTradePoints(ctx, "", []domain.TradePoint{{TradePointID: 1, TradePointName: "Market"}}, time.Now()); err != nil {
+		t.Fatalf("SyncTradePoints: %v", err)
+	}
+	tradePoints, err := s.TradePoints(ctx, "")
+	if err != nil {
+		t.Fatalf("TradePoints: %v", err)
+	}
+	if len(tradePoints) != 1 || tradePoints[0].TradePointID != 1 || !tradePoints[0].Enabled {
+		t.Errorf("TradePoints after the trade point came back = %+v", tradePoints)
+	}
+
+	tradePoints, err = s.TradePoints(ctx, "000000000000")
+	if err != nil {
+		t.Fatalf("TradePoints of an unknown organization: %v", err)
+	}
+	if len(tradePoints) != 0 {
+		t.Errorf("TradePoints of an unknown organization = %+v", tradePoints)
+	}
+}
+
+func testPayments(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+
+	saved := time.Now()
+	expireDate := saved.Add(time.Hour)
+	payment := domain.Payment{
+		QrPaymentID:    1,
+		Kind:           domain.PaymentKindQR,
+		ExternalID:     "order-1",
+		DeviceToken:    "token-1",
+		Amount:         150.5,
+		ExpireDate:     expireDate,
+		PaymentMethods: []string{"Gold", "Loan"},
+		Status:         domain.PaymentStatusCreated,
+		QrToken:        "qr-token",
+	}
+
+	if err := s.SavePayment(ctx, payment); err != nil {
+		t.Fatalf("SavePayment: %v", err)
+	}
+
+	stored, err := s.Payment(ctx, 1)
+	if err != nil {
+		t.Fatalf("Payment: %v", err)
+	}
+	if stored.TradePointID != 10 {
+		t.Errorf("TradePointID = %d, want 10 resolved from the device", stored.TradePointID)
+	}
+	if stored.ExternalID != "order-1" || stored.Amount != 150.5 || stored.QrToken != "qr-token" || stored.Status != domain.PaymentStatusCreated {
+		t.Errorf("Payment = %+v", stored)
+	}
+	if !slices.Equal(stored.PaymentMethods, []string{"Gold", "Loan"}) {
+		t.Errorf("PaymentMethods = %v", stored.PaymentMethods)
+	}
+	if !sameInstant(stored.ExpireDate, expireDate) {
+		t.Errorf("ExpireDate = %v, want %v", stored.ExpireDate, expireDate)
+	}
+	if d := stored.CreatedAt.Sub(saved); d < -time.Second || d > time.Minute {
+		t.Errorf("CreatedAt = %v, want about %v", stored.CreatedAt, saved)
+	}
+
+	if _, err = s.Payment(ctx, 2); !errors.Is(err, storage.ErrPaymentNotFound) {
+		t.Errorf("Payment of an unknown ID: got %v, want ErrPaymentNotFound", err)
+	}
+
+	live, err := s.LivePaymentByExternalID(ctx, domain.PaymentKindQR, "order-1", "token-1", "")
+	if err != nil {
+		t.Fatalf("LivePaymentByExternalID: %v", err)
+	}
+	if live.QrPaymentID != 1 {
+		t.Errorf("live payment = %d, want 1", live.QrPaymentID)
+	}
+
+	previous, err := s.UpdatePaymentStatus(ctx, 1, domain.PaymentStatusResponse{
+		Status:        domain.PaymentStatusProcessed,
+		TransactionID: "txn-1",
+		ProductType:   "Gold",
+	})
+	if err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+	if previous != domain.PaymentStatusCreated {
+		t.Errorf("previous status = %q, want %q", previous, domain.PaymentStatusCreated)
+	}
+
+	stored, err = s.Payment(ctx, 1)
+	if err != nil {
+		t.Fatalf("Payment: %v", err)
+	}
+	if stored.Status != domain.PaymentStatusProcessed || stored.TransactionID != "txn-1" || stored.ProductType != "Gold" {
+		t.Errorf("updated Payment = %+v", stored)
+	}
+
+	// a status without details keeps the stored ones, and a repeated status keeps the update time
+	if _, err = s.UpdatePaymentStatus(ctx, 1, domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed}); err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+	repeated, _ := s.Payment(ctx, 1)
+	if repeated.TransactionID != "txn-1" {
+		t.Errorf("TransactionID = %q, want it kept", repeated.TransactionID)
+	}
+	if !sameInstant(repeated.UpdatedAt, stored.UpdatedAt) {
+		t.Errorf("UpdatedAt = %v, want %v of the status change", repeated.UpdatedAt, stored.UpdatedAt)
+	}
+
+	if _, err = s.UpdatePaymentStatus(ctx, 2, domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed}); !errors.Is(err, storage.ErrPaymentNotFound) {
+		t.Errorf("UpdatePaymentStatus of an unknown ID: got %v, want ErrPaymentNotFound", err)
+	}
+
+	if _, err = s.LivePaymentByExternalID(ctx, domain.PaymentKindQR, "order-1", "token-1", ""); !errors.Is(err, storage.ErrPaymentNotFound) {
+		t.Errorf("LivePaymentByExternalID of a processed payment: got %v, want ErrPaymentNotFound", err)
+	}
+
+	payments, err := s.PaymentsCreatedBetween(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PaymentsCreatedBetween: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Errorf("PaymentsCreatedBetween = %d payments, want 1", len(payments))
+	}
+
+	payments, err = s.PaymentsCreatedBetween(ctx, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("PaymentsCreatedBetween: %v", err)
+	}
+	if len(payments) != 0 {
+		t.Errorf("PaymentsCreatedBetween of a later period = %d payments, want 0", len(payments))
+	}
+}
+
+func testPaymentsByExternalID(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	remote := domain.Payment{
+		QrPaymentID:     1,
+		Kind:            domain.PaymentKindRemote,
+		ExternalID:      "order-1",
+		DeviceToken:     "token-1",
+		OrganizationBin: "123456789012",
+		Amount:          100,
+		Status:          domain.PaymentStatusWait,
+		PhoneNumber:     "7701***4567",
+	}
+	qr := domain.Payment{
+		QrPaymentID: 2,
+		Kind:        domain.PaymentKindQR,
+		ExternalID:  "order-1",
+		DeviceToken: "token-1",
+		Amount:      100,
+		Status:      domain.PaymentStatusCreated,
+	}
+
+	for _, payment := range []domain.Payment{remote, qr} {
+		if err := s.SavePayment(ctx, payment); err != nil {
+			t.Fatalf("SavePayment: %v", err)
+		}
+	}
+
+	payments, err := s.PaymentsByExternalID(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("PaymentsByExternalID: %v", err)
+	}
+	if len(payments) != 2 {
+		t.Fatalf("PaymentsByExternalID = %d payments, want 2", len(payments))
+	}
+
+	live, err := s.LivePaymentByExternalID(ctx, domain.PaymentKindRemote, "order-1", "", "123456789012")
+	if err != nil {
+		t.Fatalf("LivePaymentByExternalID: %v", err)
+	}
+	if live.QrPaymentID != 1 || live.PhoneNumber != "7701***4567" {
+		t.Errorf("live remote payment = %+v", live)
+	}
+
+	pending, err := s.PendingRemotePayments(ctx, "123456789012", time.Time{})
+	if err != nil {
+		t.Fatalf("PendingRemotePayments: %v", err)
+	}
+	if len(pending) != 1 || pending[0].QrPaymentID != 1 {
+		t.Errorf("PendingRemotePayments = %+v", pending)
+	}
+
+	pending, err = s.PendingRemotePayments(ctx, "", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("PendingRemotePayments: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("PendingRemotePayments created an hour ago = %+v", pending)
+	}
+
+	pending, err = s.PendingPayments(ctx)
+	if err != nil {
+		t.Fatalf("PendingPayments: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Errorf("PendingPayments = %+v", pending)
+	}
+}
+
+func testListPayments(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	for i, amount := range []float64{300, 100, 500, 200, 400} {
+		payment := domain.Payment{
+			QrPaymentID: int64(i + 1),
+			Kind:        domain.PaymentKindQR,
+			ExternalID:  "Order-" + string(rune('A'+i)),
+			DeviceToken: "token-1",
+			Amount:      amount,
+			Status:      domain.PaymentStatusCreated,
+		}
+		if err := s.SavePayment(ctx, payment); err != nil {
+			t.Fatalf("SavePayment: %v", err)
+		}
+	}
+
+	if _, err := s.UpdatePaymentStatus(ctx, 1, domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed, TransactionID: "txn_50%"}); err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+
+	filter := domain.PaymentFilter{PaymentListRequest: domain.PaymentListRequest{
+		SortBy:    domain.PaymentSortAmount,
+		SortOrder: domain.SortOrderAsc,
+		Limit:     2,
+	}}
+
+	var amounts []float64
+	for {
+		page, err := s.ListPayments(ctx, filter)
+		if err != nil {
+			t.Fatalf("ListPayments: %v", err)
+		}
+		for _, payment := range page {
+			amounts = append(amounts, payment.Amount)
+		}
+		if len(page) < filter.Limit {
+			break
+		}
+
+		last := page[len(page)-1]
+		filter.After = &domain.PaymentCursor{Amount: last.Amount, CreatedAt: last.CreatedAt, QrPaymentID: last.QrPaymentID}
+	}
+
+	if !slices.Equal(amounts, []float64{100, 200, 300, 400, 500}) {
+		t.Errorf("paged amounts = %v", amounts)
+	}
+
+	minAmount, maxAmount := 200.0, 400.0
+	payments, err := s.ListPayments(ctx, domain.PaymentFilter{PaymentListRequest: domain.PaymentListRequest{
+		MinAmount: &minAmount,
+		MaxAmount: &maxAmount,
+		Statuses:  []string{domain.PaymentStatusCreated},
+		Limit:     10,
+	}})
+	if err != nil {
+		t.Fatalf("ListPayments: %v", err)
+	}
+	if len(payments) != 2 {
+		t.Errorf("ListPayments by amount and status = %d payments, want 2", len(payments))
+	}
+
+	// the search is case-insensitive and matches LIKE wildcards literally
+	for search, want := range map[string]int{"order-": 5, "n_50%": 1, "n%5": 0} {
+		payments, err = s.ListPayments(ctx, domain.PaymentFilter{PaymentListRequest: domain.PaymentListRequest{
+			Search: search,
+			Limit:  10,
+		}})
+		if err != nil {
+			t.Fatalf("ListPayments: %v", err)
+		}
+		if len(payments) != want {
+			t.Errorf("ListPayments searching %q = %d payments, want %d", search, len(payments), want)
+		}
+	}
+}
+
+func testRefundQRs(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	refund := domain.RefundQR{
+		QrReturnID:  1,
+		DeviceToken: "token-1",
+		ExternalID:  "return-1",
+		ExpireDate:  time.Now().Add(time.Hour),
+		Status:      domain.PaymentStatusCreated,
+	}
+
+	if err := s.SaveRefundQR(ctx, refund); err != nil {
+		t.Fatalf("SaveRefundQR: %v", err)
+	}
+
+	previous, err := s.UpdateRefundStatus(ctx, 1, domain.PaymentStatusWait)
+	if err != nil {
+		t.Fatalf("UpdateRefundStatus: %v", err)
+	}
+	if previous != domain.PaymentStatusCreated {
+		t.Errorf("previous status = %q, want %q", previous, domain.PaymentStatusCreated)
+	}
+
+	if _, err = s.UpdateRefundStatus(ctx, 2, domain.PaymentStatusWait); !errors.Is(err, storage.ErrRefundNotFound) {
+		t.Errorf("UpdateRefundStatus of an unknown ID: got %v, want ErrRefundNotFound", err)
+	}
+
+	stored, err := s.RefundQR(ctx, 1)
+	if err != nil {
+		t.Fatalf("RefundQR: %v", err)
+	}
+	if stored.Status != domain.PaymentStatusWait || stored.ExternalID != "return-1" {
+		t.Errorf("RefundQR = %+v", stored)
+	}
+
+	if _, err = s.RefundQR(ctx, 2); !errors.Is(err, storage.ErrRefundNotFound) {
+		t.Errorf("RefundQR of an unknown ID: got %v, want ErrRefundNotFound", err)
+	}
+
+	refunds, err := s.RefundQRsCreatedBetween(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RefundQRsCreatedBetween: %v", err)
+	}
+	if len(refunds) != 1 {
+		t.Errorf("RefundQRsCreatedBetween = %d refunds, want 1", len(refunds))
+	}
+}
+
+func testRefundLedger(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	payment := domain.Payment{
+		QrPaymentID: 1,
+		Kind:        domain.PaymentKindQR,
+		DeviceToken: "token-1",
+		Amount:      1000,
+		Status:      domain.PaymentStatusProcessed,
+	}
+	if err := s.SavePayment(ctx, payment); err != nil {
+		t.Fatalf("SavePayment: %v", err)
+	}
+
+	refund := domain.Refund{QrPaymentID: 1, Amount: 300, DeviceToken: "token-1"}
+
+	first, err := s.ReserveRefund(ctx, refund, func(balance domain.RefundBalance) error {
+		if balance.PaymentAmount != 1000 || balance.Succeeded != 0 || balance.Pending != 0 {
+			t.Errorf("first balance = %+v", balance)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReserveRefund: %v", err)
+	}
+	if first.ID == 0 || first.Status != domain.RefundStatusPending {
+		t.Errorf("reserved refund = %+v", first)
+	}
+
+	second, err := s.ReserveRefund(ctx, refund, func(balance domain.RefundBalance) error {
+		if balance.Pending != 300 {
+			t.Errorf("Pending = %v, want 300", balance.Pending)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReserveRefund: %v", err)
+	}
+
+	if err = s.CompleteRefund(ctx, first.ID, 77); err != nil {
+		t.Fatalf("CompleteRefund: %v", err)
+	}
+	if err = s.FailRefund(ctx, second.ID, "rejected"); err != nil {
+		t.Fatalf("FailRefund: %v", err)
+	}
+	if err = s.FailRefund(ctx, 1000, "rejected"); !errors.Is(err, storage.ErrRefundNotFound) {
+		t.Errorf("FailRefund of an unknown ID: got %v, want ErrRefundNotFound", err)
+	}
+
+	rejected := errors.New("balance exceeded")
+	_, err = s.ReserveRefund(ctx, refund, func(balance domain.RefundBalance) error {
+		if balance.Succeeded != 300 || balance.Pending != 0 {
+			t.Errorf("balance after completion = %+v", balance)
+		}
+		return rejected
+	})
+	if !errors.Is(err, rejected) {
+		t.Errorf("ReserveRefund rejected by check: got %v", err)
+	}
+
+	totals, err := s.RefundTotals(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RefundTotals: %v", err)
+	}
+	if len(totals) != 1 || totals[0].QrPaymentID != 1 || totals[0].Amount != 300 || totals[0].DeviceToken != "token-1" {
+		t.Errorf("RefundTotals = %+v", totals)
+	}
+}
+
+func testRefundSessions(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	deadline := time.Now().Add(time.Minute)
+	session := domain.RefundSession{
+		QrReturnID:  1,
+		DeviceToken: "token-1",
+		QrToken:     "qr-token",
+		ExpireDate:  time.Now().Add(time.Hour),
+		State:       domain.RefundSessionAwaitingScan,
+		Deadline:    &deadline,
+	}
+
+	if err := s.SaveRefundSession(ctx, session); err != nil {
+		t.Fatalf("SaveRefundSession: %v", err)
+	}
+
+	session.State = domain.RefundSessionAwaitingSelection
+	session.Operations = []domain.CustomerOperation{{QrPaymentID: 5}}
+	if err := s.UpdateRefundSession(ctx, session, domain.RefundSessionAwaitingScan); err != nil {
+		t.Fatalf("UpdateRefundSession: %v", err)
+	}
+
+	// the session has moved on, so a step still expecting the old state loses
+	if err := s.UpdateRefundSession(ctx, session, domain.RefundSessionAwaitingScan); !errors.Is(err, domain.ErrRefundSessionState) {
+		t.Errorf("UpdateRefundSession from a stale state: got %v, want ErrRefundSessionState", err)
+	}
+
+	unknown := session
+	unknown.QrReturnID = 2
+	if err := s.UpdateRefundSession(ctx, unknown, domain.RefundSessionAwaitingScan); !errors.Is(err, storage.ErrRefundSessionNotFound) {
+		t.Errorf("UpdateRefundSession of an unknown ID: got %v, want ErrRefundSessionNotFound", err)
+	}
+
+	stored, err := s.RefundSession(ctx, 1)
+	if err != nil {
+		t.Fatalf("RefundSession: %v", err)
+	}
+	if stored.State != domain.RefundSessionAwaitingSelection || len(stored.Operations) != 1 || stored.Operations[0].QrPaymentID != 5 {
+		t.Errorf("RefundSession = %+v", stored)
+	}
+	if stored.Deadline == nil || !sameInstant(*stored.Deadline, deadline) {
+		t.Errorf("Deadline = %v, want %v", stored.Deadline, deadline)
+	}
+
+	if _, err = s.RefundSession(ctx, 2); !errors.Is(err, storage.ErrRefundSessionNotFound) {
+		t.Errorf("RefundSession of an unknown ID: got %v, want ErrRefundSessionNotFound", err)
+	}
+
+	waiting, err := s.WaitingRefundSessions(ctx)
+	if err != nil {
+		t.Fatalf("WaitingRefundSessions: %v", err)
+	}
+	if len(waiting) != 1 {
+		t.Errorf("WaitingRefundSessions = %d sessions, want 1", len(waiting))
+	}
+}
+
+func testReconciliation(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	now := time.Now()
+	run, err := s.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   domain.ReconciliationTriggerManual,
+		From:      now.Add(-24 * time.Hour),
+		To:        now,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("CreateReconciliationRun: %v", err)
+	}
+	if run.ID == 0 {
+		t.Fatal("CreateReconciliationRun returned no ID")
+	}
+
+	discrepancy := domain.ReconciliationDiscrepancy{
+		RunID:       run.ID,
+		Kind:        domain.DiscrepancyStatusMismatch,
+		Entity:      domain.ReconciliationEntityPayment,
+		QrPaymentID: 1,
+		LocalValue:  domain.PaymentStatusWait,
+		RemoteValue: domain.PaymentStatusProcessed,
+		Message:     "status differs",
+		CreatedAt:   now,
+	}
+	if err = s.SaveReconciliationDiscrepancy(ctx, discrepancy); err != nil {
+		t.Fatalf("SaveReconciliationDiscrepancy: %v", err)
+	}
+
+	finishedAt := time.Now()
+	run.Status = domain.ReconciliationCompleted
+	run.PaymentsChecked = 1
+	run.Discrepancies = 1
+	run.FinishedAt = &finishedAt
+	if err = s.FinishReconciliationRun(ctx, *run); err != nil {
+		t.Fatalf("FinishReconciliationRun: %v", err)
+	}
+
+	stored, err := s.ReconciliationRun(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("ReconciliationRun: %v", err)
+	}
+	if stored.Status != domain.ReconciliationCompleted || stored.Discrepancies != 1 || stored.FinishedAt == nil {
+		t.Errorf("ReconciliationRun = %+v", stored)
+	}
+
+	discrepancies, err := s.ReconciliationDiscrepancies(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("ReconciliationDiscrepancies: %v", err)
+	}
+	if len(discrepancies) != 1 || discrepancies[0].RemoteValue != domain.PaymentStatusProcessed {
+		t.Errorf("ReconciliationDiscrepancies = %+v", discrepancies)
+	}
+
+	if _, err = s.ReconciliationRun(ctx, run.ID+100); !errors.Is(err, storage.ErrReconciliationRunNotFound) {
+		t.Errorf("ReconciliationRun of an unknown ID: got %v, want ErrReconciliationRunNotFound", err)
+	}
+
+	// runs of this instance fail on start, runs of another one only when their lease expired
+	own, err := s.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   domain.ReconciliationTriggerScheduled,
+		From:      now.Add(-24 * time.Hour),
+		To:        now,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: now,
+		Owner:     "instance-1",
+	})
+	if err != nil {
+		t.Fatalf("CreateReconciliationRun: %v", err)
+	}
+
+	_, err = s.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   domain.ReconciliationTriggerScheduled,
+		From:      now.Add(-24 * time.Hour),
+		To:        now,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: now,
+		Owner:     "instance-2",
+	})
+	if !errors.Is(err, storage.ErrReconciliationRunExists) {
+		t.Errorf("CreateReconciliationRun of a scheduled period: got %v, want ErrReconciliationRunExists", err)
+	}
+
+	active, err := s.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   domain.ReconciliationTriggerManual,
+		From:      now.Add(-24 * time.Hour),
+		To:        now,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: now.Add(-time.Hour),
+		Owner:     "instance-2",
+	})
+	if err != nil {
+		t.Fatalf("CreateReconciliationRun: %v", err)
+	}
+	if err = s.RenewReconciliationRun(ctx, active.ID, now); err != nil {
+		t.Fatalf("RenewReconciliationRun: %v", err)
+	}
+	if err = s.RenewReconciliationRun(ctx, active.ID+100, now); !errors.Is(err, storage.ErrReconciliationRunNotFound) {
+		t.Errorf("RenewReconciliationRun of an unknown ID: got %v, want ErrReconciliationRunNotFound", err)
+	}
+
+	stale, err := s.CreateReconciliationRun(ctx, domain.ReconciliationRun{
+		Trigger:   domain.ReconciliationTriggerManual,
+		From:      now.Add(-24 * time.Hour),
+		To:        now,
+		Status:    domain.ReconciliationRunning,
+		StartedAt: now.Add(-time.Hour),
+		Owner:     "instance-2",
+	})
+	if err != nil {
+		t.Fatalf("CreateReconciliationRun: %v", err)
+	}
+
+	interrupted, err := s.InterruptReconciliationRuns(ctx, "instance-1", now.Add(-5*time.Minute), "service stopped")
+	if err != nil {
+		t.Fatalf("InterruptReconciliationRuns: %v", err)
+	}
+	if interrupted != 2 {
+		t.Errorf("InterruptReconciliationRuns = %d, want 2", interrupted)
+	}
+
+	runs, err := s.ReconciliationRuns(ctx, 10)
+	if err != nil {
+		t.Fatalf("ReconciliationRuns: %v", err)
+	}
+	if len(runs) != 4 {
+		t.Fatalf("ReconciliationRuns = %+v", runs)
+	}
+	statuses := map[int64]string{}
+	for _, r := range runs {
+		statuses[r.ID] = r.Status
+	}
+	if statuses[own.ID] != domain.ReconciliationFailed || statuses[stale.ID] != domain.ReconciliationFailed ||
+		statuses[active.ID] != domain.ReconciliationRunning {
+		t.Errorf("ReconciliationRuns = %+v", runs)
+	}
+	if runs[0].ID != stale.ID || runs[0].Owner != "instance-2" || !sameInstant(runs[1].HeartbeatAt, now) {
+		t.Errorf("ReconciliationRuns = %+v", runs)
+	}
+}
+
+func testReports(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	if err := s.SaveDevice(ctx, "device-1", "token-1", 10); err != nil {
+		t.Fatalf("SaveDevice: %v", err)
+	}
+
+	for _, payment := range []domain.Payment{
+		{QrPaymentID: 1, Kind: domain.PaymentKindQR, ExternalID: "order-1", DeviceToken: "token-1", Amount: 1000, Status: domain.PaymentStatusCreated},
+		{QrPaymentID: 2, Kind: domain.PaymentKindQR, DeviceToken: "token-1", Amount: 500, Status: domain.PaymentStatusCreated},
+	} {
+		if err := s.SavePayment(ctx, payment); err != nil {
+			t.Fatalf("SavePayment: %v", err)
+		}
+	}
+
+	// only processed payments are reported
+	if _, err := s.UpdatePaymentStatus(ctx, 1, domain.PaymentStatusResponse{Status: domain.PaymentStatusProcessed, TransactionID: "txn-1", ProductType: "Gold"}); err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+
+	refund, err := s.ReserveRefund(ctx, domain.Refund{QrPaymentID: 1, Amount: 200, DeviceToken: "token-1"},
+		func(domain.RefundBalance) error { return nil })
+	if err != nil {
+		t.Fatalf("ReserveRefund: %v", err)
+	}
+	if err = s.CompleteRefund(ctx, refund.ID, 77); err != nil {
+		t.Fatalf("CompleteRefund: %v", err)
+	}
+
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	entries, err := s.SettlementEntries(ctx, from, to, 0, "")
+	if err != nil {
+		t.Fatalf("SettlementEntries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("SettlementEntries = %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.QrPaymentID != 1 || entry.DeviceID != "device-1" || entry.TradePointID != 10 || entry.ProductType != "Gold" {
+			t.Errorf("settlement entry = %+v", entry)
+		}
+	}
+	if entries[0].Kind != domain.SettlementSale || entries[0].Amount != 1000 || entries[1].Kind != domain.SettlementRefund || entries[1].Amount != 200 {
+		t.Errorf("SettlementEntries = %+v", entries)
+	}
+
+	entries, err = s.SettlementEntries(ctx, from, to, 11, "")
+	if err != nil {
+		t.Fatalf("SettlementEntries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("SettlementEntries of another trade point = %+v", entries)
+	}
+
+	documents, err := s.AccountingDocuments(ctx, from, to, 10)
+	if err != nil {
+		t.Fatalf("AccountingDocuments: %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("AccountingDocuments = %+v", documents)
+	}
+	if documents[0].Kind != domain.SettlementSale || documents[0].ExternalID != "order-1" || documents[0].TransactionID != "txn-1" {
+		t.Errorf("sale document = %+v", documents[0])
+	}
+	if documents[1].Kind != domain.SettlementRefund || documents[1].RefundID != refund.ID || documents[1].ExternalID != "order-1" {
+		t.Errorf("refund document = %+v", documents[1])
+	}
+}
+
+func testWebhooks(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	event := domain.WebhookEvent{
+		ID:         "event-1",
+		Type:       domain.EventPaymentStatusChanged,
+		SubjectID:  1,
+		Status:     domain.PaymentStatusProcessed,
+		OccurredAt: time.Now(),
+	}
+
+	if err := s.SaveWebhookEvent(ctx, event, []string{"http://a.example", "http://b.example"}); err != nil {
+		t.Fatalf("SaveWebhookEvent: %v", err)
+	}
+
+	now := time.Now().Add(time.Second)
+	deliveries, err := s.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("ClaimWebhookDeliveries = %d deliveries, want 2", len(deliveries))
+	}
+	for _, delivery := range deliveries {
+		if delivery.Event.ID != "event-1" || delivery.Event.Status != domain.PaymentStatusProcessed || delivery.Attempts != 0 {
+			t.Errorf("claimed delivery = %+v", delivery)
+		}
+	}
+
+	// leased deliveries are not claimed again
+	leased, err := s.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+	if len(leased) != 0 {
+		t.Errorf("ClaimWebhookDeliveries of leased deliveries = %d, want 0", len(leased))
+	}
+
+	if err = s.MarkWebhookDelivered(ctx, deliveries[0].ID); err != nil {
+		t.Fatalf("MarkWebhookDelivered: %v", err)
+	}
+	if err = s.MarkWebhookFailed(ctx, deliveries[1].ID, now, false, "timeout"); err != nil {
+		t.Fatalf("MarkWebhookFailed: %v", err)
+	}
+
+	retried, err := s.ClaimWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+	if len(retried) != 1 || retried[0].ID != deliveries[1].ID || retried[0].Attempts != 1 {
+		t.Errorf("retried deliveries = %+v", retried)
+	}
+
+	if err = s.MarkWebhookFailed(ctx, deliveries[1].ID, now, true, "gone"); err != nil {
+		t.Fatalf("MarkWebhookFailed: %v", err)
+	}
+
+	replayed, err := s.ReplayWebhookDeliveries(ctx, domain.WebhookReplayFilter{EventID: "event-1"})
+	if err != nil {
+		t.Fatalf("ReplayWebhookDeliveries: %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("ReplayWebhookDeliveries = %d, want 2", replayed)
+	}
+
+	if _, err = s.ReplayWebhookDeliveries(ctx, domain.WebhookReplayFilter{EventID: "unknown"}); !errors.Is(err, storage.ErrWebhookEventNotFound) {
+		t.Errorf("ReplayWebhookDeliveries of an unknown event: got %v, want ErrWebhookEventNotFound", err)
+	}
+
+	replayed, err = s.ReplayWebhookDeliveries(ctx, domain.WebhookReplayFilter{Since: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("ReplayWebhookDeliveries: %v", err)
+	}
+	if replayed != 0 {
+		t.Errorf("ReplayWebhookDeliveries of later events = %d, want 0", replayed)
+	}
+
+	deliveries, err = s.ClaimWebhookDeliveries(ctx, time.Now().Add(time.Second), now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimWebhookDeliveries: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Errorf("ClaimWebhookDeliveries after replay = %d deliveries, want 2", len(deliveries))
+	}
+}
+
+func testIdempotency(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	expiredBefore, staleBefore := time.Now().Add(-24*time.Hour), time.Now().Add(-time.Minute)
+
+	record, acquired, err := s.AcquireIdempotencyKey(ctx, "key-1", "fingerprint-1", expiredBefore, staleBefore)
+	if err != nil {
+		t.Fatalf("AcquireIdempotencyKey: %v", err)
+	}
+	if !acquired || record.Status != domain.IdempotencyStatusInProgress {
+		t.Errorf("AcquireIdempotencyKey = %+v, %v", record, acquired)
+	}
+
+	record, acquired, err = s.AcquireIdempotencyKey(ctx, "key-1", "fingerprint-2", expiredBefore, staleBefore)
+	if err != nil {
+		t.Fatalf("AcquireIdempotencyKey: %v", err)
+	}
+	if acquired || record.Fingerprint != "fingerprint-1" {
+		t.Errorf("AcquireIdempotencyKey of a taken key = %+v, %v", record, acquired)
+	}
+
+	if err = s.CompleteIdempotencyKey(ctx, "key-1", 200, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+
+	// completed keys are kept, only in-progress ones are released
+	if err = s.ReleaseIdempotencyKey(ctx, "key-1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+
+	record, err = s.IdempotencyRecord(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("IdempotencyRecord: %v", err)
+	}
+	if record.Status != domain.IdempotencyStatusCompleted || record.ResponseStatus != 200 || string(record.ResponseBody) != `{"ok":true}` {
+		t.Errorf("IdempotencyRecord = %+v", record)
+	}
+
+	// an expired key is taken over by a new request
+	_, acquired, err = s.AcquireIdempotencyKey(ctx, "key-1", "fingerprint-2", time.Now().Add(time.Minute), staleBefore)
+	if err != nil {
+		t.Fatalf("AcquireIdempotencyKey: %v", err)
+	}
+	if !acquired {
+		t.Error("AcquireIdempotencyKey of an expired key was not acquired")
+	}
+
+	if err = s.ReleaseIdempotencyKey(ctx, "key-1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	if _, err = s.IdempotencyRecord(ctx, "key-1"); !errors.Is(err, storage.ErrIdempotencyKeyNotFound) {
+		t.Errorf("IdempotencyRecord of a released key: got %v, want ErrIdempotencyKeyNotFound", err)
+	}
+}
+
+// sameInstant compares times that went through a database, which may round them to microseconds
+func sameInstant(got, want time.Time) bool {
+	diff := got.Sub(want)
+	return diff > -time.Millisecond && diff < time.Millisecond
+}
@@ -0,0 +1,266 @@
+package tokencrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size of master keys in bytes (AES-256)
+const KeySize = 32
+
+// prefix marks sealed tokens, anything without it is a plaintext token
+const prefix = "enc:v1:"
+
+var (
+	ErrUnknownKey = errors.New("token is sealed with an unknown master key")
+	ErrMalformed  = errors.New("malformed sealed token")
+)
+
+// tokenData is the additional data of every token encryption, it ties ciphertexts to this use
+var tokenData = []byte("kaspi-api-wrapper device token")
+
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// Cipher protects device tokens at rest. Every token is encrypted with its own random data key using AES-GCM,
+// and the data key is stored next to it encrypted with the master key (envelope encryption). Sealed tokens
+// carry the ID of their master key, so tokens sealed with a previous key can still be opened while they
+// are rotated to the current one
+type Cipher struct {
+	current  masterKey
+	keys     map[string]masterKey
+	indexKey []byte
+}
+
+// New creates a Cipher that seals with current and opens tokens sealed with current or any of previous
+func New(current []byte, previous ...[]byte) (*Cipher, error) {
+	const op = "tokencrypt.New"
+
+	key, err := newMasterKey(current)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := &Cipher{
+		current:  key,
+		keys:     map[string]masterKey{key.id: key},
+		indexKey: deriveIndexKey(current),
+	}
+
+	for _, raw := range previous {
+		key, err := newMasterKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: previous key: %w", op, err)
+		}
+		if _, ok := c.keys[key.id]; !ok {
+			c.keys[key.id] = key
+		}
+	}
+
+	return c, nil
+}
+
+// ParseKey decodes a base64 encoded master key
+func ParseKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		if key, err = base64.RawURLEncoding.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("master key is not base64: %w", err)
+		}
+	}
+
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	return key, nil
+}
+
+// LoadKey returns the master key from value, or from the file when value is empty.
+// It returns nil when neither is set
+func LoadKey(value, file string) ([]byte, error) {
+	if value == "" && file == "" {
+		return nil, nil
+	}
+
+	if value == "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read master key file: %w", err)
+		}
+		value = string(data)
+	}
+
+	return ParseKey(value)
+}
+
+// IsSealed reports whether the stored value is a sealed token and not a plaintext one
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Seal encrypts the token with a new data key
+func (c *Cipher) Seal(token string) (string, error) {
+	const op = "tokencrypt.Seal"
+
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	wrappedKey, err := seal(c.current.aead, dataKey, []byte(c.current.id))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	ciphertext, err := seal(aead, []byte(token), tokenData)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return prefix + c.current.id + ":" +
+		base64.RawURLEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a sealed token
+func (c *Cipher) Open(sealed string) (string, error) {
+	const op = "tokencrypt.Open"
+
+	keyID, wrappedKey, ciphertext, err := split(sealed)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	key, ok := c.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%s: %s: %w", op, keyID, ErrUnknownKey)
+	}
+
+	dataKey, err := open(key.aead, wrappedKey, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("%s: data key: %w", op, err)
+	}
+
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := open(aead, ciphertext, tokenData)
+	if err != nil {
+		return "", fmt.Errorf("%s: token: %w", op, err)
+	}
+
+	return string(token), nil
+}
+
+// Current reports whether the value is sealed with the current master key
+func (c *Cipher) Current(value string) bool {
+	keyID, _, _, err := split(value)
+	return err == nil && keyID == c.current.id
+}
+
+// Index returns the deterministic lookup index of the token, a hex HMAC-SHA256 keyed by the current master key
+func (c *Cipher) Index(token string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PlainIndex is the index of tokens stored without encryption, it matches the SHA-256 backfill of the migration
+func PlainIndex(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newMasterKey(raw []byte) (masterKey, error) {
+	if len(raw) != KeySize {
+		return masterKey{}, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(raw))
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return masterKey{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return masterKey{}, err
+	}
+
+	// the ID identifies the key without revealing it
+	sum := sha256.Sum256(append([]byte("kaspi-api-wrapper key id"), raw...))
+
+	return masterKey{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+// deriveIndexKey separates the HMAC key from the encryption key, so the index reveals nothing about it
+func deriveIndexKey(master []byte) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte("kaspi-api-wrapper device token index"))
+	return mac.Sum(nil)
+}
+
+// seal encrypts with a random nonce and prepends it to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], additionalData)
+}
+
+func split(sealed string) (keyID string, wrappedKey, ciphertext []byte, err error) {
+	if !IsSealed(sealed) {
+		return "", nil, nil, ErrMalformed
+	}
+
+	parts := strings.Split(strings.TrimPrefix(sealed, prefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, ErrMalformed
+	}
+
+	if wrappedKey, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+	if ciphertext, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+
+	return parts[0], wrappedKey, ciphertext, nil
+}
@@ -0,0 +1,143 @@
+package tokencrypt_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"kaspi-api-wrapper/internal/tokencrypt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, tokencrypt.KeySize)
+}
+
+func TestSealOpen(t *testing.T) {
+	c, err := tokencrypt.New(testKey(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	first, err := c.Seal("2be4cc91-5895-48f8-8bc2-86c7bd419b3b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := c.Seal("2be4cc91-5895-48f8-8bc2-86c7bd419b3b")
+
+	if first == second {
+		t.Error("Expected different ciphertexts for the same token")
+	}
+
+	if strings.Contains(first, "2be4cc91") || !tokencrypt.IsSealed(first) || !c.Current(first) {
+		t.Errorf("Unexpected sealed token %s", first)
+	}
+
+	token, err := c.Open(first)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if token != "2be4cc91-5895-48f8-8bc2-86c7bd419b3b" {
+		t.Errorf("Expected original token, got %s", token)
+	}
+}
+
+func TestOpenRejectsTamperedToken(t *testing.T) {
+	c, _ := tokencrypt.New(testKey(1))
+
+	sealed, _ := c.Seal("test-token")
+
+	// flip a character of the token ciphertext
+	tampered := []byte(sealed)
+	last := len(tampered) - 2
+	if tampered[last] == 'A' {
+		tampered[last] = 'B'
+	} else {
+		tampered[last] = 'A'
+	}
+
+	if _, err := c.Open(string(tampered)); err == nil {
+		t.Error("Expected error for tampered token")
+	}
+
+	if _, err := c.Open("test-token"); !errors.Is(err, tokencrypt.ErrMalformed) {
+		t.Errorf("Expected ErrMalformed for plaintext, got %v", err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	oldCipher, _ := tokencrypt.New(testKey(1))
+	sealed, _ := oldCipher.Seal("test-token")
+
+	newCipher, err := tokencrypt.New(testKey(2))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err = newCipher.Open(sealed); !errors.Is(err, tokencrypt.ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey without previous key, got %v", err)
+	}
+
+	rotating, _ := tokencrypt.New(testKey(2), testKey(1))
+
+	if rotating.Current(sealed) {
+		t.Error("Token sealed with the previous key must not be current")
+	}
+
+	token, err := rotating.Open(sealed)
+	if err != nil || token != "test-token" {
+		t.Fatalf("Expected test-token, got %q and %v", token, err)
+	}
+
+	resealed, _ := rotating.Seal(token)
+	if !newCipher.Current(resealed) {
+		t.Error("Expected token sealed with the current key")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	c, _ := tokencrypt.New(testKey(1))
+	other, _ := tokencrypt.New(testKey(2))
+
+	if c.Index("test-token") != c.Index("test-token") {
+		t.Error("Expected deterministic index")
+	}
+
+	if c.Index("test-token") == c.Index("other-token") {
+		t.Error("Expected different index for different tokens")
+	}
+
+	if c.Index("test-token") == other.Index("test-token") || c.Index("test-token") == tokencrypt.PlainIndex("test-token") {
+		t.Error("Expected index keyed by the master key")
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testKey(3))
+
+	key, err := tokencrypt.LoadKey(encoded, "")
+	if err != nil || !bytes.Equal(key, testKey(3)) {
+		t.Errorf("Expected key from value, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "device-token.key")
+	if err = os.WriteFile(path, []byte(encoded+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	key, err = tokencrypt.LoadKey("", path)
+	if err != nil || !bytes.Equal(key, testKey(3)) {
+		t.Errorf("Expected key from file, got %v", err)
+	}
+
+	if key, err = tokencrypt.LoadKey("", ""); key != nil || err != nil {
+		t.Errorf("Expected no key, got %v and %v", key, err)
+	}
+
+	if _, err = tokencrypt.LoadKey(base64.StdEncoding.EncodeToString([]byte("short")), ""); err == nil {
+		t.Error("Expected error for short key")
+	}
+}
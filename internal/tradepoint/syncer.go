@@ -0,0 +1,150 @@
+package tradepoint
+
+import (
+	"context"
+	"kaspi-api-wrapper/internal/domain"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Refresher syncs the cached trade points with Kaspi
+type Refresher interface {
+	RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error)
+	RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error)
+}
+
+// Organizations lists the organizations whose trade points are synced in the enhanced scheme
+type Organizations interface {
+	ListOrganizations(ctx context.Context) ([]domain.Organization, error)
+}
+
+// Config holds the scheme the trade points are synced for and how often
+type Config struct {
+	Enhanced bool
+	Interval time.Duration
+}
+
+// Syncer periodically refreshes the trade point cache, so that the trade points are served from the
+// database and new trade points are known before a device is registered to them
+type Syncer struct {
+	log           *slog.Logger
+	refresher     Refresher
+	organizations Organizations
+	cfg           Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+func New(log *slog.Logger, refresher Refresher, organizations Organizations, cfg Config) *Syncer {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Syncer{
+		log:           log,
+		refresher:     refresher,
+		organizations: organizations,
+		cfg:           cfg,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start runs the sync loop in the background
+func (s *Syncer) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		s.run()
+	}()
+}
+
+// Stop stops the sync loop and waits for the running sync to finish
+func (s *Syncer) Stop() {
+	s.log.Info("stopping trade point syncer", slog.String("op", "tradepoint.Stop"))
+
+	// cancel under the lock so that Start never adds to the wait group after Wait started
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Syncer) run() {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sync(s.ctx)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync refreshes the trade points of the basic and standard schemes, or of every enabled organization
+// in the enhanced scheme, and returns how many trade points are cached
+func (s *Syncer) Sync(ctx context.Context) int {
+	const op = "tradepoint.Sync"
+
+	log := s.log.With(slog.String("op", op))
+
+	if !s.cfg.Enhanced {
+		tradePoints, err := s.refresher.RefreshTradePoints(ctx)
+		if err != nil {
+			log.Warn("failed to sync trade points", "error", err.Error())
+			return 0
+		}
+
+		log.Debug("trade points synced", "tradePoints", len(tradePoints))
+		return len(tradePoints)
+	}
+
+	organizations, err := s.organizations.ListOrganizations(ctx)
+	if err != nil {
+		log.Error("failed to load organizations", "error", err.Error())
+		return 0
+	}
+
+	synced := 0
+	for _, organization := range organizations {
+		if ctx.Err() != nil {
+			break
+		}
+		if !organization.Enabled {
+			continue
+		}
+
+		tradePoints, err := s.refresher.RefreshTradePointsEnhanced(ctx, organization.OrganizationBin)
+		if err != nil {
+			log.Warn("failed to sync trade points of organization",
+				"organizationBin", organization.OrganizationBin,
+				"error", err.Error(),
+			)
+			continue
+		}
+		synced += len(tradePoints)
+	}
+
+	log.Debug("trade points synced", "tradePoints", synced)
+
+	return synced
+}
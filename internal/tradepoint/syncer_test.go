@@ -0,0 +1,116 @@
+package tradepoint_test
+
+import (
+	"context"
+	"errors"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/tradepoint"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"sync"
+	"testing"
+	"time"
+)
+
+type MockRefresher struct {
+	mu        sync.Mutex
+	refreshed []string
+	fail      map[string]bool
+}
+
+func (m *MockRefresher) RefreshTradePoints(ctx context.Context) ([]domain.TradePoint, error) {
+	return m.refresh("")
+}
+
+func (m *MockRefresher) RefreshTradePointsEnhanced(ctx context.Context, organizationBin string) ([]domain.TradePoint, error) {
+	return m.refresh(organizationBin)
+}
+
+func (m *MockRefresher) refresh(organizationBin string) ([]domain.TradePoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fail[organizationBin] {
+		return nil, errors.New("kaspi is unavailable")
+	}
+	m.refreshed = append(m.refreshed, organizationBin)
+
+	return []domain.TradePoint{{TradePointID: 1}, {TradePointID: 2}}, nil
+}
+
+func (m *MockRefresher) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.refreshed)
+}
+
+type MockOrganizations struct {
+	ListOrganizationsFunc func(ctx context.Context) ([]domain.Organization, error)
+}
+
+func (m *MockOrganizations) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	return m.ListOrganizationsFunc(ctx)
+}
+
+func TestSyncer(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+
+	t.Run("syncs trade points of the partner", func(t *testing.T) {
+		refresher := &MockRefresher{}
+
+		s := tradepoint.New(log, refresher, nil, tradepoint.Config{})
+
+		if synced := s.Sync(context.Background()); synced != 2 {
+			t.Errorf("Expected 2 synced trade points, got %d", synced)
+		}
+		if len(refresher.refreshed) != 1 || refresher.refreshed[0] != "" {
+			t.Errorf("Unexpected refreshed organizations: %v", refresher.refreshed)
+		}
+	})
+
+	t.Run("syncs enabled organizations in the enhanced scheme", func(t *testing.T) {
+		refresher := &MockRefresher{fail: map[string]bool{"123456789012": true}}
+		organizations := &MockOrganizations{
+			ListOrganizationsFunc: func(ctx context.Context) ([]domain.Organization, error) {
+				return []domain.Organization{
+					{OrganizationBin: "123456789012", Enabled: true},
+					{OrganizationBin: "180340021791", Enabled: true},
+					{OrganizationBin: "210987654321", Enabled: false},
+				}, nil
+			},
+		}
+
+		s := tradepoint.New(log, refresher, organizations, tradepoint.Config{Enhanced: true})
+
+		if synced := s.Sync(context.Background()); synced != 2 {
+			t.Errorf("Expected 2 synced trade points, got %d", synced)
+		}
+		if len(refresher.refreshed) != 1 || refresher.refreshed[0] != "180340021791" {
+			t.Errorf("Unexpected refreshed organizations: %v", refresher.refreshed)
+		}
+	})
+
+	t.Run("syncs periodically until stopped", func(t *testing.T) {
+		refresher := &MockRefresher{}
+
+		s := tradepoint.New(log, refresher, nil, tradepoint.Config{Interval: time.Millisecond})
+		s.Start()
+
+		deadline := time.Now().Add(2 * time.Second)
+		for refresher.count() < 3 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		s.Stop()
+
+		if refresher.count() < 3 {
+			t.Fatalf("Expected at least 3 syncs, got %d", refresher.count())
+		}
+
+		stopped := refresher.count()
+		time.Sleep(20 * time.Millisecond)
+		if refresher.count() != stopped {
+			t.Error("Expected no syncs after stop")
+		}
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"kaspi-api-wrapper/internal/domain"
+	"strings"
+	"time"
 )
 
 // Validation errors
@@ -119,6 +121,83 @@ func ValidateEnhancedDeviceDeleteRequest(req domain.EnhancedDeviceDeleteRequest)
 	return nil
 }
 
+// ValidateOrganizationRequest validates the registration or update of an organization
+func ValidateOrganizationRequest(req domain.OrganizationRequest) error {
+	if err := ValidateOrganizationBin(req.OrganizationBin); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return &ValidationError{
+			Field:   "name",
+			Message: "organization name is required",
+			Err:     ErrRequiredField,
+		}
+	}
+
+	return nil
+}
+
+// ValidateOrganizationBin validates a BIN, a business identification number of 12 digits
+func ValidateOrganizationBin(organizationBin string) error {
+	if organizationBin == "" {
+		return &ValidationError{
+			Field:   "organizationBin",
+			Message: "organization BIN is required",
+			Err:     ErrRequiredField,
+		}
+	}
+
+	if len(organizationBin) != 12 || strings.Trim(organizationBin, "0123456789") != "" {
+		return &ValidationError{
+			Field:   "organizationBin",
+			Message: "organization BIN must be 12 digits",
+			Err:     ErrInvalidOrgBin,
+		}
+	}
+
+	return nil
+}
+
+// ValidateTradePointUpdateRequest validates the metadata of a cached trade point
+func ValidateTradePointUpdateRequest(req domain.TradePointUpdateRequest) error {
+	if req.TradePointID <= 0 {
+		return &ValidationError{
+			Field:   "tradePointId",
+			Message: "trade point ID must be a positive number",
+			Err:     ErrInvalidID,
+		}
+	}
+
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return &ValidationError{
+				Field:   "timezone",
+				Message: "timezone must be an IANA time zone name, e.g. Asia/Almaty",
+				Err:     ErrInvalidValue,
+			}
+		}
+	}
+
+	if req.MinAmount < 0 || req.MaxAmount < 0 {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount limits must not be negative",
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	if req.MaxAmount > 0 && req.MinAmount > req.MaxAmount {
+		return &ValidationError{
+			Field:   "minAmount",
+			Message: "minimum amount must not exceed the maximum amount",
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	return nil
+}
+
 // Payment Validation Functions
 
 // ValidateQRCreateRequest validates a QR creation request
@@ -283,6 +362,44 @@ func ValidateRefundRequest(req domain.RefundRequest) error {
 	return nil
 }
 
+// ValidateRefundSessionRefundRequest validates the operation chosen in a refund session
+func ValidateRefundSessionRefundRequest(session domain.RefundSession, req domain.RefundSessionRefundRequest) error {
+	if req.QrPaymentID <= 0 {
+		return &ValidationError{
+			Field:   "qrPaymentId",
+			Message: "QR payment ID must be a positive number",
+			Err:     ErrInvalidID,
+		}
+	}
+
+	operation, ok := session.Operation(req.QrPaymentID)
+	if !ok {
+		return &ValidationError{
+			Field:   "qrPaymentId",
+			Message: "payment is not among the customer operations of the session",
+			Err:     ErrInvalidID,
+		}
+	}
+
+	if req.Amount <= 0 {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be greater than zero",
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	if req.Amount-operation.Amount >= 0.005 {
+		return &ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("amount must not exceed the operation amount %.2f", operation.Amount),
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	return nil
+}
+
 // ValidateEnhancedRefundRequest validates an enhanced refund request
 func ValidateEnhancedRefundRequest(req domain.EnhancedRefundRequest) error {
 	if err := ValidateDeviceToken(req.DeviceToken); err != nil {
@@ -456,3 +573,256 @@ func ValidateTestConfirmErrorRequest(req domain.TestConfirmErrorRequest) error {
 
 	return nil
 }
+
+// ValidateWebhookReplayFilter validates a webhook replay request
+func ValidateWebhookReplayFilter(filter domain.WebhookReplayFilter) error {
+	if filter.EventID == "" && filter.Since.IsZero() {
+		return &ValidationError{
+			Field:   "eventId",
+			Message: "either event ID or start of the period is required",
+			Err:     ErrRequiredField,
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return &ValidationError{
+			Field:   "until",
+			Message: "end of the period must be after its start",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	return nil
+}
+
+// ValidateExternalID validates an ExternalId used for payment lookup
+func ValidateExternalID(externalID string) error {
+	if strings.TrimSpace(externalID) == "" {
+		return &ValidationError{
+			Field:   "externalId",
+			Message: "external ID is required",
+			Err:     ErrRequiredField,
+		}
+	}
+
+	return nil
+}
+
+// ValidateQRImageOptions validates QR image options after defaults are applied
+func ValidateQRImageOptions(opts domain.QRImageOptions, maxSize int) error {
+	if opts.Format != domain.QRImageFormatPNG && opts.Format != domain.QRImageFormatSVG {
+		return &ValidationError{
+			Field:   "format",
+			Message: "format must be png or svg",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if opts.Size <= 0 || opts.Size > maxSize {
+		return &ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("size must be between 1 and %d pixels", maxSize),
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if opts.Margin != nil && (*opts.Margin < 0 || *opts.Margin > 16) {
+		return &ValidationError{
+			Field:   "margin",
+			Message: "margin must be between 0 and 16 modules",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	switch opts.ErrorCorrection {
+	case domain.QRErrorCorrectionLow, domain.QRErrorCorrectionMedium,
+		domain.QRErrorCorrectionQuarter, domain.QRErrorCorrectionHigh:
+	default:
+		return &ValidationError{
+			Field:   "errorCorrection",
+			Message: "error correction level must be L, M, Q or H",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	return nil
+}
+
+// ValidateReconciliationRequest validates the period of a reconciliation run after defaults are applied
+func ValidateReconciliationRequest(req domain.ReconciliationRequest, maxPeriod time.Duration) error {
+	if req.From.IsZero() || req.To.IsZero() {
+		return &ValidationError{
+			Field:   "from",
+			Message: "both start and end of the period are required",
+			Err:     ErrRequiredField,
+		}
+	}
+
+	if !req.To.After(req.From) {
+		return &ValidationError{
+			Field:   "to",
+			Message: "end of the period must be after its start",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if req.To.Sub(req.From) > maxPeriod {
+		return &ValidationError{
+			Field:   "to",
+			Message: fmt.Sprintf("period must not be longer than %s", maxPeriod),
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	return nil
+}
+
+// ValidatePaymentListRequest validates the filters and the page size of a payment search
+func ValidatePaymentListRequest(req domain.PaymentListRequest, maxLimit int) error {
+	for _, status := range req.Statuses {
+		switch status {
+		case domain.PaymentStatusCreated, domain.PaymentStatusWait, domain.PaymentStatusProcessed,
+			domain.PaymentStatusError, domain.PaymentStatusExpired, domain.PaymentStatusCanceled:
+		default:
+			return &ValidationError{
+				Field:   "status",
+				Message: fmt.Sprintf("unknown payment status %q", status),
+				Err:     ErrInvalidValue,
+			}
+		}
+	}
+
+	if (req.MinAmount != nil && *req.MinAmount < 0) || (req.MaxAmount != nil && *req.MaxAmount < 0) {
+		return &ValidationError{
+			Field:   "minAmount",
+			Message: "amount range must not be negative",
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MaxAmount < *req.MinAmount {
+		return &ValidationError{
+			Field:   "maxAmount",
+			Message: "maximum amount must not be less than the minimum",
+			Err:     ErrInvalidAmount,
+		}
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && !req.To.After(req.From) {
+		return &ValidationError{
+			Field:   "to",
+			Message: "end of the period must be after its start",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if req.Search != "" && len([]rune(strings.TrimSpace(req.Search))) < 3 {
+		return &ValidationError{
+			Field:   "search",
+			Message: "search must contain at least 3 characters",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if req.SortBy != domain.PaymentSortCreatedAt && req.SortBy != domain.PaymentSortAmount {
+		return &ValidationError{
+			Field:   "sortBy",
+			Message: "sort field must be CreatedAt or Amount",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if req.SortOrder != domain.SortOrderAsc && req.SortOrder != domain.SortOrderDesc {
+		return &ValidationError{
+			Field:   "sortOrder",
+			Message: "sort order must be asc or desc",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	if req.Limit <= 0 || req.Limit > maxLimit {
+		return &ValidationError{
+			Field:   "limit",
+			Message: fmt.Sprintf("limit must be between 1 and %d", maxLimit),
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	return nil
+}
+
+// ValidateSettlementReportRequest checks the dates of a report and returns them parsed in the location
+func ValidateSettlementReportRequest(req domain.SettlementReportRequest, location *time.Location, maxDays int) (time.Time, time.Time, error) {
+	return ValidateDateRange(req.DateFrom, req.DateTo, location, maxDays)
+}
+
+// ValidateDateRange parses an inclusive YYYY-MM-DD period in the location, the end date defaults to the start date
+func ValidateDateRange(dateFrom, dateTo string, location *time.Location, maxDays int) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(time.DateOnly, dateFrom, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, &ValidationError{
+			Field:   "dateFrom",
+			Message: "start date is required as YYYY-MM-DD",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	to := from
+	if dateTo != "" {
+		to, err = time.ParseInLocation(time.DateOnly, dateTo, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, &ValidationError{
+				Field:   "dateTo",
+				Message: "end date must be YYYY-MM-DD",
+				Err:     ErrInvalidValue,
+			}
+		}
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, &ValidationError{
+			Field:   "dateTo",
+			Message: "end date must not be before the start date",
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	// rounded to whole days, a DST change makes a day 23 or 25 hours long
+	if days := int((to.Sub(from)+12*time.Hour)/(24*time.Hour)) + 1; days > maxDays {
+		return time.Time{}, time.Time{}, &ValidationError{
+			Field:   "dateTo",
+			Message: fmt.Sprintf("period must not cover more than %d days", maxDays),
+			Err:     ErrInvalidValue,
+		}
+	}
+
+	return from, to, nil
+}
+
+// ValidateReportFormat validates the format of a settlement report
+func ValidateReportFormat(format string) error {
+	switch format {
+	case domain.ReportFormatJSON, domain.ReportFormatCSV, domain.ReportFormatXLSX:
+		return nil
+	default:
+		return &ValidationError{
+			Field:   "format",
+			Message: "format must be json, csv or xlsx",
+			Err:     ErrInvalidValue,
+		}
+	}
+}
+
+// ValidateOneCExportFormat validates the format of a 1C export
+func ValidateOneCExportFormat(format string) error {
+	switch format {
+	case domain.OneCFormatCommerceML, domain.OneCFormatStatement:
+		return nil
+	default:
+		return &ValidationError{
+			Field:   "format",
+			Message: "format must be commerceml or statement",
+			Err:     ErrInvalidValue,
+		}
+	}
+}
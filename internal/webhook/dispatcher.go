@@ -0,0 +1,326 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/validator"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook request
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEventID   = "X-Webhook-Event-Id"
+	HeaderEventType = "X-Webhook-Event-Type"
+)
+
+type Storage interface {
+	SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error
+	ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryID int64) error
+	MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error
+	ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error)
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Config describes where events are sent and how failed deliveries are retried
+type Config struct {
+	URLs         []string
+	Secret       string
+	MaxAttempts  int           // attempts before a delivery is moved to dead letters
+	BaseDelay    time.Duration // delay before the first retry
+	MaxDelay     time.Duration // upper bound for a single delay
+	PollInterval time.Duration // how often the queue is checked for due deliveries
+	Timeout      time.Duration // timeout of a single delivery request
+	BatchSize    int
+}
+
+// Dispatcher persists status change events and delivers them to merchant URLs
+type Dispatcher struct {
+	log        *slog.Logger
+	storage    Storage
+	httpClient HTTPClient
+	cfg        Config
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, storage Storage, cfg Config) *Dispatcher {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+
+	return &Dispatcher{
+		log:        log,
+		storage:    storage,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		cfg:        cfg,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// SetHTTPClient sets a custom HTTP client (mostly for testing)
+func (d *Dispatcher) SetHTTPClient(client HTTPClient) {
+	d.httpClient = client
+}
+
+// Publish persists the event for every configured URL and wakes up the delivery loop
+func (d *Dispatcher) Publish(ctx context.Context, event domain.WebhookEvent) error {
+	const op = "webhook.Publish"
+
+	if event.ID == "" {
+		id, err := newEventID()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		event.ID = id
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	if err := d.storage.SaveWebhookEvent(ctx, event, d.cfg.URLs); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	d.log.Debug("webhook event queued",
+		slog.String("op", op),
+		slog.String("eventID", event.ID),
+		slog.String("type", event.Type),
+	)
+
+	d.notify()
+
+	return nil
+}
+
+// ReplayWebhooks requeues deliveries of matching events, e.g. after a receiver outage
+func (d *Dispatcher) ReplayWebhooks(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	const op = "webhook.ReplayWebhooks"
+
+	if err := validator.ValidateWebhookReplayFilter(filter); err != nil {
+		return 0, err
+	}
+
+	replayed, err := d.storage.ReplayWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	d.log.Info("webhook deliveries requeued",
+		slog.String("op", op),
+		slog.Int64("count", replayed),
+	)
+
+	d.notify()
+
+	return replayed, nil
+}
+
+// Start runs the delivery loop in the background
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+// Stop stops the delivery loop and waits for the current batch to finish
+func (d *Dispatcher) Stop() {
+	d.log.Info("stopping webhook dispatcher", slog.String("op", "webhook.Stop"))
+
+	if d.cancel != nil {
+		d.cancel()
+	}
+
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more deliveries may be due, keep draining
+		if d.deliverDue(ctx) == d.cfg.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// deliverDue sends one batch of due deliveries and returns its size
+func (d *Dispatcher) deliverDue(ctx context.Context) int {
+	const op = "webhook.deliverDue"
+
+	log := d.log.With(slog.String("op", op))
+
+	now := time.Now()
+	// lease long enough for the whole batch to be sent sequentially
+	leaseUntil := now.Add(d.cfg.Timeout * time.Duration(d.cfg.BatchSize+1))
+
+	deliveries, err := d.storage.ClaimWebhookDeliveries(ctx, now, leaseUntil, d.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Error("failed to claim webhook deliveries", "error", err.Error())
+		}
+		return 0
+	}
+
+	for _, delivery := range deliveries {
+		d.deliver(ctx, log, delivery)
+	}
+
+	return len(deliveries)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, log *slog.Logger, delivery domain.WebhookDelivery) {
+	log = log.With(
+		slog.Int64("deliveryID", delivery.ID),
+		slog.String("eventID", delivery.Event.ID),
+		slog.String("url", delivery.URL),
+	)
+
+	// finish the bookkeeping even if the dispatcher is being stopped
+	storeCtx := context.WithoutCancel(ctx)
+
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		log.Debug("webhook delivered")
+
+		if err := d.storage.MarkWebhookDelivered(storeCtx, delivery.ID); err != nil {
+			log.Error("failed to mark webhook as delivered", "error", err.Error())
+		}
+		return
+	}
+
+	attempt := delivery.Attempts + 1
+	dead := attempt >= d.cfg.MaxAttempts
+	nextAttemptAt := time.Now().Add(d.backoff(attempt))
+
+	if dead {
+		log.Error("webhook moved to dead letters", "attempts", attempt, "error", sendErr.Error())
+	} else {
+		log.Warn("webhook delivery failed", "attempt", attempt, "retryAt", nextAttemptAt, "error", sendErr.Error())
+	}
+
+	if err := d.storage.MarkWebhookFailed(storeCtx, delivery.ID, nextAttemptAt, dead, sendErr.Error()); err != nil {
+		log.Error("failed to record webhook failure", "error", err.Error())
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery domain.WebhookDelivery) error {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, delivery.Event.ID)
+	req.Header.Set(HeaderEventType, delivery.Event.Type)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.cfg.Secret, timestamp, body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// backoff returns the exponential delay before the given retry attempt (starting from 1)
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	if d.cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := d.cfg.BaseDelay << (attempt - 1)
+	if delay <= 0 || (d.cfg.MaxDelay > 0 && delay > d.cfg.MaxDelay) {
+		delay = d.cfg.MaxDelay
+	}
+
+	return delay
+}
+
+// Sign returns the signature header value for a webhook body:
+// "sha256=" followed by hex encoded HMAC-SHA256 of "<timestamp>.<body>"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
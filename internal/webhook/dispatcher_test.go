@@ -0,0 +1,276 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"kaspi-api-wrapper/internal/domain"
+	"kaspi-api-wrapper/internal/webhook"
+	"kaspi-api-wrapper/pkg/lib/logger/handlers/slogdiscard"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MockStorage keeps the delivery queue in memory
+type MockStorage struct {
+	mu         sync.Mutex
+	nextID     int64
+	deliveries map[int64]*mockDelivery
+}
+
+type mockDelivery struct {
+	delivery      domain.WebhookDelivery
+	status        string
+	nextAttemptAt time.Time
+}
+
+func (m *MockStorage) SaveWebhookEvent(ctx context.Context, event domain.WebhookEvent, urls []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.deliveries == nil {
+		m.deliveries = make(map[int64]*mockDelivery)
+	}
+
+	for _, url := range urls {
+		m.nextID++
+		m.deliveries[m.nextID] = &mockDelivery{
+			delivery:      domain.WebhookDelivery{ID: m.nextID, URL: url, Event: event},
+			status:        domain.DeliveryStatusPending,
+			nextAttemptAt: time.Now(),
+		}
+	}
+
+	return nil
+}
+
+func (m *MockStorage) ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deliveries []domain.WebhookDelivery
+	for _, d := range m.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if d.status == domain.DeliveryStatusPending && !d.nextAttemptAt.After(now) {
+			d.nextAttemptAt = leaseUntil
+			deliveries = append(deliveries, d.delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (m *MockStorage) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d := m.deliveries[deliveryID]
+	d.delivery.Attempts++
+	d.status = domain.DeliveryStatusDelivered
+
+	return nil
+}
+
+func (m *MockStorage) MarkWebhookFailed(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, dead bool, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d := m.deliveries[deliveryID]
+	d.delivery.Attempts++
+	d.nextAttemptAt = nextAttemptAt
+	if dead {
+		d.status = domain.DeliveryStatusDead
+	}
+
+	return nil
+}
+
+func (m *MockStorage) ReplayWebhookDeliveries(ctx context.Context, filter domain.WebhookReplayFilter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var replayed int64
+	for _, d := range m.deliveries {
+		if filter.EventID != "" && d.delivery.Event.ID != filter.EventID {
+			continue
+		}
+		d.status = domain.DeliveryStatusPending
+		d.delivery.Attempts = 0
+		d.nextAttemptAt = time.Now()
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (m *MockStorage) statuses() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make(map[string]int)
+	for _, d := range m.deliveries {
+		statuses[d.status]++
+	}
+
+	return statuses
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met in time")
+}
+
+func testConfig(urls ...string) webhook.Config {
+	return webhook.Config{
+		URLs:         urls,
+		Secret:       "secret",
+		MaxAttempts:  3,
+		BaseDelay:    time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+		Timeout:      time.Second,
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+
+	t.Run("delivers signed event", func(t *testing.T) {
+		var received atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+			if err != nil {
+				t.Errorf("Invalid timestamp header: %v", err)
+			}
+
+			if r.Header.Get(webhook.HeaderSignature) != webhook.Sign("secret", timestamp, body) {
+				t.Errorf("Invalid signature %s", r.Header.Get(webhook.HeaderSignature))
+			}
+
+			if r.Header.Get(webhook.HeaderEventType) != domain.EventPaymentStatusChanged {
+				t.Errorf("Unexpected event type %s", r.Header.Get(webhook.HeaderEventType))
+			}
+
+			received.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		store := &MockStorage{}
+		d := webhook.New(log, store, testConfig(srv.URL))
+		d.Start()
+		defer d.Stop()
+
+		err := d.Publish(context.Background(), domain.WebhookEvent{
+			Type:      domain.EventPaymentStatusChanged,
+			SubjectID: 15,
+			Status:    domain.PaymentStatusProcessed,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.statuses()[domain.DeliveryStatusDelivered] == 1 })
+
+		if received.Load() != 1 {
+			t.Errorf("Expected 1 request, got %d", received.Load())
+		}
+	})
+
+	t.Run("moves delivery to dead letters after max attempts", func(t *testing.T) {
+		var received atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		store := &MockStorage{}
+		d := webhook.New(log, store, testConfig(srv.URL))
+		d.Start()
+		defer d.Stop()
+
+		err := d.Publish(context.Background(), domain.WebhookEvent{
+			Type:      domain.EventRefundStatusChanged,
+			SubjectID: 15,
+			Status:    "Wait",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.statuses()[domain.DeliveryStatusDead] == 1 })
+
+		if received.Load() != 3 {
+			t.Errorf("Expected 3 attempts, got %d", received.Load())
+		}
+	})
+
+	t.Run("replays dead deliveries", func(t *testing.T) {
+		var healthy atomic.Bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		store := &MockStorage{}
+		d := webhook.New(log, store, testConfig(srv.URL))
+		d.Start()
+		defer d.Stop()
+
+		event := domain.WebhookEvent{
+			ID:        "event-1",
+			Type:      domain.EventPaymentStatusChanged,
+			SubjectID: 15,
+			Status:    domain.PaymentStatusExpired,
+		}
+		if err := d.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		waitUntil(t, func() bool { return store.statuses()[domain.DeliveryStatusDead] == 1 })
+
+		healthy.Store(true)
+
+		replayed, err := d.ReplayWebhooks(context.Background(), domain.WebhookReplayFilter{EventID: "event-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if replayed != 1 {
+			t.Errorf("Expected 1 replayed delivery, got %d", replayed)
+		}
+
+		waitUntil(t, func() bool { return store.statuses()[domain.DeliveryStatusDelivered] == 1 })
+	})
+
+	t.Run("rejects replay without filter", func(t *testing.T) {
+		d := webhook.New(log, &MockStorage{}, testConfig("http://localhost"))
+
+		_, err := d.ReplayWebhooks(context.Background(), domain.WebhookReplayFilter{})
+		if err == nil {
+			t.Fatal("Expected validation error, got nil")
+		}
+	})
+}
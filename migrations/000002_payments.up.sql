@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS payments (
+                                        qr_payment_id BIGINT PRIMARY KEY,
+                                        kind TEXT NOT NULL,
+                                        external_id TEXT NOT NULL DEFAULT '',
+                                        device_token TEXT NOT NULL,
+                                        tradepoint_id BIGINT,
+                                        organization_bin TEXT NOT NULL DEFAULT '',
+                                        amount NUMERIC(18, 2) NOT NULL,
+                                        expire_date TIMESTAMP,
+                                        payment_methods TEXT[] NOT NULL DEFAULT '{}',
+                                        status TEXT NOT NULL,
+                                        transaction_id TEXT NOT NULL DEFAULT '',
+                                        product_type TEXT NOT NULL DEFAULT '',
+                                        loan_offer_name TEXT NOT NULL DEFAULT '',
+                                        loan_term INT NOT NULL DEFAULT 0,
+                                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                        updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS payments_external_id_idx ON payments (external_id);
+CREATE INDEX IF NOT EXISTS payments_status_idx ON payments (status);
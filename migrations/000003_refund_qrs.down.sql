@@ -0,0 +1 @@
+DROP TABLE IF EXISTS refund_qrs;
\ No newline at end of file
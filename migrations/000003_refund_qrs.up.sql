@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS refund_qrs (
+                                          qr_return_id BIGINT PRIMARY KEY,
+                                          device_token TEXT NOT NULL,
+                                          external_id TEXT NOT NULL DEFAULT '',
+                                          expire_date TIMESTAMP,
+                                          status TEXT NOT NULL,
+                                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                          updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS webhook_events (
+                                              id TEXT PRIMARY KEY,
+                                              type TEXT NOT NULL,
+                                              subject_id BIGINT NOT NULL,
+                                              payload JSONB NOT NULL,
+                                              created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                                  id BIGSERIAL PRIMARY KEY,
+                                                  event_id TEXT NOT NULL REFERENCES webhook_events (id) ON DELETE CASCADE,
+                                                  url TEXT NOT NULL,
+                                                  status TEXT NOT NULL,
+                                                  attempts INT NOT NULL DEFAULT 0,
+                                                  next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                                  last_error TEXT NOT NULL DEFAULT '',
+                                                  delivered_at TIMESTAMP,
+                                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+                                                  UNIQUE (event_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                                key TEXT PRIMARY KEY,
+                                                fingerprint TEXT NOT NULL,
+                                                status TEXT NOT NULL,
+                                                response_status INT NOT NULL DEFAULT 0,
+                                                response_body BYTEA,
+                                                created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                                updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS payments_live_external_id_idx;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS qr_token,
+    DROP COLUMN IF EXISTS payment_link,
+    DROP COLUMN IF EXISTS status_polling_interval,
+    DROP COLUMN IF EXISTS scan_wait_timeout,
+    DROP COLUMN IF EXISTS confirmation_timeout;
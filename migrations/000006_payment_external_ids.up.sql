@@ -0,0 +1,9 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS qr_token TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS payment_link TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status_polling_interval INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS scan_wait_timeout INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS confirmation_timeout INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS payments_live_external_id_idx ON payments (external_id, kind, created_at DESC)
+    WHERE external_id <> '' AND status IN ('QrTokenCreated', 'Wait');
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS refunds (
+                                       id BIGSERIAL PRIMARY KEY,
+                                       qr_payment_id BIGINT NOT NULL,
+                                       qr_return_id BIGINT NOT NULL DEFAULT 0,
+                                       return_operation_id BIGINT NOT NULL DEFAULT 0,
+                                       amount NUMERIC(18, 2) NOT NULL,
+                                       device_token TEXT NOT NULL,
+                                       organization_bin TEXT NOT NULL DEFAULT '',
+                                       initiator TEXT NOT NULL DEFAULT '',
+                                       status TEXT NOT NULL,
+                                       error TEXT NOT NULL DEFAULT '',
+                                       created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                       updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS refunds_qr_payment_id_idx ON refunds (qr_payment_id, status);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS refund_sessions (
+                                               qr_return_id BIGINT PRIMARY KEY,
+                                               device_token TEXT NOT NULL,
+                                               external_id TEXT NOT NULL DEFAULT '',
+                                               max_result BIGINT NOT NULL DEFAULT 0,
+                                               qr_token TEXT NOT NULL,
+                                               expire_date TIMESTAMP,
+                                               polling_interval INT NOT NULL DEFAULT 0,
+                                               scan_wait_timeout INT NOT NULL DEFAULT 0,
+                                               state TEXT NOT NULL,
+                                               kaspi_status TEXT NOT NULL DEFAULT '',
+                                               deadline TIMESTAMP,
+                                               operations JSONB NOT NULL DEFAULT '[]',
+                                               qr_payment_id BIGINT NOT NULL DEFAULT 0,
+                                               amount NUMERIC(18, 2) NOT NULL DEFAULT 0,
+                                               available_return_amount NUMERIC(18, 2) NOT NULL DEFAULT 0,
+                                               return_operation_id BIGINT NOT NULL DEFAULT 0,
+                                               error TEXT NOT NULL DEFAULT '',
+                                               created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                               updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS refund_sessions_active_idx ON refund_sessions (state)
+    WHERE state IN ('awaiting_scan', 'awaiting_selection');
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS payments_pending_remote_idx;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS phone_number,
+    DROP COLUMN IF EXISTS comment;
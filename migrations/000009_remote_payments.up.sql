@@ -0,0 +1,6 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS phone_number TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS comment TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS payments_pending_remote_idx ON payments (organization_bin, created_at)
+    WHERE kind = 'remote' AND status IN ('QrTokenCreated', 'Wait');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS refund_qrs_created_at_idx;
+DROP INDEX IF EXISTS payments_created_at_idx;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
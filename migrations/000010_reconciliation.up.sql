@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+                                                   id BIGSERIAL PRIMARY KEY,
+                                                   trigger TEXT NOT NULL,
+                                                   period_from TIMESTAMP NOT NULL,
+                                                   period_to TIMESTAMP NOT NULL,
+                                                   status TEXT NOT NULL,
+                                                   payments_checked INT NOT NULL DEFAULT 0,
+                                                   refunds_checked INT NOT NULL DEFAULT 0,
+                                                   discrepancies INT NOT NULL DEFAULT 0,
+                                                   error TEXT NOT NULL DEFAULT '',
+                                                   started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                                   finished_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies (
+                                                            id BIGSERIAL PRIMARY KEY,
+                                                            run_id BIGINT NOT NULL REFERENCES reconciliation_runs (id) ON DELETE CASCADE,
+                                                            kind TEXT NOT NULL,
+                                                            entity TEXT NOT NULL,
+                                                            qr_payment_id BIGINT NOT NULL DEFAULT 0,
+                                                            qr_return_id BIGINT NOT NULL DEFAULT 0,
+                                                            local_value TEXT NOT NULL DEFAULT '',
+                                                            remote_value TEXT NOT NULL DEFAULT '',
+                                                            message TEXT NOT NULL DEFAULT '',
+                                                            created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS reconciliation_discrepancies_run_id_idx ON reconciliation_discrepancies (run_id);
+CREATE INDEX IF NOT EXISTS payments_created_at_idx ON payments (created_at);
+CREATE INDEX IF NOT EXISTS refund_qrs_created_at_idx ON refund_qrs (created_at);
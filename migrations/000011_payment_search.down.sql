@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS payments_transaction_id_trgm_idx;
+DROP INDEX IF EXISTS payments_external_id_trgm_idx;
+DROP INDEX IF EXISTS payments_organization_bin_created_at_idx;
+DROP INDEX IF EXISTS payments_device_token_created_at_idx;
+DROP INDEX IF EXISTS payments_tradepoint_created_at_idx;
+DROP INDEX IF EXISTS payments_amount_id_idx;
+DROP INDEX IF EXISTS payments_created_at_id_idx;
@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS payments_created_at_id_idx ON payments (created_at, qr_payment_id);
+CREATE INDEX IF NOT EXISTS payments_amount_id_idx ON payments (amount, qr_payment_id);
+CREATE INDEX IF NOT EXISTS payments_tradepoint_created_at_idx ON payments (tradepoint_id, created_at);
+CREATE INDEX IF NOT EXISTS payments_device_token_created_at_idx ON payments (device_token, created_at);
+CREATE INDEX IF NOT EXISTS payments_organization_bin_created_at_idx ON payments (organization_bin, created_at);
+
+-- partial ExternalId and TransactionId search with ILIKE '%...%'
+CREATE INDEX IF NOT EXISTS payments_external_id_trgm_idx ON payments USING GIN (external_id gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS payments_transaction_id_trgm_idx ON payments USING GIN (transaction_id gin_trgm_ops);
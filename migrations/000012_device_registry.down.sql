@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS devices_enhanced_organization_bin_idx;
+DROP INDEX IF EXISTS devices_enhanced_tradepoint_id_idx;
+DROP INDEX IF EXISTS devices_tradepoint_id_idx;
+
+ALTER TABLE devices_enhanced
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE devices
+    DROP COLUMN IF EXISTS deleted_at;
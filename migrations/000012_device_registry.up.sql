@@ -0,0 +1,9 @@
+ALTER TABLE devices
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+ALTER TABLE devices_enhanced
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS devices_tradepoint_id_idx ON devices (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_enhanced_tradepoint_id_idx ON devices_enhanced (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_enhanced_organization_bin_idx ON devices_enhanced (organization_bin);
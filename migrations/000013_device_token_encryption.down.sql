@@ -0,0 +1,11 @@
+-- encrypted tokens have to be decrypted before with `go run ./cmd/api rotate-device-token-key -decrypt`,
+-- and the service must run without DEVICE_TOKEN_KEY afterwards
+ALTER TABLE devices
+    DROP CONSTRAINT IF EXISTS devices_device_token_hmac_key,
+    DROP COLUMN IF EXISTS device_token_hmac,
+    ADD CONSTRAINT devices_device_token_key UNIQUE (device_token);
+
+ALTER TABLE devices_enhanced
+    DROP CONSTRAINT IF EXISTS devices_enhanced_device_token_hmac_key,
+    DROP COLUMN IF EXISTS device_token_hmac,
+    ADD CONSTRAINT devices_enhanced_device_token_key UNIQUE (device_token);
@@ -0,0 +1,26 @@
+-- device tokens are encrypted by the service when DEVICE_TOKEN_KEY is set, so they are looked up
+-- by a deterministic index instead. The index of plaintext tokens is their SHA-256, the service
+-- replaces it with an HMAC when it encrypts them
+ALTER TABLE devices
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+ALTER TABLE devices_enhanced
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+UPDATE devices
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+UPDATE devices_enhanced
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+ALTER TABLE devices
+    ALTER COLUMN device_token_hmac SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS devices_device_token_key,
+    ADD CONSTRAINT devices_device_token_hmac_key UNIQUE (device_token_hmac);
+
+ALTER TABLE devices_enhanced
+    ALTER COLUMN device_token_hmac SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS devices_enhanced_device_token_key,
+    ADD CONSTRAINT devices_enhanced_device_token_hmac_key UNIQUE (device_token_hmac);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS devices_enhanced (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL,
+    tradepoint_id BIGINT NOT NULL,
+    organization_bin TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+
+    UNIQUE (device_id, tradepoint_id),
+    CONSTRAINT devices_enhanced_device_token_hmac_key UNIQUE (device_token_hmac)
+);
+
+CREATE INDEX IF NOT EXISTS devices_enhanced_tradepoint_id_idx ON devices_enhanced (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_enhanced_organization_bin_idx ON devices_enhanced (organization_bin);
+
+INSERT INTO devices_enhanced (device_id, device_token, device_token_hmac, tradepoint_id, organization_bin, created_at, deleted_at)
+SELECT device_id, device_token, device_token_hmac, tradepoint_id, organization_bin, created_at, deleted_at
+FROM devices
+WHERE scheme = 'enhanced';
+
+DELETE FROM devices WHERE scheme = 'enhanced';
+
+DROP INDEX IF EXISTS devices_organization_bin_idx;
+
+ALTER TABLE devices
+    DROP COLUMN scheme,
+    DROP COLUMN organization_bin,
+    ADD CONSTRAINT devices_device_id_tradepoint_id_key UNIQUE (device_id, tradepoint_id);
+
+DROP TABLE IF EXISTS devices_merge_conflicts;
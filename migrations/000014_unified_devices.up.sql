@@ -0,0 +1,65 @@
+-- devices of both schemes are kept in one table, the organization BIN is set for enhanced devices only
+CREATE TABLE devices_unified (
+    device_id TEXT PRIMARY KEY,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL UNIQUE,
+    tradepoint_id BIGINT NOT NULL,
+    scheme TEXT NOT NULL,
+    organization_bin TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+
+    CONSTRAINT devices_scheme_check CHECK (scheme IN ('standard', 'enhanced')),
+    CONSTRAINT devices_organization_bin_check CHECK ((scheme = 'enhanced') = (organization_bin IS NOT NULL))
+);
+
+-- rows losing the merge, a device ID or token stored in both tables, are archived instead of dropped,
+-- they are reviewed and registered again by hand if needed
+CREATE TABLE devices_merge_conflicts (
+    device_id TEXT NOT NULL,
+    device_token TEXT NOT NULL,
+    device_token_hmac TEXT NOT NULL,
+    tradepoint_id BIGINT NOT NULL,
+    scheme TEXT NOT NULL,
+    organization_bin TEXT,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- a device ID or token stored in both tables keeps its active, most recently registered row
+INSERT INTO devices_unified (device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at, deleted_at)
+SELECT device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at, deleted_at
+FROM (
+    SELECT device_id, device_token, device_token_hmac, tradepoint_id, 'standard' AS scheme, NULL::TEXT AS organization_bin, created_at, deleted_at
+    FROM devices
+    UNION ALL
+    SELECT device_id, device_token, device_token_hmac, tradepoint_id, 'enhanced', organization_bin, created_at, deleted_at
+    FROM devices_enhanced
+) d
+ORDER BY deleted_at IS NULL DESC, created_at DESC
+ON CONFLICT DO NOTHING;
+
+INSERT INTO devices_merge_conflicts (device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at, deleted_at)
+SELECT device_id, device_token, device_token_hmac, tradepoint_id, scheme, organization_bin, created_at, deleted_at
+FROM (
+    SELECT device_id, device_token, device_token_hmac, tradepoint_id, 'standard' AS scheme, NULL::TEXT AS organization_bin, created_at, deleted_at
+    FROM devices
+    UNION ALL
+    SELECT device_id, device_token, device_token_hmac, tradepoint_id, 'enhanced', organization_bin, created_at, deleted_at
+    FROM devices_enhanced
+) d
+WHERE NOT EXISTS (
+    SELECT 1 FROM devices_unified u
+    WHERE u.device_id = d.device_id AND u.device_token_hmac = d.device_token_hmac AND u.scheme = d.scheme
+);
+
+DROP TABLE devices;
+DROP TABLE devices_enhanced;
+
+ALTER TABLE devices_unified RENAME TO devices;
+ALTER TABLE devices RENAME CONSTRAINT devices_unified_pkey TO devices_pkey;
+ALTER TABLE devices RENAME CONSTRAINT devices_unified_device_token_hmac_key TO devices_device_token_hmac_key;
+
+CREATE INDEX IF NOT EXISTS devices_tradepoint_id_idx ON devices (tradepoint_id);
+CREATE INDEX IF NOT EXISTS devices_organization_bin_idx ON devices (organization_bin);
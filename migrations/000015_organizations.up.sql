@@ -0,0 +1,19 @@
+-- organizations the wrapper operates in the enhanced scheme, enhanced requests for other BINs are rejected
+CREATE TABLE IF NOT EXISTS organizations (
+    organization_bin TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    settings JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the BINs already in use are registered under their own name, so existing deployments keep working
+INSERT INTO organizations (organization_bin, name)
+SELECT organization_bin, organization_bin
+FROM (
+    SELECT organization_bin FROM devices WHERE organization_bin IS NOT NULL
+    UNION
+    SELECT organization_bin FROM payments WHERE organization_bin <> ''
+) o
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,17 @@
+-- trade points returned by Kaspi with the metadata kept by the wrapper. The organization BIN is empty
+-- for the basic and standard schemes, trade points Kaspi no longer returns are marked removed and keep
+-- their metadata
+CREATE TABLE IF NOT EXISTS tradepoints (
+    organization_bin TEXT NOT NULL DEFAULT '',
+    tradepoint_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    timezone TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    min_amount NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    max_amount NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    removed BOOLEAN NOT NULL DEFAULT FALSE,
+    synced_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (organization_bin, tradepoint_id)
+);
@@ -0,0 +1,9 @@
+-- encrypted tokens have to be decrypted before with `go run ./cmd/api rotate-device-token-key -decrypt`,
+-- and the service must run without DEVICE_TOKEN_KEY afterwards
+DROP INDEX IF EXISTS payments_device_token_hmac_created_at_idx;
+CREATE INDEX IF NOT EXISTS payments_device_token_created_at_idx ON payments (device_token, created_at);
+
+ALTER TABLE payments DROP COLUMN IF EXISTS device_token_hmac;
+ALTER TABLE refunds DROP COLUMN IF EXISTS device_token_hmac;
+ALTER TABLE refund_qrs DROP COLUMN IF EXISTS device_token_hmac;
+ALTER TABLE refund_sessions DROP COLUMN IF EXISTS device_token_hmac;
@@ -0,0 +1,39 @@
+-- payments, refunds, refund QRs and refund sessions keep the token of their device, it is encrypted
+-- like the device registry and looked up by its index. The index of plaintext tokens is their SHA-256,
+-- the service encrypts the rows and replaces the index on its first start with DEVICE_TOKEN_KEY
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+ALTER TABLE refunds
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+ALTER TABLE refund_qrs
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+ALTER TABLE refund_sessions
+    ADD COLUMN IF NOT EXISTS device_token_hmac TEXT;
+
+UPDATE payments
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+UPDATE refunds
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+UPDATE refund_qrs
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+UPDATE refund_sessions
+SET device_token_hmac = encode(sha256(convert_to(device_token, 'UTF8')), 'hex')
+WHERE device_token_hmac IS NULL;
+
+ALTER TABLE payments ALTER COLUMN device_token_hmac SET NOT NULL;
+ALTER TABLE refunds ALTER COLUMN device_token_hmac SET NOT NULL;
+ALTER TABLE refund_qrs ALTER COLUMN device_token_hmac SET NOT NULL;
+ALTER TABLE refund_sessions ALTER COLUMN device_token_hmac SET NOT NULL;
+
+-- encrypted tokens differ on every row, payments of a device are searched by the index
+DROP INDEX IF EXISTS payments_device_token_created_at_idx;
+CREATE INDEX IF NOT EXISTS payments_device_token_hmac_created_at_idx ON payments (device_token_hmac, created_at);
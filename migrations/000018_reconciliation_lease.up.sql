@@ -0,0 +1,11 @@
+-- a running run belongs to the instance executing it, which renews heartbeat_at while the run is in
+-- progress. A starting instance fails its own running runs and the runs whose lease expired
+ALTER TABLE reconciliation_runs
+    ADD COLUMN IF NOT EXISTS owner TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMP;
+
+UPDATE reconciliation_runs
+SET heartbeat_at = started_at
+WHERE heartbeat_at IS NULL;
+
+ALTER TABLE reconciliation_runs ALTER COLUMN heartbeat_at SET NOT NULL;
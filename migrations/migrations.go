@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds the Postgres migrations, they are applied by the service on startup or with the migrate subcommand
+//
+//go:embed *.sql
+var FS embed.FS
@@ -19,12 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DeviceService_GetTradePoints_FullMethodName         = "/kaspi.api.v1.DeviceService/GetTradePoints"
-	DeviceService_RegisterDevice_FullMethodName         = "/kaspi.api.v1.DeviceService/RegisterDevice"
-	DeviceService_DeleteDevice_FullMethodName           = "/kaspi.api.v1.DeviceService/DeleteDevice"
-	DeviceService_GetTradePointsEnhanced_FullMethodName = "/kaspi.api.v1.DeviceService/GetTradePointsEnhanced"
-	DeviceService_RegisterDeviceEnhanced_FullMethodName = "/kaspi.api.v1.DeviceService/RegisterDeviceEnhanced"
-	DeviceService_DeleteDeviceEnhanced_FullMethodName   = "/kaspi.api.v1.DeviceService/DeleteDeviceEnhanced"
+	DeviceService_GetTradePoints_FullMethodName             = "/kaspi.api.v1.DeviceService/GetTradePoints"
+	DeviceService_RefreshTradePoints_FullMethodName         = "/kaspi.api.v1.DeviceService/RefreshTradePoints"
+	DeviceService_RegisterDevice_FullMethodName             = "/kaspi.api.v1.DeviceService/RegisterDevice"
+	DeviceService_DeleteDevice_FullMethodName               = "/kaspi.api.v1.DeviceService/DeleteDevice"
+	DeviceService_GetTradePointsEnhanced_FullMethodName     = "/kaspi.api.v1.DeviceService/GetTradePointsEnhanced"
+	DeviceService_RefreshTradePointsEnhanced_FullMethodName = "/kaspi.api.v1.DeviceService/RefreshTradePointsEnhanced"
+	DeviceService_RegisterDeviceEnhanced_FullMethodName     = "/kaspi.api.v1.DeviceService/RegisterDeviceEnhanced"
+	DeviceService_DeleteDeviceEnhanced_FullMethodName       = "/kaspi.api.v1.DeviceService/DeleteDeviceEnhanced"
+	DeviceService_ListDevices_FullMethodName                = "/kaspi.api.v1.DeviceService/ListDevices"
+	DeviceService_GetDevice_FullMethodName                  = "/kaspi.api.v1.DeviceService/GetDevice"
+	DeviceService_UpdateTradePoint_FullMethodName           = "/kaspi.api.v1.DeviceService/UpdateTradePoint"
 )
 
 // DeviceServiceClient is the client API for DeviceService service.
@@ -33,12 +38,19 @@ const (
 type DeviceServiceClient interface {
 	// Basic/Standard scheme methods
 	GetTradePoints(ctx context.Context, in *GetTradePointsRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error)
+	RefreshTradePoints(ctx context.Context, in *GetTradePointsRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error)
 	RegisterDevice(ctx context.Context, in *RegisterDeviceRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error)
 	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
 	// Enhanced scheme methods
 	GetTradePointsEnhanced(ctx context.Context, in *GetTradePointsEnhancedRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error)
+	RefreshTradePointsEnhanced(ctx context.Context, in *GetTradePointsEnhancedRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error)
 	RegisterDeviceEnhanced(ctx context.Context, in *RegisterDeviceEnhancedRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error)
 	DeleteDeviceEnhanced(ctx context.Context, in *DeleteDeviceEnhancedRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
+	// Device registry of all schemes
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Metadata of the cached trade points of all schemes
+	UpdateTradePoint(ctx context.Context, in *UpdateTradePointRequest, opts ...grpc.CallOption) (*TradePoint, error)
 }
 
 type deviceServiceClient struct {
@@ -59,6 +71,16 @@ func (c *deviceServiceClient) GetTradePoints(ctx context.Context, in *GetTradePo
 	return out, nil
 }
 
+func (c *deviceServiceClient) RefreshTradePoints(ctx context.Context, in *GetTradePointsRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTradePointsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RefreshTradePoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deviceServiceClient) RegisterDevice(ctx context.Context, in *RegisterDeviceRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RegisterDeviceResponse)
@@ -89,6 +111,16 @@ func (c *deviceServiceClient) GetTradePointsEnhanced(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *deviceServiceClient) RefreshTradePointsEnhanced(ctx context.Context, in *GetTradePointsEnhancedRequest, opts ...grpc.CallOption) (*GetTradePointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTradePointsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RefreshTradePointsEnhanced_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deviceServiceClient) RegisterDeviceEnhanced(ctx context.Context, in *RegisterDeviceEnhancedRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RegisterDeviceResponse)
@@ -109,18 +141,55 @@ func (c *deviceServiceClient) DeleteDeviceEnhanced(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *deviceServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, DeviceService_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateTradePoint(ctx context.Context, in *UpdateTradePointRequest, opts ...grpc.CallOption) (*TradePoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TradePoint)
+	err := c.cc.Invoke(ctx, DeviceService_UpdateTradePoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceServiceServer is the server API for DeviceService service.
 // All implementations must embed UnimplementedDeviceServiceServer
 // for forward compatibility.
 type DeviceServiceServer interface {
 	// Basic/Standard scheme methods
 	GetTradePoints(context.Context, *GetTradePointsRequest) (*GetTradePointsResponse, error)
+	RefreshTradePoints(context.Context, *GetTradePointsRequest) (*GetTradePointsResponse, error)
 	RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error)
 	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error)
 	// Enhanced scheme methods
 	GetTradePointsEnhanced(context.Context, *GetTradePointsEnhancedRequest) (*GetTradePointsResponse, error)
+	RefreshTradePointsEnhanced(context.Context, *GetTradePointsEnhancedRequest) (*GetTradePointsResponse, error)
 	RegisterDeviceEnhanced(context.Context, *RegisterDeviceEnhancedRequest) (*RegisterDeviceResponse, error)
 	DeleteDeviceEnhanced(context.Context, *DeleteDeviceEnhancedRequest) (*DeleteDeviceResponse, error)
+	// Device registry of all schemes
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
+	// Metadata of the cached trade points of all schemes
+	UpdateTradePoint(context.Context, *UpdateTradePointRequest) (*TradePoint, error)
 	mustEmbedUnimplementedDeviceServiceServer()
 }
 
@@ -134,6 +203,9 @@ type UnimplementedDeviceServiceServer struct{}
 func (UnimplementedDeviceServiceServer) GetTradePoints(context.Context, *GetTradePointsRequest) (*GetTradePointsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTradePoints not implemented")
 }
+func (UnimplementedDeviceServiceServer) RefreshTradePoints(context.Context, *GetTradePointsRequest) (*GetTradePointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshTradePoints not implemented")
+}
 func (UnimplementedDeviceServiceServer) RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterDevice not implemented")
 }
@@ -143,12 +215,24 @@ func (UnimplementedDeviceServiceServer) DeleteDevice(context.Context, *DeleteDev
 func (UnimplementedDeviceServiceServer) GetTradePointsEnhanced(context.Context, *GetTradePointsEnhancedRequest) (*GetTradePointsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTradePointsEnhanced not implemented")
 }
+func (UnimplementedDeviceServiceServer) RefreshTradePointsEnhanced(context.Context, *GetTradePointsEnhancedRequest) (*GetTradePointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshTradePointsEnhanced not implemented")
+}
 func (UnimplementedDeviceServiceServer) RegisterDeviceEnhanced(context.Context, *RegisterDeviceEnhancedRequest) (*RegisterDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterDeviceEnhanced not implemented")
 }
 func (UnimplementedDeviceServiceServer) DeleteDeviceEnhanced(context.Context, *DeleteDeviceEnhancedRequest) (*DeleteDeviceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceEnhanced not implemented")
 }
+func (UnimplementedDeviceServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDevice(context.Context, *GetDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateTradePoint(context.Context, *UpdateTradePointRequest) (*TradePoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTradePoint not implemented")
+}
 func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
 func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
 
@@ -188,6 +272,24 @@ func _DeviceService_GetTradePoints_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_RefreshTradePoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTradePointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RefreshTradePoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RefreshTradePoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RefreshTradePoints(ctx, req.(*GetTradePointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DeviceService_RegisterDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterDeviceRequest)
 	if err := dec(in); err != nil {
@@ -242,6 +344,24 @@ func _DeviceService_GetTradePointsEnhanced_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_RefreshTradePointsEnhanced_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTradePointsEnhancedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RefreshTradePointsEnhanced(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RefreshTradePointsEnhanced_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RefreshTradePointsEnhanced(ctx, req.(*GetTradePointsEnhancedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DeviceService_RegisterDeviceEnhanced_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterDeviceEnhancedRequest)
 	if err := dec(in); err != nil {
@@ -278,6 +398,60 @@ func _DeviceService_DeleteDeviceEnhanced_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateTradePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTradePointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateTradePoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_UpdateTradePoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateTradePoint(ctx, req.(*UpdateTradePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeviceService_ServiceDesc is the grpc.ServiceDesc for DeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +463,10 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTradePoints",
 			Handler:    _DeviceService_GetTradePoints_Handler,
 		},
+		{
+			MethodName: "RefreshTradePoints",
+			Handler:    _DeviceService_RefreshTradePoints_Handler,
+		},
 		{
 			MethodName: "RegisterDevice",
 			Handler:    _DeviceService_RegisterDevice_Handler,
@@ -301,6 +479,10 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTradePointsEnhanced",
 			Handler:    _DeviceService_GetTradePointsEnhanced_Handler,
 		},
+		{
+			MethodName: "RefreshTradePointsEnhanced",
+			Handler:    _DeviceService_RefreshTradePointsEnhanced_Handler,
+		},
 		{
 			MethodName: "RegisterDeviceEnhanced",
 			Handler:    _DeviceService_RegisterDeviceEnhanced_Handler,
@@ -309,6 +491,18 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDeviceEnhanced",
 			Handler:    _DeviceService_DeleteDeviceEnhanced_Handler,
 		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _DeviceService_ListDevices_Handler,
+		},
+		{
+			MethodName: "GetDevice",
+			Handler:    _DeviceService_GetDevice_Handler,
+		},
+		{
+			MethodName: "UpdateTradePoint",
+			Handler:    _DeviceService_UpdateTradePoint_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "device/device.proto",
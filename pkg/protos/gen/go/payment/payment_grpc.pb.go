@@ -22,6 +22,10 @@ const (
 	PaymentService_CreateQR_FullMethodName                  = "/kaspi.api.v1.PaymentService/CreateQR"
 	PaymentService_CreatePaymentLink_FullMethodName         = "/kaspi.api.v1.PaymentService/CreatePaymentLink"
 	PaymentService_GetPaymentStatus_FullMethodName          = "/kaspi.api.v1.PaymentService/GetPaymentStatus"
+	PaymentService_WatchPaymentStatus_FullMethodName        = "/kaspi.api.v1.PaymentService/WatchPaymentStatus"
+	PaymentService_GetPaymentsByExternalId_FullMethodName   = "/kaspi.api.v1.PaymentService/GetPaymentsByExternalId"
+	PaymentService_ListPayments_FullMethodName              = "/kaspi.api.v1.PaymentService/ListPayments"
+	PaymentService_RenderQR_FullMethodName                  = "/kaspi.api.v1.PaymentService/RenderQR"
 	PaymentService_CreateQREnhanced_FullMethodName          = "/kaspi.api.v1.PaymentService/CreateQREnhanced"
 	PaymentService_CreatePaymentLinkEnhanced_FullMethodName = "/kaspi.api.v1.PaymentService/CreatePaymentLinkEnhanced"
 )
@@ -34,6 +38,10 @@ type PaymentServiceClient interface {
 	CreateQR(ctx context.Context, in *CreateQRRequest, opts ...grpc.CallOption) (*CreateQRResponse, error)
 	CreatePaymentLink(ctx context.Context, in *CreatePaymentLinkRequest, opts ...grpc.CallOption) (*CreatePaymentLinkResponse, error)
 	GetPaymentStatus(ctx context.Context, in *GetPaymentStatusRequest, opts ...grpc.CallOption) (*GetPaymentStatusResponse, error)
+	WatchPaymentStatus(ctx context.Context, in *WatchPaymentStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PaymentStatusUpdate], error)
+	GetPaymentsByExternalId(ctx context.Context, in *GetPaymentsByExternalIdRequest, opts ...grpc.CallOption) (*GetPaymentsByExternalIdResponse, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	RenderQR(ctx context.Context, in *RenderQRRequest, opts ...grpc.CallOption) (*RenderQRResponse, error)
 	// Enhanced scheme methods
 	CreateQREnhanced(ctx context.Context, in *CreateQREnhancedRequest, opts ...grpc.CallOption) (*CreateQRResponse, error)
 	CreatePaymentLinkEnhanced(ctx context.Context, in *CreatePaymentLinkEnhancedRequest, opts ...grpc.CallOption) (*CreatePaymentLinkResponse, error)
@@ -77,6 +85,55 @@ func (c *paymentServiceClient) GetPaymentStatus(ctx context.Context, in *GetPaym
 	return out, nil
 }
 
+func (c *paymentServiceClient) WatchPaymentStatus(ctx context.Context, in *WatchPaymentStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PaymentStatusUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PaymentService_ServiceDesc.Streams[0], PaymentService_WatchPaymentStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPaymentStatusRequest, PaymentStatusUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PaymentService_WatchPaymentStatusClient = grpc.ServerStreamingClient[PaymentStatusUpdate]
+
+func (c *paymentServiceClient) GetPaymentsByExternalId(ctx context.Context, in *GetPaymentsByExternalIdRequest, opts ...grpc.CallOption) (*GetPaymentsByExternalIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPaymentsByExternalIdResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetPaymentsByExternalId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RenderQR(ctx context.Context, in *RenderQRRequest, opts ...grpc.CallOption) (*RenderQRResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderQRResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RenderQR_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *paymentServiceClient) CreateQREnhanced(ctx context.Context, in *CreateQREnhancedRequest, opts ...grpc.CallOption) (*CreateQRResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateQRResponse)
@@ -105,6 +162,10 @@ type PaymentServiceServer interface {
 	CreateQR(context.Context, *CreateQRRequest) (*CreateQRResponse, error)
 	CreatePaymentLink(context.Context, *CreatePaymentLinkRequest) (*CreatePaymentLinkResponse, error)
 	GetPaymentStatus(context.Context, *GetPaymentStatusRequest) (*GetPaymentStatusResponse, error)
+	WatchPaymentStatus(*WatchPaymentStatusRequest, grpc.ServerStreamingServer[PaymentStatusUpdate]) error
+	GetPaymentsByExternalId(context.Context, *GetPaymentsByExternalIdRequest) (*GetPaymentsByExternalIdResponse, error)
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	RenderQR(context.Context, *RenderQRRequest) (*RenderQRResponse, error)
 	// Enhanced scheme methods
 	CreateQREnhanced(context.Context, *CreateQREnhancedRequest) (*CreateQRResponse, error)
 	CreatePaymentLinkEnhanced(context.Context, *CreatePaymentLinkEnhancedRequest) (*CreatePaymentLinkResponse, error)
@@ -127,6 +188,18 @@ func (UnimplementedPaymentServiceServer) CreatePaymentLink(context.Context, *Cre
 func (UnimplementedPaymentServiceServer) GetPaymentStatus(context.Context, *GetPaymentStatusRequest) (*GetPaymentStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentStatus not implemented")
 }
+func (UnimplementedPaymentServiceServer) WatchPaymentStatus(*WatchPaymentStatusRequest, grpc.ServerStreamingServer[PaymentStatusUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPaymentStatus not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetPaymentsByExternalId(context.Context, *GetPaymentsByExternalIdRequest) (*GetPaymentsByExternalIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentsByExternalId not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
+}
+func (UnimplementedPaymentServiceServer) RenderQR(context.Context, *RenderQRRequest) (*RenderQRResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderQR not implemented")
+}
 func (UnimplementedPaymentServiceServer) CreateQREnhanced(context.Context, *CreateQREnhancedRequest) (*CreateQRResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateQREnhanced not implemented")
 }
@@ -208,6 +281,71 @@ func _PaymentService_GetPaymentStatus_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_WatchPaymentStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPaymentStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PaymentServiceServer).WatchPaymentStatus(m, &grpc.GenericServerStream[WatchPaymentStatusRequest, PaymentStatusUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PaymentService_WatchPaymentStatusServer = grpc.ServerStreamingServer[PaymentStatusUpdate]
+
+func _PaymentService_GetPaymentsByExternalId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentsByExternalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPaymentsByExternalId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetPaymentsByExternalId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPaymentsByExternalId(ctx, req.(*GetPaymentsByExternalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RenderQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderQRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RenderQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RenderQR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RenderQR(ctx, req.(*RenderQRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PaymentService_CreateQREnhanced_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateQREnhancedRequest)
 	if err := dec(in); err != nil {
@@ -263,6 +401,18 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaymentStatus",
 			Handler:    _PaymentService_GetPaymentStatus_Handler,
 		},
+		{
+			MethodName: "GetPaymentsByExternalId",
+			Handler:    _PaymentService_GetPaymentsByExternalId_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _PaymentService_ListPayments_Handler,
+		},
+		{
+			MethodName: "RenderQR",
+			Handler:    _PaymentService_RenderQR_Handler,
+		},
 		{
 			MethodName: "CreateQREnhanced",
 			Handler:    _PaymentService_CreateQREnhanced_Handler,
@@ -272,6 +422,12 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PaymentService_CreatePaymentLinkEnhanced_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPaymentStatus",
+			Handler:       _PaymentService_WatchPaymentStatus_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "payment/payment.proto",
 }
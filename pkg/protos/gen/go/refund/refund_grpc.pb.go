@@ -24,6 +24,9 @@ const (
 	RefundService_GetCustomerOperations_FullMethodName = "/kaspi.api.v1.RefundService/GetCustomerOperations"
 	RefundService_GetPaymentDetails_FullMethodName     = "/kaspi.api.v1.RefundService/GetPaymentDetails"
 	RefundService_RefundPayment_FullMethodName         = "/kaspi.api.v1.RefundService/RefundPayment"
+	RefundService_CreateRefundSession_FullMethodName   = "/kaspi.api.v1.RefundService/CreateRefundSession"
+	RefundService_GetRefundSession_FullMethodName      = "/kaspi.api.v1.RefundService/GetRefundSession"
+	RefundService_RefundSessionPayment_FullMethodName  = "/kaspi.api.v1.RefundService/RefundSessionPayment"
 )
 
 // RefundServiceClient is the client API for RefundService service.
@@ -35,6 +38,10 @@ type RefundServiceClient interface {
 	GetCustomerOperations(ctx context.Context, in *GetCustomerOperationsRequest, opts ...grpc.CallOption) (*GetCustomerOperationsResponse, error)
 	GetPaymentDetails(ctx context.Context, in *GetPaymentDetailsRequest, opts ...grpc.CallOption) (*GetPaymentDetailsResponse, error)
 	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	// Refund sessions drive the calls above as one persisted flow
+	CreateRefundSession(ctx context.Context, in *CreateRefundSessionRequest, opts ...grpc.CallOption) (*RefundSession, error)
+	GetRefundSession(ctx context.Context, in *GetRefundSessionRequest, opts ...grpc.CallOption) (*RefundSession, error)
+	RefundSessionPayment(ctx context.Context, in *RefundSessionPaymentRequest, opts ...grpc.CallOption) (*RefundSession, error)
 }
 
 type refundServiceClient struct {
@@ -95,6 +102,36 @@ func (c *refundServiceClient) RefundPayment(ctx context.Context, in *RefundPayme
 	return out, nil
 }
 
+func (c *refundServiceClient) CreateRefundSession(ctx context.Context, in *CreateRefundSessionRequest, opts ...grpc.CallOption) (*RefundSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundSession)
+	err := c.cc.Invoke(ctx, RefundService_CreateRefundSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) GetRefundSession(ctx context.Context, in *GetRefundSessionRequest, opts ...grpc.CallOption) (*RefundSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundSession)
+	err := c.cc.Invoke(ctx, RefundService_GetRefundSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) RefundSessionPayment(ctx context.Context, in *RefundSessionPaymentRequest, opts ...grpc.CallOption) (*RefundSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundSession)
+	err := c.cc.Invoke(ctx, RefundService_RefundSessionPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RefundServiceServer is the server API for RefundService service.
 // All implementations must embed UnimplementedRefundServiceServer
 // for forward compatibility.
@@ -104,6 +141,10 @@ type RefundServiceServer interface {
 	GetCustomerOperations(context.Context, *GetCustomerOperationsRequest) (*GetCustomerOperationsResponse, error)
 	GetPaymentDetails(context.Context, *GetPaymentDetailsRequest) (*GetPaymentDetailsResponse, error)
 	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	// Refund sessions drive the calls above as one persisted flow
+	CreateRefundSession(context.Context, *CreateRefundSessionRequest) (*RefundSession, error)
+	GetRefundSession(context.Context, *GetRefundSessionRequest) (*RefundSession, error)
+	RefundSessionPayment(context.Context, *RefundSessionPaymentRequest) (*RefundSession, error)
 	mustEmbedUnimplementedRefundServiceServer()
 }
 
@@ -129,6 +170,15 @@ func (UnimplementedRefundServiceServer) GetPaymentDetails(context.Context, *GetP
 func (UnimplementedRefundServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
 }
+func (UnimplementedRefundServiceServer) CreateRefundSession(context.Context, *CreateRefundSessionRequest) (*RefundSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRefundSession not implemented")
+}
+func (UnimplementedRefundServiceServer) GetRefundSession(context.Context, *GetRefundSessionRequest) (*RefundSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefundSession not implemented")
+}
+func (UnimplementedRefundServiceServer) RefundSessionPayment(context.Context, *RefundSessionPaymentRequest) (*RefundSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundSessionPayment not implemented")
+}
 func (UnimplementedRefundServiceServer) mustEmbedUnimplementedRefundServiceServer() {}
 func (UnimplementedRefundServiceServer) testEmbeddedByValue()                       {}
 
@@ -240,6 +290,60 @@ func _RefundService_RefundPayment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RefundService_CreateRefundSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRefundSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).CreateRefundSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_CreateRefundSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).CreateRefundSession(ctx, req.(*CreateRefundSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_GetRefundSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefundSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).GetRefundSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_GetRefundSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).GetRefundSession(ctx, req.(*GetRefundSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_RefundSessionPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundSessionPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).RefundSessionPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_RefundSessionPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).RefundSessionPayment(ctx, req.(*RefundSessionPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RefundService_ServiceDesc is the grpc.ServiceDesc for RefundService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +371,18 @@ var RefundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefundPayment",
 			Handler:    _RefundService_RefundPayment_Handler,
 		},
+		{
+			MethodName: "CreateRefundSession",
+			Handler:    _RefundService_CreateRefundSession_Handler,
+		},
+		{
+			MethodName: "GetRefundSession",
+			Handler:    _RefundService_GetRefundSession_Handler,
+		},
+		{
+			MethodName: "RefundSessionPayment",
+			Handler:    _RefundService_RefundSessionPayment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "refund/refund.proto",
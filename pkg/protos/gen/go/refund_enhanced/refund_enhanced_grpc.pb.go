@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EnhancedRefundService_RefundPaymentEnhanced_FullMethodName = "/kaspi.api.v1.EnhancedRefundService/RefundPaymentEnhanced"
-	EnhancedRefundService_GetClientInfo_FullMethodName         = "/kaspi.api.v1.EnhancedRefundService/GetClientInfo"
-	EnhancedRefundService_CreateRemotePayment_FullMethodName   = "/kaspi.api.v1.EnhancedRefundService/CreateRemotePayment"
-	EnhancedRefundService_CancelRemotePayment_FullMethodName   = "/kaspi.api.v1.EnhancedRefundService/CancelRemotePayment"
+	EnhancedRefundService_RefundPaymentEnhanced_FullMethodName     = "/kaspi.api.v1.EnhancedRefundService/RefundPaymentEnhanced"
+	EnhancedRefundService_GetClientInfo_FullMethodName             = "/kaspi.api.v1.EnhancedRefundService/GetClientInfo"
+	EnhancedRefundService_CreateRemotePayment_FullMethodName       = "/kaspi.api.v1.EnhancedRefundService/CreateRemotePayment"
+	EnhancedRefundService_CancelRemotePayment_FullMethodName       = "/kaspi.api.v1.EnhancedRefundService/CancelRemotePayment"
+	EnhancedRefundService_ListPendingRemotePayments_FullMethodName = "/kaspi.api.v1.EnhancedRefundService/ListPendingRemotePayments"
 )
 
 // EnhancedRefundServiceClient is the client API for EnhancedRefundService service.
@@ -33,6 +34,7 @@ type EnhancedRefundServiceClient interface {
 	GetClientInfo(ctx context.Context, in *GetClientInfoRequest, opts ...grpc.CallOption) (*GetClientInfoResponse, error)
 	CreateRemotePayment(ctx context.Context, in *CreateRemotePaymentRequest, opts ...grpc.CallOption) (*CreateRemotePaymentResponse, error)
 	CancelRemotePayment(ctx context.Context, in *CancelRemotePaymentRequest, opts ...grpc.CallOption) (*CancelRemotePaymentResponse, error)
+	ListPendingRemotePayments(ctx context.Context, in *ListPendingRemotePaymentsRequest, opts ...grpc.CallOption) (*ListPendingRemotePaymentsResponse, error)
 }
 
 type enhancedRefundServiceClient struct {
@@ -83,6 +85,16 @@ func (c *enhancedRefundServiceClient) CancelRemotePayment(ctx context.Context, i
 	return out, nil
 }
 
+func (c *enhancedRefundServiceClient) ListPendingRemotePayments(ctx context.Context, in *ListPendingRemotePaymentsRequest, opts ...grpc.CallOption) (*ListPendingRemotePaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingRemotePaymentsResponse)
+	err := c.cc.Invoke(ctx, EnhancedRefundService_ListPendingRemotePayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EnhancedRefundServiceServer is the server API for EnhancedRefundService service.
 // All implementations must embed UnimplementedEnhancedRefundServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type EnhancedRefundServiceServer interface {
 	GetClientInfo(context.Context, *GetClientInfoRequest) (*GetClientInfoResponse, error)
 	CreateRemotePayment(context.Context, *CreateRemotePaymentRequest) (*CreateRemotePaymentResponse, error)
 	CancelRemotePayment(context.Context, *CancelRemotePaymentRequest) (*CancelRemotePaymentResponse, error)
+	ListPendingRemotePayments(context.Context, *ListPendingRemotePaymentsRequest) (*ListPendingRemotePaymentsResponse, error)
 	mustEmbedUnimplementedEnhancedRefundServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedEnhancedRefundServiceServer) CreateRemotePayment(context.Cont
 func (UnimplementedEnhancedRefundServiceServer) CancelRemotePayment(context.Context, *CancelRemotePaymentRequest) (*CancelRemotePaymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelRemotePayment not implemented")
 }
+func (UnimplementedEnhancedRefundServiceServer) ListPendingRemotePayments(context.Context, *ListPendingRemotePaymentsRequest) (*ListPendingRemotePaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingRemotePayments not implemented")
+}
 func (UnimplementedEnhancedRefundServiceServer) mustEmbedUnimplementedEnhancedRefundServiceServer() {}
 func (UnimplementedEnhancedRefundServiceServer) testEmbeddedByValue()                               {}
 
@@ -206,6 +222,24 @@ func _EnhancedRefundService_CancelRemotePayment_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EnhancedRefundService_ListPendingRemotePayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingRemotePaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnhancedRefundServiceServer).ListPendingRemotePayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnhancedRefundService_ListPendingRemotePayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnhancedRefundServiceServer).ListPendingRemotePayments(ctx, req.(*ListPendingRemotePaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EnhancedRefundService_ServiceDesc is the grpc.ServiceDesc for EnhancedRefundService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +263,10 @@ var EnhancedRefundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelRemotePayment",
 			Handler:    _EnhancedRefundService_CancelRemotePayment_Handler,
 		},
+		{
+			MethodName: "ListPendingRemotePayments",
+			Handler:    _EnhancedRefundService_ListPendingRemotePayments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "refund_enhanced/refund_enhanced.proto",
@@ -9,6 +9,7 @@ package kaspiv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -61,7 +62,8 @@ type HealthCheckResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CircuitBreakers []*CircuitBreakerState `protobuf:"bytes,2,rep,name=circuit_breakers,json=circuitBreakers,proto3" json:"circuit_breakers,omitempty"`
 }
 
 func (x *HealthCheckResponse) Reset() {
@@ -101,6 +103,82 @@ func (x *HealthCheckResponse) GetStatus() string {
 	return ""
 }
 
+func (x *HealthCheckResponse) GetCircuitBreakers() []*CircuitBreakerState {
+	if x != nil {
+		return x.CircuitBreakers
+	}
+	return nil
+}
+
+type CircuitBreakerState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Endpoint string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	State    string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Failures int64                  `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	OpenedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+}
+
+func (x *CircuitBreakerState) Reset() {
+	*x = CircuitBreakerState{}
+	mi := &file_utility_utility_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitBreakerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreakerState) ProtoMessage() {}
+
+func (x *CircuitBreakerState) ProtoReflect() protoreflect.Message {
+	mi := &file_utility_utility_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreakerState.ProtoReflect.Descriptor instead.
+func (*CircuitBreakerState) Descriptor() ([]byte, []int) {
+	return file_utility_utility_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CircuitBreakerState) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *CircuitBreakerState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CircuitBreakerState) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *CircuitBreakerState) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
 type TestScanQRRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -111,7 +189,7 @@ type TestScanQRRequest struct {
 
 func (x *TestScanQRRequest) Reset() {
 	*x = TestScanQRRequest{}
-	mi := &file_utility_utility_proto_msgTypes[2]
+	mi := &file_utility_utility_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -123,7 +201,7 @@ func (x *TestScanQRRequest) String() string {
 func (*TestScanQRRequest) ProtoMessage() {}
 
 func (x *TestScanQRRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[2]
+	mi := &file_utility_utility_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -136,7 +214,7 @@ func (x *TestScanQRRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestScanQRRequest.ProtoReflect.Descriptor instead.
 func (*TestScanQRRequest) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{2}
+	return file_utility_utility_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TestScanQRRequest) GetQrPaymentId() string {
@@ -156,7 +234,7 @@ type TestScanQRResponse struct {
 
 func (x *TestScanQRResponse) Reset() {
 	*x = TestScanQRResponse{}
-	mi := &file_utility_utility_proto_msgTypes[3]
+	mi := &file_utility_utility_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -168,7 +246,7 @@ func (x *TestScanQRResponse) String() string {
 func (*TestScanQRResponse) ProtoMessage() {}
 
 func (x *TestScanQRResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[3]
+	mi := &file_utility_utility_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -181,7 +259,7 @@ func (x *TestScanQRResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestScanQRResponse.ProtoReflect.Descriptor instead.
 func (*TestScanQRResponse) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{3}
+	return file_utility_utility_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TestScanQRResponse) GetMessage() string {
@@ -201,7 +279,7 @@ type TestConfirmPaymentRequest struct {
 
 func (x *TestConfirmPaymentRequest) Reset() {
 	*x = TestConfirmPaymentRequest{}
-	mi := &file_utility_utility_proto_msgTypes[4]
+	mi := &file_utility_utility_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -213,7 +291,7 @@ func (x *TestConfirmPaymentRequest) String() string {
 func (*TestConfirmPaymentRequest) ProtoMessage() {}
 
 func (x *TestConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[4]
+	mi := &file_utility_utility_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +304,7 @@ func (x *TestConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestConfirmPaymentRequest.ProtoReflect.Descriptor instead.
 func (*TestConfirmPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{4}
+	return file_utility_utility_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TestConfirmPaymentRequest) GetQrPaymentId() string {
@@ -246,7 +324,7 @@ type TestConfirmPaymentResponse struct {
 
 func (x *TestConfirmPaymentResponse) Reset() {
 	*x = TestConfirmPaymentResponse{}
-	mi := &file_utility_utility_proto_msgTypes[5]
+	mi := &file_utility_utility_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -258,7 +336,7 @@ func (x *TestConfirmPaymentResponse) String() string {
 func (*TestConfirmPaymentResponse) ProtoMessage() {}
 
 func (x *TestConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[5]
+	mi := &file_utility_utility_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,7 +349,7 @@ func (x *TestConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestConfirmPaymentResponse.ProtoReflect.Descriptor instead.
 func (*TestConfirmPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{5}
+	return file_utility_utility_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TestConfirmPaymentResponse) GetMessage() string {
@@ -291,7 +369,7 @@ type TestScanErrorRequest struct {
 
 func (x *TestScanErrorRequest) Reset() {
 	*x = TestScanErrorRequest{}
-	mi := &file_utility_utility_proto_msgTypes[6]
+	mi := &file_utility_utility_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +381,7 @@ func (x *TestScanErrorRequest) String() string {
 func (*TestScanErrorRequest) ProtoMessage() {}
 
 func (x *TestScanErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[6]
+	mi := &file_utility_utility_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +394,7 @@ func (x *TestScanErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestScanErrorRequest.ProtoReflect.Descriptor instead.
 func (*TestScanErrorRequest) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{6}
+	return file_utility_utility_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TestScanErrorRequest) GetQrPaymentId() string {
@@ -336,7 +414,7 @@ type TestScanErrorResponse struct {
 
 func (x *TestScanErrorResponse) Reset() {
 	*x = TestScanErrorResponse{}
-	mi := &file_utility_utility_proto_msgTypes[7]
+	mi := &file_utility_utility_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +426,7 @@ func (x *TestScanErrorResponse) String() string {
 func (*TestScanErrorResponse) ProtoMessage() {}
 
 func (x *TestScanErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[7]
+	mi := &file_utility_utility_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +439,7 @@ func (x *TestScanErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestScanErrorResponse.ProtoReflect.Descriptor instead.
 func (*TestScanErrorResponse) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{7}
+	return file_utility_utility_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TestScanErrorResponse) GetMessage() string {
@@ -381,7 +459,7 @@ type TestConfirmErrorRequest struct {
 
 func (x *TestConfirmErrorRequest) Reset() {
 	*x = TestConfirmErrorRequest{}
-	mi := &file_utility_utility_proto_msgTypes[8]
+	mi := &file_utility_utility_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +471,7 @@ func (x *TestConfirmErrorRequest) String() string {
 func (*TestConfirmErrorRequest) ProtoMessage() {}
 
 func (x *TestConfirmErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[8]
+	mi := &file_utility_utility_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +484,7 @@ func (x *TestConfirmErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestConfirmErrorRequest.ProtoReflect.Descriptor instead.
 func (*TestConfirmErrorRequest) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{8}
+	return file_utility_utility_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TestConfirmErrorRequest) GetQrPaymentId() string {
@@ -426,7 +504,7 @@ type TestConfirmErrorResponse struct {
 
 func (x *TestConfirmErrorResponse) Reset() {
 	*x = TestConfirmErrorResponse{}
-	mi := &file_utility_utility_proto_msgTypes[9]
+	mi := &file_utility_utility_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +516,7 @@ func (x *TestConfirmErrorResponse) String() string {
 func (*TestConfirmErrorResponse) ProtoMessage() {}
 
 func (x *TestConfirmErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_utility_utility_proto_msgTypes[9]
+	mi := &file_utility_utility_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +529,7 @@ func (x *TestConfirmErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestConfirmErrorResponse.ProtoReflect.Descriptor instead.
 func (*TestConfirmErrorResponse) Descriptor() ([]byte, []int) {
-	return file_utility_utility_proto_rawDescGZIP(), []int{9}
+	return file_utility_utility_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TestConfirmErrorResponse) GetMessage() string {
@@ -466,73 +544,90 @@ var File_utility_utility_proto protoreflect.FileDescriptor
 var file_utility_utility_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2f, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x74,
 	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x22, 0x14, 0x0a, 0x12, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x48,
-	0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x37, 0x0a, 0x11, 0x54, 0x65,
-	0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x22, 0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51,
-	0x52, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x3f, 0x0a, 0x19, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x22, 0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x1a, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x0a, 0x14,
-	0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7b, 0x0a, 0x13,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x10, 0x63,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61,
+	0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69,
+	0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x13, 0x43, 0x69,
+	0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12,
+	0x37, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08,
+	0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x22, 0x37, 0x0a, 0x11, 0x54, 0x65, 0x73, 0x74,
+	0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
+	0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x22, 0x2e, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x3f, 0x0a, 0x19, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x22, 0x36, 0x0a, 0x1a, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x0a, 0x14, 0x54, 0x65,
+	0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x15, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63,
+	0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3d, 0x0a, 0x17, 0x54, 0x65, 0x73,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65,
 	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x72, 0x50,
-	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x15, 0x54, 0x65, 0x73, 0x74,
-	0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3d, 0x0a, 0x17, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x71, 0x72, 0x5f, 0x70, 0x61, 0x79,
-	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71,
-	0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x18, 0x54, 0x65,
-	0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x32, 0xdb, 0x03, 0x0a, 0x0e, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x12, 0x20, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x53,
-	0x63, 0x61, 0x6e, 0x51, 0x52, 0x12, 0x1f, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x27,
-	0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65,
-	0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x58, 0x0a, 0x0d, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x22, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x10, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
-	0x25, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
-	0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38,
-	0x5a, 0x36, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2d, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73,
-	0x2d, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
-	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2f, 0x76, 0x31,
-	0x3b, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x18, 0x54, 0x65, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xdb,
+	0x03, 0x0a, 0x0e, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x52, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x12, 0x20, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61,
+	0x6e, 0x51, 0x52, 0x12, 0x1f, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x51, 0x52, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x12, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x2e, 0x6b,
+	0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x58, 0x0a, 0x0d, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x22, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x10, 0x54, 0x65, 0x73,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x2e,
+	0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38, 0x5a, 0x36,
+	0x6b, 0x61, 0x73, 0x70, 0x69, 0x2d, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73, 0x2d, 0x77,
+	0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x61, 0x73, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x6b,
+	0x61, 0x73, 0x70, 0x69, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -547,35 +642,39 @@ func file_utility_utility_proto_rawDescGZIP() []byte {
 	return file_utility_utility_proto_rawDescData
 }
 
-var file_utility_utility_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_utility_utility_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_utility_utility_proto_goTypes = []any{
 	(*HealthCheckRequest)(nil),         // 0: kaspi.api.v1.HealthCheckRequest
 	(*HealthCheckResponse)(nil),        // 1: kaspi.api.v1.HealthCheckResponse
-	(*TestScanQRRequest)(nil),          // 2: kaspi.api.v1.TestScanQRRequest
-	(*TestScanQRResponse)(nil),         // 3: kaspi.api.v1.TestScanQRResponse
-	(*TestConfirmPaymentRequest)(nil),  // 4: kaspi.api.v1.TestConfirmPaymentRequest
-	(*TestConfirmPaymentResponse)(nil), // 5: kaspi.api.v1.TestConfirmPaymentResponse
-	(*TestScanErrorRequest)(nil),       // 6: kaspi.api.v1.TestScanErrorRequest
-	(*TestScanErrorResponse)(nil),      // 7: kaspi.api.v1.TestScanErrorResponse
-	(*TestConfirmErrorRequest)(nil),    // 8: kaspi.api.v1.TestConfirmErrorRequest
-	(*TestConfirmErrorResponse)(nil),   // 9: kaspi.api.v1.TestConfirmErrorResponse
+	(*CircuitBreakerState)(nil),        // 2: kaspi.api.v1.CircuitBreakerState
+	(*TestScanQRRequest)(nil),          // 3: kaspi.api.v1.TestScanQRRequest
+	(*TestScanQRResponse)(nil),         // 4: kaspi.api.v1.TestScanQRResponse
+	(*TestConfirmPaymentRequest)(nil),  // 5: kaspi.api.v1.TestConfirmPaymentRequest
+	(*TestConfirmPaymentResponse)(nil), // 6: kaspi.api.v1.TestConfirmPaymentResponse
+	(*TestScanErrorRequest)(nil),       // 7: kaspi.api.v1.TestScanErrorRequest
+	(*TestScanErrorResponse)(nil),      // 8: kaspi.api.v1.TestScanErrorResponse
+	(*TestConfirmErrorRequest)(nil),    // 9: kaspi.api.v1.TestConfirmErrorRequest
+	(*TestConfirmErrorResponse)(nil),   // 10: kaspi.api.v1.TestConfirmErrorResponse
+	(*timestamppb.Timestamp)(nil),      // 11: google.protobuf.Timestamp
 }
 var file_utility_utility_proto_depIdxs = []int32{
-	0, // 0: kaspi.api.v1.UtilityService.HealthCheck:input_type -> kaspi.api.v1.HealthCheckRequest
-	2, // 1: kaspi.api.v1.UtilityService.TestScanQR:input_type -> kaspi.api.v1.TestScanQRRequest
-	4, // 2: kaspi.api.v1.UtilityService.TestConfirmPayment:input_type -> kaspi.api.v1.TestConfirmPaymentRequest
-	6, // 3: kaspi.api.v1.UtilityService.TestScanError:input_type -> kaspi.api.v1.TestScanErrorRequest
-	8, // 4: kaspi.api.v1.UtilityService.TestConfirmError:input_type -> kaspi.api.v1.TestConfirmErrorRequest
-	1, // 5: kaspi.api.v1.UtilityService.HealthCheck:output_type -> kaspi.api.v1.HealthCheckResponse
-	3, // 6: kaspi.api.v1.UtilityService.TestScanQR:output_type -> kaspi.api.v1.TestScanQRResponse
-	5, // 7: kaspi.api.v1.UtilityService.TestConfirmPayment:output_type -> kaspi.api.v1.TestConfirmPaymentResponse
-	7, // 8: kaspi.api.v1.UtilityService.TestScanError:output_type -> kaspi.api.v1.TestScanErrorResponse
-	9, // 9: kaspi.api.v1.UtilityService.TestConfirmError:output_type -> kaspi.api.v1.TestConfirmErrorResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2,  // 0: kaspi.api.v1.HealthCheckResponse.circuit_breakers:type_name -> kaspi.api.v1.CircuitBreakerState
+	11, // 1: kaspi.api.v1.CircuitBreakerState.opened_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: kaspi.api.v1.UtilityService.HealthCheck:input_type -> kaspi.api.v1.HealthCheckRequest
+	3,  // 3: kaspi.api.v1.UtilityService.TestScanQR:input_type -> kaspi.api.v1.TestScanQRRequest
+	5,  // 4: kaspi.api.v1.UtilityService.TestConfirmPayment:input_type -> kaspi.api.v1.TestConfirmPaymentRequest
+	7,  // 5: kaspi.api.v1.UtilityService.TestScanError:input_type -> kaspi.api.v1.TestScanErrorRequest
+	9,  // 6: kaspi.api.v1.UtilityService.TestConfirmError:input_type -> kaspi.api.v1.TestConfirmErrorRequest
+	1,  // 7: kaspi.api.v1.UtilityService.HealthCheck:output_type -> kaspi.api.v1.HealthCheckResponse
+	4,  // 8: kaspi.api.v1.UtilityService.TestScanQR:output_type -> kaspi.api.v1.TestScanQRResponse
+	6,  // 9: kaspi.api.v1.UtilityService.TestConfirmPayment:output_type -> kaspi.api.v1.TestConfirmPaymentResponse
+	8,  // 10: kaspi.api.v1.UtilityService.TestScanError:output_type -> kaspi.api.v1.TestScanErrorResponse
+	10, // 11: kaspi.api.v1.UtilityService.TestConfirmError:output_type -> kaspi.api.v1.TestConfirmErrorResponse
+	7,  // [7:12] is the sub-list for method output_type
+	2,  // [2:7] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_utility_utility_proto_init() }
@@ -589,7 +688,7 @@ func file_utility_utility_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_utility_utility_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},